@@ -0,0 +1,38 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package checkpt provides coordinated full-run checkpointing: saving and
+restoring the network weights, environment and other State items, the
+random number stream, looper counters, and elog log tables, together as one
+versioned bundle, so that a run can be resumed or forked exactly.
+
+Each checkpoint is a directory within Manager.Dir, named by Manager.Name
+and a sequence number, containing a manifest.json file describing the
+bundle, along with the weights, log, and state files.  It is written first
+to a temporary directory that is then renamed, so a checkpoint is never
+left partially written.
+
+By default, only the network weights are saved, but with Manager.NetState,
+the complete runtime state of the network is also saved (all unit and
+synapse variables and network counters -- see emer.NetState), so that a
+long simulation resumes exactly as if it had not been interrupted.
+
+Checkpoints are saved on a schedule by calling Manager.AddToLoop, which
+saves at the end of every N iterations of a looper Loop (e.g., every 10
+Epochs), and also when requested by a signal (Manager.SaveOnSignal), at
+the next scheduled loop boundary.
+
+The looper counters are saved along with which loops had started their
+current iteration, so that resuming does not call the Start functions of
+the outer loops again (e.g., initializing the weights at the start of a Run).
+
+Random numbers: the standard Go math/rand state cannot be saved, so instead,
+with Manager.Reseed, at each checkpoint a new seed is drawn and the global
+generator is reseeded with it, and this seed is saved.  Restoring reseeds
+with the same seed, so the random sequence after restoring is identical to
+that after saving.  This changes the random sequence relative to a run
+without checkpoints, so it is off by default.
+*/
+package checkpt
@@ -0,0 +1,410 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package checkpt
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/emer/emergent/elog"
+	"github.com/emer/emergent/emer"
+	"github.com/emer/emergent/env"
+	"github.com/emer/emergent/looper"
+	"github.com/emer/etable/etable"
+	"github.com/goki/gi/gi"
+)
+
+// CurVersion is the current version of the checkpoint bundle format
+const CurVersion = 1
+
+// State is the interface for any additional state saved in a checkpoint,
+// e.g., an environment with its current order of trials.
+type State interface {
+	// WriteState writes the state to given writer
+	WriteState(w io.Writer) error
+
+	// ReadState reads the state from given reader, as written by WriteState
+	ReadState(r io.Reader) error
+}
+
+// Manifest describes the contents of a checkpoint bundle
+type Manifest struct {
+	Version  int                        `desc:"version of the bundle format"`
+	Name     string                     `desc:"name of the run"`
+	Seq      int                        `desc:"sequence number of the checkpoint"`
+	Time     time.Time                  `desc:"time when saved"`
+	Seed     int64                      `desc:"random seed that the global rand generator was reseeded with when saving, if Manager.Reseed -- 0 if not reseeded"`
+	Counters map[string]map[string]int  `desc:"looper loop counters to resume from, by mode and time scale"`
+	Started  map[string]map[string]bool `desc:"looper loops whose current iteration had started (Start functions called), by mode and time scale, so that their Start functions are not called again when resuming"`
+	Wts      string                     `desc:"weights file name, if saved"`
+	NetState string                     `desc:"complete network runtime state file name, if saved (see emer.NetState)"`
+	Logs     []string                   `desc:"log tables saved, by scope name"`
+	States   []string                   `desc:"names of additional State items saved"`
+}
+
+// Manager manages saving and restoring checkpoints.  Set the fields for
+// the elements of the run to be saved -- any that are nil are skipped.
+type Manager struct {
+	Dir      string           `desc:"directory where checkpoints are saved, one subdirectory per checkpoint"`
+	Name     string           `desc:"name of the run, used as the prefix for checkpoint directory names"`
+	Keep     int              `desc:"number of most recent checkpoints to keep -- older ones are removed -- 0 = keep all"`
+	Net      emer.Network     `desc:"network whose weights are saved"`
+	NetState bool             `desc:"also save the complete runtime state of the network (all unit and synapse variables and counters, see emer.NetState), so that the run resumes exactly as if it had not been interrupted"`
+	Loops    *looper.Set      `desc:"loops whose counters are saved"`
+	Logs     *elog.Logs       `desc:"logs whose tables are saved"`
+	States   map[string]State `desc:"additional state to save, by name -- e.g., the environments"`
+	Reseed   bool             `desc:"reseed the global math/rand generator with a new seed drawn from it at each checkpoint, and save the seed, so that the random sequence after restoring is identical to that after saving -- this changes the random sequence relative to a run that does not checkpoint, so it is off by default"`
+	Seq      int              `desc:"sequence number of the most recent checkpoint"`
+
+	sigReq int32 // atomic flag: save requested by signal
+}
+
+// AddState adds a named State item to be saved
+func (cm *Manager) AddState(name string, st State) {
+	if cm.States == nil {
+		cm.States = make(map[string]State)
+	}
+	cm.States[name] = st
+}
+
+// AddToLoop adds an End function to the loop at given time scale in the stack,
+// which saves a checkpoint every n iterations of the loop (n = 0 only saves
+// when requested by SaveOnSignal).  The saved counter for this loop is the
+// next iteration, so restoring resumes with the iteration after the save.
+// Call after adding any logging functions to the loop End, so that the
+// logs for the current iteration are included.  Errors are logged.
+func (cm *Manager) AddToLoop(st *looper.Stack, t env.TimeScales, n int) {
+	lp := st.Loop(t)
+	if lp == nil {
+		log.Printf("checkpt.AddToLoop: loop: %v not found in stack: %v\n", t, st.Mode)
+		return
+	}
+	lp.End.Add("Checkpoint", func() {
+		nxt := lp.Ctr.Cur + 1
+		if (n > 0 && nxt%n == 0) || atomic.CompareAndSwapInt32(&cm.sigReq, 1, 0) {
+			cm.save(st.Mode, t, nxt)
+		}
+	})
+}
+
+// SaveOnSignal requests a checkpoint at the next scheduled loop boundary
+// (see AddToLoop) whenever one of the given signals is received
+// (e.g., syscall.SIGUSR1).
+func (cm *Manager) SaveOnSignal(sigs ...os.Signal) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sigs...)
+	go func() {
+		for range ch {
+			cm.RequestSave()
+		}
+	}()
+}
+
+// RequestSave requests a checkpoint at the next scheduled loop boundary
+func (cm *Manager) RequestSave() {
+	atomic.StoreInt32(&cm.sigReq, 1)
+}
+
+// Save saves a checkpoint now, with the current loop counters,
+// returning the directory where it was saved
+func (cm *Manager) Save() (string, error) {
+	return cm.save("", 0, 0)
+}
+
+// save saves a checkpoint, with counter for given mode and time
+// set to given value, if mode is non-empty
+func (cm *Manager) save(mode string, t env.TimeScales, ctr int) (string, error) {
+	seq := cm.Seq + 1
+	mf := &Manifest{Version: CurVersion, Name: cm.Name, Seq: seq, Time: time.Now()}
+	if cm.Reseed {
+		mf.Seed = rand.Int63()
+		rand.Seed(mf.Seed)
+	}
+	mf.Counters, mf.Started = cm.counters()
+	if mode != "" {
+		if mf.Counters[mode] == nil {
+			mf.Counters[mode] = make(map[string]int)
+			mf.Started[mode] = make(map[string]bool)
+		}
+		mf.Counters[mode][t.String()] = ctr
+		mf.Started[mode][t.String()] = false // resumes with the next iteration
+	}
+	dir := cm.SeqDir(seq)
+	tmp := dir + ".tmp"
+	os.RemoveAll(tmp)
+	err := cm.write(tmp, mf)
+	if err == nil {
+		os.RemoveAll(dir) // e.g., from a later run after restoring an earlier checkpoint
+		err = os.Rename(tmp, dir)
+	}
+	if err != nil {
+		os.RemoveAll(tmp)
+		log.Println(err)
+		return "", err
+	}
+	cm.Seq = seq
+	cm.prune()
+	return dir, nil
+}
+
+// write writes all the checkpoint files to given directory
+func (cm *Manager) write(dir string, mf *Manifest) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if cm.Net != nil {
+		mf.Wts = "weights.wts.gz"
+		if err := cm.Net.SaveWtsJSON(gi.FileName(filepath.Join(dir, mf.Wts))); err != nil {
+			return err
+		}
+		if cm.NetState {
+			mf.NetState = "netstate.gob.gz"
+			if err := emer.SaveNetState(cm.Net, gi.FileName(filepath.Join(dir, mf.NetState))); err != nil {
+				return err
+			}
+		}
+	}
+	if cm.Logs != nil {
+		for _, sc := range cm.Logs.Scopes() {
+			dt := cm.Logs.Tables[sc]
+			if dt == nil {
+				continue
+			}
+			nm := sc.String()
+			if err := dt.SaveCSV(gi.FileName(filepath.Join(dir, nm+".tsv")), etable.Tab, true); err != nil {
+				return err
+			}
+			mf.Logs = append(mf.Logs, nm)
+		}
+	}
+	for _, nm := range cm.stateNames() {
+		if err := writeState(filepath.Join(dir, nm+".state"), cm.States[nm]); err != nil {
+			return err
+		}
+		mf.States = append(mf.States, nm)
+	}
+	b, err := json.MarshalIndent(mf, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, "manifest.json"), b, 0644)
+}
+
+func writeState(filename string, st State) error {
+	fp, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	err = st.WriteState(fp)
+	if cerr := fp.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Restore restores the checkpoint in given directory, returning its Manifest.
+// All items present in the checkpoint must have a corresponding item in the
+// Manager, else an error is returned.  The Stacks must not be running,
+// and are typically Init'ed first.
+func (cm *Manager) Restore(dir string) (*Manifest, error) {
+	mf, err := OpenManifest(dir)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	if mf.Wts != "" && cm.Net != nil {
+		if err := cm.Net.OpenWtsJSON(gi.FileName(filepath.Join(dir, mf.Wts))); err != nil {
+			return nil, err
+		}
+	}
+	if mf.NetState != "" && cm.Net != nil {
+		if err := emer.OpenNetState(cm.Net, gi.FileName(filepath.Join(dir, mf.NetState))); err != nil {
+			return nil, err
+		}
+	}
+	if cm.Logs != nil {
+		for _, nm := range mf.Logs {
+			dt := cm.logTable(nm)
+			if dt == nil {
+				return nil, fmt.Errorf("checkpt.Restore: log: %v not found in Logs", nm)
+			}
+			if err := dt.OpenCSV(gi.FileName(filepath.Join(dir, nm+".tsv")), etable.Tab); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, nm := range mf.States {
+		st, has := cm.States[nm]
+		if !has {
+			return nil, fmt.Errorf("checkpt.Restore: state: %v not found in States", nm)
+		}
+		fp, err := os.Open(filepath.Join(dir, nm+".state"))
+		if err != nil {
+			return nil, err
+		}
+		err = st.ReadState(fp)
+		fp.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := cm.setCounters(mf.Counters, mf.Started); err != nil {
+		return nil, err
+	}
+	if mf.Seed != 0 {
+		rand.Seed(mf.Seed)
+	}
+	cm.Seq = mf.Seq
+	return mf, nil
+}
+
+// RestoreLatest restores the most recent checkpoint in Dir, if any,
+// returning nil Manifest if there are none
+func (cm *Manager) RestoreLatest() (*Manifest, error) {
+	seqs := cm.Seqs()
+	if len(seqs) == 0 {
+		return nil, nil
+	}
+	return cm.Restore(cm.SeqDir(seqs[len(seqs)-1]))
+}
+
+// OpenManifest opens the manifest of the checkpoint in given directory,
+// returning an error if it is not a supported version
+func OpenManifest(dir string) (*Manifest, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, "manifest.json"))
+	if err != nil {
+		return nil, err
+	}
+	mf := &Manifest{}
+	if err := json.Unmarshal(b, mf); err != nil {
+		return nil, err
+	}
+	if mf.Version > CurVersion || mf.Version < 1 {
+		return nil, fmt.Errorf("checkpt.OpenManifest: checkpoint: %v has unsupported version: %d", dir, mf.Version)
+	}
+	return mf, nil
+}
+
+// SeqDir returns the directory for checkpoint with given sequence number
+func (cm *Manager) SeqDir(seq int) string {
+	return filepath.Join(cm.Dir, fmt.Sprintf("%s_ckpt%05d", cm.Name, seq))
+}
+
+// Seqs returns the sequence numbers of the checkpoints present in Dir, in order
+func (cm *Manager) Seqs() []int {
+	fis, err := ioutil.ReadDir(cm.Dir)
+	if err != nil {
+		return nil
+	}
+	var seqs []int
+	pfx := cm.Name + "_ckpt"
+	for _, fi := range fis {
+		nm := fi.Name()
+		if !fi.IsDir() || !strings.HasPrefix(nm, pfx) || strings.HasSuffix(nm, ".tmp") {
+			continue
+		}
+		var seq int
+		if _, err := fmt.Sscanf(nm[len(pfx):], "%d", &seq); err == nil {
+			seqs = append(seqs, seq)
+		}
+	}
+	sort.Ints(seqs)
+	return seqs
+}
+
+// prune removes older checkpoints beyond Keep
+func (cm *Manager) prune() {
+	if cm.Keep <= 0 {
+		return
+	}
+	seqs := cm.Seqs()
+	for i := 0; i < len(seqs)-cm.Keep; i++ {
+		os.RemoveAll(cm.SeqDir(seqs[i]))
+	}
+}
+
+// stateNames returns the sorted names of the States
+func (cm *Manager) stateNames() []string {
+	nms := make([]string, 0, len(cm.States))
+	for nm := range cm.States {
+		nms = append(nms, nm)
+	}
+	sort.Strings(nms)
+	return nms
+}
+
+// logTable returns the log table with given scope name
+func (cm *Manager) logTable(nm string) *etable.Table {
+	for sc, dt := range cm.Logs.Tables {
+		if sc.String() == nm {
+			return dt
+		}
+	}
+	return nil
+}
+
+// counters returns the current loop counters and started states by mode and time
+func (cm *Manager) counters() (map[string]map[string]int, map[string]map[string]bool) {
+	ctrs := make(map[string]map[string]int)
+	strs := make(map[string]map[string]bool)
+	if cm.Loops == nil {
+		return ctrs, strs
+	}
+	for _, m := range cm.Loops.Modes {
+		st := cm.Loops.Stack(m)
+		mc := make(map[string]int, len(st.Order))
+		ms := make(map[string]bool, len(st.Order))
+		for _, t := range st.Order {
+			lp := st.Loops[t]
+			mc[t.String()] = lp.Ctr.Cur
+			ms[t.String()] = lp.IsStarted()
+		}
+		ctrs[m] = mc
+		strs[m] = ms
+	}
+	return ctrs, strs
+}
+
+// setCounters sets the loop counters and started states from given values
+// by mode and time
+func (cm *Manager) setCounters(ctrs map[string]map[string]int, strs map[string]map[string]bool) error {
+	if cm.Loops == nil {
+		return nil
+	}
+	for m, mc := range ctrs {
+		st := cm.Loops.Stack(m)
+		if st == nil {
+			return fmt.Errorf("checkpt.Restore: loop mode: %v not found in Loops", m)
+		}
+		if st.IsRunning() {
+			return fmt.Errorf("checkpt.Restore: loop mode: %v is running", m)
+		}
+		for tnm, cur := range mc {
+			var t env.TimeScales
+			if err := t.FromString(tnm); err != nil {
+				return err
+			}
+			lp := st.Loop(t)
+			if lp == nil {
+				return fmt.Errorf("checkpt.Restore: loop: %v not found in mode: %v", tnm, m)
+			}
+			lp.Ctr.Cur = cur
+			lp.Ctr.Prv = cur - 1
+			lp.SetStarted(strs[m][tnm])
+		}
+	}
+	return nil
+}
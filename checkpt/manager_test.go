@@ -0,0 +1,232 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package checkpt
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/emer/emergent/env"
+	"github.com/emer/emergent/looper"
+)
+
+// testState is a fake State with one counter
+type testState struct {
+	N int
+}
+
+func (st *testState) WriteState(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "%d\n", st.N)
+	return err
+}
+
+func (st *testState) ReadState(r io.Reader) error {
+	_, err := fmt.Fscan(r, &st.N)
+	return err
+}
+
+// testRun is a Manager for a Train stack with Epoch and Trial loops of
+// 3 x 4 iterations, counting the Epoch Start calls and Trials run, and
+// with an Env testState that counts the Trials
+type testRun struct {
+	cm     *Manager
+	st     *looper.Stack
+	env    *testState
+	starts int
+	trls   int
+}
+
+func newTestRun(dir string) *testRun {
+	tr := &testRun{env: &testState{}}
+	set := &looper.Set{}
+	tr.st = set.NewStack("Train", env.Epoch, env.Trial)
+	tr.st.Loop(env.Epoch).Max = 3
+	tr.st.Loop(env.Trial).Max = 4
+	tr.st.Loop(env.Epoch).Start.Add("Count", func() { tr.starts++ })
+	tr.st.Loop(env.Trial).Main.Add("Count", func() {
+		tr.trls++
+		tr.env.N++
+	})
+	set.Init()
+	tr.cm = &Manager{Dir: dir, Name: "run", Loops: set}
+	tr.cm.AddState("Env", tr.env)
+	return tr
+}
+
+// tempDir returns a new temporary directory, removed at the end of the test
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "checkpt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestSaveRestore(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	tr := newTestRun(dir)
+	tr.st.Step(env.Trial, 6) // within the 2nd epoch
+	sdir, err := tr.cm.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sdir != tr.cm.SeqDir(1) || tr.cm.Seq != 1 || !reflect.DeepEqual(tr.cm.Seqs(), []int{1}) {
+		t.Fatalf("Save: dir: %v seq: %d", sdir, tr.cm.Seq)
+	}
+	mf, err := OpenManifest(sdir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mf.Version != CurVersion || mf.Name != "run" || mf.Seq != 1 || mf.Seed != 0 || mf.Wts != "" || mf.NetState != "" ||
+		!reflect.DeepEqual(mf.States, []string{"Env"}) || len(mf.Logs) != 0 {
+		t.Errorf("manifest: %+v", mf)
+	}
+	if ctrs := mf.Counters["Train"]; ctrs["Epoch"] != 1 || ctrs["Trial"] != 2 {
+		t.Errorf("manifest counters: %v", mf.Counters)
+	}
+	if strs := mf.Started["Train"]; !strs["Epoch"] || strs["Trial"] {
+		t.Errorf("manifest started: %v", mf.Started)
+	}
+
+	// finish the run, then restore into a new run and finish it again
+	tr.st.Run()
+	rr := newTestRun(dir)
+	rmf, err := rr.cm.RestoreLatest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rmf == nil || rmf.Seq != 1 || rr.cm.Seq != 1 || rr.env.N != 6 {
+		t.Fatalf("RestoreLatest: %+v env: %d", rmf, rr.env.N)
+	}
+	if ep := rr.st.Loop(env.Epoch); ep.Cur() != 1 || !ep.IsStarted() || rr.st.Loop(env.Trial).Cur() != 2 {
+		t.Errorf("restored counters: %s", rr.st.CountersString())
+	}
+	rr.st.Run()
+	if rr.trls != 12-6 || rr.starts != 1 || rr.env.N != tr.env.N {
+		t.Errorf("restored run: trials: %d epoch starts: %d env: %d != %d", rr.trls, rr.starts, rr.env.N, tr.env.N)
+	}
+}
+
+func TestAddToLoop(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	tr := newTestRun(dir)
+	tr.cm.Keep = 2
+	tr.cm.AddToLoop(tr.st, env.Epoch, 1)
+	tr.st.Run()
+	if seqs := tr.cm.Seqs(); !reflect.DeepEqual(seqs, []int{2, 3}) || tr.cm.Seq != 3 {
+		t.Fatalf("AddToLoop: Keep 2: checkpoints: %v", seqs)
+	}
+	mf, err := OpenManifest(tr.cm.SeqDir(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mf.Counters["Train"]["Epoch"] != 2 || mf.Counters["Train"]["Trial"] != 0 || mf.Started["Train"]["Epoch"] {
+		t.Errorf("manifest: counters: %v started: %v", mf.Counters, mf.Started)
+	}
+
+	// restoring an earlier checkpoint resumes with the next epoch
+	rr := newTestRun(dir)
+	if _, err := rr.cm.Restore(rr.cm.SeqDir(2)); err != nil {
+		t.Fatal(err)
+	}
+	rr.st.Run()
+	if rr.trls != 4 || rr.starts != 1 || rr.env.N != 12 {
+		t.Errorf("restored run: trials: %d epoch starts: %d env: %d", rr.trls, rr.starts, rr.env.N)
+	}
+
+	// saving again after restoring replaces the later checkpoint
+	if _, err := rr.cm.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if seqs := rr.cm.Seqs(); !reflect.DeepEqual(seqs, []int{2, 3}) {
+		t.Errorf("Save after Restore: checkpoints: %v", seqs)
+	}
+}
+
+func TestRequestSave(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	tr := newTestRun(dir)
+	tr.cm.AddToLoop(tr.st, env.Trial, 0)
+	tr.st.Step(env.Trial, 2)
+	if seqs := tr.cm.Seqs(); len(seqs) != 0 {
+		t.Errorf("AddToLoop n = 0: saved without request: %v", seqs)
+	}
+	tr.cm.RequestSave()
+	tr.st.Step(env.Trial, 2)
+	if seqs := tr.cm.Seqs(); !reflect.DeepEqual(seqs, []int{1}) {
+		t.Fatalf("RequestSave: checkpoints: %v", seqs)
+	}
+	mf, err := OpenManifest(tr.cm.SeqDir(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mf.Counters["Train"]["Trial"] != 3 || mf.Started["Train"]["Trial"] || !mf.Started["Train"]["Epoch"] {
+		t.Errorf("manifest: counters: %v started: %v", mf.Counters, mf.Started)
+	}
+}
+
+func TestReseed(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	tr := newTestRun(dir)
+	tr.cm.Reseed = true
+	if _, err := tr.cm.Save(); err != nil {
+		t.Fatal(err)
+	}
+	r1 := rand.Int63()
+	rr := newTestRun(dir)
+	mf, err := rr.cm.RestoreLatest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mf.Seed == 0 {
+		t.Errorf("Reseed: seed not saved")
+	}
+	if r2 := rand.Int63(); r2 != r1 {
+		t.Errorf("Reseed: random sequence differs after restore: %d != %d", r2, r1)
+	}
+}
+
+func TestRestoreErrors(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	rr := newTestRun(dir)
+	if mf, err := rr.cm.RestoreLatest(); mf != nil || err != nil {
+		t.Errorf("RestoreLatest no checkpoints: %v %v", mf, err)
+	}
+	if _, err := rr.cm.Restore(filepath.Join(dir, "none")); err == nil {
+		t.Errorf("Restore missing dir: no error")
+	}
+	tr := newTestRun(dir)
+	tr.cm.AddState("Other", &testState{})
+	sdir, err := tr.cm.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rr.cm.Restore(sdir); err == nil {
+		t.Errorf("Restore state not in Manager: no error")
+	}
+	rr.cm.AddState("Other", &testState{})
+	rr.cm.Loops = &looper.Set{}
+	rr.cm.Loops.NewStack("Test", env.Trial)
+	if _, err := rr.cm.Restore(sdir); err == nil {
+		t.Errorf("Restore mode not in Loops: no error")
+	}
+	if err := ioutil.WriteFile(filepath.Join(sdir, "manifest.json"), []byte(`{"Version": 99}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenManifest(sdir); err == nil {
+		t.Errorf("OpenManifest unsupported version: no error")
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package efuns provides standard activation functions for building unit
+dynamics: Softplus, GELU, and a parameterized Sigmoid, each with an optional
+precomputed lookup table evaluation mode (see Table) that uses linear
+interpolation over a uniform grid of input values, as in the leabra XX1
+noisy-XX1 function, for minimal per-cycle cost.
+
+Each function type has Defaults, Fun (direct computation), Eval (uses the
+lookup table if UseTable is set), and Update to recompute the table after
+changing parameters.
+*/
+package efuns
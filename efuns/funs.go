@@ -0,0 +1,160 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package efuns
+
+import "math"
+
+// TableParams are the lookup table parameters shared by all functions
+type TableParams struct {
+	UseTable bool    `desc:"use the precomputed lookup table for evaluation, instead of computing the function directly"`
+	Min      float32 `viewif:"UseTable" desc:"minimum input value in the table"`
+	Max      float32 `viewif:"UseTable" desc:"maximum input value in the table"`
+	Res      float32 `viewif:"UseTable" def:"0.001" desc:"resolution of the table -- spacing between input values"`
+	Table    Table   `view:"-" desc:"the lookup table"`
+}
+
+// Defaults sets the table range to -10..10 with resolution 0.001, not used by default
+func (tp *TableParams) Defaults() {
+	tp.UseTable = false
+	tp.Min = -10
+	tp.Max = 10
+	tp.Res = 0.001
+}
+
+// Update recomputes the table for given function, if UseTable is set
+func (tp *TableParams) Update(fun func(x float32) float32) {
+	if !tp.UseTable {
+		return
+	}
+	tp.Table.Set(tp.Min, tp.Max, tp.Res, fun)
+}
+
+// Eval evaluates the function using the table if UseTable is set, else directly
+func (tp *TableParams) Eval(x float32, fun func(x float32) float32) float32 {
+	if tp.UseTable {
+		return tp.Table.Eval(x, fun)
+	}
+	return fun(x)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+//  Softplus
+
+// Softplus is a smooth version of the rectified linear function:
+// Gain * log(1 + exp(x / Gain)), which is close to max(0, x) as Gain -> 0
+type Softplus struct {
+	Gain  float32     `def:"1" min:"0" desc:"gain (temperature): smaller values are sharper, closer to max(0, x)"`
+	Table TableParams `desc:"lookup table parameters"`
+}
+
+// Defaults sets default parameters
+func (sp *Softplus) Defaults() {
+	sp.Gain = 1
+	sp.Table.Defaults()
+	sp.Update()
+}
+
+// Update recomputes the lookup table -- call after changing params
+func (sp *Softplus) Update() {
+	sp.Table.Update(sp.Fun)
+}
+
+// Fun computes the function directly
+func (sp *Softplus) Fun(x float32) float32 {
+	xg := x / sp.Gain
+	if xg > 20 { // avoid overflow: log(1+exp(x)) ~= x
+		return x
+	}
+	return sp.Gain * float32(math.Log1p(math.Exp(float64(xg))))
+}
+
+// Eval computes the function, using the lookup table if Table.UseTable
+func (sp *Softplus) Eval(x float32) float32 {
+	return sp.Table.Eval(x, sp.Fun)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+//  GELU
+
+// GELU is the Gaussian Error Linear Unit: x * Phi(x), where Phi is the
+// standard normal cumulative distribution function
+type GELU struct {
+	Approx bool        `desc:"use the standard tanh approximation instead of the exact erf version"`
+	Table  TableParams `desc:"lookup table parameters"`
+}
+
+// Defaults sets default parameters
+func (gl *GELU) Defaults() {
+	gl.Approx = false
+	gl.Table.Defaults()
+	gl.Update()
+}
+
+// Update recomputes the lookup table -- call after changing params
+func (gl *GELU) Update() {
+	gl.Table.Update(gl.Fun)
+}
+
+// Fun computes the function directly
+func (gl *GELU) Fun(x float32) float32 {
+	if gl.Approx {
+		const c = 0.7978845608 // sqrt(2 / pi)
+		return 0.5 * x * (1 + float32(math.Tanh(float64(c*(x+0.044715*x*x*x)))))
+	}
+	return 0.5 * x * (1 + float32(math.Erf(float64(x)/math.Sqrt2)))
+}
+
+// Eval computes the function, using the lookup table if Table.UseTable
+func (gl *GELU) Eval(x float32) float32 {
+	return gl.Table.Eval(x, gl.Fun)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+//  Sigmoid
+
+// Sigmoid is a parameterized logistic sigmoid function:
+// Min + (Max - Min) / (1 + exp(-Gain * (x - Off)))
+type Sigmoid struct {
+	Gain  float32     `def:"1" desc:"gain (slope) of the sigmoid"`
+	Off   float32     `def:"0" desc:"offset: input value at the midpoint of the sigmoid"`
+	Min   float32     `def:"0" desc:"minimum output value"`
+	Max   float32     `def:"1" desc:"maximum output value"`
+	Table TableParams `desc:"lookup table parameters"`
+}
+
+// Defaults sets default parameters
+func (sg *Sigmoid) Defaults() {
+	sg.Gain = 1
+	sg.Off = 0
+	sg.Min = 0
+	sg.Max = 1
+	sg.Table.Defaults()
+	sg.Update()
+}
+
+// Update recomputes the lookup table -- call after changing params
+func (sg *Sigmoid) Update() {
+	sg.Table.Update(sg.Fun)
+}
+
+// Fun computes the function directly
+func (sg *Sigmoid) Fun(x float32) float32 {
+	return sg.Min + (sg.Max-sg.Min)/(1+float32(math.Exp(float64(-sg.Gain*(x-sg.Off)))))
+}
+
+// Eval computes the function, using the lookup table if Table.UseTable
+func (sg *Sigmoid) Eval(x float32) float32 {
+	return sg.Table.Eval(x, sg.Fun)
+}
+
+// Deriv returns the derivative of the sigmoid as a function of its output value y
+func (sg *Sigmoid) Deriv(y float32) float32 {
+	rng := sg.Max - sg.Min
+	if rng == 0 {
+		return 0
+	}
+	yn := (y - sg.Min) / rng
+	return sg.Gain * rng * yn * (1 - yn)
+}
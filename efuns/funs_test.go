@@ -0,0 +1,154 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package efuns
+
+import (
+	"math"
+	"testing"
+)
+
+// tol is the tolerance for direct function values at known points
+const tol = 1.0e-5
+
+// tblTol is the tolerance for lookup table values vs. direct values
+const tblTol = 1.0e-4
+
+func near(a, b, tol float32) bool {
+	return math.Abs(float64(a-b)) <= float64(tol)
+}
+
+func TestSoftplus(t *testing.T) {
+	sp := &Softplus{}
+	sp.Defaults()
+	tests := []struct {
+		gain, x, ex float32
+	}{
+		{1, 0, float32(math.Ln2)},
+		{1, 1, 1.3132616},
+		{1, -1, 0.3132617},
+		{1, 30, 30}, // overflow path: ~x
+		{1, -30, 0}, // ~0
+		{0.5, 0, 0.5 * float32(math.Ln2)},
+		{0.5, 1, 1.0634640},
+	}
+	for _, ts := range tests {
+		sp.Gain = ts.gain
+		if v := sp.Fun(ts.x); !near(v, ts.ex, tol) {
+			t.Errorf("Softplus gain %v Fun(%v): %v != %v", ts.gain, ts.x, v, ts.ex)
+		}
+	}
+}
+
+func TestGELU(t *testing.T) {
+	gl := &GELU{}
+	gl.Defaults()
+	tests := []struct {
+		x, ex, exApprox float32
+	}{
+		{0, 0, 0},
+		{1, 0.8413447, 0.8411920},
+		{-1, -0.1586553, -0.1588080},
+		{2, 1.9544997, 1.9545977},
+		{-3, -0.0040497, -0.0036374},
+	}
+	for _, ts := range tests {
+		gl.Approx = false
+		if v := gl.Fun(ts.x); !near(v, ts.ex, tol) {
+			t.Errorf("GELU Fun(%v): %v != %v", ts.x, v, ts.ex)
+		}
+		gl.Approx = true
+		if v := gl.Fun(ts.x); !near(v, ts.exApprox, tol) {
+			t.Errorf("GELU Approx Fun(%v): %v != %v", ts.x, v, ts.exApprox)
+		}
+	}
+}
+
+func TestSigmoid(t *testing.T) {
+	sg := &Sigmoid{}
+	sg.Defaults()
+	tests := []struct {
+		gain, off, min, max float32
+		x, ex               float32
+	}{
+		{1, 0, 0, 1, 0, 0.5},
+		{1, 0, 0, 1, 2, 0.8807971},
+		{1, 0, 0, 1, -2, 0.1192029},
+		{2, 1, 0, 1, 1, 0.5},
+		{2, 1, 0, 1, 2, 0.8807971},
+		{1, 0, -1, 1, 0, 0},
+		{1, 0, -1, 1, 2, 0.7615942},
+	}
+	for _, ts := range tests {
+		sg.Gain, sg.Off, sg.Min, sg.Max = ts.gain, ts.off, ts.min, ts.max
+		y := sg.Fun(ts.x)
+		if !near(y, ts.ex, tol) {
+			t.Errorf("Sigmoid %v Fun(%v): %v != %v", *sg, ts.x, y, ts.ex)
+		}
+		// derivative from output value vs. numerical derivative
+		const dx = 1.0e-3
+		nd := (sg.Fun(ts.x+dx) - sg.Fun(ts.x-dx)) / (2 * dx)
+		if d := sg.Deriv(y); !near(d, nd, 1.0e-3) {
+			t.Errorf("Sigmoid %v Deriv(%v): %v != numerical %v", *sg, y, d, nd)
+		}
+	}
+	sg.Min, sg.Max = 1, 1
+	if d := sg.Deriv(1); d != 0 {
+		t.Errorf("Sigmoid Deriv with zero range: %v != 0", d)
+	}
+}
+
+func TestTable(t *testing.T) {
+	sp := &Softplus{}
+	sp.Defaults()
+	gl := &GELU{}
+	gl.Defaults()
+	sg := &Sigmoid{}
+	sg.Defaults()
+	funs := []struct {
+		name   string
+		tbl    *TableParams
+		update func()
+		fun    func(x float32) float32
+		eval   func(x float32) float32
+	}{
+		{"Softplus", &sp.Table, sp.Update, sp.Fun, sp.Eval},
+		{"GELU", &gl.Table, gl.Update, gl.Fun, gl.Eval},
+		{"Sigmoid", &sg.Table, sg.Update, sg.Fun, sg.Eval},
+	}
+	xs := []float32{-12, -10, -3.3333, -0.5, 0, 0.0005, 0.25, 1, 2.71828, 9.9995, 10, 15}
+	for _, fn := range funs {
+		if fn.tbl.Table.IsSet() {
+			t.Errorf("%s: table computed without UseTable", fn.name)
+		}
+		fn.tbl.UseTable = true
+		fn.update()
+		if !fn.tbl.Table.IsSet() {
+			t.Errorf("%s: table not computed with UseTable", fn.name)
+		}
+		for _, x := range xs {
+			if v, ex := fn.eval(x), fn.fun(x); !near(v, ex, tblTol) {
+				t.Errorf("%s: table Eval(%v): %v != %v", fn.name, x, v, ex)
+			}
+		}
+	}
+}
+
+func TestTableInterp(t *testing.T) {
+	// linear function is exact with linear interpolation
+	lin := func(x float32) float32 { return 2*x + 1 }
+	tb := &Table{}
+	if v := tb.Eval(0.5, lin); v != 2 {
+		t.Errorf("unset table Eval: %v != 2", v)
+	}
+	tb.Set(0, 1, 0.25, lin)
+	if len(tb.Vals) != 6 {
+		t.Errorf("table size: %d != 6", len(tb.Vals))
+	}
+	for _, x := range []float32{0, 0.1, 0.25, 0.6, 0.99, -1, 1, 2} {
+		if v := tb.Eval(x, lin); !near(v, lin(x), 1.0e-6) {
+			t.Errorf("Eval(%v): %v != %v", x, v, lin(x))
+		}
+	}
+}
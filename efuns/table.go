@@ -0,0 +1,56 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package efuns
+
+// Table is a lookup table of precomputed function values over a uniform
+// grid of input values from Min to Max, with linear interpolation between
+// grid points.  Inputs outside the range are computed using the function
+// directly, so results are always valid, while the table provides the
+// speedup for the typical range of values.
+type Table struct {
+	Min    float32   `desc:"minimum input value in the table"`
+	Max    float32   `desc:"maximum input value in the table"`
+	Res    float32   `desc:"resolution: spacing between grid points"`
+	Vals   []float32 `view:"-" desc:"precomputed function values at each grid point"`
+	invRes float32
+}
+
+// Set computes the table values for given function over the range min..max
+// at given resolution
+func (tb *Table) Set(min, max, res float32, fun func(x float32) float32) {
+	tb.Min = min
+	tb.Max = max
+	tb.Res = res
+	tb.invRes = 1 / res
+	n := int((max-min)*tb.invRes) + 2
+	if cap(tb.Vals) >= n {
+		tb.Vals = tb.Vals[:n]
+	} else {
+		tb.Vals = make([]float32, n)
+	}
+	for i := range tb.Vals {
+		tb.Vals[i] = fun(min + float32(i)*res)
+	}
+}
+
+// IsSet returns true if the table has been computed
+func (tb *Table) IsSet() bool {
+	return len(tb.Vals) > 1
+}
+
+// Eval returns the table value for given input, using linear interpolation,
+// and the function directly for values outside of the table range.
+func (tb *Table) Eval(x float32, fun func(x float32) float32) float32 {
+	if x < tb.Min || x >= tb.Max || !tb.IsSet() {
+		return fun(x)
+	}
+	fi := (x - tb.Min) * tb.invRes
+	i := int(fi)
+	if i >= len(tb.Vals)-1 {
+		return fun(x)
+	}
+	df := fi - float32(i)
+	return tb.Vals[i] + df*(tb.Vals[i+1]-tb.Vals[i])
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package elog
+
+import (
+	"math"
+
+	"github.com/goki/ki/kit"
+)
+
+// Aggs are the different ways of aggregating values from a lower time scale
+// into a higher one, e.g., from Trial to Epoch.
+type Aggs int32
+
+//go:generate stringer -type=Aggs
+
+var KiT_Aggs = kit.Enums.AddEnum(AggsN, false, nil)
+
+func (ev Aggs) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *Aggs) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// The aggregation functions
+const (
+	// AggNone means no aggregation -- item is only logged where it has a Compute function
+	AggNone Aggs = iota
+
+	// AggMean is the mean over the values at the lower time scale
+	AggMean
+
+	// AggSum is the sum over the values at the lower time scale
+	AggSum
+
+	// AggMin is the minimum over the values at the lower time scale
+	AggMin
+
+	// AggMax is the maximum over the values at the lower time scale
+	AggMax
+
+	// AggLast is the last (most recent) value at the lower time scale
+	AggLast
+
+	AggsN
+)
+
+// Agg returns the aggregate of given values according to the aggregation function.
+// Returns 0 if there are no values.
+func (ag Aggs) Agg(vals []float64) float64 {
+	if len(vals) == 0 {
+		return 0
+	}
+	switch ag {
+	case AggMean, AggSum:
+		sum := 0.0
+		for _, v := range vals {
+			sum += v
+		}
+		if ag == AggMean {
+			return sum / float64(len(vals))
+		}
+		return sum
+	case AggMin:
+		mn := math.Inf(1)
+		for _, v := range vals {
+			mn = math.Min(mn, v)
+		}
+		return mn
+	case AggMax:
+		mx := math.Inf(-1)
+		for _, v := range vals {
+			mx = math.Max(mx, v)
+		}
+		return mx
+	case AggLast:
+		return vals[len(vals)-1]
+	}
+	return 0
+}
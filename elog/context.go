@@ -0,0 +1,86 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package elog
+
+import (
+	"github.com/emer/emergent/emer"
+	"github.com/emer/emergent/env"
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+)
+
+// Context provides the information needed by a ComputeFunc to compute
+// the value of an Item, and methods to set that value in the current row.
+type Context struct {
+	Logs  *Logs         `desc:"the logs that this context is for"`
+	Net   emer.Network  `desc:"network -- optional, for access by compute functions"`
+	Env   env.Env       `desc:"environment -- optional, for access by compute functions"`
+	Scope Scope         `desc:"current scope being logged"`
+	Item  *Item         `desc:"current item being computed"`
+	Table *etable.Table `desc:"current table being logged into"`
+	Row   int           `desc:"current row being logged"`
+}
+
+// SetFloat64 sets the value of the current item in the current row
+func (ctx *Context) SetFloat64(val float64) {
+	ctx.Table.SetCellFloat(ctx.Item.Name, ctx.Row, val)
+}
+
+// SetFloat32 sets the value of the current item in the current row
+func (ctx *Context) SetFloat32(val float32) {
+	ctx.Table.SetCellFloat(ctx.Item.Name, ctx.Row, float64(val))
+}
+
+// SetInt sets the value of the current item in the current row
+func (ctx *Context) SetInt(val int) {
+	ctx.Table.SetCellFloat(ctx.Item.Name, ctx.Row, float64(val))
+}
+
+// SetString sets the value of the current item in the current row
+func (ctx *Context) SetString(val string) {
+	ctx.Table.SetCellString(ctx.Item.Name, ctx.Row, val)
+}
+
+// SetTensor sets the value of the current item in the current row,
+// for items with a CellShape
+func (ctx *Context) SetTensor(val etensor.Tensor) {
+	ctx.Table.SetCellTensor(ctx.Item.Name, ctx.Row, val)
+}
+
+// SetLayerTensor sets the value of the current item in the current row to
+// the values of given unit variable in given layer of the Net,
+// returning the tensor of values (which is reused across calls).
+// The item CellShape should be the layer shape.
+func (ctx *Context) SetLayerTensor(layNm, unitVar string) etensor.Tensor {
+	ly := ctx.Net.LayerByName(layNm)
+	if ly == nil {
+		return nil
+	}
+	tsr := ctx.Logs.layerTensor(layNm, ly.Shape().Shp)
+	ly.UnitValsTensor(tsr, unitVar)
+	ctx.SetTensor(tsr)
+	return tsr
+}
+
+// EnvCounter returns the current counter value for given time scale from the Env
+func (ctx *Context) EnvCounter(scale env.TimeScales) int {
+	return env.CounterCur(ctx.Env, scale)
+}
+
+// SetEnvCounter sets the value of the current item in the current row to the current
+// value of the Env counter at given time scale
+func (ctx *Context) SetEnvCounter(scale env.TimeScales) {
+	ctx.SetInt(ctx.EnvCounter(scale))
+}
+
+// LowerTable returns the log table for the next lower time scale in the
+// same mode as the current scope (in Logs.Times order), or nil if none
+func (ctx *Context) LowerTable() *etable.Table {
+	lt, ok := ctx.Logs.LowerTime(ctx.Scope.Time)
+	if !ok {
+		return nil
+	}
+	return ctx.Logs.Table(ctx.Scope.Mode, lt)
+}
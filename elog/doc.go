@@ -0,0 +1,44 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package elog provides a unified logging system that manages etable.Table logs
+across different evaluation Modes (e.g., "Train", "Test") and time scales
+(env.TimeScales, e.g., Run, Epoch, Trial, Cycle), replacing the bespoke logging
+code that otherwise must be written for each simulation.
+
+Each column of data is described declaratively by an Item, which has a Name,
+data Type, and a Compute function for each Scope (Mode + Time) where it is
+recorded.  Compute functions receive a Context that provides access to the
+emer.Network, env.Env, and the current table and row, with convenience methods
+for setting the value of the item.
+
+Items can also be automatically aggregated from lower to higher time scales,
+in the order given by Logs.Times, using the Agg function (e.g., AggMean),
+so that e.g., an error statistic computed at the Trial level can be
+automatically averaged at the Epoch level, without writing any further code.
+
+Each log can also be written to a file as rows are added, using SetLogFile,
+tab-separated by default, or with another delimiter (e.g., Comma for .csv)
+set in Logs.Delim, or for a given scope with SetLogFileDelim.
+
+Basic usage:
+
+	lg := &elog.Logs{}
+	lg.Times = []env.TimeScales{env.Cycle, env.Trial, env.Epoch, env.Run}
+	lg.AddItem(&elog.Item{Name: "Epoch", Type: etensor.INT64,
+		Compute: elog.ComputeMap{elog.Scope{"Train", env.Epoch}: func(ctx *elog.Context) {
+			ctx.SetInt(env.CounterCur(ctx.Env, env.Epoch))
+		}}})
+	lg.AddItem(&elog.Item{Name: "SSE", Type: etensor.FLOAT64, Agg: elog.AggMean,
+		Compute: elog.ComputeMap{elog.Scope{"Train", env.Trial}: func(ctx *elog.Context) {
+			ctx.SetFloat64(ss.TrlSSE)
+		}}})
+	lg.Config()
+	...
+	lg.Log("Train", env.Trial) // at the end of each trial
+	lg.Log("Train", env.Epoch) // at the end of each epoch -- SSE is mean over trials
+	lg.ResetLog("Train", env.Trial) // start fresh for next epoch
+*/
+package elog
@@ -0,0 +1,67 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package elog
+
+import (
+	"fmt"
+
+	"github.com/emer/emergent/env"
+	"github.com/emer/etable/etensor"
+)
+
+// Scope is the combination of an evaluation Mode (e.g., "Train", "Test")
+// and a Time scale (e.g., env.Epoch), identifying one log table
+type Scope struct {
+	Mode string         `desc:"evaluation mode, e.g., Train, Test"`
+	Time env.TimeScales `desc:"time scale, e.g., Trial, Epoch"`
+}
+
+// String returns Mode + Time, e.g., TrainEpoch
+func (sc Scope) String() string {
+	return sc.Mode + sc.Time.String()
+}
+
+// ComputeFunc is a function that computes the value of a log Item,
+// setting it in the current table and row using the Context Set* methods.
+type ComputeFunc func(ctx *Context)
+
+// ComputeMap is a map of compute functions for each Scope
+type ComputeMap map[Scope]ComputeFunc
+
+// Item describes one column of data to be logged, with a Compute function
+// for each Scope where it is computed directly, and an optional Agg
+// aggregation function for automatically computing values at higher time scales
+// from the values at the next lower time scale (in Logs.Times order).
+type Item struct {
+	Name      string       `desc:"name of column -- must be unique for a table"`
+	Type      etensor.Type `desc:"data type, using etensor types"`
+	CellShape []int        `desc:"shape of a single cell in the column (i.e., without the row dimension) -- for scalars this is nil -- tensor column will add the outer row dimension to this shape"`
+	DimNames  []string     `desc:"names of the dimensions within the CellShape -- 'Row' will be added to outer dimension"`
+	Compute   ComputeMap   `view:"-" desc:"compute functions for each scope where this item is computed directly"`
+	Agg       Aggs         `desc:"how to aggregate values at higher time scales from the next lower time scale, for scopes without an explicit Compute function -- only for scalar numerical items"`
+	Desc      string       `desc:"description of the item, e.g., for the table column meta data"`
+}
+
+// HasCompute returns true if the item has an explicit compute function for the scope
+func (it *Item) HasCompute(sc Scope) bool {
+	_, has := it.Compute[sc]
+	return has
+}
+
+// CanAgg returns true if this item can be aggregated -- scalar numerical type with Agg set
+func (it *Item) CanAgg() bool {
+	return it.Agg != AggNone && len(it.CellShape) == 0 && it.Type != etensor.STRING
+}
+
+// Validate returns an error if the item is not properly configured
+func (it *Item) Validate() error {
+	if it.Name == "" {
+		return fmt.Errorf("elog.Item: Name is empty")
+	}
+	if it.Agg != AggNone && !it.CanAgg() {
+		return fmt.Errorf("elog.Item: %v: Agg can only be used for scalar numerical items", it.Name)
+	}
+	return nil
+}
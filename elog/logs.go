@@ -0,0 +1,290 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package elog
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/emer/emergent/env"
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+)
+
+// Logs contains all the logging state and configuration: the Items to log,
+// the Times in order from lowest to highest for aggregation, and the
+// resulting Tables for each Scope (Mode + Time).
+type Logs struct {
+	Items   []*Item                 `desc:"items to log, in column order"`
+	Times   []env.TimeScales        `desc:"time scales in order from lowest to highest (e.g., Cycle, Trial, Epoch, Run), which determines the aggregation of items from lower to higher time scales"`
+	Tables  map[Scope]*etable.Table `desc:"log tables for each scope, created by Config"`
+	Context Context                 `desc:"context passed to compute functions -- set the Net and Env here as needed"`
+	ItemIdx map[string]int          `view:"-" desc:"index of items by name"`
+	Files   map[Scope]*os.File      `view:"-" desc:"files that logs are being written to, for each scope"`
+	Delim   etable.Delims           `desc:"delimiter for log files opened with SetLogFile: Tab (default) for .tsv files, or Comma for .csv -- use SetLogFileDelim to set it for a given scope"`
+
+	hdrs   map[Scope]bool              // whether headers have been written to file for scope
+	delims map[Scope]etable.Delims     // delimiter for file for scope
+	lyTsrs map[string]*etensor.Float32 // tensors for SetLayerTensor
+}
+
+// AddItem adds given item to the list of items to log, returning the item.
+// Must be called prior to Config.
+func (lg *Logs) AddItem(it *Item) *Item {
+	if lg.ItemIdx == nil {
+		lg.ItemIdx = make(map[string]int)
+	}
+	lg.ItemIdx[it.Name] = len(lg.Items)
+	lg.Items = append(lg.Items, it)
+	return it
+}
+
+// ItemByName returns item of given name, nil if not found
+func (lg *Logs) ItemByName(name string) *Item {
+	idx, has := lg.ItemIdx[name]
+	if !has {
+		return nil
+	}
+	return lg.Items[idx]
+}
+
+// LowerTime returns the time scale just below given one in the Times order,
+// false if none
+func (lg *Logs) LowerTime(t env.TimeScales) (env.TimeScales, bool) {
+	for i, ti := range lg.Times {
+		if ti == t && i > 0 {
+			return lg.Times[i-1], true
+		}
+	}
+	return t, false
+}
+
+// IsActive returns true if the item is logged in given scope: either it has an
+// explicit compute function for that scope, or it can be aggregated and is
+// active at the next lower time scale in the same mode.
+func (lg *Logs) IsActive(it *Item, sc Scope) bool {
+	if it.HasCompute(sc) {
+		return true
+	}
+	if !it.CanAgg() {
+		return false
+	}
+	lt, ok := lg.LowerTime(sc.Time)
+	if !ok {
+		return false
+	}
+	return lg.IsActive(it, Scope{sc.Mode, lt})
+}
+
+// Scopes returns the list of all scopes that have at least one active item,
+// ordered by mode (in order of first appearance among the items) and
+// then by the Times order
+func (lg *Logs) Scopes() []Scope {
+	var modes []string
+	times := append([]env.TimeScales{}, lg.Times...)
+	hasMode := make(map[string]bool)
+	hasTime := make(map[env.TimeScales]bool)
+	for _, t := range times {
+		hasTime[t] = true
+	}
+	for _, it := range lg.Items {
+		for sc := range it.Compute {
+			if !hasMode[sc.Mode] {
+				hasMode[sc.Mode] = true
+				modes = append(modes, sc.Mode)
+			}
+			if !hasTime[sc.Time] {
+				hasTime[sc.Time] = true
+				times = append(times, sc.Time)
+			}
+		}
+	}
+	var scs []Scope
+	for _, m := range modes {
+		for _, t := range times {
+			sc := Scope{m, t}
+			for _, it := range lg.Items {
+				if lg.IsActive(it, sc) {
+					scs = append(scs, sc)
+					break
+				}
+			}
+		}
+	}
+	return scs
+}
+
+// Config validates the items and creates the log Tables for each scope,
+// with a column for each item active in that scope.  Must be called
+// after all items have been added, and before logging.
+func (lg *Logs) Config() error {
+	for _, it := range lg.Items {
+		if err := it.Validate(); err != nil {
+			return err
+		}
+	}
+	lg.Tables = make(map[Scope]*etable.Table)
+	for _, sc := range lg.Scopes() {
+		var sch etable.Schema
+		for _, it := range lg.Items {
+			if lg.IsActive(it, sc) {
+				sch = append(sch, etable.Column{Name: it.Name, Type: it.Type, CellShape: it.CellShape, DimNames: it.DimNames})
+			}
+		}
+		dt := &etable.Table{}
+		dt.SetFromSchema(sch, 0)
+		dt.SetMetaData("name", sc.String()+"Log")
+		dt.SetMetaData("desc", "Record of "+sc.Mode+" data at the "+sc.Time.String()+" scale")
+		lg.Tables[sc] = dt
+	}
+	lg.Context.Logs = lg
+	return nil
+}
+
+// Table returns the log table for given mode and time, nil if none
+func (lg *Logs) Table(mode string, time env.TimeScales) *etable.Table {
+	return lg.Tables[Scope{mode, time}]
+}
+
+// Log adds a new row to the log for given mode and time, computes all the
+// items for that row, and writes the row to the log file if one is set.
+// Returns the row number, or -1 if there is no log for that scope.
+func (lg *Logs) Log(mode string, time env.TimeScales) int {
+	dt := lg.Table(mode, time)
+	if dt == nil {
+		return -1
+	}
+	row := dt.Rows
+	dt.SetNumRows(row + 1)
+	lg.LogRow(mode, time, row)
+	return row
+}
+
+// LogRow computes all the items for given row in the log for given mode and time,
+// and writes the row to the log file if one is set.  The row must already exist.
+func (lg *Logs) LogRow(mode string, time env.TimeScales, row int) {
+	sc := Scope{mode, time}
+	dt := lg.Tables[sc]
+	if dt == nil {
+		return
+	}
+	ctx := &lg.Context
+	ctx.Logs = lg
+	ctx.Scope = sc
+	ctx.Table = dt
+	ctx.Row = row
+	for _, it := range lg.Items {
+		if fun, has := it.Compute[sc]; has {
+			ctx.Item = it
+			fun(ctx)
+		} else if lg.IsActive(it, sc) {
+			lg.aggItem(it, sc, dt, row)
+		}
+	}
+	lg.writeRow(sc, dt, row)
+}
+
+// aggItem computes the aggregate value of item over the next lower time scale log
+func (lg *Logs) aggItem(it *Item, sc Scope, dt *etable.Table, row int) {
+	lt, _ := lg.LowerTime(sc.Time)
+	ldt := lg.Table(sc.Mode, lt)
+	if ldt == nil {
+		return
+	}
+	vals := make([]float64, ldt.Rows)
+	for r := range vals {
+		vals[r] = ldt.CellFloat(it.Name, r)
+	}
+	dt.SetCellFloat(it.Name, row, it.Agg.Agg(vals))
+}
+
+// ResetLog resets the log for given mode and time to have no rows.
+// Typically the lower-level logs are reset at the start of each higher-level
+// period (e.g., the Trial log at the start of each Epoch), so that the aggregation
+// is computed over the values within that period.
+func (lg *Logs) ResetLog(mode string, time env.TimeScales) {
+	dt := lg.Table(mode, time)
+	if dt == nil {
+		return
+	}
+	dt.SetNumRows(0)
+}
+
+// SetLogFile sets the log file for given mode and time, which will be
+// written as rows are logged, with headers, using the Delim delimiter
+// (tab-separated by default).  Any existing file for that scope is closed.
+func (lg *Logs) SetLogFile(mode string, time env.TimeScales, filename string) error {
+	return lg.SetLogFileDelim(mode, time, filename, lg.Delim)
+}
+
+// SetLogFileDelim sets the log file for given mode and time, which will be
+// written as rows are logged, with headers, using given delimiter (Tab,
+// Comma, or Space).  Any existing file for that scope is closed.
+func (lg *Logs) SetLogFileDelim(mode string, time env.TimeScales, filename string, delim etable.Delims) error {
+	sc := Scope{mode, time}
+	if lg.Table(mode, time) == nil {
+		err := fmt.Errorf("elog.SetLogFile: no log for scope: %v", sc)
+		log.Println(err)
+		return err
+	}
+	if delim != etable.Tab && delim != etable.Comma && delim != etable.Space {
+		err := fmt.Errorf("elog.SetLogFile: invalid delimiter: %v for scope: %v", delim, sc)
+		log.Println(err)
+		return err
+	}
+	if lg.Files == nil {
+		lg.Files = make(map[Scope]*os.File)
+		lg.hdrs = make(map[Scope]bool)
+		lg.delims = make(map[Scope]etable.Delims)
+	}
+	if fp, has := lg.Files[sc]; has {
+		fp.Close()
+	}
+	fp, err := os.Create(filename)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	lg.Files[sc] = fp
+	lg.hdrs[sc] = false
+	lg.delims[sc] = delim
+	return nil
+}
+
+// CloseLogFiles closes all the log files
+func (lg *Logs) CloseLogFiles() {
+	for sc, fp := range lg.Files {
+		fp.Close()
+		delete(lg.Files, sc)
+	}
+}
+
+// writeRow writes given row to the log file for given scope, if set
+func (lg *Logs) writeRow(sc Scope, dt *etable.Table, row int) {
+	fp, has := lg.Files[sc]
+	if !has {
+		return
+	}
+	delim := lg.delims[sc]
+	if !lg.hdrs[sc] {
+		dt.WriteCSVHeaders(fp, delim)
+		lg.hdrs[sc] = true
+	}
+	dt.WriteCSVRow(fp, row, delim)
+}
+
+// layerTensor returns a tensor for layer values, for SetLayerTensor
+func (lg *Logs) layerTensor(layNm string, shp []int) *etensor.Float32 {
+	if lg.lyTsrs == nil {
+		lg.lyTsrs = make(map[string]*etensor.Float32)
+	}
+	tsr, has := lg.lyTsrs[layNm]
+	if !has {
+		tsr = etensor.NewFloat32(shp, nil, nil)
+		lg.lyTsrs[layNm] = tsr
+	}
+	return tsr
+}
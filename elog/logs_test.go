@@ -0,0 +1,263 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package elog
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/emer/emergent/env"
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+)
+
+// testLogs returns logs with Cycle, Trial and Epoch scopes in Train mode,
+// where Act is computed at the Cycle level from *act and aggregated above,
+// and Cyc is only logged at the Cycle level
+func testLogs(act *float64, cyc *int) *Logs {
+	lg := &Logs{}
+	lg.Times = []env.TimeScales{env.Cycle, env.Trial, env.Epoch}
+	lg.AddItem(&Item{Name: "Cyc", Type: etensor.INT64, Compute: ComputeMap{
+		Scope{"Train", env.Cycle}: func(ctx *Context) {
+			ctx.SetInt(*cyc)
+		}}})
+	lg.AddItem(&Item{Name: "Act", Type: etensor.FLOAT64, Agg: AggMean, Compute: ComputeMap{
+		Scope{"Train", env.Cycle}: func(ctx *Context) {
+			ctx.SetFloat64(*act)
+		}}})
+	lg.AddItem(&Item{Name: "MaxAct", Type: etensor.FLOAT64, Compute: ComputeMap{
+		Scope{"Train", env.Trial}: func(ctx *Context) {
+			dt := ctx.LowerTable()
+			mx := 0.0
+			for r := 0; r < dt.Rows; r++ {
+				if v := dt.CellFloat("Act", r); v > mx {
+					mx = v
+				}
+			}
+			ctx.SetFloat64(mx)
+		}}})
+	return lg
+}
+
+func TestCycleTimeScale(t *testing.T) {
+	if nm := env.Cycle.String(); nm != "Cycle" {
+		t.Errorf("env.Cycle.String(): %v != Cycle", nm)
+	}
+	var ts env.TimeScales
+	if err := ts.FromString("Cycle"); err != nil || ts != env.Cycle {
+		t.Errorf("FromString(Cycle): %v, %v", ts, err)
+	}
+	if (Scope{"Train", env.Cycle}).String() != "TrainCycle" {
+		t.Errorf("Scope String: %v != TrainCycle", Scope{"Train", env.Cycle})
+	}
+}
+
+func TestLogsConfig(t *testing.T) {
+	var act float64
+	var cyc int
+	lg := testLogs(&act, &cyc)
+	if err := lg.Config(); err != nil {
+		t.Fatal(err)
+	}
+	scs := lg.Scopes()
+	exscs := []Scope{{"Train", env.Cycle}, {"Train", env.Trial}, {"Train", env.Epoch}}
+	if len(scs) != len(exscs) {
+		t.Fatalf("Scopes: %v != %v", scs, exscs)
+	}
+	for i := range scs {
+		if scs[i] != exscs[i] {
+			t.Errorf("Scopes[%d]: %v != %v", i, scs[i], exscs[i])
+		}
+	}
+	excols := map[env.TimeScales][]string{
+		env.Cycle: {"Cyc", "Act"},
+		env.Trial: {"Act", "MaxAct"},
+		env.Epoch: {"Act"},
+	}
+	for tm, cols := range excols {
+		dt := lg.Table("Train", tm)
+		if dt == nil {
+			t.Errorf("no table for Train %v", tm)
+			continue
+		}
+		if strings.Join(dt.ColNames, ",") != strings.Join(cols, ",") {
+			t.Errorf("Train %v columns: %v != %v", tm, dt.ColNames, cols)
+		}
+		if dt.Rows != 0 {
+			t.Errorf("Train %v rows: %d != 0", tm, dt.Rows)
+		}
+	}
+	if dt := lg.Table("Test", env.Trial); dt != nil {
+		t.Errorf("unexpected table for Test Trial")
+	}
+	if row := lg.Log("Test", env.Trial); row != -1 {
+		t.Errorf("Log for missing scope: %d != -1", row)
+	}
+}
+
+func TestLogsConfigErr(t *testing.T) {
+	lg := &Logs{}
+	lg.Times = []env.TimeScales{env.Trial, env.Epoch}
+	lg.AddItem(&Item{Name: "Name", Type: etensor.STRING, Agg: AggMean})
+	if err := lg.Config(); err == nil {
+		t.Errorf("Config did not return error for Agg of a string item")
+	}
+	lg = &Logs{}
+	lg.AddItem(&Item{Type: etensor.FLOAT64})
+	if err := lg.Config(); err == nil {
+		t.Errorf("Config did not return error for empty item Name")
+	}
+}
+
+func TestLogsRows(t *testing.T) {
+	var act float64
+	var cyc int
+	lg := testLogs(&act, &cyc)
+	if err := lg.Config(); err != nil {
+		t.Fatal(err)
+	}
+	for trl := 0; trl < 2; trl++ {
+		lg.ResetLog("Train", env.Cycle)
+		for cyc = 0; cyc < 4; cyc++ {
+			act = float64(trl*10 + cyc)
+			if row := lg.Log("Train", env.Cycle); row != cyc {
+				t.Errorf("Cycle log row: %d != %d", row, cyc)
+			}
+		}
+		if row := lg.Log("Train", env.Trial); row != trl {
+			t.Errorf("Trial log row: %d != %d", row, trl)
+		}
+	}
+	lg.Log("Train", env.Epoch)
+
+	cdt := lg.Table("Train", env.Cycle)
+	if cdt.Rows != 4 {
+		t.Errorf("Cycle rows after reset: %d != 4", cdt.Rows)
+	}
+	for r := 0; r < cdt.Rows; r++ {
+		if v := cdt.CellFloat("Cyc", r); v != float64(r) {
+			t.Errorf("Cycle Cyc[%d]: %v != %v", r, v, r)
+		}
+		if v := cdt.CellFloat("Act", r); v != float64(10+r) {
+			t.Errorf("Cycle Act[%d]: %v != %v", r, v, 10+r)
+		}
+	}
+	tdt := lg.Table("Train", env.Trial)
+	exmean := []float64{1.5, 11.5}
+	exmax := []float64{3, 13}
+	for r := 0; r < 2; r++ {
+		if v := tdt.CellFloat("Act", r); v != exmean[r] {
+			t.Errorf("Trial Act[%d] mean: %v != %v", r, v, exmean[r])
+		}
+		if v := tdt.CellFloat("MaxAct", r); v != exmax[r] {
+			t.Errorf("Trial MaxAct[%d]: %v != %v", r, v, exmax[r])
+		}
+	}
+	edt := lg.Table("Train", env.Epoch)
+	if v := edt.CellFloat("Act", 0); v != 6.5 {
+		t.Errorf("Epoch Act mean: %v != 6.5", v)
+	}
+}
+
+func TestLogFile(t *testing.T) {
+	var act float64
+	var cyc int
+	lg := testLogs(&act, &cyc)
+	if err := lg.Config(); err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "elog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fn := filepath.Join(dir, "train_cycle.tsv")
+	if err := lg.SetLogFile("Train", env.Cycle, fn); err != nil {
+		t.Fatal(err)
+	}
+	if err := lg.SetLogFile("Test", env.Cycle, fn); err == nil {
+		t.Errorf("SetLogFile did not return error for missing scope")
+	}
+	for cyc = 0; cyc < 3; cyc++ {
+		act = float64(cyc) / 2
+		lg.Log("Train", env.Cycle)
+	}
+	lg.CloseLogFiles()
+	if len(lg.Files) != 0 {
+		t.Errorf("files still open after CloseLogFiles: %d", len(lg.Files))
+	}
+	b, err := ioutil.ReadFile(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("log file lines: %d != 4 (header + 3 rows):\n%s", len(lines), string(b))
+	}
+	if !strings.Contains(lines[0], "Cyc") || !strings.Contains(lines[0], "Act") {
+		t.Errorf("log file header missing columns: %v", lines[0])
+	}
+	for i, ln := range lines[1:] {
+		flds := strings.Split(ln, "\t")
+		if len(flds) < 2 || flds[len(flds)-2] != []string{"0", "1", "2"}[i] {
+			t.Errorf("log file row %d: %v", i, ln)
+		}
+	}
+}
+
+func TestLogFileDelim(t *testing.T) {
+	var act float64
+	var cyc int
+	lg := testLogs(&act, &cyc)
+	if err := lg.Config(); err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "elog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	lg.Delim = etable.Comma
+	cfn := filepath.Join(dir, "train_cycle.csv")
+	if err := lg.SetLogFile("Train", env.Cycle, cfn); err != nil {
+		t.Fatal(err)
+	}
+	tfn := filepath.Join(dir, "train_trial.tsv")
+	if err := lg.SetLogFileDelim("Train", env.Trial, tfn, etable.Tab); err != nil {
+		t.Fatal(err)
+	}
+	if err := lg.SetLogFileDelim("Train", env.Epoch, filepath.Join(dir, "bad.tsv"), etable.Detect); err == nil {
+		t.Errorf("SetLogFileDelim did not return error for Detect delimiter")
+	}
+	for cyc = 0; cyc < 2; cyc++ {
+		act = float64(cyc)
+		lg.Log("Train", env.Cycle)
+	}
+	lg.Log("Train", env.Trial)
+	lg.CloseLogFiles()
+
+	for _, fd := range []struct {
+		fn    string
+		delim string
+		rows  int
+	}{{cfn, ",", 2}, {tfn, "\t", 1}} {
+		b, err := ioutil.ReadFile(fd.fn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+		if len(lines) != fd.rows+1 {
+			t.Fatalf("%s: lines: %d != %d:\n%s", fd.fn, len(lines), fd.rows+1, string(b))
+		}
+		for _, ln := range lines {
+			if flds := strings.Split(ln, fd.delim); len(flds) < 2 {
+				t.Errorf("%s: line not delimited by %q: %v", fd.fn, fd.delim, ln)
+			}
+		}
+	}
+}
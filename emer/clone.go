@@ -0,0 +1,185 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"unsafe"
+)
+
+// NetworkCloner is an optional interface for networks that can make a deep
+// copy of themselves, including all of their layers, projections, and learned
+// state (weights etc), e.g., for ensembling or parallel what-if testing from
+// the same starting weights.  Networks with state that cannot be copied
+// generically by CloneNetwork (e.g., worker goroutines) should implement it.
+type NetworkCloner interface {
+	// Clone returns a deep copy of the (built) network with given name,
+	// that shares no state with this network
+	Clone(name string) (Network, error)
+}
+
+// CloneNetwork returns a deep copy of given (built) network with given name,
+// using its Clone method if it implements NetworkCloner, and otherwise copying
+// all of its state via reflection (see DeepCopy), after which InitName is
+// called on the copy with the new name.  Networks with channels (e.g., for
+// worker threads) cannot be copied via reflection and return an error, so
+// they must implement NetworkCloner.
+func CloneNetwork(net Network, name string) (Network, error) {
+	if nc, ok := net.(NetworkCloner); ok {
+		nn, err := nc.Clone(name)
+		if err != nil {
+			log.Println(err)
+		}
+		return nn, err
+	}
+	cp, err := DeepCopy(net)
+	if err != nil {
+		return nil, err
+	}
+	nn := cp.(Network)
+	nn.InitName(nn, name)
+	return nn, nil
+}
+
+// DeepCopy returns a deep copy of given object (typically a pointer), copying
+// everything it refers to, including unexported fields, with each pointer,
+// map, and slice copied only once so that references among the copied
+// objects (e.g., from layers and projections back to the network) refer to
+// the copies.  Pointers into the interior of other objects (e.g., to an
+// element of a slice) are copied separately.  Functions are shared, not
+// copied.  Channels cannot be copied, as the copy would share any worker
+// threads (goroutines) communicating over them, so an error is returned
+// if there are any non-nil channels.
+func DeepCopy(obj interface{}) (cp interface{}, err error) {
+	if obj == nil {
+		err = fmt.Errorf("emer.DeepCopy: object is nil")
+		log.Println(err)
+		return nil, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("emer.DeepCopy: type: %T could not be copied: %v", obj, r)
+			log.Println(err)
+			cp = nil
+		}
+	}()
+	cl := &deepCopier{done: make(map[deepCopyKey]reflect.Value)}
+	cp = cl.copy(reflect.ValueOf(obj)).Interface()
+	if cl.err != nil {
+		err = fmt.Errorf("emer.DeepCopy: type: %T could not be copied: %v", obj, cl.err)
+		log.Println(err)
+		return nil, err
+	}
+	return cp, nil
+}
+
+// deepCopyKey identifies an object that has already been copied
+type deepCopyKey struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+// deepCopier makes deep copies, recording the copies made of each object
+type deepCopier struct {
+	done map[deepCopyKey]reflect.Value
+	err  error
+}
+
+// copy returns a deep copy of given value
+func (cl *deepCopier) copy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		key := deepCopyKey{ptr: v.Pointer(), typ: v.Type()}
+		if nv, ok := cl.done[key]; ok {
+			return nv
+		}
+		nv := reflect.New(v.Type().Elem())
+		cl.done[key] = nv
+		cl.copyInto(nv.Elem(), v.Elem())
+		return nv
+	case reflect.Interface:
+		nv := reflect.New(v.Type()).Elem()
+		if !v.IsNil() {
+			nv.Set(cl.copy(v.Elem()))
+		}
+		return nv
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		key := deepCopyKey{ptr: v.Pointer(), typ: v.Type(), len: v.Len()}
+		if nv, ok := cl.done[key]; ok {
+			return nv
+		}
+		nv := reflect.MakeSlice(v.Type(), v.Len(), v.Cap())
+		cl.done[key] = nv
+		for i := 0; i < v.Len(); i++ {
+			cl.copyInto(nv.Index(i), v.Index(i))
+		}
+		return nv
+	case reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		key := deepCopyKey{ptr: v.Pointer(), typ: v.Type()}
+		if nv, ok := cl.done[key]; ok {
+			return nv
+		}
+		nv := reflect.MakeMapWithSize(v.Type(), v.Len())
+		cl.done[key] = nv
+		for _, k := range v.MapKeys() {
+			nv.SetMapIndex(cl.copy(k), cl.copy(v.MapIndex(k)))
+		}
+		return nv
+	case reflect.Chan:
+		if !v.IsNil() && cl.err == nil { // would otherwise share the channel, e.g., of worker threads
+			cl.err = fmt.Errorf("channel of type: %v cannot be copied", v.Type())
+		}
+		return reflect.Zero(v.Type())
+	case reflect.Struct, reflect.Array:
+		nv := reflect.New(v.Type()).Elem()
+		if !v.CanAddr() {
+			av := reflect.New(v.Type()).Elem()
+			av.Set(v)
+			v = av
+		}
+		cl.copyInto(nv, v)
+		return nv
+	default:
+		return v
+	}
+}
+
+// copyInto sets dst to a deep copy of src, where dst is settable and
+// structs and arrays are addressable
+func (cl *deepCopier) copyInto(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			cl.copyInto(fieldValue(dst.Field(i)), fieldValue(src.Field(i)))
+		}
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			cl.copyInto(dst.Index(i), src.Index(i))
+		}
+	default:
+		dst.Set(cl.copy(src))
+	}
+}
+
+// fieldValue returns given addressable struct field value such that it can
+// be read and set, even if it is unexported
+func fieldValue(v reflect.Value) reflect.Value {
+	if v.CanSet() {
+		return v
+	}
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
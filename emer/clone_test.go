@@ -0,0 +1,188 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"errors"
+	"testing"
+)
+
+type cpNode struct {
+	Name   string
+	Parent *cpNode
+	Kids   []*cpNode
+	Attrs  map[string]*cpNode
+	Val    interface{}
+	Fun    func() int
+	vals   []float32
+	valp   *float32
+	arr    [2]*cpNode
+	ch     chan int
+	shared *cpShared
+}
+
+type cpShared struct {
+	N int
+}
+
+type cpValer interface {
+	Value() int
+}
+
+func (sh *cpShared) Value() int { return sh.N }
+
+func TestDeepCopyCycles(t *testing.T) {
+	root := &cpNode{Name: "root"}
+	kid := &cpNode{Name: "kid", Parent: root}
+	root.Kids = []*cpNode{kid}
+	root.arr[0] = root
+	root.Val = root // interface with cycle
+	cp, err := DeepCopy(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cr := cp.(*cpNode)
+	if cr == root || cr.Name != "root" || len(cr.Kids) != 1 || cr.Kids[0] == kid {
+		t.Fatalf("copy: %+v", cr)
+	}
+	if cr.Kids[0].Parent != cr || cr.arr[0] != cr || cr.Val.(*cpNode) != cr {
+		t.Errorf("cycles do not refer to the copy")
+	}
+}
+
+func TestDeepCopyShared(t *testing.T) {
+	sh := &cpShared{N: 1}
+	vals := []float32{1, 2, 3}
+	a := &cpNode{Name: "a", shared: sh, vals: vals, valp: &vals[1]}
+	b := &cpNode{Name: "b", shared: sh, vals: vals}
+	root := &cpNode{Kids: []*cpNode{a, b, a}}
+	cp, err := DeepCopy(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cr := cp.(*cpNode)
+	ca, cb := cr.Kids[0], cr.Kids[1]
+	if cr.Kids[2] != ca {
+		t.Errorf("repeated pointer not copied once")
+	}
+	if ca.shared == sh || ca.shared != cb.shared || ca.shared.N != 1 {
+		t.Errorf("shared pointer: %p %p %p", sh, ca.shared, cb.shared)
+	}
+	ca.vals[0] = 10
+	if vals[0] != 1 || cb.vals[0] != 10 {
+		t.Errorf("shared slice: orig: %v copies: %v %v", vals, ca.vals, cb.vals)
+	}
+	if ca.valp == &vals[1] || *ca.valp != 2 { // interior pointer copied separately
+		t.Errorf("interior pointer: %v", *ca.valp)
+	}
+}
+
+func TestDeepCopyMaps(t *testing.T) {
+	kid := &cpNode{Name: "kid"}
+	root := &cpNode{Kids: []*cpNode{kid}, Attrs: map[string]*cpNode{"k": kid, "nil": nil}}
+	kid.Attrs = root.Attrs // same map twice
+	cp, err := DeepCopy(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cr := cp.(*cpNode)
+	if len(cr.Attrs) != 2 || cr.Attrs["k"] != cr.Kids[0] || cr.Attrs["nil"] != nil {
+		t.Errorf("map: %v", cr.Attrs)
+	}
+	cr.Attrs["new"] = cr
+	if len(root.Attrs) != 2 || len(cr.Kids[0].Attrs) != 3 {
+		t.Errorf("map not copied once: orig: %v copy: %v", root.Attrs, cr.Kids[0].Attrs)
+	}
+	cp, err = DeepCopy(map[cpShared][]int{{N: 1}: {1, 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cm := cp.(map[cpShared][]int); len(cm[cpShared{N: 1}]) != 2 {
+		t.Errorf("map value: %v", cm)
+	}
+}
+
+func TestDeepCopyInterfaces(t *testing.T) {
+	sh := &cpShared{N: 3}
+	var vl cpValer = sh
+	root := &cpNode{Val: vl, shared: sh, Fun: func() int { return 7 }}
+	kid := &cpNode{Val: cpShared{N: 4}}
+	root.Kids = []*cpNode{kid}
+	cp, err := DeepCopy(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cr := cp.(*cpNode)
+	cv, ok := cr.Val.(cpValer)
+	if !ok || cv.Value() != 3 || cr.Val.(*cpShared) == sh || cr.Val.(*cpShared) != cr.shared {
+		t.Errorf("interface pointer: %v", cr.Val)
+	}
+	if cr.Kids[0].Val.(cpShared).N != 4 {
+		t.Errorf("interface value: %v", cr.Kids[0].Val)
+	}
+	if cr.Fun == nil || cr.Fun() != 7 {
+		t.Errorf("function not shared")
+	}
+	cp, err = DeepCopy(vl)
+	if err != nil || cp.(cpValer).Value() != 3 || cp.(*cpShared) == sh {
+		t.Errorf("top-level interface: %v %v", cp, err)
+	}
+}
+
+func TestDeepCopyChannels(t *testing.T) {
+	root := &cpNode{Name: "root"}
+	if _, err := DeepCopy(root); err != nil { // nil channel is ok
+		t.Errorf("nil channel: %v", err)
+	}
+	root.Kids = []*cpNode{{ch: make(chan int)}}
+	if cp, err := DeepCopy(root); err == nil || cp != nil {
+		t.Errorf("channel: no error: %v", cp)
+	}
+	if cp, err := DeepCopy(nil); err == nil || cp != nil {
+		t.Errorf("nil: no error: %v", cp)
+	}
+}
+
+// testClonerNet is a network implementing NetworkCloner
+type testClonerNet struct {
+	testNet
+	err error
+}
+
+func (nt *testClonerNet) Clone(name string) (Network, error) {
+	if nt.err != nil {
+		return nil, nt.err
+	}
+	return newTestNet(name), nil
+}
+
+func TestCloneNetwork(t *testing.T) {
+	nt := testNet3()
+	cn, err := CloneNetwork(nt, "Copy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp := cn.(*testNet)
+	if cp == nt || cp.Name() != "Copy" || nt.Name() != "Test" || cp.NLayers() != 3 || cp.lays[1] == nt.lays[1] {
+		t.Fatalf("CloneNetwork: %v %v", cp.Name(), cp.NLayers())
+	}
+	pj := cp.lays[1].rcv[0].(*testPrjn)
+	if pj.SendLay() != cp.lays[0] || pj.RecvLay() != cp.lays[1] || cp.lays[0].snd[0] != pj {
+		t.Errorf("CloneNetwork: projections do not refer to the copied layers")
+	}
+	pj.wts[0] = 100
+	if nt.lays[1].rcv[0].(*testPrjn).wts[0] != 0 {
+		t.Errorf("CloneNetwork: weights are shared")
+	}
+
+	cnt := &testClonerNet{}
+	if cn, err := CloneNetwork(cnt, "Cloned"); err != nil || cn.Name() != "Cloned" {
+		t.Errorf("NetworkCloner: %v %v", cn, err)
+	}
+	cnt.err = errors.New("cannot clone")
+	if _, err := CloneNetwork(cnt, "Cloned"); err != cnt.err {
+		t.Errorf("NetworkCloner error: %v", err)
+	}
+}
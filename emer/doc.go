@@ -10,9 +10,24 @@ including:
 These interfaces are intended to be just sufficient to support visualization and generic
 analysis kinds of functions, but explicitly avoid exposing ANY of the algorithmic aspects,
 so that those can be purely encoded in the implementation structs.
+For example, emer.NetSyns and emer.PrjnSyns iterate over all of the synapses in a network
+or projection, with access to their variables, for weight histograms, pruning, etc.,
+and emer.NetSynStats returns a table of summary stats of a synapse variable for each
+projection, for monitoring learning.
+Layers can be lesioned as a whole (SetOff), or unit by unit if they implement the optional
+emer.LayerLesioner interface, including a random fraction of units with a given seed
+(emer.LesionUnitsFrac), for graded lesions.
 
 At this point, given the extra complexity it would require, these interfaces do not support
-the ability to build or modify networks.
+the ability to build networks, but networks that implement the optional emer.NetworkEditor
+interface can add and delete layers and projections after they are built (see
+emer.AddLayerBuilt, ConnectBuilt, DeleteLayerName, and DeletePrjnNames), e.g., for
+growing architectures and ablation experiments.  A built network can be duplicated in memory,
+with all of its learned state, by emer.CloneNetwork, e.g., for an emer.Ensemble of copies
+that receive the same inputs, with their outputs averaged and the variance across copies.
+The architecture of a network can be written as a Graphviz DOT or GraphML description
+(emer.SaveDOT, SaveGraphML), e.g., for generating diagrams for papers, and emer.LayerOrder
+sorts the layers in computation order by their projections, e.g., for AssignThreads.
 
 */
 package emer
@@ -0,0 +1,172 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/emer/etable/etensor"
+)
+
+// LayerExter is an optional interface for layers that can have external
+// input (or target) patterns applied to them, as used by Ensemble.ApplyExt
+type LayerExter interface {
+	// ApplyExt applies external input in the form of an etensor.Tensor
+	ApplyExt(ext etensor.Tensor)
+}
+
+// Ensemble holds N copies of a network (typically with different initial
+// weights), to which the same inputs are applied, with the outputs averaged
+// across copies along with their variance, for uncertainty estimation and
+// more robust evaluation.
+type Ensemble struct {
+	Nets     []Network `desc:"the networks in the ensemble"`
+	Parallel bool      `desc:"run each network in a separate goroutine in Run"`
+}
+
+// NewEnsemble returns a new Ensemble of given networks, e.g., each configured
+// and built by the same function with a different random seed
+func NewEnsemble(nets ...Network) *Ensemble {
+	return &Ensemble{Nets: nets}
+}
+
+// CloneEnsemble returns a new Ensemble of n copies of given (built) network,
+// the first of which is the network itself, and the others made by
+// CloneNetwork with names Name_1, Name_2, etc.  Re-initialize the weights of
+// each copy with a different random seed as needed.
+func CloneEnsemble(net Network, n int) (*Ensemble, error) {
+	en := &Ensemble{Nets: []Network{net}}
+	for i := 1; i < n; i++ {
+		cn, err := CloneNetwork(net, fmt.Sprintf("%s_%d", net.Name(), i))
+		if err != nil {
+			return nil, err
+		}
+		en.Nets = append(en.Nets, cn)
+	}
+	return en, nil
+}
+
+// N returns the number of networks in the ensemble
+func (en *Ensemble) N() int {
+	return len(en.Nets)
+}
+
+// Run calls given function on each network in the ensemble, e.g., to run a
+// trial, in separate goroutines if Parallel, waiting until all are done
+func (en *Ensemble) Run(fun func(idx int, net Network)) {
+	if !en.Parallel {
+		for i, net := range en.Nets {
+			fun(i, net)
+		}
+		return
+	}
+	var wg sync.WaitGroup
+	for i, net := range en.Nets {
+		wg.Add(1)
+		go func(i int, net Network) {
+			fun(i, net)
+			wg.Done()
+		}(i, net)
+	}
+	wg.Wait()
+}
+
+// ApplyExt applies the same external input pattern to the layer of given
+// name in each network in the ensemble (see LayerExter)
+func (en *Ensemble) ApplyExt(layNm string, ext etensor.Tensor) error {
+	for _, net := range en.Nets {
+		ly, err := net.LayerByNameTry(layNm)
+		if err != nil {
+			return err
+		}
+		le, ok := ly.(LayerExter)
+		if !ok {
+			err := fmt.Errorf("emer.Ensemble.ApplyExt: layer: %v does not support external input (emer.LayerExter)", layNm)
+			log.Println(err)
+			return err
+		}
+		le.ApplyExt(ext)
+	}
+	return nil
+}
+
+// UnitMeanVar computes the mean and variance across the networks in the
+// ensemble of given variable on each unit in the layer of given name, into
+// given slices (resized as needed), in the order of Layer.UnitVals
+func (en *Ensemble) UnitMeanVar(layNm, varNm string, mean, vr *[]float32) error {
+	if len(en.Nets) == 0 {
+		err := fmt.Errorf("emer.Ensemble.UnitMeanVar: no networks in ensemble")
+		log.Println(err)
+		return err
+	}
+	var vals []float32
+	n := float32(len(en.Nets))
+	for ni, net := range en.Nets {
+		ly, err := net.LayerByNameTry(layNm)
+		if err != nil {
+			return err
+		}
+		if err := ly.UnitVals(&vals, varNm); err != nil {
+			log.Println(err)
+			return err
+		}
+		if ni == 0 {
+			*mean = resizeF32(*mean, len(vals))
+			*vr = resizeF32(*vr, len(vals))
+		}
+		if len(vals) != len(*mean) {
+			err := fmt.Errorf("emer.Ensemble.UnitMeanVar: layer: %v in network: %v has a different number of units", layNm, net.Name())
+			log.Println(err)
+			return err
+		}
+		for i, v := range vals {
+			(*mean)[i] += v
+			(*vr)[i] += v * v
+		}
+	}
+	for i, s := range *mean {
+		m := s / n
+		(*mean)[i] = m
+		v := (*vr)[i]/n - m*m
+		if v < 0 {
+			v = 0
+		}
+		(*vr)[i] = v
+	}
+	return nil
+}
+
+// UnitMeanVarTensor computes the mean and variance across the networks in the
+// ensemble of given variable on each unit in the layer of given name (see
+// UnitMeanVar), into given tensors, which are set to the shape of the layer
+func (en *Ensemble) UnitMeanVarTensor(layNm, varNm string, mean, vr etensor.Tensor) error {
+	var mv, vv []float32
+	if err := en.UnitMeanVar(layNm, varNm, &mv, &vv); err != nil {
+		return err
+	}
+	shp := en.Nets[0].LayerByName(layNm).Shape()
+	for _, ts := range []etensor.Tensor{mean, vr} {
+		ts.SetShape(shp.Shp, shp.Strd, shp.Nms)
+	}
+	for i := range mv {
+		mean.SetFloat1D(i, float64(mv[i]))
+		vr.SetFloat1D(i, float64(vv[i]))
+	}
+	return nil
+}
+
+// resizeF32 returns given slice with given length, with all values zero
+func resizeF32(vals []float32, n int) []float32 {
+	if cap(vals) < n {
+		vals = make([]float32, n)
+	}
+	vals = vals[:n]
+	for i := range vals {
+		vals[i] = 0
+	}
+	return vals
+}
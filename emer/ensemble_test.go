@@ -0,0 +1,164 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"math"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/emer/etable/etensor"
+)
+
+// extLay is a mock layer implementing LayerExter, setting Act to the input
+type extLay struct {
+	*testLay
+}
+
+func (ly extLay) ApplyExt(ext etensor.Tensor) {
+	for i := range ly.acts {
+		ly.acts[i] = float32(ext.FloatVal1D(i))
+	}
+}
+
+// extNet is a mock network whose layers implement LayerExter
+type extNet struct {
+	*testNet
+}
+
+func (nt extNet) LayerByNameTry(name string) (Layer, error) {
+	ly, err := nt.testNet.LayerByNameTry(name)
+	if err != nil {
+		return nil, err
+	}
+	return extLay{ly.(*testLay)}, nil
+}
+
+// testEnsemble returns an ensemble of 3 testNet3 networks with Hidden Act
+// values of: 1, 2, 3 / 0, 2, 4 / 2, 2, 5
+func testEnsemble() *Ensemble {
+	acts := [][]float32{{1, 2, 3}, {0, 2, 4}, {2, 2, 5}}
+	en := NewEnsemble()
+	for _, a := range acts {
+		nt := testNet3()
+		copy(nt.lays[1].acts, a)
+		en.Nets = append(en.Nets, nt)
+	}
+	return en
+}
+
+func TestCloneEnsemble(t *testing.T) {
+	nt := testNet3()
+	en, err := CloneEnsemble(nt, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if en.N() != 3 || en.Nets[0] != nt {
+		t.Fatalf("CloneEnsemble: N: %d", en.N())
+	}
+	for i, nm := range []string{"Test", "Test_1", "Test_2"} {
+		if en.Nets[i].Name() != nm || en.Nets[i].NLayers() != 3 {
+			t.Errorf("net: %d: %s != %s", i, en.Nets[i].Name(), nm)
+		}
+	}
+	cp := en.Nets[1].(*testNet)
+	cp.lays[0].acts[0] = 5
+	if nt.lays[0].acts[0] == 5 || cp.lays[1].rcv[0].RecvLay() != cp.lays[1] {
+		t.Errorf("CloneEnsemble: copy shares state with original")
+	}
+	if en, err := CloneEnsemble(nt, 1); err != nil || en.N() != 1 {
+		t.Errorf("CloneEnsemble 1: %v", err)
+	}
+}
+
+func TestEnsembleRun(t *testing.T) {
+	for _, par := range []bool{false, true} {
+		en := testEnsemble()
+		en.Parallel = par
+		var n int32
+		seen := make([]bool, en.N())
+		en.Run(func(idx int, net Network) {
+			atomic.AddInt32(&n, 1)
+			if net == en.Nets[idx] {
+				seen[idx] = true
+			}
+		})
+		if n != 3 || !reflect.DeepEqual(seen, []bool{true, true, true}) {
+			t.Errorf("Parallel: %v: calls: %d seen: %v", par, n, seen)
+		}
+	}
+}
+
+func TestEnsembleApplyExt(t *testing.T) {
+	en := NewEnsemble(extNet{testNet3()}, extNet{testNet3()})
+	ext := etensor.NewFloat32([]int{2, 2}, nil, nil)
+	for i := 0; i < 4; i++ {
+		ext.SetFloat1D(i, float64(i+1))
+	}
+	if err := en.ApplyExt("Input", ext); err != nil {
+		t.Fatal(err)
+	}
+	for i, net := range en.Nets {
+		if acts := net.(extNet).lays[0].acts; !reflect.DeepEqual(acts, []float32{1, 2, 3, 4}) {
+			t.Errorf("net: %d: acts: %v", i, acts)
+		}
+	}
+	if err := en.ApplyExt("Nope", ext); err == nil {
+		t.Errorf("ApplyExt unknown layer: no error")
+	}
+	if err := testEnsemble().ApplyExt("Input", ext); err == nil {
+		t.Errorf("ApplyExt without LayerExter: no error")
+	}
+}
+
+func TestEnsembleUnitMeanVar(t *testing.T) {
+	en := testEnsemble()
+	mean := []float32{9, 9, 9, 9, 9} // reused, and longer than needed
+	var vr []float32
+	for i := 0; i < 2; i++ {
+		if err := en.UnitMeanVar("Hidden", "Act", &mean, &vr); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(mean, []float32{1, 2, 4}) {
+			t.Errorf("mean: %v", mean)
+		}
+		want := []float32{2.0 / 3.0, 0, 2.0 / 3.0}
+		for j, v := range vr {
+			if math.Abs(float64(v-want[j])) > 1.0e-6 {
+				t.Errorf("var: %v != %v", vr, want)
+				break
+			}
+		}
+	}
+
+	mt := &etensor.Float32{}
+	vt := &etensor.Float32{}
+	if err := en.UnitMeanVarTensor("Hidden", "Act", mt, vt); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(mt.Shp, []int{1, 3}) || !reflect.DeepEqual(mt.Values, mean) || !reflect.DeepEqual(vt.Shp, []int{1, 3}) || !reflect.DeepEqual(vt.Values, vr) {
+		t.Errorf("UnitMeanVarTensor: %v %v", mt, vt)
+	}
+
+	if err := en.UnitMeanVar("Nope", "Act", &mean, &vr); err == nil {
+		t.Errorf("UnitMeanVar unknown layer: no error")
+	}
+	if err := en.UnitMeanVar("Hidden", "Nope", &mean, &vr); err == nil {
+		t.Errorf("UnitMeanVar unknown variable: no error")
+	}
+	if err := en.UnitMeanVarTensor("Hidden", "Nope", mt, vt); err == nil {
+		t.Errorf("UnitMeanVarTensor unknown variable: no error")
+	}
+	if err := NewEnsemble().UnitMeanVar("Hidden", "Act", &mean, &vr); err == nil {
+		t.Errorf("UnitMeanVar empty ensemble: no error")
+	}
+	odd := newTestNet("Odd")
+	odd.AddLayer("Hidden", []int{2, 3})
+	en.Nets = append(en.Nets, odd)
+	if err := en.UnitMeanVar("Hidden", "Act", &mean, &vr); err == nil {
+		t.Errorf("UnitMeanVar different unit counts: no error")
+	}
+}
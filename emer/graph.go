@@ -0,0 +1,144 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/goki/gi/gi"
+)
+
+// LayerShapeString returns the shape of given layer as dimensions separated
+// by x, e.g., "5x5" or "2x2x4x4"
+func LayerShapeString(ly Layer) string {
+	shp := ly.Shape().Shp
+	ds := make([]string, len(shp))
+	for i, d := range shp {
+		ds[i] = strconv.Itoa(d)
+	}
+	return strings.Join(ds, "x")
+}
+
+// WriteDOT writes a Graphviz DOT description of the architecture of given
+// network, with a node for each layer labeled with its name, type, and shape,
+// and an edge for each projection labeled with its pattern name, and its type
+// if not Forward.  Back projections are dashed, and layers and projections
+// that are off are gray.  Render with e.g., dot -Tpdf net.dot -o net.pdf
+func WriteDOT(net Network, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "digraph %s {\n", strconv.Quote(net.Name()))
+	fmt.Fprintf(bw, "\trankdir=BT;\n\tnode [shape=box];\n")
+	for li := 0; li < net.NLayers(); li++ {
+		ly := net.Layer(li)
+		lbl := fmt.Sprintf("%s\n%s %s", ly.Name(), ly.Type(), LayerShapeString(ly))
+		attr := ""
+		if ly.IsOff() {
+			attr = ", color=gray, fontcolor=gray"
+		}
+		fmt.Fprintf(bw, "\t%s [label=%s%s];\n", strconv.Quote(ly.Name()), strconv.Quote(lbl), attr)
+	}
+	for li := 0; li < net.NLayers(); li++ {
+		ly := net.Layer(li)
+		for pi := 0; pi < ly.NRecvPrjns(); pi++ {
+			pj := ly.RecvPrjn(pi)
+			lbl := pj.Pattern().Name()
+			if pj.Type() != Forward {
+				lbl += " (" + pj.Type().String() + ")"
+			}
+			attr := ""
+			if pj.Type() == Back {
+				attr += ", style=dashed"
+			}
+			if pj.IsOff() {
+				attr += ", color=gray, fontcolor=gray"
+			}
+			fmt.Fprintf(bw, "\t%s -> %s [label=%s%s];\n", strconv.Quote(pj.SendLay().Name()), strconv.Quote(ly.Name()), strconv.Quote(lbl), attr)
+		}
+	}
+	fmt.Fprintf(bw, "}\n")
+	return bw.Flush()
+}
+
+// xmlEsc returns given string escaped for XML
+func xmlEsc(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// WriteGraphML writes a GraphML description of the architecture of given
+// network, with a node for each layer with its type, shape, and off status,
+// and an edge for each projection with its pattern name, type, and off status,
+// e.g., for laying out with yEd or Gephi.
+func WriteGraphML(net Network, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	fmt.Fprintf(bw, "<graphml xmlns=\"http://graphml.graphdrawing.org/xmlns\">\n")
+	keys := [][3]string{
+		{"ltype", "node", "Type"}, {"shape", "node", "Shape"}, {"loff", "node", "Off"},
+		{"pattern", "edge", "Pattern"}, {"ptype", "edge", "Type"}, {"poff", "edge", "Off"},
+	}
+	for _, k := range keys {
+		typ := "string"
+		if k[2] == "Off" {
+			typ = "boolean"
+		}
+		fmt.Fprintf(bw, "  <key id=%q for=%q attr.name=%q attr.type=%q/>\n", k[0], k[1], k[2], typ)
+	}
+	fmt.Fprintf(bw, "  <graph id=\"%s\" edgedefault=\"directed\">\n", xmlEsc(net.Name()))
+	for li := 0; li < net.NLayers(); li++ {
+		ly := net.Layer(li)
+		fmt.Fprintf(bw, "    <node id=\"%s\">\n", xmlEsc(ly.Name()))
+		fmt.Fprintf(bw, "      <data key=\"ltype\">%s</data>\n", xmlEsc(ly.Type().String()))
+		fmt.Fprintf(bw, "      <data key=\"shape\">%s</data>\n", LayerShapeString(ly))
+		fmt.Fprintf(bw, "      <data key=\"loff\">%v</data>\n", ly.IsOff())
+		fmt.Fprintf(bw, "    </node>\n")
+	}
+	for li := 0; li < net.NLayers(); li++ {
+		ly := net.Layer(li)
+		for pi := 0; pi < ly.NRecvPrjns(); pi++ {
+			pj := ly.RecvPrjn(pi)
+			fmt.Fprintf(bw, "    <edge id=\"%s\" source=\"%s\" target=\"%s\">\n", xmlEsc(pj.Name()), xmlEsc(pj.SendLay().Name()), xmlEsc(ly.Name()))
+			fmt.Fprintf(bw, "      <data key=\"pattern\">%s</data>\n", xmlEsc(pj.Pattern().Name()))
+			fmt.Fprintf(bw, "      <data key=\"ptype\">%s</data>\n", xmlEsc(pj.Type().String()))
+			fmt.Fprintf(bw, "      <data key=\"poff\">%v</data>\n", pj.IsOff())
+			fmt.Fprintf(bw, "    </edge>\n")
+		}
+	}
+	fmt.Fprintf(bw, "  </graph>\n</graphml>\n")
+	return bw.Flush()
+}
+
+// SaveDOT saves a Graphviz DOT description of the architecture of given
+// network to given file (see WriteDOT)
+func SaveDOT(net Network, filename gi.FileName) error {
+	var b bytes.Buffer
+	WriteDOT(net, &b)
+	err := ioutil.WriteFile(string(filename), b.Bytes(), 0644)
+	if err != nil {
+		log.Println(err)
+	}
+	return err
+}
+
+// SaveGraphML saves a GraphML description of the architecture of given
+// network to given file (see WriteGraphML)
+func SaveGraphML(net Network, filename gi.FileName) error {
+	var b bytes.Buffer
+	WriteGraphML(net, &b)
+	err := ioutil.WriteFile(string(filename), b.Bytes(), 0644)
+	if err != nil {
+		log.Println(err)
+	}
+	return err
+}
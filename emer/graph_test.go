@@ -0,0 +1,151 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goki/gi/gi"
+)
+
+// testNetGraph returns testNet3 with Input and Target layer types, and the
+// Output layer off
+func testNetGraph() *testNet {
+	nt := testNet3()
+	nt.lays[0].typ = Input
+	nt.lays[2].typ = Target
+	nt.lays[2].off = true
+	return nt
+}
+
+func TestLayerShapeString(t *testing.T) {
+	nt := newTestNet("Test")
+	tests := []struct {
+		shp []int
+		str string
+	}{
+		{[]int{5, 5}, "5x5"},
+		{[]int{2, 2, 4, 4}, "2x2x4x4"},
+		{[]int{7}, "7"},
+	}
+	for _, tt := range tests {
+		if str := LayerShapeString(nt.AddLayer("L", tt.shp)); str != tt.str {
+			t.Errorf("%v: %q != %q", tt.shp, str, tt.str)
+		}
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteDOT(testNetGraph(), &b); err != nil {
+		t.Fatal(err)
+	}
+	want := `digraph "Test" {
+	rankdir=BT;
+	node [shape=box];
+	"Input" [label="Input\nInput 2x2"];
+	"Hidden" [label="Hidden\nHidden 1x3"];
+	"Output" [label="Output\nTarget 1x2", color=gray, fontcolor=gray];
+	"Input" -> "Hidden" [label="Full"];
+	"Output" -> "Hidden" [label="Full (Back)", style=dashed, color=gray, fontcolor=gray];
+	"Hidden" -> "Output" [label="Full", color=gray, fontcolor=gray];
+}
+`
+	if b.String() != want {
+		t.Errorf("WriteDOT:\n%s\n!=\n%s", b.String(), want)
+	}
+}
+
+// graphML is the structure of the GraphML output, for checking it
+type graphML struct {
+	Keys []struct {
+		ID   string `xml:"id,attr"`
+		Name string `xml:"attr.name,attr"`
+	} `xml:"key"`
+	Graph struct {
+		ID    string `xml:"id,attr"`
+		Nodes []struct {
+			ID   string `xml:"id,attr"`
+			Data []struct {
+				Key string `xml:"key,attr"`
+				Val string `xml:",chardata"`
+			} `xml:"data"`
+		} `xml:"node"`
+		Edges []struct {
+			ID     string `xml:"id,attr"`
+			Source string `xml:"source,attr"`
+			Target string `xml:"target,attr"`
+			Data   []struct {
+				Key string `xml:"key,attr"`
+				Val string `xml:",chardata"`
+			} `xml:"data"`
+		} `xml:"edge"`
+	} `xml:"graph"`
+}
+
+func TestWriteGraphML(t *testing.T) {
+	nt := testNetGraph()
+	nt.nm = `Test <"&">`
+	var b bytes.Buffer
+	if err := WriteGraphML(nt, &b); err != nil {
+		t.Fatal(err)
+	}
+	gm := &graphML{}
+	if err := xml.Unmarshal(b.Bytes(), gm); err != nil {
+		t.Fatalf("WriteGraphML: invalid XML: %v\n%s", err, b.String())
+	}
+	if gm.Graph.ID != nt.nm || len(gm.Keys) != 6 || len(gm.Graph.Nodes) != 3 || len(gm.Graph.Edges) != 3 {
+		t.Fatalf("WriteGraphML: graph: %q keys: %d nodes: %d edges: %d", gm.Graph.ID, len(gm.Keys), len(gm.Graph.Nodes), len(gm.Graph.Edges))
+	}
+	out := gm.Graph.Nodes[2]
+	if out.ID != "Output" || len(out.Data) != 3 || out.Data[0].Val != "Target" || out.Data[1].Val != "1x2" || out.Data[2].Val != "true" {
+		t.Errorf("WriteGraphML: node: %+v", out)
+	}
+	back := gm.Graph.Edges[1]
+	if back.ID != "OutputToHidden" || back.Source != "Output" || back.Target != "Hidden" || len(back.Data) != 3 ||
+		back.Data[0].Val != "Full" || back.Data[1].Val != "Back" || back.Data[2].Val != "true" {
+		t.Errorf("WriteGraphML: edge: %+v", back)
+	}
+	if fwd := gm.Graph.Edges[0]; fwd.Data[1].Val != "Forward" || fwd.Data[2].Val != "false" {
+		t.Errorf("WriteGraphML: edge: %+v", fwd)
+	}
+}
+
+func TestSaveGraph(t *testing.T) {
+	dir, err := ioutil.TempDir("", "graph")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	nt := testNetGraph()
+	var dot, gml bytes.Buffer
+	WriteDOT(nt, &dot)
+	WriteGraphML(nt, &gml)
+	fnm := filepath.Join(dir, "net.dot")
+	if err := SaveDOT(nt, gi.FileName(fnm)); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := ioutil.ReadFile(fnm); err != nil || !bytes.Equal(b, dot.Bytes()) {
+		t.Errorf("SaveDOT: file differs from WriteDOT: %v", err)
+	}
+	fnm = filepath.Join(dir, "net.graphml")
+	if err := SaveGraphML(nt, gi.FileName(fnm)); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := ioutil.ReadFile(fnm); err != nil || !bytes.Equal(b, gml.Bytes()) {
+		t.Errorf("SaveGraphML: file differs from WriteGraphML: %v", err)
+	}
+	if err := SaveDOT(nt, gi.FileName(filepath.Join(dir, "nodir", "net.dot"))); err == nil {
+		t.Errorf("SaveDOT bad path: no error")
+	}
+	if err := SaveGraphML(nt, gi.FileName(filepath.Join(dir, "nodir", "net.graphml"))); err == nil {
+		t.Errorf("SaveGraphML bad path: no error")
+	}
+}
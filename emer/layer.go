@@ -119,6 +119,9 @@ type Layer interface {
 	// min:"##" max:"##" = min, max display range
 	// auto-scale:"+" or "-" = use automatic scaling instead of fixed range or not.
 	// zeroctr:"+" or "-" = control whether zero-centering is used
+	// colormap:"name" = name of color map to use for this variable (see netview.RegisterColorMap)
+	// cat:"name" = category of the variable (e.g., Act, Learn, Stats), for presenting
+	// variables in organized sections (see UnitVarCats)
 	// Note: this is a global list so do not modify!
 	UnitVarProps() map[string]string
 
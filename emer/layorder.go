@@ -0,0 +1,140 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import "fmt"
+
+// LayerOrder returns the layers of given network in computation order,
+// topologically sorted by their projections so that each layer comes after
+// all of the layers that send to it, along with the projections that had to
+// be ignored to do so (the recurrent links).  Back projections and
+// self-projections are always ignored, and any remaining cycles are broken
+// by taking the remaining layer with the lowest index next, ignoring its
+// projections from the layers that remain.
+func LayerOrder(net Network) (order []Layer, cut []Prjn) {
+	nlay := net.NLayers()
+	done := make(map[Layer]bool, nlay)
+	nin := make(map[Layer]int, nlay)
+	for li := 0; li < nlay; li++ {
+		ly := net.Layer(li)
+		for pi := 0; pi < ly.NRecvPrjns(); pi++ {
+			pj := ly.RecvPrjn(pi)
+			if orderPrjn(pj) {
+				nin[ly]++
+			}
+		}
+	}
+	for len(order) < nlay {
+		var nxt Layer
+		for li := 0; li < nlay; li++ {
+			ly := net.Layer(li)
+			if !done[ly] && nin[ly] == 0 {
+				nxt = ly
+				break
+			}
+		}
+		if nxt == nil { // cycle: take the first remaining layer
+			for li := 0; li < nlay; li++ {
+				ly := net.Layer(li)
+				if done[ly] {
+					continue
+				}
+				nxt = ly
+				for pi := 0; pi < ly.NRecvPrjns(); pi++ {
+					pj := ly.RecvPrjn(pi)
+					if orderPrjn(pj) && !done[pj.SendLay()] {
+						cut = append(cut, pj)
+					}
+				}
+				break
+			}
+		}
+		done[nxt] = true
+		order = append(order, nxt)
+		for pi := 0; pi < nxt.NSendPrjns(); pi++ {
+			pj := nxt.SendPrjn(pi)
+			if orderPrjn(pj) && !done[pj.RecvLay()] {
+				nin[pj.RecvLay()]--
+			}
+		}
+	}
+	return
+}
+
+// orderPrjn returns true if given projection determines the order of its
+// layers in LayerOrder: not a Back projection or a self-projection
+func orderPrjn(pj Prjn) bool {
+	return pj.Type() != Back && pj.SendLay() != pj.RecvLay()
+}
+
+// LayerLevels returns the layers of given network grouped into levels in
+// computation order (see LayerOrder), where each layer is in the level after
+// the highest level of the layers that send to it (ignoring the recurrent
+// links), so the layers within a level can be computed in parallel.
+func LayerLevels(net Network) [][]Layer {
+	order, cut := LayerOrder(net)
+	iscut := make(map[Prjn]bool, len(cut))
+	for _, pj := range cut {
+		iscut[pj] = true
+	}
+	lev := make(map[Layer]int, len(order))
+	var levels [][]Layer
+	for _, ly := range order {
+		lv := 0
+		for pi := 0; pi < ly.NRecvPrjns(); pi++ {
+			pj := ly.RecvPrjn(pi)
+			if !orderPrjn(pj) || iscut[pj] {
+				continue
+			}
+			if sl := lev[pj.SendLay()] + 1; sl > lv {
+				lv = sl
+			}
+		}
+		lev[ly] = lv
+		if lv >= len(levels) {
+			levels = append(levels, make([][]Layer, lv+1-len(levels))...)
+		}
+		levels[lv] = append(levels[lv], ly)
+	}
+	return levels
+}
+
+// LayerOrderString returns a report of the computation order of the layers
+// of given network, one level per line (see LayerLevels), followed by the
+// recurrent projections ignored in ordering them
+func LayerOrderString(net Network) string {
+	str := ""
+	for li, lv := range LayerLevels(net) {
+		str += fmt.Sprintf("%d:", li)
+		for _, ly := range lv {
+			str += " " + ly.Name()
+		}
+		str += "\n"
+	}
+	_, cut := LayerOrder(net)
+	if len(cut) > 0 {
+		str += "recurrent:"
+		for _, pj := range cut {
+			str += " " + pj.Name()
+		}
+		str += "\n"
+	}
+	return str
+}
+
+// AssignThreads assigns the layers of given network to given number of
+// threads (see Layer.SetThread), distributing the layers within each level
+// of the computation order (see LayerLevels) across threads, so that layers
+// that can be computed in parallel are on different threads.
+func AssignThreads(net Network, nthr int) {
+	if nthr < 1 {
+		nthr = 1
+	}
+	for _, lv := range LayerLevels(net) {
+		for i, ly := range lv {
+			ly.SetThread(i % nthr)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// testNetLoop returns a network with a recurrent loop between A and B
+// (Forward projections in both directions), a self projection on A, a Back
+// projection from Out to B, and a second input Ctx to Out
+func testNetLoop() *testNet {
+	nt := newTestNet("Loop")
+	in := nt.AddLayer("In", []int{1, 2})
+	a := nt.AddLayer("A", []int{1, 2})
+	b := nt.AddLayer("B", []int{1, 2})
+	out := nt.AddLayer("Out", []int{1, 2})
+	ctx := nt.AddLayer("Ctx", []int{1, 2})
+	nt.Connect(in, a, Forward)
+	nt.Connect(a, a, Lateral)
+	nt.Connect(b, a, Forward)
+	nt.Connect(a, b, Forward)
+	nt.Connect(out, b, Back)
+	nt.Connect(b, out, Forward)
+	nt.Connect(ctx, out, Forward)
+	return nt
+}
+
+// layNames returns the names of given layers
+func layNames(lays []Layer) []string {
+	nms := make([]string, len(lays))
+	for i, ly := range lays {
+		nms[i] = ly.Name()
+	}
+	return nms
+}
+
+func TestLayerOrder(t *testing.T) {
+	// layers added in reverse order of computation
+	rev := newTestNet("Rev")
+	out := rev.AddLayer("Out", []int{1, 2})
+	hid := rev.AddLayer("Hid", []int{1, 2})
+	in := rev.AddLayer("In", []int{1, 2})
+	rev.Connect(in, hid, Forward)
+	rev.Connect(hid, out, Forward)
+	rev.Connect(out, hid, Back)
+
+	tests := []struct {
+		name  string
+		net   *testNet
+		order string
+		cut   []string
+	}{
+		{"forward", testNet3(), "[Input Hidden Output]", nil},
+		{"reverse", rev, "[In Hid Out]", nil},
+		{"loop", testNetLoop(), "[In Ctx A B Out]", []string{"BToA"}},
+	}
+	for _, tt := range tests {
+		order, cut := LayerOrder(tt.net)
+		if nms := fmt.Sprint(layNames(order)); nms != tt.order {
+			t.Errorf("%s: order: %s != %s", tt.name, nms, tt.order)
+		}
+		if len(cut) != len(tt.cut) {
+			t.Errorf("%s: cut: %d != %d", tt.name, len(cut), len(tt.cut))
+			continue
+		}
+		for i, pj := range cut {
+			if pj.Name() != tt.cut[i] {
+				t.Errorf("%s: cut: %d: %s != %s", tt.name, i, pj.Name(), tt.cut[i])
+			}
+		}
+	}
+
+	// all layers in a cycle: the first layer is taken first
+	cyc := newTestNet("Cycle")
+	a := cyc.AddLayer("A", []int{1, 2})
+	b := cyc.AddLayer("B", []int{1, 2})
+	c := cyc.AddLayer("C", []int{1, 2})
+	cyc.Connect(c, a, Forward)
+	cyc.Connect(a, b, Forward)
+	cyc.Connect(b, c, Forward)
+	order, cut := LayerOrder(cyc)
+	if nms := fmt.Sprint(layNames(order)); nms != "[A B C]" || len(cut) != 1 || cut[0].Name() != "CToA" {
+		t.Errorf("cycle: order: %s cut: %v", nms, cut)
+	}
+}
+
+func TestLayerLevels(t *testing.T) {
+	levs := LayerLevels(testNetLoop())
+	want := []string{"[In Ctx]", "[A]", "[B]", "[Out]"}
+	if len(levs) != len(want) {
+		t.Fatalf("levels: %d != %d", len(levs), len(want))
+	}
+	for li, lv := range levs {
+		if nms := fmt.Sprint(layNames(lv)); nms != want[li] {
+			t.Errorf("level: %d: %s != %s", li, nms, want[li])
+		}
+	}
+	str := LayerOrderString(testNetLoop())
+	if want := "0: In Ctx\n1: A\n2: B\n3: Out\nrecurrent: BToA\n"; str != want {
+		t.Errorf("LayerOrderString: %q != %q", str, want)
+	}
+	if str := LayerOrderString(testNet3()); str != "0: Input\n1: Hidden\n2: Output\n" {
+		t.Errorf("LayerOrderString: %q", str)
+	}
+}
+
+func TestAssignThreads(t *testing.T) {
+	nt := testNetLoop()
+	AssignThreads(nt, 2)
+	thrs := make([]int, nt.NLayers())
+	for li, ly := range nt.lays {
+		thrs[li] = ly.Thread()
+	}
+	if want := []int{0, 0, 0, 0, 1}; !reflect.DeepEqual(thrs, want) { // In A B Out Ctx
+		t.Errorf("AssignThreads: %v != %v", thrs, want)
+	}
+	AssignThreads(nt, 0)
+	for _, ly := range nt.lays {
+		if ly.Thread() != 0 {
+			t.Errorf("AssignThreads 0: layer: %s thread: %d", ly.Name(), ly.Thread())
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+)
+
+// LayerLesioner is an optional interface for layers that support lesioning
+// a subset of their units, beyond lesioning the whole layer with SetOff,
+// e.g., for graded lesion experiments (see LesionUnitsFrac)
+type LayerLesioner interface {
+	// LesionUnits sets the lesioned (off) status of the individual units in the layer
+	// from given mask, indexed by the 1D (flat) unit index, with true = lesioned.
+	// A nil mask clears all unit lesions.  Returns error if mask is the wrong length.
+	LesionUnits(mask []bool) error
+
+	// UnitLesioned returns true if the unit at given 1D (flat) index has been
+	// lesioned by LesionUnits
+	UnitLesioned(idx int) bool
+}
+
+// layerLesioner returns the layer as a LayerLesioner, or an error if it
+// does not support it
+func layerLesioner(ly Layer, fun string) (LayerLesioner, error) {
+	ll, ok := ly.(LayerLesioner)
+	if !ok {
+		err := fmt.Errorf("emer.%s: layer: %v does not support lesioning units (emer.LayerLesioner)", fun, ly.Name())
+		log.Println(err)
+		return nil, err
+	}
+	return ll, nil
+}
+
+// LesionUnits sets the lesioned status of the units in given layer from
+// given mask (see LayerLesioner), returning an error if the layer does not
+// support lesioning units
+func LesionUnits(ly Layer, mask []bool) error {
+	ll, err := layerLesioner(ly, "LesionUnits")
+	if err != nil {
+		return err
+	}
+	err = ll.LesionUnits(mask)
+	if err != nil {
+		log.Println(err)
+	}
+	return err
+}
+
+// LesionMaskFrac returns a lesion mask for n units with given fraction of them
+// (rounded to the nearest unit) lesioned (true), chosen at random using given
+// seed, so the same seed always lesions the same units.  The units lesioned
+// for a smaller fraction are a subset of those for a larger one with the same
+// seed, for graded lesion experiments.
+func LesionMaskFrac(n int, frac float64, seed int64) ([]bool, error) {
+	if frac < 0 || frac > 1 {
+		err := fmt.Errorf("emer.LesionMaskFrac: fraction: %v must be between 0 and 1", frac)
+		log.Println(err)
+		return nil, err
+	}
+	nles := int(math.Round(frac * float64(n)))
+	mask := make([]bool, n)
+	rnd := rand.New(rand.NewSource(seed))
+	for _, ui := range rnd.Perm(n)[:nles] {
+		mask[ui] = true
+	}
+	return mask, nil
+}
+
+// LesionUnitsFrac lesions given fraction of the units in given layer, chosen
+// at random using given seed (see LesionMaskFrac), replacing any existing unit
+// lesions, and returns the mask of lesioned units.
+func LesionUnitsFrac(ly Layer, frac float64, seed int64) ([]bool, error) {
+	ll, err := layerLesioner(ly, "LesionUnitsFrac")
+	if err != nil {
+		return nil, err
+	}
+	mask, err := LesionMaskFrac(ly.Shape().Len(), frac, seed)
+	if err != nil {
+		return nil, err
+	}
+	err = ll.LesionUnits(mask)
+	if err != nil {
+		log.Println(err)
+	}
+	return mask, err
+}
+
+// LesionedUnits returns the 1D (flat) indexes of the lesioned units in given
+// layer -- none if it does not support lesioning units (see LayerLesioner)
+func LesionedUnits(ly Layer) []int {
+	ll, ok := ly.(LayerLesioner)
+	if !ok {
+		return nil
+	}
+	var idxs []int
+	n := ly.Shape().Len()
+	for ui := 0; ui < n; ui++ {
+		if ll.UnitLesioned(ui) {
+			idxs = append(idxs, ui)
+		}
+	}
+	return idxs
+}
+
+// NLesionedUnits returns the number of lesioned units in given layer
+func NLesionedUnits(ly Layer) int {
+	return len(LesionedUnits(ly))
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// lesLay is a mock layer implementing LayerLesioner
+type lesLay struct {
+	*testLay
+	les []bool
+}
+
+func (ly *lesLay) LesionUnits(mask []bool) error {
+	if mask == nil {
+		ly.les = nil
+		return nil
+	}
+	if len(mask) != ly.shp.Len() {
+		return fmt.Errorf("mask length: %d != %d", len(mask), ly.shp.Len())
+	}
+	ly.les = append([]bool{}, mask...)
+	return nil
+}
+
+func (ly *lesLay) UnitLesioned(idx int) bool {
+	return ly.les != nil && ly.les[idx]
+}
+
+// nTrue returns the number of true values in mask
+func nTrue(mask []bool) int {
+	n := 0
+	for _, m := range mask {
+		if m {
+			n++
+		}
+	}
+	return n
+}
+
+func TestLesionMaskFrac(t *testing.T) {
+	tests := []struct {
+		n    int
+		frac float64
+		nles int
+	}{
+		{10, 0, 0},
+		{10, 1, 10},
+		{10, 0.25, 3}, // rounded
+		{10, 0.2, 2},
+		{7, 0.5, 4},
+		{0, 0.5, 0},
+	}
+	for _, tt := range tests {
+		mask, err := LesionMaskFrac(tt.n, tt.frac, 1)
+		if err != nil || len(mask) != tt.n || nTrue(mask) != tt.nles {
+			t.Errorf("n: %d frac: %g: mask: %v != %d lesioned, err: %v", tt.n, tt.frac, mask, tt.nles, err)
+		}
+	}
+	m1, _ := LesionMaskFrac(100, 0.3, 5)
+	m2, _ := LesionMaskFrac(100, 0.3, 5)
+	m3, _ := LesionMaskFrac(100, 0.3, 6)
+	if !reflect.DeepEqual(m1, m2) {
+		t.Errorf("same seed gives different masks")
+	}
+	if reflect.DeepEqual(m1, m3) {
+		t.Errorf("different seeds give the same mask")
+	}
+	prv := make([]bool, 100)
+	for _, frac := range []float64{0.1, 0.3, 0.5, 0.9, 1} {
+		mask, _ := LesionMaskFrac(100, frac, 5)
+		for i, m := range prv {
+			if m && !mask[i] {
+				t.Errorf("frac: %g: unit: %d lesioned at smaller fraction is not lesioned", frac, i)
+			}
+		}
+		prv = mask
+	}
+	for _, frac := range []float64{-0.1, 1.1} {
+		if mask, err := LesionMaskFrac(10, frac, 1); err == nil || mask != nil {
+			t.Errorf("frac: %g: no error", frac)
+		}
+	}
+}
+
+func TestLesionUnits(t *testing.T) {
+	nt := testNet3()
+	ly := &lesLay{testLay: nt.lays[0]} // 2x2
+	mask := []bool{false, true, false, true}
+	if err := LesionUnits(ly, mask); err != nil {
+		t.Fatal(err)
+	}
+	if idxs := LesionedUnits(ly); !reflect.DeepEqual(idxs, []int{1, 3}) || NLesionedUnits(ly) != 2 {
+		t.Errorf("LesionedUnits: %v", idxs)
+	}
+	if err := LesionUnits(ly, []bool{true}); err == nil {
+		t.Errorf("wrong mask length: no error")
+	}
+	if err := LesionUnits(ly, nil); err != nil || NLesionedUnits(ly) != 0 {
+		t.Errorf("nil mask: %v %v", err, LesionedUnits(ly))
+	}
+	mask, err := LesionUnitsFrac(ly, 0.5, 3)
+	if err != nil || nTrue(mask) != 2 || NLesionedUnits(ly) != 2 || !reflect.DeepEqual(ly.les, mask) {
+		t.Errorf("LesionUnitsFrac: %v %v err: %v", mask, ly.les, err)
+	}
+	if _, err := LesionUnitsFrac(ly, 2, 3); err == nil || NLesionedUnits(ly) != 2 {
+		t.Errorf("LesionUnitsFrac invalid fraction: no error or lesions changed: %v", ly.les)
+	}
+
+	hid := nt.lays[1] // does not implement LayerLesioner
+	if err := LesionUnits(hid, mask); err == nil {
+		t.Errorf("LesionUnits without LayerLesioner: no error")
+	}
+	if mask, err := LesionUnitsFrac(hid, 0.5, 3); err == nil || mask != nil {
+		t.Errorf("LesionUnitsFrac without LayerLesioner: no error")
+	}
+	if idxs := LesionedUnits(hid); idxs != nil || NLesionedUnits(hid) != 0 {
+		t.Errorf("LesionedUnits without LayerLesioner: %v", idxs)
+	}
+}
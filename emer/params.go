@@ -0,0 +1,151 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import "github.com/emer/emergent/params"
+
+// ParamObjs returns the objects in given network that ApplyParams applies
+// params to: each layer, followed by its receiving projections.
+func ParamObjs(net Network) []interface{} {
+	var objs []interface{}
+	for li := 0; li < net.NLayers(); li++ {
+		ly := net.Layer(li)
+		objs = append(objs, ly)
+		for pi := 0; pi < ly.NRecvPrjns(); pi++ {
+			objs = append(objs, ly.RecvPrjn(pi))
+		}
+	}
+	return objs
+}
+
+// ValidateParams does a dry-run application of given params to the layers and
+// projections of given network, without setting anything, reporting which Sels
+// matched nothing, which params would fail to be set, and what would change.
+// See params.Sheet.Validate.
+func ValidateParams(net Network, pars *params.Sheet) *params.ValidateReport {
+	return pars.Validate(ParamObjs(net))
+}
+
+// ApplyParamsHist applies given params to the layers and projections of given
+// network, recording the values that change in given history under given name
+// (e.g., Set:Sheet), so they can be undone (see params.History).
+// Returns true if any Sels applied, and error if any params failed to be set.
+func ApplyParamsHist(net Network, pars *params.Sheet, hist *params.History, name string, setMsg bool) (bool, error) {
+	return hist.Apply(name, pars, ParamObjs(net), setMsg)
+}
+
+// NonDefaultSheet returns a params Sheet with a #Name Sel for each layer and
+// projection of given network that has params differing from their default
+// values (see params.NonDefaults), with the current values of those params,
+// e.g., to save as the params that were actually used.
+func NonDefaultSheet(net Network) *params.Sheet {
+	sh := &params.Sheet{}
+	for _, obj := range ParamObjs(net) {
+		dd := params.NonDefaults(obj)
+		if len(dd) == 0 {
+			continue
+		}
+		stylr := obj.(params.Styler)
+		*sh = append(*sh, &params.Sel{Sel: "#" + stylr.Name(), Desc: "non-default params", Params: dd.Params()})
+	}
+	return sh
+}
+
+// ApplyParamsProv applies given params to given network, and records which
+// Sel sets each param on each layer and projection in given provenance under
+// given name (e.g., Set:Sheet), so conflicts between selectors can be debugged
+// (see params.Provenance).
+func ApplyParamsProv(net Network, pars *params.Sheet, prov *params.Provenance, name string, setMsg bool) (bool, error) {
+	prov.Record(name, pars, ParamObjs(net))
+	return net.ApplyParams(pars, setMsg)
+}
+
+// ApplyParamsFilter applies given params to the layers and projections of
+// given network for which given filter function returns true, e.g., to apply
+// a sheet to only part of a network.  Objects that are not params.Stylers
+// (e.g., nil projections in a network that is not fully configured) are
+// skipped.  Returns true if any Sels applied, and error if any params failed
+// to be set.
+func ApplyParamsFilter(net Network, pars *params.Sheet, filter func(obj params.Styler) bool, setMsg bool) (bool, error) {
+	applied := false
+	var rerr error
+	for _, obj := range ParamObjs(net) {
+		stylr, ok := obj.(params.Styler)
+		if !ok || !filter(stylr) {
+			continue
+		}
+		app, err := pars.Apply(obj, setMsg)
+		if app {
+			applied = true
+		}
+		if err != nil {
+			rerr = err
+		}
+	}
+	return applied, rerr
+}
+
+// ApplyParamsNames applies given params to only the named layers, along with
+// their receiving projections (as in Layer.ApplyParams), and the named
+// projections, of given network (e.g., just a new module being added).
+// Returns true if any Sels applied, and error if any params failed to be set.
+func ApplyParamsNames(net Network, pars *params.Sheet, names []string, setMsg bool) (bool, error) {
+	nms := make(map[string]bool, len(names))
+	for _, nm := range names {
+		nms[nm] = true
+	}
+	return ApplyParamsFilter(net, pars, func(obj params.Styler) bool {
+		if nms[obj.Name()] {
+			return true
+		}
+		if pj, ok := obj.(Prjn); ok {
+			return nms[pj.RecvLay().Name()]
+		}
+		return false
+	}, setMsg)
+}
+
+// LayerStyleAttr returns the standard params selector attributes of given
+// layer, which layer types can use to implement the params.StylerAttrs
+// interface (along with any others of their own, e.g., user-set tags):
+// Type is the LayerType (e.g., Hidden), and Shape is 2D or 4D.
+// Returns false for other attributes.
+func LayerStyleAttr(ly Layer, attr string) (string, bool) {
+	switch attr {
+	case "Type":
+		return ly.Type().String(), true
+	case "Shape":
+		if ly.Is4D() {
+			return "4D", true
+		}
+		return "2D", true
+	}
+	return "", false
+}
+
+// SnapshotParams returns a params Sheet with the current values of given
+// param paths (e.g., Layer.Inhib.Layer.Gi) on each layer and projection of
+// given network, with a #Name Sel for each -- the inverse of ApplyParams,
+// so that a hand-tuned state can be captured exactly (see params.Snapshot).
+func SnapshotParams(net Network, paths []string) (*params.Sheet, error) {
+	return params.Snapshot(ParamObjs(net), paths)
+}
+
+// SnapshotSet returns a params Set with given name containing a Network
+// sheet with the current values of given param paths on given network
+// (see SnapshotParams), e.g., to add to the Sets and save for re-running.
+func SnapshotSet(net Network, name string, paths []string) (*params.Set, error) {
+	sh, err := SnapshotParams(net, paths)
+	return &params.Set{Name: name, Desc: "snapshot of current values", Sheets: params.Sheets{"Network": sh}}, err
+}
+
+// LintParams checks that every selector in given params Sets matches at least
+// one layer or projection of given network, and that every param path resolves
+// to a field on the objects it matches, returning the problems found (empty
+// if none), e.g., for checking at sim startup and in tests (see params.Sets.Lint).
+// Only the given sheets are checked, e.g., Network, or all if none are given.
+func LintParams(net Network, sets *params.Sets, sheets ...string) params.LintDiags {
+	return sets.Lint(ParamObjs(net), sheets...)
+}
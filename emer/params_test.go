@@ -0,0 +1,81 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/emer/emergent/params"
+)
+
+// gainSheet sets Gain on all layers and projections
+var gainSheet = params.Sheet{
+	{Sel: "Layer", Params: params.Params{"Layer.Gain": "2"}},
+	{Sel: "Prjn", Params: params.Params{"Prjn.Gain": "3"}},
+}
+
+// gains returns the Gain of each layer and projection of given network,
+// in the order of ParamObjs
+func gains(nt *testNet) []float32 {
+	var gs []float32
+	for _, ly := range nt.lays {
+		gs = append(gs, ly.Gain)
+		for _, pj := range ly.rcv {
+			if tp, ok := pj.(*testPrjn); ok {
+				gs = append(gs, tp.Gain)
+			}
+		}
+	}
+	return gs
+}
+
+func TestApplyParamsFilter(t *testing.T) {
+	nt := testNet3()
+	app, err := ApplyParamsFilter(nt, &gainSheet, func(obj params.Styler) bool {
+		return obj.TypeName() == "Prjn"
+	}, false)
+	if !app || err != nil {
+		t.Fatalf("ApplyParamsFilter: %v %v", app, err)
+	}
+	// Input, Hidden, InputToHidden, OutputToHidden, Output, HiddenToOutput
+	if gs := gains(nt); !reflect.DeepEqual(gs, []float32{0, 0, 3, 3, 0, 3}) {
+		t.Errorf("ApplyParamsFilter: gains: %v", gs)
+	}
+	if app, err := ApplyParamsFilter(nt, &gainSheet, func(obj params.Styler) bool { return false }, false); app || err != nil {
+		t.Errorf("ApplyParamsFilter none: %v %v", app, err)
+	}
+	bad := params.Sheet{{Sel: "Layer", Params: params.Params{"Layer.Nope": "1"}}}
+	if _, err := ApplyParamsFilter(nt, &bad, func(obj params.Styler) bool { return true }, false); err == nil {
+		t.Errorf("ApplyParamsFilter bad param: no error")
+	}
+
+	// nil projection is skipped, not asserted
+	nt = testNet3()
+	nt.lays[1].rcv = append(nt.lays[1].rcv, nil)
+	if app, err := ApplyParamsFilter(nt, &gainSheet, func(obj params.Styler) bool { return true }, false); !app || err != nil {
+		t.Errorf("ApplyParamsFilter nil prjn: %v %v", app, err)
+	}
+	if gs := gains(nt); !reflect.DeepEqual(gs, []float32{2, 2, 3, 3, 2, 3}) {
+		t.Errorf("ApplyParamsFilter nil prjn: gains: %v", gs)
+	}
+}
+
+func TestApplyParamsNames(t *testing.T) {
+	nt := testNet3()
+	if app, err := ApplyParamsNames(nt, &gainSheet, []string{"Hidden", "HiddenToOutput"}, false); !app || err != nil {
+		t.Fatalf("ApplyParamsNames: %v %v", app, err)
+	}
+	if gs := gains(nt); !reflect.DeepEqual(gs, []float32{0, 2, 3, 3, 0, 3}) {
+		t.Errorf("ApplyParamsNames: gains: %v", gs)
+	}
+	nt = testNet3()
+	if app, err := ApplyParamsNames(nt, &gainSheet, []string{"Nope"}, false); app || err != nil {
+		t.Errorf("ApplyParamsNames unknown name: %v %v", app, err)
+	}
+	if gs := gains(nt); !reflect.DeepEqual(gs, []float32{0, 0, 0, 0, 0, 0}) {
+		t.Errorf("ApplyParamsNames unknown name: gains: %v", gs)
+	}
+}
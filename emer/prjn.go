@@ -69,6 +69,9 @@ type Prjn interface {
 	// min:"##" max:"##" = min, max display range
 	// auto-scale:"+" or "-" = use automatic scaling instead of fixed range or not.
 	// zeroctr:"+" or "-" = control whether zero-centering is used
+	// colormap:"name" = name of color map to use for this variable (see netview.RegisterColorMap)
+	// cat:"name" = category of the variable (e.g., Act, Learn, Stats), for presenting
+	// variables in organized sections (see SynVarCats)
 	// Note: this is a global list so do not modify!
 	SynVarProps() map[string]string
 
@@ -145,6 +148,18 @@ func (pl *Prjns) Add(p Prjn) {
 	(*pl) = append(*pl, p)
 }
 
+// Delete deletes given projection from the list, returning false if not found,
+// e.g., for implementing NetworkEditor.DeletePrjn
+func (pl *Prjns) Delete(p Prjn) bool {
+	for i, pj := range *pl {
+		if pj == p {
+			*pl = append((*pl)[:i], (*pl)[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 // Send finds the projection with given send layer
 func (pl *Prjns) Send(send Layer) (Prjn, bool) {
 	for _, pj := range *pl {
@@ -0,0 +1,236 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"compress/gzip"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/goki/gi/gi"
+)
+
+// NetCounters is an optional interface for networks with runtime counters
+// (e.g., the current cycle or time) that are part of the complete runtime
+// state saved in a NetState.
+type NetCounters interface {
+	// StateCounters returns the current values of the network counters, by name
+	StateCounters() map[string]float64
+
+	// SetStateCounters sets the network counters from given values,
+	// as returned by StateCounters
+	SetStateCounters(ctrs map[string]float64) error
+}
+
+// UnitValsSetter is an optional interface for layers that can set the values
+// of their unit variables, as needed for restoring a NetState
+type UnitValsSetter interface {
+	// SetUnitVals sets the values of given variable name on each unit in the layer
+	// from given slice, in the same order as UnitVals.  Variables that are computed
+	// from others are recomputed or ignored as appropriate.
+	// Returns error on invalid var name or if the slice is too short.
+	SetUnitVals(vals []float32, varnm string) error
+}
+
+// SynValsSetter is an optional interface for projections that can set the
+// values of their synapse variables, as needed for restoring a NetState
+type SynValsSetter interface {
+	// SetSynVals sets the values of given variable name on each synapse from given
+	// slice, in the same natural ordering as SynVals.
+	// Returns error on invalid var name or if the slice is too short.
+	SetSynVals(vals []float32, varNm string) error
+}
+
+// NetState is the complete runtime state of a network: the values of all of
+// the variables on all of its units and synapses, and any network counters
+// (see NetCounters), beyond just the weights, so that a simulation can be
+// resumed exactly after interruption (see checkpt.Manager.NetState).
+type NetState struct {
+	Counters map[string]float64 `desc:"network counters, if the network implements NetCounters"`
+	Layers   []LayerState       `desc:"state of each layer, in order"`
+}
+
+// LayerState is the state of the units in a layer and its receiving projections
+type LayerState struct {
+	Name  string               `desc:"name of the layer"`
+	Units map[string][]float32 `desc:"values of each unit variable, in the order of UnitVals"`
+	Prjns []PrjnState          `desc:"state of each receiving projection, in order"`
+}
+
+// PrjnState is the state of the synapses in a projection
+type PrjnState struct {
+	From string               `desc:"name of the sending layer"`
+	Syns map[string][]float32 `desc:"values of each synapse variable, in the order of SynVals"`
+}
+
+// Get gets the complete runtime state of given network
+func (ns *NetState) Get(net Network) error {
+	ns.Counters = nil
+	if nc, ok := net.(NetCounters); ok {
+		ns.Counters = nc.StateCounters()
+	}
+	ns.Layers = make([]LayerState, net.NLayers())
+	for li := range ns.Layers {
+		ly := net.Layer(li)
+		ls := &ns.Layers[li]
+		ls.Name = ly.Name()
+		ls.Units = make(map[string][]float32)
+		for _, vnm := range ly.UnitVarNames() {
+			var vals []float32
+			if err := ly.UnitVals(&vals, vnm); err != nil {
+				log.Println(err)
+				return err
+			}
+			ls.Units[vnm] = vals
+		}
+		ls.Prjns = make([]PrjnState, ly.NRecvPrjns())
+		for pi := range ls.Prjns {
+			pj := ly.RecvPrjn(pi)
+			ps := &ls.Prjns[pi]
+			ps.From = pj.SendLay().Name()
+			ps.Syns = make(map[string][]float32)
+			for _, vnm := range pj.SynVarNames() {
+				var vals []float32
+				if err := pj.SynVals(&vals, vnm); err != nil {
+					log.Println(err)
+					return err
+				}
+				ps.Syns[vnm] = vals
+			}
+		}
+	}
+	return nil
+}
+
+// Set sets the complete runtime state of given network from this state,
+// which must have been obtained (Get) from a network with the same structure.
+// The layers and projections must implement UnitValsSetter and SynValsSetter.
+func (ns *NetState) Set(net Network) error {
+	for _, ls := range ns.Layers {
+		ly, err := net.LayerByNameTry(ls.Name)
+		if err != nil {
+			return err
+		}
+		us, ok := ly.(UnitValsSetter)
+		if !ok {
+			err := fmt.Errorf("emer.NetState.Set: layer: %v cannot set its unit values (emer.UnitValsSetter)", ls.Name)
+			log.Println(err)
+			return err
+		}
+		for vnm, vals := range ls.Units {
+			if err := us.SetUnitVals(vals, vnm); err != nil {
+				log.Println(err)
+				return err
+			}
+		}
+		for _, ps := range ls.Prjns {
+			pj, err := ly.RecvPrjns().SendNameTry(ps.From)
+			if err != nil {
+				err = fmt.Errorf("emer.NetState.Set: layer: %v: %v", ls.Name, err)
+				log.Println(err)
+				return err
+			}
+			ss, ok := pj.(SynValsSetter)
+			if !ok {
+				err := fmt.Errorf("emer.NetState.Set: projection: %v cannot set its synapse values (emer.SynValsSetter)", pj.Name())
+				log.Println(err)
+				return err
+			}
+			for vnm, vals := range ps.Syns {
+				if err := ss.SetSynVals(vals, vnm); err != nil {
+					log.Println(err)
+					return err
+				}
+			}
+		}
+	}
+	if ns.Counters != nil {
+		nc, ok := net.(NetCounters)
+		if !ok {
+			err := fmt.Errorf("emer.NetState.Set: network: %v does not have counters (emer.NetCounters)", net.Name())
+			log.Println(err)
+			return err
+		}
+		if err := nc.SetStateCounters(ns.Counters); err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+	return nil
+}
+
+// Write writes the state in binary (gob) format, which preserves all values
+// exactly (including NaN)
+func (ns *NetState) Write(w io.Writer) error {
+	return gob.NewEncoder(w).Encode(ns)
+}
+
+// Read reads the state in the binary format written by Write
+func (ns *NetState) Read(r io.Reader) error {
+	return gob.NewDecoder(r).Decode(ns)
+}
+
+// SaveNetState saves the complete runtime state of given network to given
+// file.  If filename has .gz extension, then file is gzip compressed.
+func SaveNetState(net Network, filename gi.FileName) error {
+	ns := &NetState{}
+	if err := ns.Get(net); err != nil {
+		return err
+	}
+	fp, err := os.Create(string(filename))
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	if filepath.Ext(string(filename)) == ".gz" {
+		gzw := gzip.NewWriter(fp)
+		err = ns.Write(gzw)
+		if cerr := gzw.Close(); err == nil {
+			err = cerr
+		}
+	} else {
+		err = ns.Write(fp)
+	}
+	if cerr := fp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		log.Println(err)
+	}
+	return err
+}
+
+// OpenNetState opens the complete runtime state of given network from given
+// file, as saved by SaveNetState.  If filename has .gz extension, then file
+// is gzip uncompressed.
+func OpenNetState(net Network, filename gi.FileName) error {
+	fp, err := os.Open(string(filename))
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	defer fp.Close()
+	ns := &NetState{}
+	if filepath.Ext(string(filename)) == ".gz" {
+		gzr, gerr := gzip.NewReader(fp)
+		if gerr != nil {
+			log.Println(gerr)
+			return gerr
+		}
+		defer gzr.Close()
+		err = ns.Read(gzr)
+	} else {
+		err = ns.Read(fp)
+	}
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return ns.Set(net)
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/goki/gi/gi"
+)
+
+// ctrNet is a mock network implementing NetCounters
+type ctrNet struct {
+	*testNet
+	ctrs map[string]float64
+}
+
+func (nt *ctrNet) StateCounters() map[string]float64 {
+	ctrs := make(map[string]float64, len(nt.ctrs))
+	for k, v := range nt.ctrs {
+		ctrs[k] = v
+	}
+	return ctrs
+}
+
+func (nt *ctrNet) SetStateCounters(ctrs map[string]float64) error {
+	if _, ok := ctrs["Cycle"]; !ok {
+		return errors.New("no Cycle counter")
+	}
+	nt.ctrs = ctrs
+	return nil
+}
+
+// noSetNet is a mock network whose layers do not implement UnitValsSetter
+type noSetNet struct {
+	*testNet
+}
+
+func (nt *noSetNet) LayerByNameTry(name string) (Layer, error) {
+	ly, err := nt.testNet.LayerByNameTry(name)
+	if err != nil {
+		return nil, err
+	}
+	return struct{ Layer }{ly}, nil
+}
+
+// zeroState sets all the unit and synapse values of given network to 0
+func zeroState(nt *testNet) {
+	for _, ly := range nt.lays {
+		for i := range ly.acts {
+			ly.acts[i] = 0
+		}
+		for _, pj := range ly.rcv {
+			wts := pj.(*testPrjn).wts
+			for i := range wts {
+				wts[i] = 0
+			}
+		}
+	}
+}
+
+// sameState returns an error if the state of given networks differs
+func sameState(a, b *testNet) error {
+	for li, ly := range a.lays {
+		if !reflect.DeepEqual(ly.acts, b.lays[li].acts) {
+			return fmt.Errorf("layer: %v: acts: %v != %v", ly.nm, ly.acts, b.lays[li].acts)
+		}
+		for pi, pj := range ly.rcv {
+			if !reflect.DeepEqual(pj.(*testPrjn).wts, b.lays[li].rcv[pi].(*testPrjn).wts) {
+				return fmt.Errorf("prjn: %v: wts differ", pj.Name())
+			}
+		}
+	}
+	return nil
+}
+
+func TestNetStateGetSet(t *testing.T) {
+	src := &ctrNet{testNet: testNet3(), ctrs: map[string]float64{"Cycle": 42, "Time": 0.5}}
+	src.lays[1].acts[2] = float32(math.NaN())
+	src.lays[1].rcv[0].(*testPrjn).wts[3] = -1
+	ns := &NetState{}
+	if err := ns.Get(src); err != nil {
+		t.Fatal(err)
+	}
+	if len(ns.Layers) != 3 || len(ns.Layers[1].Prjns) != 2 || ns.Layers[1].Prjns[1].From != "Output" || len(ns.Layers[1].Units["Act"]) != 3 {
+		t.Fatalf("Get: %+v", ns)
+	}
+	var b bytes.Buffer
+	if err := ns.Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	rs := &NetState{}
+	if err := rs.Read(&b); err != nil {
+		t.Fatal(err)
+	}
+	dst := &ctrNet{testNet: testNet3()}
+	zeroState(dst.testNet)
+	if err := rs.Set(dst); err != nil {
+		t.Fatal(err)
+	}
+	if !math.IsNaN(float64(dst.lays[1].acts[2])) {
+		t.Errorf("NaN not restored: %v", dst.lays[1].acts)
+	}
+	dst.lays[1].acts[2], src.lays[1].acts[2] = 0, 0
+	if err := sameState(src.testNet, dst.testNet); err != nil {
+		t.Error(err)
+	}
+	if !reflect.DeepEqual(dst.ctrs, src.ctrs) {
+		t.Errorf("counters: %v != %v", dst.ctrs, src.ctrs)
+	}
+
+	// counters, but network without them
+	if err := rs.Set(testNet3()); err == nil {
+		t.Errorf("Set counters without NetCounters: no error")
+	}
+	// network without counters
+	ns = &NetState{}
+	if err := ns.Get(testNet3()); err != nil || ns.Counters != nil {
+		t.Errorf("Get without NetCounters: %v %v", ns.Counters, err)
+	}
+	rs.Counters = map[string]float64{"Time": 1}
+	if err := rs.Set(dst); err == nil {
+		t.Errorf("SetStateCounters error: no error")
+	}
+}
+
+func TestNetStateSetErrors(t *testing.T) {
+	ns := &NetState{}
+	if err := ns.Get(testNet3()); err != nil {
+		t.Fatal(err)
+	}
+	nt := newTestNet("Other")
+	nt.AddLayer("Input", []int{2, 2})
+	if err := ns.Set(nt); err == nil {
+		t.Errorf("missing layer: no error")
+	}
+	nt = testNet3()
+	nt.lays[1].rcv = nt.lays[1].rcv[:1]
+	if err := ns.Set(nt); err == nil {
+		t.Errorf("missing projection: no error")
+	}
+	nt = testNet3()
+	nt.lays[1].rcv[0] = noIterPrjn{nt.lays[1].rcv[0]}
+	if err := ns.Set(nt); err == nil {
+		t.Errorf("projection without SynValsSetter: no error")
+	}
+	if err := ns.Set(&noSetNet{testNet3()}); err == nil {
+		t.Errorf("layer without UnitValsSetter: no error")
+	}
+	nt = newTestNet("Test")
+	nt.AddLayer("Input", []int{3, 2})
+	nt.AddLayer("Hidden", []int{1, 3})
+	nt.AddLayer("Output", []int{1, 2})
+	if err := ns.Set(nt); err == nil {
+		t.Errorf("state with fewer units than layer: no error")
+	}
+}
+
+func TestSaveOpenNetState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "netstate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, fn := range []string{"net.state", "net.state.gz"} {
+		fnm := gi.FileName(filepath.Join(dir, fn))
+		src := testNet3()
+		src.lays[2].acts[1] = 7
+		if err := SaveNetState(src, fnm); err != nil {
+			t.Fatal(err)
+		}
+		dst := testNet3()
+		zeroState(dst)
+		if err := OpenNetState(dst, fnm); err != nil {
+			t.Fatal(err)
+		}
+		if err := sameState(src, dst); err != nil {
+			t.Errorf("%s: %v", fn, err)
+		}
+	}
+	if err := OpenNetState(testNet3(), gi.FileName(filepath.Join(dir, "none.state"))); err == nil {
+		t.Errorf("OpenNetState missing file: no error")
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "bad.state.gz"), []byte("not gzip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := OpenNetState(testNet3(), gi.FileName(filepath.Join(dir, "bad.state.gz"))); err == nil {
+		t.Errorf("OpenNetState bad gzip: no error")
+	}
+	if err := SaveNetState(testNet3(), gi.FileName(filepath.Join(dir, "nodir", "net.state"))); err == nil {
+		t.Errorf("SaveNetState bad path: no error")
+	}
+}
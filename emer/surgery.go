@@ -0,0 +1,130 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/emer/emergent/prjn"
+)
+
+// NetworkEditor is an optional interface for networks that support adding and
+// deleting layers and projections after the network has been built, rebuilding
+// only the affected structures, e.g., for growing architectures and ablation
+// experiments without rebuilding the whole sim.  See the AddLayerBuilt,
+// ConnectBuilt, DeleteLayerName, and DeletePrjnNames helper functions.
+type NetworkEditor interface {
+	// AddBuiltLayer adds given layer (from NewLayer, with InitName and Config
+	// already called) to the built network, and builds it, updating the
+	// layer indexes and any network-level structures as needed.
+	AddBuiltLayer(ly Layer) error
+
+	// BuildPrjn builds given projection, which was just connected (see
+	// ConnectLayers) on the built network, and rebuilds any structures of its
+	// send and recv layers that depend on their projections.
+	BuildPrjn(pj Prjn) error
+
+	// DeleteLayer deletes given layer from the built network, along with all
+	// of its send and recv projections (see DeletePrjn), updating the indexes
+	// of the remaining layers.
+	DeleteLayer(ly Layer) error
+
+	// DeletePrjn deletes given projection from the send and recv projections
+	// of its layers, and rebuilds any structures of those layers that depend
+	// on their projections.
+	DeletePrjn(pj Prjn) error
+}
+
+// netEditor returns the network as a NetworkEditor, or an error if it
+// does not support it
+func netEditor(net Network, fun string) (NetworkEditor, error) {
+	ne, ok := net.(NetworkEditor)
+	if !ok {
+		err := fmt.Errorf("emer.%s: network: %v does not support editing after it has been built (emer.NetworkEditor)", fun, net.Name())
+		log.Println(err)
+		return nil, err
+	}
+	return ne, nil
+}
+
+// AddLayerBuilt adds a new layer with given name, shape, and type to the built
+// network (see NetworkEditor), returning the new layer -- set its RelPos to
+// position it for display as needed.
+func AddLayerBuilt(net Network, name string, shape []int, typ LayerType) (Layer, error) {
+	ne, err := netEditor(net, "AddLayerBuilt")
+	if err != nil {
+		return nil, err
+	}
+	if ly := net.LayerByName(name); ly != nil {
+		err := fmt.Errorf("emer.AddLayerBuilt: layer named: %v already exists in network: %v", name, net.Name())
+		log.Println(err)
+		return nil, err
+	}
+	ly := net.NewLayer()
+	ly.InitName(ly, name, net)
+	ly.Config(shape, typ)
+	if err := ne.AddBuiltLayer(ly); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return ly, nil
+}
+
+// ConnectBuilt connects the layers of given names with a new projection on the
+// built network and builds it (see NetworkEditor), returning the projection.
+// Call ApplyParams for the params of the new projection as needed.
+func ConnectBuilt(net Network, send, recv string, pat prjn.Pattern, typ PrjnType) (Prjn, error) {
+	ne, err := netEditor(net, "ConnectBuilt")
+	if err != nil {
+		return nil, err
+	}
+	_, _, pj, err := net.ConnectLayerNames(send, recv, pat, typ)
+	if err != nil {
+		return nil, err
+	}
+	if err := ne.BuildPrjn(pj); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return pj, nil
+}
+
+// DeleteLayerName deletes the layer of given name, along with all of its
+// projections, from the built network (see NetworkEditor).
+func DeleteLayerName(net Network, name string) error {
+	ne, err := netEditor(net, "DeleteLayerName")
+	if err != nil {
+		return err
+	}
+	ly, err := net.LayerByNameTry(name)
+	if err != nil {
+		return err
+	}
+	err = ne.DeleteLayer(ly)
+	if err != nil {
+		log.Println(err)
+	}
+	return err
+}
+
+// DeletePrjnNames deletes the projection from the send to the recv layer of
+// given names from the built network (see NetworkEditor).
+func DeletePrjnNames(net Network, send, recv string) error {
+	ne, err := netEditor(net, "DeletePrjnNames")
+	if err != nil {
+		return err
+	}
+	pj, err := PrjnByNames(net, send, recv)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	err = ne.DeletePrjn(pj)
+	if err != nil {
+		log.Println(err)
+	}
+	return err
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/emer/emergent/prjn"
+)
+
+// editNet is a mock network implementing NetworkEditor
+type editNet struct {
+	*testNet
+	buildErr error
+}
+
+func (nt *editNet) NewLayer() Layer { return &testLay{} }
+
+func (nt *editNet) AddBuiltLayer(ly Layer) error {
+	tl := ly.(*testLay)
+	tl.idx = len(nt.lays)
+	nt.lays = append(nt.lays, tl)
+	return nil
+}
+
+func (nt *editNet) ConnectLayerNames(send, recv string, pat prjn.Pattern, typ PrjnType) (rlay, slay Layer, pj Prjn, err error) {
+	rlay, err = nt.LayerByNameTry(recv)
+	if err != nil {
+		return
+	}
+	slay, err = nt.LayerByNameTry(send)
+	if err != nil {
+		return
+	}
+	pj = nt.Connect(slay.(*testLay), rlay.(*testLay), typ)
+	return
+}
+
+func (nt *editNet) BuildPrjn(pj Prjn) error {
+	return nt.buildErr
+}
+
+func (nt *editNet) DeletePrjn(pj Prjn) error {
+	if !pj.RecvLay().RecvPrjns().Delete(pj) || !pj.SendLay().SendPrjns().Delete(pj) {
+		return errors.New("projection not found")
+	}
+	return nil
+}
+
+func (nt *editNet) DeleteLayer(ly Layer) error {
+	for ly.NRecvPrjns() > 0 {
+		nt.DeletePrjn(ly.RecvPrjn(0))
+	}
+	for ly.NSendPrjns() > 0 {
+		nt.DeletePrjn(ly.SendPrjn(0))
+	}
+	for li, tl := range nt.lays {
+		if tl == ly {
+			nt.lays = append(nt.lays[:li], nt.lays[li+1:]...)
+			break
+		}
+	}
+	for li, tl := range nt.lays {
+		tl.idx = li
+	}
+	return nil
+}
+
+func TestAddLayerBuilt(t *testing.T) {
+	nt := &editNet{testNet: testNet3()}
+	ly, err := AddLayerBuilt(nt, "Extra", []int{2, 3}, Target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nt.NLayers() != 4 || nt.Layer(3) != ly || ly.Name() != "Extra" || ly.Index() != 3 || ly.Shape().Len() != 6 || ly.Type() != Target {
+		t.Errorf("AddLayerBuilt: %v layers: %d", ly.Name(), nt.NLayers())
+	}
+	if ly, err := AddLayerBuilt(nt, "Hidden", []int{2, 3}, Hidden); err == nil || ly != nil || nt.NLayers() != 4 {
+		t.Errorf("AddLayerBuilt existing name: no error")
+	}
+	if _, err := AddLayerBuilt(testNet3(), "Extra", []int{2, 3}, Hidden); err == nil {
+		t.Errorf("AddLayerBuilt without NetworkEditor: no error")
+	}
+}
+
+func TestConnectBuilt(t *testing.T) {
+	nt := &editNet{testNet: testNet3()}
+	pj, err := ConnectBuilt(nt, "Input", "Output", prjn.NewFull(), Forward)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := nt.lays[2]
+	if pj.Name() != "InputToOutput" || out.NRecvPrjns() != 2 || out.RecvPrjn(1) != pj || nt.lays[0].NSendPrjns() != 2 {
+		t.Errorf("ConnectBuilt: %v recv: %d", pj.Name(), out.NRecvPrjns())
+	}
+	if pj, err := ConnectBuilt(nt, "Nope", "Output", prjn.NewFull(), Forward); err == nil || pj != nil {
+		t.Errorf("ConnectBuilt unknown layer: no error")
+	}
+	nt.buildErr = errors.New("build failed")
+	if pj, err := ConnectBuilt(nt, "Input", "Output", prjn.NewFull(), Forward); err != nt.buildErr || pj != nil {
+		t.Errorf("ConnectBuilt build error: %v", err)
+	}
+	if _, err := ConnectBuilt(testNet3(), "Input", "Output", prjn.NewFull(), Forward); err == nil {
+		t.Errorf("ConnectBuilt without NetworkEditor: no error")
+	}
+}
+
+func TestDeleteBuilt(t *testing.T) {
+	nt := &editNet{testNet: testNet3()}
+	if err := DeletePrjnNames(nt, "Output", "Hidden"); err != nil {
+		t.Fatal(err)
+	}
+	hid := nt.lays[1]
+	if hid.NRecvPrjns() != 1 || hid.RecvPrjn(0).SendLay().Name() != "Input" || nt.lays[2].NSendPrjns() != 0 {
+		t.Errorf("DeletePrjnNames: recv: %d", hid.NRecvPrjns())
+	}
+	if err := DeletePrjnNames(nt, "Output", "Hidden"); err == nil {
+		t.Errorf("DeletePrjnNames deleted prjn: no error")
+	}
+	if err := DeletePrjnNames(nt, "Input", "Nope"); err == nil {
+		t.Errorf("DeletePrjnNames unknown layer: no error")
+	}
+
+	if err := DeleteLayerName(nt, "Hidden"); err != nil {
+		t.Fatal(err)
+	}
+	if nt.NLayers() != 2 || nt.LayerByName("Hidden") != nil || nt.lays[1].Name() != "Output" || nt.lays[1].Index() != 1 {
+		t.Errorf("DeleteLayerName: layers: %d", nt.NLayers())
+	}
+	if nt.lays[0].NSendPrjns() != 0 || nt.lays[1].NRecvPrjns() != 0 {
+		t.Errorf("DeleteLayerName: projections not deleted")
+	}
+	if err := DeleteLayerName(nt, "Hidden"); err == nil {
+		t.Errorf("DeleteLayerName unknown layer: no error")
+	}
+	if err := DeleteLayerName(testNet3(), "Hidden"); err == nil {
+		t.Errorf("DeleteLayerName without NetworkEditor: no error")
+	}
+	if err := DeletePrjnNames(testNet3(), "Input", "Hidden"); err == nil {
+		t.Errorf("DeletePrjnNames without NetworkEditor: no error")
+	}
+}
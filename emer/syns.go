@@ -0,0 +1,147 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"fmt"
+	"log"
+)
+
+// Syn identifies one synapse in a network, as passed to the functions of
+// PrjnSyns and NetSyns, with access to the values of its variables.
+type Syn struct {
+	Prjn Prjn `desc:"projection that the synapse is in"`
+	SIdx int  `desc:"index of the sending unit in the sending layer (1D, flat index)"`
+	RIdx int  `desc:"index of the receiving unit in the receiving layer (1D, flat index)"`
+}
+
+// SendLay returns the sending layer of the synapse
+func (sy *Syn) SendLay() Layer {
+	return sy.Prjn.SendLay()
+}
+
+// RecvLay returns the receiving layer of the synapse
+func (sy *Syn) RecvLay() Layer {
+	return sy.Prjn.RecvLay()
+}
+
+// Val returns the value of given variable (see Prjn.SynVarNames) on the
+// synapse -- NaN if the variable name is not valid
+func (sy *Syn) Val(varNm string) float32 {
+	return sy.Prjn.SynVal(varNm, sy.SIdx, sy.RIdx)
+}
+
+// SetVal sets the value of given variable on the synapse, e.g., for pruning,
+// returning an error if the variable name is not valid
+func (sy *Syn) SetVal(varNm string, val float32) error {
+	return sy.Prjn.SetSynVal(varNm, sy.SIdx, sy.RIdx, val)
+}
+
+// SynIterer is an optional interface for projections to efficiently
+// iterate over the synapses that they actually have, which is required
+// for PrjnSyns and NetSyns.
+type SynIterer interface {
+	// SynIter calls given function with the sending and receiving unit
+	// indexes (1D, flat) of each synapse, stopping if it returns false
+	SynIter(fun func(sidx, ridx int) bool)
+}
+
+// synIterer returns the projection as a SynIterer, or an error if it
+// does not support it
+func synIterer(pj Prjn, fun string) (SynIterer, error) {
+	si, ok := pj.(SynIterer)
+	if !ok {
+		err := fmt.Errorf("emer.%s: projection: %v does not support synapse iteration (emer.SynIterer)", fun, pj.Name())
+		log.Println(err)
+		return nil, err
+	}
+	return si, nil
+}
+
+// PrjnSyns calls given function for each synapse in given (built)
+// projection, stopping if it returns false, in which case it returns false.
+// The projection must implement the SynIterer interface, and otherwise
+// an error is returned without calling the function.
+// The same Syn is updated and passed to each call, so copy it to retain it.
+// This allows analysis code (e.g., weight histograms, pruning) to work with
+// any algorithm.
+func PrjnSyns(pj Prjn, fun func(sy *Syn) bool) (bool, error) {
+	si, err := synIterer(pj, "PrjnSyns")
+	if err != nil {
+		return false, err
+	}
+	sy := &Syn{Prjn: pj}
+	done := true
+	si.SynIter(func(sidx, ridx int) bool {
+		sy.SIdx, sy.RIdx = sidx, ridx
+		if !fun(sy) {
+			done = false
+		}
+		return done
+	})
+	return done, nil
+}
+
+// NetSyns calls given function for each synapse in given (built) network,
+// in the receiving projections of each layer in order (see PrjnSyns),
+// skipping projections that are off, stopping if it returns false.
+// All of the projections must implement the SynIterer interface, and
+// otherwise an error is returned without calling the function.
+func NetSyns(net Network, fun func(sy *Syn) bool) error {
+	var pjs []Prjn
+	for li := 0; li < net.NLayers(); li++ {
+		ly := net.Layer(li)
+		for pi := 0; pi < ly.NRecvPrjns(); pi++ {
+			pj := ly.RecvPrjn(pi)
+			if pj.IsOff() {
+				continue
+			}
+			if _, err := synIterer(pj, "NetSyns"); err != nil {
+				return err
+			}
+			pjs = append(pjs, pj)
+		}
+	}
+	for _, pj := range pjs {
+		if done, _ := PrjnSyns(pj, fun); !done {
+			break
+		}
+	}
+	return nil
+}
+
+// PrjnByNames returns the projection from the send to the recv layer of
+// given names in given network, or an error if not found
+func PrjnByNames(net Network, send, recv string) (Prjn, error) {
+	rlay, err := net.LayerByNameTry(recv)
+	if err != nil {
+		return nil, err
+	}
+	return rlay.RecvPrjns().SendNameTry(send)
+}
+
+// SynValNames returns the value of given variable name on the synapse between
+// given send, recv unit indexes (1D, flat indexes) in the projection from the
+// send to the recv layer of given names (see Prjn.SynValTry), e.g., for
+// probing individual synapses in tests.  Returns error for access errors.
+func SynValNames(net Network, send, recv string, varNm string, sidx, ridx int) (float32, error) {
+	pj, err := PrjnByNames(net, send, recv)
+	if err != nil {
+		return 0, err
+	}
+	return pj.SynValTry(varNm, sidx, ridx)
+}
+
+// SetSynValNames sets the value of given variable name on the synapse between
+// given send, recv unit indexes (1D, flat indexes) in the projection from the
+// send to the recv layer of given names (see Prjn.SetSynVal).
+// Returns error for access errors.
+func SetSynValNames(net Network, send, recv string, varNm string, sidx, ridx int, val float32) error {
+	pj, err := PrjnByNames(net, send, recv)
+	if err != nil {
+		return err
+	}
+	return pj.SetSynVal(varNm, sidx, ridx, val)
+}
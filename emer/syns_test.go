@@ -0,0 +1,58 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import "testing"
+
+func TestPrjnSyns(t *testing.T) {
+	nt := testNet3()
+	pj := nt.lays[1].rcv[0].(*testPrjn)
+	var syns []Syn
+	done, err := PrjnSyns(pj, func(sy *Syn) bool {
+		syns = append(syns, *sy)
+		return true
+	})
+	if err != nil || !done || len(syns) != 12 {
+		t.Fatalf("PrjnSyns: done: %v err: %v n: %d != 12", done, err, len(syns))
+	}
+	if sy := syns[5]; sy.SIdx != 1 || sy.RIdx != 1 || sy.Val("Wt") != 5 || sy.RecvLay() != nt.lays[1] || sy.SendLay() != nt.lays[0] {
+		t.Errorf("PrjnSyns: syn 5: %+v Wt: %g", sy, sy.Val("Wt"))
+	}
+	n := 0
+	done, err = PrjnSyns(pj, func(sy *Syn) bool {
+		n++
+		return sy.SetVal("Wt", 0) == nil && n < 3
+	})
+	if err != nil || done || n != 3 || pj.wts[2] != 0 || pj.wts[3] != 3 {
+		t.Errorf("PrjnSyns stop: done: %v err: %v n: %d != 3 wts: %v", done, err, n, pj.wts)
+	}
+
+	n = 0
+	done, err = PrjnSyns(noIterPrjn{pj}, func(sy *Syn) bool {
+		n++
+		return true
+	})
+	if err == nil || done || n != 0 {
+		t.Errorf("PrjnSyns without SynIterer: done: %v err: %v n: %d", done, err, n)
+	}
+}
+
+func TestNetSyns(t *testing.T) {
+	nt := testNet3()
+	n := 0
+	if err := NetSyns(nt, func(sy *Syn) bool { n++; return true }); err != nil || n != 12+6+6 {
+		t.Errorf("NetSyns: err: %v n: %d != 24", err, n)
+	}
+	nt.lays[2].rcv[0].SetOff(true)
+	n = 0
+	if err := NetSyns(nt, func(sy *Syn) bool { n++; return n < 13 }); err != nil || n != 13 {
+		t.Errorf("NetSyns stop: err: %v n: %d != 13", err, n)
+	}
+	nt.lays[1].rcv[1] = noIterPrjn{nt.lays[1].rcv[1]}
+	n = 0
+	if err := NetSyns(nt, func(sy *Syn) bool { n++; return true }); err == nil || n != 0 {
+		t.Errorf("NetSyns without SynIterer: err: %v n: %d", err, n)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"log"
+	"math"
+
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+)
+
+// SynStats are summary statistics of the values of a synapse variable
+// in a projection, for monitoring learning without exporting the weights
+type SynStats struct {
+	N    int     `desc:"number of synapses with valid (non-NaN) values"`
+	Mean float32 `desc:"mean value"`
+	Std  float32 `desc:"standard deviation of the values"`
+	Min  float32 `desc:"minimum value"`
+	Max  float32 `desc:"maximum value"`
+	Hist []int   `desc:"histogram of counts of values in equal-width bins from Min to Max"`
+}
+
+// Compute computes the stats from given values, ignoring NaN values,
+// with given number of histogram bins (0 for no histogram)
+func (ss *SynStats) Compute(vals []float32, nbins int) {
+	*ss = SynStats{}
+	var sum, sumsq float64
+	for _, v := range vals {
+		if math.IsNaN(float64(v)) {
+			continue
+		}
+		if ss.N == 0 || v < ss.Min {
+			ss.Min = v
+		}
+		if ss.N == 0 || v > ss.Max {
+			ss.Max = v
+		}
+		ss.N++
+		sum += float64(v)
+		sumsq += float64(v) * float64(v)
+	}
+	if nbins > 0 {
+		ss.Hist = make([]int, nbins)
+	}
+	if ss.N == 0 {
+		return
+	}
+	mean := sum / float64(ss.N)
+	ss.Mean = float32(mean)
+	ss.Std = float32(math.Sqrt(math.Max(sumsq/float64(ss.N)-mean*mean, 0)))
+	if nbins == 0 {
+		return
+	}
+	rng := ss.Max - ss.Min
+	for _, v := range vals {
+		if math.IsNaN(float64(v)) {
+			continue
+		}
+		bi := 0
+		if rng > 0 {
+			bi = int(float32(nbins) * (v - ss.Min) / rng)
+		}
+		if bi >= nbins {
+			bi = nbins - 1
+		}
+		ss.Hist[bi]++
+	}
+}
+
+// PrjnSynStats returns the stats for given synapse variable (see SynVarNames)
+// in given projection, with given number of histogram bins (0 for none)
+func PrjnSynStats(pj Prjn, varNm string, nbins int) (*SynStats, error) {
+	var vals []float32
+	err := pj.SynVals(&vals, varNm)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	ss := &SynStats{}
+	ss.Compute(vals, nbins)
+	return ss, nil
+}
+
+// NetSynStats returns an etable.Table of the stats for given synapse variable
+// in each projection in the network (skipping those that are off), with one row
+// per projection, and a Hist column with given number of histogram bins
+// (0 for none).  Projections that do not have the variable are skipped.
+func NetSynStats(net Network, varNm string, nbins int) *etable.Table {
+	var pjs []Prjn
+	var sts []*SynStats
+	for li := 0; li < net.NLayers(); li++ {
+		ly := net.Layer(li)
+		for pi := 0; pi < ly.NRecvPrjns(); pi++ {
+			pj := ly.RecvPrjn(pi)
+			if pj.IsOff() {
+				continue
+			}
+			ss, err := PrjnSynStats(pj, varNm, nbins)
+			if err != nil {
+				continue
+			}
+			pjs = append(pjs, pj)
+			sts = append(sts, ss)
+		}
+	}
+	dt := &etable.Table{}
+	sch := etable.Schema{
+		{"Layer", etensor.STRING, nil, nil},
+		{"From", etensor.STRING, nil, nil},
+		{"N", etensor.INT64, nil, nil},
+		{"Mean", etensor.FLOAT32, nil, nil},
+		{"Std", etensor.FLOAT32, nil, nil},
+		{"Min", etensor.FLOAT32, nil, nil},
+		{"Max", etensor.FLOAT32, nil, nil},
+	}
+	if nbins > 0 {
+		sch = append(sch, etable.Column{"Hist", etensor.INT64, []int{nbins}, []string{"Bin"}})
+	}
+	dt.SetFromSchema(sch, len(pjs))
+	dt.SetMetaData("name", "SynStats")
+	dt.SetMetaData("desc", "stats of synapse variable: "+varNm+" in each projection")
+	for row, pj := range pjs {
+		ss := sts[row]
+		dt.SetCellString("Layer", row, pj.RecvLay().Name())
+		dt.SetCellString("From", row, pj.SendLay().Name())
+		dt.SetCellFloat("N", row, float64(ss.N))
+		dt.SetCellFloat("Mean", row, float64(ss.Mean))
+		dt.SetCellFloat("Std", row, float64(ss.Std))
+		dt.SetCellFloat("Min", row, float64(ss.Min))
+		dt.SetCellFloat("Max", row, float64(ss.Max))
+		if nbins > 0 {
+			ht := dt.CellTensor("Hist", row)
+			for bi, n := range ss.Hist {
+				ht.SetFloat1D(bi, float64(n))
+			}
+		}
+	}
+	return dt
+}
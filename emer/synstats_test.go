@@ -0,0 +1,71 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSynStatsCompute(t *testing.T) {
+	nan := float32(math.NaN())
+	tests := []struct {
+		name  string
+		vals  []float32
+		nbins int
+		want  SynStats
+	}{
+		{"empty", nil, 2, SynStats{Hist: []int{0, 0}}},
+		{"all nan", []float32{nan, nan}, 0, SynStats{}},
+		{"one", []float32{0.5}, 2, SynStats{N: 1, Mean: 0.5, Min: 0.5, Max: 0.5, Hist: []int{1, 0}}},
+		{"constant", []float32{2, 2, 2}, 3, SynStats{N: 3, Mean: 2, Min: 2, Max: 2, Hist: []int{3, 0, 0}}},
+		{"no hist", []float32{1, 3}, 0, SynStats{N: 2, Mean: 2, Std: 1, Min: 1, Max: 3}},
+		{"nan", []float32{1, nan, 2, 3, 4, nan}, 3, SynStats{N: 4, Mean: 2.5, Std: float32(math.Sqrt(1.25)), Min: 1, Max: 4, Hist: []int{1, 1, 2}}},
+		{"negative", []float32{-1, 1, -1, 1}, 2, SynStats{N: 4, Mean: 0, Std: 1, Min: -1, Max: 1, Hist: []int{2, 2}}},
+	}
+	for _, tt := range tests {
+		ss := SynStats{N: 10, Hist: []int{5}} // prior values are reset
+		ss.Compute(tt.vals, tt.nbins)
+		if !reflect.DeepEqual(ss, tt.want) {
+			t.Errorf("%s: %+v != %+v", tt.name, ss, tt.want)
+		}
+	}
+}
+
+func TestPrjnSynStats(t *testing.T) {
+	nt := testNet3()
+	ss, err := PrjnSynStats(nt.lays[2].rcv[0], "Wt", 2) // Wt: 0..5
+	if err != nil || ss.N != 6 || ss.Mean != 2.5 || ss.Min != 0 || ss.Max != 5 || !reflect.DeepEqual(ss.Hist, []int{3, 3}) {
+		t.Errorf("PrjnSynStats: %+v err: %v", ss, err)
+	}
+	if ss, err := PrjnSynStats(nt.lays[2].rcv[0], "Nope", 2); err == nil || ss != nil {
+		t.Errorf("PrjnSynStats invalid var: %+v err: %v", ss, err)
+	}
+}
+
+func TestNetSynStats(t *testing.T) {
+	nt := testNet3()
+	nt.lays[1].rcv[1].SetOff(true)
+	dt := NetSynStats(nt, "Wt", 4)
+	if dt.Rows != 2 {
+		t.Fatalf("NetSynStats: rows: %d != 2", dt.Rows)
+	}
+	if dt.CellString("Layer", 0) != "Hidden" || dt.CellString("From", 0) != "Input" || dt.CellFloat("N", 0) != 12 || dt.CellFloat("Max", 0) != 11 {
+		t.Errorf("NetSynStats: row 0: %v %v %v %v", dt.CellString("Layer", 0), dt.CellString("From", 0), dt.CellFloat("N", 0), dt.CellFloat("Max", 0))
+	}
+	if dt.CellString("Layer", 1) != "Output" || dt.CellFloat("Mean", 1) != 2.5 {
+		t.Errorf("NetSynStats: row 1: %v %v", dt.CellString("Layer", 1), dt.CellFloat("Mean", 1))
+	}
+	ht := dt.CellTensor("Hist", 0)
+	for bi := 0; bi < 4; bi++ {
+		if ht.FloatVal1D(bi) != 3 {
+			t.Errorf("NetSynStats: row 0 Hist[%d]: %v != 3", bi, ht.FloatVal1D(bi))
+		}
+	}
+	if dt := NetSynStats(nt, "Nope", 0); dt.Rows != 0 {
+		t.Errorf("NetSynStats invalid var: rows: %d != 0", dt.Rows)
+	}
+}
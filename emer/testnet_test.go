@@ -0,0 +1,248 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/emer/emergent/prjn"
+	"github.com/emer/etable/etensor"
+)
+
+// testNet is a minimal mock network for testing the generic functions in
+// this package -- it only implements the methods that they use, and
+// calling any other method panics
+type testNet struct {
+	Network
+	nm   string
+	lays []*testLay
+}
+
+func newTestNet(nm string) *testNet {
+	return &testNet{nm: nm}
+}
+
+func (nt *testNet) InitName(net Network, name string) { nt.nm = name }
+func (nt *testNet) Name() string                      { return nt.nm }
+func (nt *testNet) Label() string                     { return nt.nm }
+func (nt *testNet) NLayers() int                      { return len(nt.lays) }
+
+func (nt *testNet) Layer(idx int) Layer { return nt.lays[idx] }
+
+func (nt *testNet) LayerByName(name string) Layer {
+	ly, _ := nt.LayerByNameTry(name)
+	return ly
+}
+
+func (nt *testNet) LayerByNameTry(name string) (Layer, error) {
+	for _, ly := range nt.lays {
+		if ly.nm == name {
+			return ly, nil
+		}
+	}
+	return nil, fmt.Errorf("layer: %v not found", name)
+}
+
+// AddLayer adds a new layer with given name and shape, with unit values
+// for the Act variable of 0..n-1
+func (nt *testNet) AddLayer(nm string, shp []int) *testLay {
+	ly := &testLay{nm: nm, idx: len(nt.lays)}
+	ly.Config(shp, Hidden)
+	nt.lays = append(nt.lays, ly)
+	return ly
+}
+
+// Connect adds a fully connected projection of given type from send to recv,
+// with Wt values of 0..n-1 for the synapses in receiver-based order
+func (nt *testNet) Connect(send, recv *testLay, typ PrjnType) *testPrjn {
+	pj := &testPrjn{send: send, recv: recv, typ: typ}
+	for ri := 0; ri < recv.shp.Len(); ri++ {
+		for si := 0; si < send.shp.Len(); si++ {
+			pj.syns = append(pj.syns, [2]int{si, ri})
+			pj.wts = append(pj.wts, float32(len(pj.wts)))
+		}
+	}
+	recv.rcv.Add(pj)
+	send.snd.Add(pj)
+	return pj
+}
+
+// testLay is a minimal mock layer, with one unit variable: Act,
+// which implements UnitValsSetter, and one param: Gain
+type testLay struct {
+	Layer
+	Gain float32
+	nm   string
+	idx  int
+	typ  LayerType
+	shp  etensor.Shape
+	off  bool
+	thr  int
+	acts []float32
+	rcv  Prjns
+	snd  Prjns
+}
+
+func (ly *testLay) InitName(lay Layer, name string, net Network) { ly.nm = name }
+
+func (ly *testLay) Config(shape []int, typ LayerType) {
+	ly.typ = typ
+	ly.shp.SetShape(shape, nil, nil)
+	ly.acts = make([]float32, ly.shp.Len())
+	for i := range ly.acts {
+		ly.acts[i] = float32(i)
+	}
+}
+
+func (ly *testLay) Name() string          { return ly.nm }
+func (ly *testLay) Label() string         { return ly.nm }
+func (ly *testLay) TypeName() string      { return "Layer" }
+func (ly *testLay) Class() string         { return "" }
+func (ly *testLay) IsOff() bool           { return ly.off }
+func (ly *testLay) SetOff(off bool)       { ly.off = off }
+func (ly *testLay) Shape() *etensor.Shape { return &ly.shp }
+func (ly *testLay) Index() int            { return ly.idx }
+func (ly *testLay) Type() LayerType       { return ly.typ }
+func (ly *testLay) Thread() int           { return ly.thr }
+func (ly *testLay) SetThread(thr int)     { ly.thr = thr }
+func (ly *testLay) RecvPrjns() *Prjns     { return &ly.rcv }
+func (ly *testLay) NRecvPrjns() int       { return len(ly.rcv) }
+func (ly *testLay) RecvPrjn(idx int) Prjn { return ly.rcv[idx] }
+func (ly *testLay) SendPrjns() *Prjns     { return &ly.snd }
+func (ly *testLay) NSendPrjns() int       { return len(ly.snd) }
+func (ly *testLay) SendPrjn(idx int) Prjn { return ly.snd[idx] }
+func (ly *testLay) UnitVarNames() []string {
+	return []string{"Act"}
+}
+
+func (ly *testLay) UnitVarProps() map[string]string {
+	return map[string]string{"Act": `range:"1" cat:"Act"`}
+}
+
+func (ly *testLay) UnitVals(vals *[]float32, varNm string) error {
+	if varNm != "Act" {
+		return fmt.Errorf("variable: %v not found", varNm)
+	}
+	*vals = append((*vals)[:0], ly.acts...)
+	return nil
+}
+
+func (ly *testLay) SetUnitVals(vals []float32, varNm string) error {
+	if varNm != "Act" || len(vals) < len(ly.acts) {
+		return fmt.Errorf("variable: %v not found or too few values: %d", varNm, len(vals))
+	}
+	copy(ly.acts, vals)
+	return nil
+}
+
+// testPrjn is a minimal mock projection, with one synapse variable: Wt,
+// which implements SynIterer and SynValsSetter, and one param: Gain
+type testPrjn struct {
+	Prjn
+	Gain float32
+	send *testLay
+	recv *testLay
+	typ  PrjnType
+	off  bool
+	syns [][2]int
+	wts  []float32
+}
+
+func (pj *testPrjn) Name() string          { return pj.send.nm + "To" + pj.recv.nm }
+func (pj *testPrjn) Label() string         { return pj.Name() }
+func (pj *testPrjn) TypeName() string      { return "Prjn" }
+func (pj *testPrjn) Class() string         { return "" }
+func (pj *testPrjn) SendLay() Layer        { return pj.send }
+func (pj *testPrjn) RecvLay() Layer        { return pj.recv }
+func (pj *testPrjn) Type() PrjnType        { return pj.typ }
+func (pj *testPrjn) Pattern() prjn.Pattern { return prjn.NewFull() }
+func (pj *testPrjn) IsOff() bool           { return pj.off || pj.send.off || pj.recv.off }
+func (pj *testPrjn) SetOff(off bool)       { pj.off = off }
+func (pj *testPrjn) SynVarNames() []string {
+	return []string{"Wt"}
+}
+
+func (pj *testPrjn) SynVarProps() map[string]string {
+	return map[string]string{"Wt": `cat:"Learn"`}
+}
+
+func (pj *testPrjn) SynIter(fun func(sidx, ridx int) bool) {
+	for _, sy := range pj.syns {
+		if !fun(sy[0], sy[1]) {
+			return
+		}
+	}
+}
+
+// synIdx returns the index of the synapse between given units, -1 if none
+func (pj *testPrjn) synIdx(sidx, ridx int) int {
+	for i, sy := range pj.syns {
+		if sy[0] == sidx && sy[1] == ridx {
+			return i
+		}
+	}
+	return -1
+}
+
+func (pj *testPrjn) SynVals(vals *[]float32, varNm string) error {
+	if varNm != "Wt" {
+		return fmt.Errorf("variable: %v not found", varNm)
+	}
+	*vals = append((*vals)[:0], pj.wts...)
+	return nil
+}
+
+func (pj *testPrjn) SynValTry(varNm string, sidx, ridx int) (float32, error) {
+	si := pj.synIdx(sidx, ridx)
+	if varNm != "Wt" || si < 0 {
+		return 0, fmt.Errorf("synapse: %v %d %d not found", varNm, sidx, ridx)
+	}
+	return pj.wts[si], nil
+}
+
+func (pj *testPrjn) SynVal(varNm string, sidx, ridx int) float32 {
+	v, err := pj.SynValTry(varNm, sidx, ridx)
+	if err != nil {
+		return float32(math.NaN())
+	}
+	return v
+}
+
+func (pj *testPrjn) SetSynVal(varNm string, sidx, ridx int, val float32) error {
+	si := pj.synIdx(sidx, ridx)
+	if varNm != "Wt" || si < 0 {
+		return fmt.Errorf("synapse: %v %d %d not found", varNm, sidx, ridx)
+	}
+	pj.wts[si] = val
+	return nil
+}
+
+func (pj *testPrjn) SetSynVals(vals []float32, varNm string) error {
+	if varNm != "Wt" || len(vals) < len(pj.wts) {
+		return fmt.Errorf("variable: %v not found or too few values: %d", varNm, len(vals))
+	}
+	copy(pj.wts, vals)
+	return nil
+}
+
+// noIterPrjn hides the optional interfaces of a projection
+type noIterPrjn struct {
+	Prjn
+}
+
+// testNet3 returns a network with a 2x2 Input, 1x3 Hidden and 1x2 Output
+// layer, with Forward projections from Input to Hidden and Hidden to Output,
+// and a Back projection from Output to Hidden
+func testNet3() *testNet {
+	nt := newTestNet("Test")
+	in := nt.AddLayer("Input", []int{2, 2})
+	hid := nt.AddLayer("Hidden", []int{1, 3})
+	out := nt.AddLayer("Output", []int{1, 2})
+	nt.Connect(in, hid, Forward)
+	nt.Connect(hid, out, Forward)
+	nt.Connect(out, hid, Back)
+	return nt
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import "reflect"
+
+// VarCatOther is the category of variables without a cat property
+const VarCatOther = "Other"
+
+// VarCat is a category of unit or synapse variables (e.g., Act, Learn, Stats),
+// from the cat:"name" property of the variables (see Layer.UnitVarProps),
+// with the names of the variables in it
+type VarCat struct {
+	Cat  string   `desc:"name of the category"`
+	Vars []string `desc:"names of the variables in the category, in their original order"`
+}
+
+// VarCats is a list of categories of variables, e.g., for presenting long
+// lists of variables in organized sections
+type VarCats []VarCat
+
+// CatVars returns given variable names grouped by their cat:"name" property
+// in given props (see Layer.UnitVarProps), with categories in order of their
+// first variable, and any variables without a category in a final
+// VarCatOther category
+func CatVars(names []string, props map[string]string) VarCats {
+	var vc VarCats
+	var other []string
+	cidx := make(map[string]int)
+	for _, nm := range names {
+		cat := reflect.StructTag(props[nm]).Get("cat")
+		if cat == "" {
+			other = append(other, nm)
+			continue
+		}
+		ci, has := cidx[cat]
+		if !has {
+			ci = len(vc)
+			cidx[cat] = ci
+			vc = append(vc, VarCat{Cat: cat})
+		}
+		vc[ci].Vars = append(vc[ci].Vars, nm)
+	}
+	if len(other) > 0 {
+		vc = append(vc, VarCat{Cat: VarCatOther, Vars: other})
+	}
+	return vc
+}
+
+// UnitVarCats returns the unit variables of given layer grouped by category
+func UnitVarCats(ly Layer) VarCats {
+	return CatVars(ly.UnitVarNames(), ly.UnitVarProps())
+}
+
+// SynVarCats returns the synapse variables of given projection grouped by category
+func SynVarCats(pj Prjn) VarCats {
+	return CatVars(pj.SynVarNames(), pj.SynVarProps())
+}
+
+// Names returns the names of all the variables, in category order
+func (vc VarCats) Names() []string {
+	var nms []string
+	for _, c := range vc {
+		nms = append(nms, c.Vars...)
+	}
+	return nms
+}
+
+// Cat returns the category of given variable name, or "" if not present
+func (vc VarCats) Cat(varNm string) string {
+	for _, c := range vc {
+		for _, nm := range c.Vars {
+			if nm == varNm {
+				return c.Cat
+			}
+		}
+	}
+	return ""
+}
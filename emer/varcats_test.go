@@ -0,0 +1,59 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCatVars(t *testing.T) {
+	props := map[string]string{
+		"Act":   `cat:"Act"`,
+		"Ge":    `range:"2" cat:"Act"`,
+		"Wt":    `cat:"Learn" auto-scale:"+"`,
+		"AvgSS": `cat:"Learn"`,
+		"Vm":    `cat:"Act"`,
+		"Spike": `range:"1"`,
+	}
+	tests := []struct {
+		name  string
+		names []string
+		want  VarCats
+	}{
+		{"none", nil, nil},
+		{"no props", []string{"Foo", "Bar"}, VarCats{{VarCatOther, []string{"Foo", "Bar"}}}},
+		{"ordered", []string{"Act", "Ge", "Vm", "Wt", "AvgSS"}, VarCats{{"Act", []string{"Act", "Ge", "Vm"}}, {"Learn", []string{"Wt", "AvgSS"}}}},
+		{"interleaved", []string{"Wt", "Spike", "Act", "AvgSS", "Ge"}, VarCats{{"Learn", []string{"Wt", "AvgSS"}}, {"Act", []string{"Act", "Ge"}}, {VarCatOther, []string{"Spike"}}}},
+	}
+	for _, tt := range tests {
+		vc := CatVars(tt.names, props)
+		if !reflect.DeepEqual(vc, tt.want) {
+			t.Errorf("%s: %v != %v", tt.name, vc, tt.want)
+		}
+		if nms := vc.Names(); len(nms) != len(tt.names) {
+			t.Errorf("%s: Names: %v", tt.name, nms)
+		}
+	}
+	vc := CatVars([]string{"Wt", "Spike", "Act", "AvgSS", "Ge"}, props)
+	if nms := vc.Names(); !reflect.DeepEqual(nms, []string{"Wt", "AvgSS", "Act", "Ge", "Spike"}) {
+		t.Errorf("Names: %v", nms)
+	}
+	for nm, cat := range map[string]string{"Wt": "Learn", "Ge": "Act", "Spike": VarCatOther, "Nope": ""} {
+		if c := vc.Cat(nm); c != cat {
+			t.Errorf("Cat: %s: %q != %q", nm, c, cat)
+		}
+	}
+}
+
+func TestUnitSynVarCats(t *testing.T) {
+	nt := testNet3()
+	if vc := UnitVarCats(nt.lays[0]); !reflect.DeepEqual(vc, VarCats{{"Act", []string{"Act"}}}) {
+		t.Errorf("UnitVarCats: %v", vc)
+	}
+	if vc := SynVarCats(nt.lays[1].rcv[0]); !reflect.DeepEqual(vc, VarCats{{"Learn", []string{"Wt"}}}) {
+		t.Errorf("SynVarCats: %v", vc)
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package empi provides MPI (message passing interface) utilities for
+data-parallel training of emergent models across multiple processes / nodes:
+
+  - AvgWts averages the weights of the network across all processes, using the
+    raw synapse values of the projections (which must implement
+    emer.SynValsSetter), and AvgVals averages arbitrary values (e.g.,
+    weight changes collected by an algorithm) across processes.
+
+  - GatherTable gathers the rows of a log table from all processes into the
+    table on the root process (rank 0).
+
+  - SeedRand seeds the random number generator differently for each process,
+    and StartEnd divides a range of items (e.g., trials) among the processes.
+
+The MPI library is only used when building with the mpi build tag
+(go build -tags mpi), which requires a C MPI installation (e.g., OpenMPI)
+via cgo.  Otherwise, all functions operate as a single process of rank 0,
+so the same code runs with or without MPI.
+*/
+package empi
@@ -0,0 +1,85 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package empi
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Op is a reduction operation for AllReduce
+type Op int
+
+const (
+	// OpSum sums the values across processes
+	OpSum Op = iota
+
+	// OpMax takes the maximum value across processes
+	OpMax
+
+	// OpMin takes the minimum value across processes
+	OpMin
+)
+
+// IsRoot returns true if this is the root process (rank 0)
+func IsRoot() bool {
+	return Rank() == 0
+}
+
+// Printf prints only on the root process, to avoid duplicate output
+func Printf(format string, args ...interface{}) {
+	if IsRoot() {
+		fmt.Printf(format, args...)
+	}
+}
+
+// SeedRand seeds the global random number generator with given base seed
+// offset by the rank, so each process has a different but reproducible
+// random sequence, returning the seed used
+func SeedRand(base int64) int64 {
+	seed := base + int64(Rank())*1000003
+	rand.Seed(seed)
+	return seed
+}
+
+// StartEnd returns the start and end (exclusive) of the range of n items
+// (e.g., trials) to be processed by this process, dividing them as evenly
+// as possible among all processes
+func StartEnd(n int) (st, ed int) {
+	sz := Size()
+	rk := Rank()
+	per := n / sz
+	extra := n % sz
+	st = rk*per + min(rk, extra)
+	ed = st + per
+	if rk < extra {
+		ed++
+	}
+	return
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// AvgVals replaces the values with their average across all processes,
+// e.g., for weight changes collected by an algorithm prior to applying them
+func AvgVals(vals []float32) error {
+	if Size() == 1 {
+		return nil
+	}
+	sum := make([]float32, len(vals))
+	if err := AllReduceF32(OpSum, sum, vals); err != nil {
+		return err
+	}
+	nf := float32(Size())
+	for i, v := range sum {
+		vals[i] = v / nf
+	}
+	return nil
+}
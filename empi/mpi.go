@@ -0,0 +1,154 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build mpi
+// +build mpi
+
+package empi
+
+/*
+#cgo LDFLAGS: -lmpi
+#include <mpi.h>
+#include <stdlib.h>
+
+static int empi_init() {
+	return MPI_Init(NULL, NULL);
+}
+
+static int empi_rank() {
+	int rank = 0;
+	MPI_Comm_rank(MPI_COMM_WORLD, &rank);
+	return rank;
+}
+
+static int empi_size() {
+	int size = 1;
+	MPI_Comm_size(MPI_COMM_WORLD, &size);
+	return size;
+}
+
+static MPI_Op empi_op(int op) {
+	switch (op) {
+	case 1:
+		return MPI_MAX;
+	case 2:
+		return MPI_MIN;
+	}
+	return MPI_SUM;
+}
+
+static int empi_allreduce_f32(float* src, float* dest, int n, int op) {
+	return MPI_Allreduce(src, dest, n, MPI_FLOAT, empi_op(op), MPI_COMM_WORLD);
+}
+
+static int empi_allreduce_f64(double* src, double* dest, int n, int op) {
+	return MPI_Allreduce(src, dest, n, MPI_DOUBLE, empi_op(op), MPI_COMM_WORLD);
+}
+
+static int empi_send(void* buf, int n, int to) {
+	int err = MPI_Send(&n, 1, MPI_INT, to, 0, MPI_COMM_WORLD);
+	if (err != MPI_SUCCESS || n == 0) {
+		return err;
+	}
+	return MPI_Send(buf, n, MPI_BYTE, to, 1, MPI_COMM_WORLD);
+}
+
+static int empi_recv_len(int from, int* n) {
+	return MPI_Recv(n, 1, MPI_INT, from, 0, MPI_COMM_WORLD, MPI_STATUS_IGNORE);
+}
+
+static int empi_recv(void* buf, int n, int from) {
+	return MPI_Recv(buf, n, MPI_BYTE, from, 1, MPI_COMM_WORLD, MPI_STATUS_IGNORE);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// UseMPI is true if built with MPI support (mpi build tag)
+const UseMPI = true
+
+var rank, size = 0, 1
+
+// Init initializes MPI -- must be called prior to any other functions
+func Init() error {
+	if err := mpiErr(C.empi_init(), "Init"); err != nil {
+		return err
+	}
+	rank = int(C.empi_rank())
+	size = int(C.empi_size())
+	return nil
+}
+
+// Finalize finalizes MPI -- must be called at the end of the program
+func Finalize() {
+	C.MPI_Finalize()
+}
+
+// Rank returns the rank (index) of this process, 0 = root
+func Rank() int {
+	return rank
+}
+
+// Size returns the total number of processes
+func Size() int {
+	return size
+}
+
+// Barrier waits for all processes to reach this point
+func Barrier() {
+	C.MPI_Barrier(C.MPI_COMM_WORLD)
+}
+
+// AllReduceF32 reduces the src values across all processes using given
+// operation, with the result in dest on all processes.
+// dest and src must be the same length and must not overlap.
+func AllReduceF32(op Op, dest, src []float32) error {
+	if len(src) == 0 {
+		return nil
+	}
+	return mpiErr(C.empi_allreduce_f32((*C.float)(unsafe.Pointer(&src[0])), (*C.float)(unsafe.Pointer(&dest[0])), C.int(len(src)), C.int(op)), "AllReduceF32")
+}
+
+// AllReduceF64 reduces the src values across all processes using given
+// operation, with the result in dest on all processes.
+// dest and src must be the same length and must not overlap.
+func AllReduceF64(op Op, dest, src []float64) error {
+	if len(src) == 0 {
+		return nil
+	}
+	return mpiErr(C.empi_allreduce_f64((*C.double)(unsafe.Pointer(&src[0])), (*C.double)(unsafe.Pointer(&dest[0])), C.int(len(src)), C.int(op)), "AllReduceF64")
+}
+
+// SendBytes sends the bytes to the process of given rank, which must call RecvBytes
+func SendBytes(to int, b []byte) error {
+	var ptr unsafe.Pointer
+	if len(b) > 0 {
+		ptr = unsafe.Pointer(&b[0])
+	}
+	return mpiErr(C.empi_send(ptr, C.int(len(b)), C.int(to)), "SendBytes")
+}
+
+// RecvBytes receives bytes sent by SendBytes from the process of given rank
+func RecvBytes(from int) ([]byte, error) {
+	var n C.int
+	if err := mpiErr(C.empi_recv_len(C.int(from), &n), "RecvBytes"); err != nil {
+		return nil, err
+	}
+	b := make([]byte, int(n))
+	if n == 0 {
+		return b, nil
+	}
+	return b, mpiErr(C.empi_recv(unsafe.Pointer(&b[0]), n, C.int(from)), "RecvBytes")
+}
+
+func mpiErr(rc C.int, fun string) error {
+	if rc != C.MPI_SUCCESS {
+		return fmt.Errorf("empi.%s: MPI error code: %d", fun, int(rc))
+	}
+	return nil
+}
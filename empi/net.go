@@ -0,0 +1,124 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package empi
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/emer/emergent/emer"
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+)
+
+// AvgWts averages the weights (Wt synapse variable) of the network across
+// all processes, using the raw float32 values from SynVals, so that no
+// precision is lost.  All processes must have the same network structure,
+// and the projections must implement emer.SynValsSetter to set the averaged
+// values.  This is intended for periodic synchronization -- per-trial
+// synchronization should average the weight changes using AvgVals.
+func AvgWts(net emer.Network) error {
+	return AvgSynVars(net, "Wt")
+}
+
+// AvgSynVars averages the values of given synapse variables in all of the
+// projections of the network across all processes (see AvgWts)
+func AvgSynVars(net emer.Network, vars ...string) error {
+	if Size() == 1 {
+		return nil
+	}
+	var pjs []emer.Prjn
+	for li := 0; li < net.NLayers(); li++ {
+		ly := net.Layer(li)
+		for pi := 0; pi < ly.NRecvPrjns(); pi++ {
+			pj := ly.RecvPrjn(pi)
+			if _, ok := pj.(emer.SynValsSetter); !ok {
+				return fmt.Errorf("empi.AvgSynVars: projection: %v cannot set its synapse values (emer.SynValsSetter)", pj.Name())
+			}
+			pjs = append(pjs, pj)
+		}
+	}
+	pvals := make([][]float32, len(pjs))
+	var vals []float32
+	for _, vnm := range vars {
+		vals = vals[:0]
+		for pi, pj := range pjs {
+			pvals[pi] = nil
+			if err := pj.SynVals(&pvals[pi], vnm); err != nil {
+				return err
+			}
+			vals = append(vals, pvals[pi]...)
+		}
+		if err := AvgVals(vals); err != nil {
+			return err
+		}
+		st := 0
+		for pi, pj := range pjs {
+			n := len(pvals[pi])
+			if err := pj.(emer.SynValsSetter).SetSynVals(vals[st:st+n], vnm); err != nil {
+				return err
+			}
+			st += n
+		}
+	}
+	return nil
+}
+
+// GatherTable gathers the rows of the table from all processes into the
+// table on the root process (rank 0), appending them in rank order after
+// the root's own rows.  The table must have the same columns on all processes.
+// The table on other processes is unchanged.
+func GatherTable(dt *etable.Table) error {
+	if Size() == 1 {
+		return nil
+	}
+	if !IsRoot() {
+		var b bytes.Buffer
+		if err := dt.WriteCSV(&b, etable.Tab, true); err != nil {
+			return err
+		}
+		return SendBytes(0, b.Bytes())
+	}
+	for rk := 1; rk < Size(); rk++ {
+		b, err := RecvBytes(rk)
+		if err != nil {
+			return err
+		}
+		src := dt.Clone()
+		src.SetNumRows(0)
+		if err := src.ReadCSV(bytes.NewReader(b), etable.Tab); err != nil {
+			return err
+		}
+		if err := appendRows(dt, src); err != nil {
+			return fmt.Errorf("empi.GatherTable: rank: %d: %v", rk, err)
+		}
+	}
+	return nil
+}
+
+// appendRows appends the rows of src to dt, which must have the same columns
+func appendRows(dt, src *etable.Table) error {
+	if len(src.Cols) != len(dt.Cols) {
+		return fmt.Errorf("number of columns: %d does not match: %d", len(src.Cols), len(dt.Cols))
+	}
+	st := dt.Rows
+	dt.SetNumRows(st + src.Rows)
+	for ci, dc := range dt.Cols {
+		sc := src.Cols[ci]
+		if sc.Len() == 0 {
+			continue
+		}
+		csz := sc.Len() / src.Rows
+		off := st * csz
+		for i := 0; i < sc.Len(); i++ {
+			if dc.DataType() == etensor.STRING {
+				dc.SetString1D(off+i, sc.StringVal1D(i))
+			} else {
+				dc.SetFloat1D(off+i, sc.FloatVal1D(i))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !mpi
+// +build !mpi
+
+package empi
+
+import "fmt"
+
+// UseMPI is true if built with MPI support (mpi build tag)
+const UseMPI = false
+
+// Init initializes MPI -- must be called prior to any other functions.
+// Without MPI, this does nothing.
+func Init() error {
+	return nil
+}
+
+// Finalize finalizes MPI -- must be called at the end of the program
+func Finalize() {
+}
+
+// Rank returns the rank (index) of this process, 0 = root
+func Rank() int {
+	return 0
+}
+
+// Size returns the total number of processes
+func Size() int {
+	return 1
+}
+
+// Barrier waits for all processes to reach this point
+func Barrier() {
+}
+
+// AllReduceF32 reduces the src values across all processes using given
+// operation, with the result in dest on all processes.
+// Without MPI, src is just copied to dest.
+func AllReduceF32(op Op, dest, src []float32) error {
+	copy(dest, src)
+	return nil
+}
+
+// AllReduceF64 reduces the src values across all processes using given
+// operation, with the result in dest on all processes.
+// Without MPI, src is just copied to dest.
+func AllReduceF64(op Op, dest, src []float64) error {
+	copy(dest, src)
+	return nil
+}
+
+// SendBytes sends the bytes to the process of given rank, which must call RecvBytes
+func SendBytes(to int, b []byte) error {
+	return fmt.Errorf("empi.SendBytes: not built with MPI support")
+}
+
+// RecvBytes receives bytes sent by SendBytes from the process of given rank
+func RecvBytes(from int) ([]byte, error) {
+	return nil, fmt.Errorf("empi.RecvBytes: not built with MPI support")
+}
@@ -78,6 +78,12 @@ const (
 	// This could be a chapter in a book.
 	Episode
 
+	// Cycle is the smallest unit of neural updating in a model, e.g., one step of
+	// updating membrane potentials and activations, with many cycles per Trial
+	// (typically 100 in Leabra).  It is not managed by the Env, but is a standard
+	// time scale for logging and control of the model's own updating.
+	Cycle
+
 	TimeScalesN
 )
 
@@ -9,9 +9,9 @@ import (
 
 var _ = errors.New("dummy error")
 
-const _TimeScales_name = "EventTrialSequenceBlockEpochRunExptSceneEpisodeTimeScalesN"
+const _TimeScales_name = "EventTrialSequenceBlockEpochRunExptSceneEpisodeCycleTimeScalesN"
 
-var _TimeScales_index = [...]uint8{0, 5, 10, 18, 23, 28, 31, 35, 40, 47, 58}
+var _TimeScales_index = [...]uint8{0, 5, 10, 18, 23, 28, 31, 35, 40, 47, 52, 63}
 
 func (i TimeScales) String() string {
 	if i < 0 || i >= TimeScales(len(_TimeScales_index)-1) {
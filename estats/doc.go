@@ -0,0 +1,24 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package estats provides standard statistics computed from emer.Layer
+unit values, which are ubiquitous in simulations:
+
+  - SSE and AvgSSE of a layer's activity against a target tensor (SSE).
+
+  - Closest pattern matching of a layer's activity against the rows of a
+    table column (ClosestPat).
+
+  - Activity sparseness: mean activity, proportion active, and
+    Treves-Rolls sparseness (Sparseness).
+
+  - PCA and representational similarity analysis (RSA) over activity
+    recorded in a table column (ActPCA, SimMat, RSA).
+
+The Stats type holds named values computed each trial / epoch, with
+ComputeFloat and ComputeString returning elog.ComputeFunc functions that
+write those values to the logs.
+*/
+package estats
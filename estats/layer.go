@@ -0,0 +1,113 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package estats
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/emer/emergent/emer"
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+)
+
+// SSE returns the sum-squared-error and average sum-squared-error (SSE / number
+// of units) of the given unit variable (e.g., "ActM") in the layer relative to
+// the target values in trg, which must have the same number of values as the layer.
+// Differences with absolute value < tol are counted as 0 -- e.g., 0.5 is used
+// for binary targets.
+func SSE(ly emer.Layer, varNm string, trg etensor.Tensor, tol float32) (sse, avgsse float64, err error) {
+	var vals []float32
+	if err = ly.UnitVals(&vals, varNm); err != nil {
+		return
+	}
+	if trg.Len() != len(vals) {
+		err = fmt.Errorf("estats.SSE: layer: %v has %d units, target has %d values", ly.Name(), len(vals), trg.Len())
+		return
+	}
+	for i, v := range vals {
+		d := float64(v) - trg.FloatVal1D(i)
+		if math.Abs(d) < float64(tol) {
+			continue
+		}
+		sse += d * d
+	}
+	if len(vals) > 0 {
+		avgsse = sse / float64(len(vals))
+	}
+	return
+}
+
+// ClosestPat returns the row in the given column of table dt whose values are
+// closest to the values of given unit variable in the layer, according to the
+// metric function mfun, where maxIsClose indicates if larger values are closer
+// (e.g., for correlation) vs. smaller (e.g., for sum-squared distance).
+// Also returns the metric value, and the value of the nameCol column for that
+// row, if nameCol is non-empty (e.g., the name of the closest pattern).
+func ClosestPat(ly emer.Layer, varNm string, dt *etable.Table, colNm, nameCol string, mfun func(a, b []float32) float32, maxIsClose bool) (row int, val float32, name string, err error) {
+	var vals []float32
+	if err = ly.UnitVals(&vals, varNm); err != nil {
+		return
+	}
+	cl, err := dt.ColByNameTry(colNm)
+	if err != nil {
+		return
+	}
+	row = -1
+	pat := make([]float32, len(vals))
+	for ri := 0; ri < dt.Rows; ri++ {
+		tsr := cl.SubSpace([]int{ri})
+		if tsr.Len() != len(vals) {
+			err = fmt.Errorf("estats.ClosestPat: layer: %v has %d units, column: %v cell has %d values", ly.Name(), len(vals), colNm, tsr.Len())
+			return
+		}
+		for i := range pat {
+			pat[i] = float32(tsr.FloatVal1D(i))
+		}
+		mv := mfun(vals, pat)
+		if row < 0 || (maxIsClose && mv > val) || (!maxIsClose && mv < val) {
+			row = ri
+			val = mv
+		}
+	}
+	if row >= 0 && nameCol != "" {
+		name = dt.CellString(nameCol, row)
+	}
+	return
+}
+
+// Sparseness returns the mean value of given unit variable in the layer,
+// the proportion of units with values > thr, and the Treves-Rolls
+// sparseness measure: (mean x)^2 / mean(x^2), which is 1 for uniform activity
+// and 1/N for a single active unit.
+func Sparseness(ly emer.Layer, varNm string, thr float32) (avg, pctAct, trSparse float64, err error) {
+	var vals []float32
+	if err = ly.UnitVals(&vals, varNm); err != nil {
+		return
+	}
+	n := len(vals)
+	if n == 0 {
+		return
+	}
+	var sum, ssq float64
+	nact := 0
+	for _, v := range vals {
+		if math.IsNaN(float64(v)) {
+			continue
+		}
+		sum += float64(v)
+		ssq += float64(v) * float64(v)
+		if v > thr {
+			nact++
+		}
+	}
+	nf := float64(n)
+	avg = sum / nf
+	pctAct = float64(nact) / nf
+	if ssq > 0 {
+		trSparse = (avg * avg) / (ssq / nf)
+	}
+	return
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package estats
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/metric"
+	"github.com/emer/etable/pca"
+	"github.com/emer/etable/simat"
+)
+
+// ActPCA computes the principal components analysis of the activity
+// patterns recorded in given column of the table (e.g., layer activity
+// logged each trial), returning the PCA, and the proportion of the total
+// variance accounted for by each of the top nTop components, which is a
+// useful measure of the dimensionality of the representation.
+func ActPCA(dt *etable.Table, colNm string, nTop int) (*pca.PCA, []float64, error) {
+	pc := &pca.PCA{}
+	ix := etable.NewIdxView(dt)
+	if err := pc.TableCol(ix, colNm, metric.Covariance64); err != nil {
+		return nil, nil, err
+	}
+	nv := len(pc.Values)
+	tot := 0.0
+	for _, v := range pc.Values {
+		tot += v
+	}
+	if nTop > nv {
+		nTop = nv
+	}
+	props := make([]float64, nTop)
+	if tot > 0 {
+		for i := range props {
+			props[i] = pc.Values[nv-1-i] / tot // values are in increasing order
+		}
+	}
+	return pc, props, nil
+}
+
+// SimMat computes the correlation-based similarity matrix of the activity
+// patterns recorded in given column of the table, labeled by the values
+// in the labNm column (blank for repeated labels)
+func SimMat(dt *etable.Table, colNm, labNm string) (*simat.SimMat, error) {
+	sm := &simat.SimMat{}
+	ix := etable.NewIdxView(dt)
+	if err := sm.TableCol(ix, colNm, labNm, true, metric.Correlation64); err != nil {
+		return nil, err
+	}
+	return sm, nil
+}
+
+// RSA returns the representational similarity analysis value for two
+// similarity matrices of the same size (e.g., from two layers, or a layer
+// and a model), which is the correlation between the values in their
+// upper triangles (excluding the diagonal).
+func RSA(a, b *simat.SimMat) (float64, error) {
+	am := a.Mat
+	bm := b.Mat
+	if am.Len() != bm.Len() || am.NumDims() != 2 {
+		return 0, fmt.Errorf("estats.RSA: similarity matrices must be 2D and of the same size")
+	}
+	n := am.Dim(0)
+	var av, bv []float64
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			av = append(av, am.FloatVal([]int{i, j}))
+			bv = append(bv, bm.FloatVal([]int{i, j}))
+		}
+	}
+	return corr(av, bv), nil
+}
+
+// corr returns the correlation between a and b
+func corr(a, b []float64) float64 {
+	n := float64(len(a))
+	if n == 0 {
+		return 0
+	}
+	var am, bm float64
+	for i := range a {
+		am += a[i]
+		bm += b[i]
+	}
+	am /= n
+	bm /= n
+	var ab, aa, bb float64
+	for i := range a {
+		ad := a[i] - am
+		bd := b[i] - bm
+		ab += ad * bd
+		aa += ad * ad
+		bb += bd * bd
+	}
+	if aa == 0 || bb == 0 {
+		return 0
+	}
+	return ab / math.Sqrt(aa*bb)
+}
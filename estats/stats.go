@@ -0,0 +1,62 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package estats
+
+import "github.com/emer/emergent/elog"
+
+// Stats holds named statistic values, computed e.g., at the end of each trial,
+// for use in logging (see ComputeFloat, ComputeString) and display.
+type Stats struct {
+	Floats  map[string]float64 `desc:"float statistics, by name"`
+	Strings map[string]string  `desc:"string statistics, by name"`
+}
+
+// Init initializes the maps, removing any existing values
+func (st *Stats) Init() {
+	st.Floats = make(map[string]float64)
+	st.Strings = make(map[string]string)
+}
+
+// SetFloat sets the float statistic of given name
+func (st *Stats) SetFloat(name string, val float64) {
+	if st.Floats == nil {
+		st.Init()
+	}
+	st.Floats[name] = val
+}
+
+// Float returns the float statistic of given name, 0 if not set
+func (st *Stats) Float(name string) float64 {
+	return st.Floats[name]
+}
+
+// SetString sets the string statistic of given name
+func (st *Stats) SetString(name string, val string) {
+	if st.Strings == nil {
+		st.Init()
+	}
+	st.Strings[name] = val
+}
+
+// String returns the string statistic of given name, empty if not set
+func (st *Stats) String(name string) string {
+	return st.Strings[name]
+}
+
+// ComputeFloat returns an elog.ComputeFunc that logs the current value of
+// the float statistic of given name
+func (st *Stats) ComputeFloat(name string) elog.ComputeFunc {
+	return func(ctx *elog.Context) {
+		ctx.SetFloat64(st.Float(name))
+	}
+}
+
+// ComputeString returns an elog.ComputeFunc that logs the current value of
+// the string statistic of given name
+func (st *Stats) ComputeString(name string) elog.ComputeFunc {
+	return func(ctx *elog.Context) {
+		ctx.SetString(st.String(name))
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package estats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCorr(t *testing.T) {
+	a := []float64{1, 2, 3, 4}
+	if c := corr(a, []float64{2, 4, 6, 8}); math.Abs(c-1) > 1.0e-8 {
+		t.Errorf("corr should be 1, is: %g\n", c)
+	}
+	if c := corr(a, []float64{4, 3, 2, 1}); math.Abs(c+1) > 1.0e-8 {
+		t.Errorf("corr should be -1, is: %g\n", c)
+	}
+	if c := corr(a, []float64{1, 1, 1, 1}); c != 0 {
+		t.Errorf("corr with constant should be 0, is: %g\n", c)
+	}
+}
+
+func TestStats(t *testing.T) {
+	var st Stats
+	st.SetFloat("SSE", 2.5)
+	st.SetString("TrlName", "A")
+	if st.Float("SSE") != 2.5 || st.String("TrlName") != "A" || st.Float("None") != 0 {
+		t.Errorf("Stats values not correct: %v %v\n", st.Floats, st.Strings)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package evec
+
+// ClampInt returns the value clamped to be no less than min and no greater than max.
+func ClampInt(v, min, max int) int {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+// WrapInt returns the value wrapped into the range [0, size), i.e., the
+// always-positive modulus, for wrap-around indexing.  Returns v if size <= 0.
+func WrapInt(v, size int) int {
+	if size <= 0 {
+		return v
+	}
+	v %= size
+	if v < 0 {
+		v += size
+	}
+	return v
+}
+
+// Wrap returns this vector with each component wrapped into the range
+// [0, size) for the corresponding component of size, i.e., always-positive
+// modulus, for wrap-around (toroidal) indexing.
+func (v Vec2i) Wrap(size Vec2i) Vec2i {
+	return Vec2i{WrapInt(v.X, size.X), WrapInt(v.Y, size.Y)}
+}
+
+// InBounds returns true if all components are >= 0 and < the
+// corresponding component of size
+func (v Vec2i) InBounds(size Vec2i) bool {
+	return v.X >= 0 && v.X < size.X && v.Y >= 0 && v.Y < size.Y
+}
+
+// Len returns the total number of points in an area of this size: X * Y
+func (v Vec2i) Len() int {
+	return v.X * v.Y
+}
+
+// Idx returns the 1D index of point p within an area of this size,
+// in row-major order with X varying fastest (i.e., Y, X tensor shape).
+func (v Vec2i) Idx(p Vec2i) int {
+	return p.Y*v.X + p.X
+}
+
+// FromIdx returns the point corresponding to given 1D index within an area
+// of this size -- the inverse of Idx.
+func (v Vec2i) FromIdx(idx int) Vec2i {
+	return Vec2i{idx % v.X, idx / v.X}
+}
+
+// ForEach calls given function for each point within an area of this size,
+// in Idx order (X varying fastest).
+func (v Vec2i) ForEach(fun func(p Vec2i)) {
+	var p Vec2i
+	for p.Y = 0; p.Y < v.Y; p.Y++ {
+		for p.X = 0; p.X < v.X; p.X++ {
+			fun(p)
+		}
+	}
+}
+
+// Vec3i returns a Vec3i with the X, Y components of this vector and given z
+func (v Vec2i) Vec3i(z int) Vec3i {
+	return Vec3i{v.X, v.Y, z}
+}
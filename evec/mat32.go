@@ -0,0 +1,40 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package evec
+
+import (
+	"github.com/chewxy/math32"
+	"github.com/goki/gi/mat32"
+)
+
+// ToVec2 returns the vector converted to a mat32.Vec2
+func (v Vec2i) ToVec2() mat32.Vec2 {
+	return mat32.Vec2{X: float32(v.X), Y: float32(v.Y)}
+}
+
+// NewVec2iFromVec2Floor returns a Vec2i from the floor of the mat32.Vec2 components
+func NewVec2iFromVec2Floor(v mat32.Vec2) Vec2i {
+	return Vec2i{int(math32.Floor(v.X)), int(math32.Floor(v.Y))}
+}
+
+// NewVec2iFromVec2Round returns a Vec2i from the rounded mat32.Vec2 components
+func NewVec2iFromVec2Round(v mat32.Vec2) Vec2i {
+	return Vec2i{int(math32.Round(v.X)), int(math32.Round(v.Y))}
+}
+
+// ToVec3 returns the vector converted to a mat32.Vec3
+func (v Vec3i) ToVec3() mat32.Vec3 {
+	return mat32.Vec3{X: float32(v.X), Y: float32(v.Y), Z: float32(v.Z)}
+}
+
+// NewVec3iFromVec3Floor returns a Vec3i from the floor of the mat32.Vec3 components
+func NewVec3iFromVec3Floor(v mat32.Vec3) Vec3i {
+	return Vec3i{int(math32.Floor(v.X)), int(math32.Floor(v.Y)), int(math32.Floor(v.Z))}
+}
+
+// NewVec3iFromVec3Round returns a Vec3i from the rounded mat32.Vec3 components
+func NewVec3iFromVec3Round(v mat32.Vec3) Vec3i {
+	return Vec3i{int(math32.Round(v.X)), int(math32.Round(v.Y)), int(math32.Round(v.Z))}
+}
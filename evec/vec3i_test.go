@@ -0,0 +1,142 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package evec
+
+import "testing"
+
+func TestVec3iArith(t *testing.T) {
+	a := NewVec3i(6, -4, 3)
+	b := NewVec3i(2, 3, -2)
+	tests := []struct {
+		name string
+		res  Vec3i
+		ex   Vec3i
+	}{
+		{"Add", a.Add(b), Vec3i{8, -1, 1}},
+		{"AddScalar", a.AddScalar(2), Vec3i{8, -2, 5}},
+		{"Sub", a.Sub(b), Vec3i{4, -7, 5}},
+		{"SubScalar", a.SubScalar(2), Vec3i{4, -6, 1}},
+		{"Mul", a.Mul(b), Vec3i{12, -12, -6}},
+		{"MulScalar", a.MulScalar(-2), Vec3i{-12, 8, -6}},
+		{"Div", a.Div(b), Vec3i{3, -1, -1}},
+		{"DivScalar", a.DivScalar(2), Vec3i{3, -2, 1}},
+		{"DivScalar0", a.DivScalar(0), Vec3i{}},
+		{"Mod", a.Mod(b), Vec3i{0, -1, 1}},
+		{"Min", a.Min(b), Vec3i{2, -4, -2}},
+		{"Max", a.Max(b), Vec3i{6, 3, 3}},
+		{"Negate", a.Negate(), Vec3i{-6, 4, -3}},
+		{"Wrap", a.Wrap(NewVec3iScalar(5)), Vec3i{1, 1, 3}},
+		{"FromIdx", NewVec3i(4, 3, 2).FromIdx(17), Vec3i{1, 1, 1}},
+	}
+	for _, ts := range tests {
+		if !ts.res.IsEqual(ts.ex) {
+			t.Errorf("%s: %v != %v", ts.name, ts.res, ts.ex)
+		}
+	}
+}
+
+func TestVec3iSet(t *testing.T) {
+	a := NewVec3i(6, -4, 3)
+	b := NewVec3i(2, 3, -2)
+	tests := []struct {
+		name string
+		fun  func(v *Vec3i)
+		ex   Vec3i
+	}{
+		{"SetAdd", func(v *Vec3i) { v.SetAdd(b) }, a.Add(b)},
+		{"SetAddScalar", func(v *Vec3i) { v.SetAddScalar(2) }, a.AddScalar(2)},
+		{"SetSub", func(v *Vec3i) { v.SetSub(b) }, a.Sub(b)},
+		{"SetSubScalar", func(v *Vec3i) { v.SetSubScalar(2) }, a.SubScalar(2)},
+		{"SetMul", func(v *Vec3i) { v.SetMul(b) }, a.Mul(b)},
+		{"SetMulScalar", func(v *Vec3i) { v.SetMulScalar(-2) }, a.MulScalar(-2)},
+		{"SetDiv", func(v *Vec3i) { v.SetDiv(b) }, a.Div(b)},
+		{"SetDivScalar", func(v *Vec3i) { v.SetDivScalar(2) }, a.DivScalar(2)},
+		{"SetDivScalar0", func(v *Vec3i) { v.SetDivScalar(0) }, Vec3i{}},
+		{"SetMin", func(v *Vec3i) { v.SetMin(b) }, a.Min(b)},
+		{"SetMax", func(v *Vec3i) { v.SetMax(b) }, a.Max(b)},
+		{"SetNegate", func(v *Vec3i) { v.SetNegate() }, a.Negate()},
+		{"Clamp", func(v *Vec3i) { v.Clamp(NewVec3i(0, 0, 0), NewVec3i(4, 4, 4)) }, Vec3i{4, 0, 3}},
+		{"ClampScalar", func(v *Vec3i) { v.ClampScalar(-1, 5) }, Vec3i{5, -1, 3}},
+		{"SetZero", func(v *Vec3i) { v.SetZero() }, Vec3i{}},
+		{"SetScalar", func(v *Vec3i) { v.SetScalar(7) }, NewVec3iScalar(7)},
+		{"Set", func(v *Vec3i) { v.Set(1, 2, 3) }, Vec3i{1, 2, 3}},
+		{"SetDim", func(v *Vec3i) { v.SetDim(Y, 9) }, Vec3i{6, 9, 3}},
+		{"SetByName", func(v *Vec3i) { v.SetByName("z", 9) }, Vec3i{6, -4, 9}},
+		{"FromArray", func(v *Vec3i) { v.FromArray([]int{0, 1, 2, 3}, 1) }, Vec3i{1, 2, 3}},
+	}
+	for _, ts := range tests {
+		v := a
+		ts.fun(&v)
+		if v != ts.ex {
+			t.Errorf("%s: %v != %v", ts.name, v, ts.ex)
+		}
+	}
+}
+
+func TestVec3iIdx(t *testing.T) {
+	sz := NewVec3i(4, 3, 2)
+	if sz.Len() != 24 {
+		t.Errorf("Len: %d != 24", sz.Len())
+	}
+	n := 0
+	sz.ForEach(func(p Vec3i) {
+		if idx := sz.Idx(p); idx != n {
+			t.Errorf("Idx(%v): %d != %d", p, idx, n)
+		}
+		if fp := sz.FromIdx(n); fp != p {
+			t.Errorf("FromIdx(%d): %v != %v", n, fp, p)
+		}
+		if !p.InBounds(sz) {
+			t.Errorf("InBounds(%v) false", p)
+		}
+		n++
+	})
+	if n != sz.Len() {
+		t.Errorf("ForEach points: %d != %d", n, sz.Len())
+	}
+	for _, p := range []Vec3i{{-1, 0, 0}, {4, 0, 0}, {0, 3, 0}, {0, 0, 2}} {
+		if p.InBounds(sz) {
+			t.Errorf("InBounds(%v) true", p)
+		}
+	}
+	if xy := NewVec3i(1, 2, 3).XY(); xy != (Vec2i{1, 2}) {
+		t.Errorf("XY: %v", xy)
+	}
+	arr := make([]int, 5)
+	NewVec3i(1, 2, 3).ToArray(arr, 2)
+	if arr[2] != 1 || arr[3] != 2 || arr[4] != 3 {
+		t.Errorf("ToArray: %v", arr)
+	}
+	for _, d := range []Dims{X, Y, Z} {
+		if v := NewVec3i(1, 2, 3).Dim(d); v != int(d)+1 {
+			t.Errorf("Dim(%v): %d != %d", d, v, int(d)+1)
+		}
+	}
+	if !(Vec3i{}).IsNil() || NewVec3i(0, 0, 1).IsNil() {
+		t.Errorf("IsNil")
+	}
+}
+
+// expectPanic checks that fun panics
+func expectPanic(t *testing.T, name string, fun func()) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("%s did not panic", name)
+		}
+	}()
+	fun()
+}
+
+func TestVec3iPanics(t *testing.T) {
+	v := NewVec3i(1, 2, 3)
+	expectPanic(t, "Dim(W)", func() { v.Dim(W) })
+	expectPanic(t, "SetDim(W)", func() { v.SetDim(W, 1) })
+	expectPanic(t, "SetByName(w)", func() { v.SetByName("w", 1) })
+	expectPanic(t, "Div by zero", func() { v.Div(Vec3i{1, 0, 1}) })
+	expectPanic(t, "SetDiv by zero", func() { v.SetDiv(Vec3i{1, 1, 0}) })
+	expectPanic(t, "Mod by zero", func() { v.Mod(Vec3i{0, 1, 1}) })
+	expectPanic(t, "FromIdx of empty", func() { (Vec3i{}).FromIdx(0) })
+	expectPanic(t, "FromArray short", func() { v.FromArray([]int{1, 2}, 0) })
+}
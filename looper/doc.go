@@ -0,0 +1,36 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package looper provides nested loop control for running simulations,
+e.g., Run, Epoch, Trial, Cycle, with registered function hooks at each level,
+and the ability to Stop at any point and Step by any number of iterations
+of any level, resuming exactly where it left off.  This replaces the
+Train / Step / Stop goroutine logic that otherwise is written for every
+simulation, with all the subtle race conditions involved.
+
+A Stack holds the Loops for one evaluation mode (e.g., "Train"), in order from
+outer-most to inner-most, and a Set holds the Stacks for all modes.
+Each Loop has a Ctr counter with a Max number of iterations, and lists of
+named functions that are called at the Start of each iteration, for
+the Main work of the iteration (after all inner loops have completed),
+and at the End of each iteration.  IsDone functions can terminate a loop
+early (e.g., stopping training when performance criterion is reached).
+
+	st := looper.NewStack("Train", env.Run, env.Epoch, env.Trial, env.Cycle)
+	st.Loop(env.Run).Max = 10
+	st.Loop(env.Epoch).Max = 100
+	st.Loop(env.Trial).Max = 25
+	st.Loop(env.Cycle).Max = 100
+	st.Loop(env.Cycle).Main.Add("Cycle", ss.Net.Cycle)
+	st.Loop(env.Trial).Start.Add("ApplyInputs", ss.ApplyInputs)
+	st.Loop(env.Trial).End.Add("Log", func() { ss.Logs.Log("Train", env.Trial) })
+	...
+	st.GoRun()              // run the whole thing in a separate goroutine
+	st.Stop()               // stops at the next iteration of any loop
+	st.GoStep(env.Trial, 1) // steps one trial, picking up where it stopped
+
+See ToolBar for adding standard Init, Run, Stop, and Step actions to a GUI toolbar.
+*/
+package looper
@@ -0,0 +1,89 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package looper
+
+// NamedFunc is a function with a name, so it can be found and replaced
+type NamedFunc struct {
+	Name string `desc:"name of the function"`
+	Func func() `view:"-" desc:"the function"`
+}
+
+// NamedFuncs is an ordered list of named functions
+type NamedFuncs []NamedFunc
+
+// Add adds a named function to the end of the list
+func (fs *NamedFuncs) Add(name string, fun func()) {
+	*fs = append(*fs, NamedFunc{Name: name, Func: fun})
+}
+
+// Prepend adds a named function to the start of the list
+func (fs *NamedFuncs) Prepend(name string, fun func()) {
+	*fs = append(NamedFuncs{{Name: name, Func: fun}}, *fs...)
+}
+
+// Replace replaces the function of given name, returning false if not found
+func (fs *NamedFuncs) Replace(name string, fun func()) bool {
+	for i := range *fs {
+		if (*fs)[i].Name == name {
+			(*fs)[i].Func = fun
+			return true
+		}
+	}
+	return false
+}
+
+// Delete deletes the function of given name, returning false if not found
+func (fs *NamedFuncs) Delete(name string) bool {
+	for i := range *fs {
+		if (*fs)[i].Name == name {
+			*fs = append((*fs)[:i], (*fs)[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Run calls all of the functions in order
+func (fs NamedFuncs) Run() {
+	for _, f := range fs {
+		f.Func()
+	}
+}
+
+// NamedBoolFunc is a bool-valued function with a name, so it can be found and replaced
+type NamedBoolFunc struct {
+	Name string      `desc:"name of the function"`
+	Func func() bool `view:"-" desc:"the function"`
+}
+
+// NamedBoolFuncs is an ordered list of named bool-valued functions
+type NamedBoolFuncs []NamedBoolFunc
+
+// Add adds a named function to the end of the list
+func (fs *NamedBoolFuncs) Add(name string, fun func() bool) {
+	*fs = append(*fs, NamedBoolFunc{Name: name, Func: fun})
+}
+
+// Delete deletes the function of given name, returning false if not found
+func (fs *NamedBoolFuncs) Delete(name string) bool {
+	for i := range *fs {
+		if (*fs)[i].Name == name {
+			*fs = append((*fs)[:i], (*fs)[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Any returns true if any of the functions return true (all are called)
+func (fs NamedBoolFuncs) Any() bool {
+	any := false
+	for _, f := range fs {
+		if f.Func() {
+			any = true
+		}
+	}
+	return any
+}
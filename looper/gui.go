@@ -0,0 +1,57 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package looper
+
+import (
+	"github.com/goki/gi/gi"
+	"github.com/goki/ki/ki"
+)
+
+// ToolBar adds standard Init, Run, Stop and Step actions for this Stack to
+// the given toolbar, with a Step action for each loop level.
+// Run and Step are run in a separate goroutine, and the actions are
+// only active when appropriate.  The update function (can be nil) is called
+// after Init and whenever running stops, e.g., to update the NetView and plots.
+func (st *Stack) ToolBar(tb *gi.ToolBar, update func()) {
+	st.OnStop.Add("ToolBar", func() {
+		if update != nil {
+			update()
+		}
+		tb.UpdateActions()
+		if tb.Viewport != nil {
+			tb.Viewport.SetNeedsFullRender()
+		}
+	})
+	notRunning := func(act *gi.Action) {
+		act.SetActiveStateUpdt(!st.IsRunning())
+	}
+
+	tb.AddAction(gi.ActOpts{Label: "Init", Icon: "update", Tooltip: "Initialize all the " + st.Mode + " loop counters.", UpdateFunc: notRunning}, tb.This(),
+		func(recv, send ki.Ki, sig int64, data interface{}) {
+			st.Init()
+			if update != nil {
+				update()
+			}
+		})
+	tb.AddAction(gi.ActOpts{Label: st.Mode, Icon: "run", Tooltip: "Run " + st.Mode + " loops until completion, or Stop.", UpdateFunc: notRunning}, tb.This(),
+		func(recv, send ki.Ki, sig int64, data interface{}) {
+			st.GoRun()
+			tb.UpdateActions()
+		})
+	tb.AddAction(gi.ActOpts{Label: "Stop", Icon: "stop", Tooltip: "Stop running, at the next iteration of any loop -- can resume with Run or Step.", UpdateFunc: func(act *gi.Action) {
+		act.SetActiveStateUpdt(st.IsRunning())
+	}}, tb.This(),
+		func(recv, send ki.Ki, sig int64, data interface{}) {
+			st.Stop()
+		})
+	for _, t := range st.Order {
+		tm := t
+		tb.AddAction(gi.ActOpts{Label: "Step " + tm.String(), Icon: "step-fwd", Tooltip: "Run one " + tm.String() + " of " + st.Mode + ", resuming from current state.", UpdateFunc: notRunning}, tb.This(),
+			func(recv, send ki.Ki, sig int64, data interface{}) {
+				st.GoStep(tm, 1)
+				tb.UpdateActions()
+			})
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package looper
+
+import (
+	"github.com/emer/emergent/env"
+)
+
+// Loop is one level of looping in a Stack, e.g., the Epoch loop, with a counter
+// and the functions to call at each iteration.
+type Loop struct {
+	Time   env.TimeScales `desc:"time scale of this loop"`
+	Ctr    env.Ctr        `desc:"counter for this loop -- Cur is the current iteration"`
+	Max    int            `desc:"maximum number of iterations of this loop -- 0 = no limit (loop must then use IsDone to stop)"`
+	Start  NamedFuncs     `desc:"functions called at the start of each iteration, before any inner loops"`
+	Main   NamedFuncs     `desc:"functions called for the main work of each iteration, after all inner loops have completed -- for the inner-most loop, this is the main step"`
+	End    NamedFuncs     `desc:"functions called at the end of each iteration, after Main"`
+	IsDone NamedBoolFuncs `desc:"functions called at the end of each iteration -- if any returns true, then the loop is done (e.g., for early stopping), and the counter is reset"`
+	OnInit NamedFuncs     `desc:"functions called when the Stack is initialized"`
+
+	started bool // Start has been called for current iteration
+	done    bool // loop has completed, pending return to outer loop
+}
+
+// Init initializes the loop counter and state, and calls OnInit functions
+func (lp *Loop) Init() {
+	lp.Ctr.Scale = lp.Time
+	lp.Ctr.Init()
+	lp.Ctr.Max = 0 // we manage the max ourselves
+	lp.started = false
+	lp.done = false
+	lp.OnInit.Run()
+}
+
+// IsStarted returns true if the Start functions have been called for the
+// current iteration, which is then resumed without calling them again
+func (lp *Loop) IsStarted() bool {
+	return lp.started
+}
+
+// SetStarted sets whether the Start functions have been called for the
+// current iteration, e.g., when restoring a checkpoint of a run that was
+// saved within the current iteration of this loop.  Must not be called
+// while running.
+func (lp *Loop) SetStarted(started bool) {
+	lp.started = started
+}
+
+// Cur returns the current counter value
+func (lp *Loop) Cur() int {
+	return lp.Ctr.Cur
+}
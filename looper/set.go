@@ -0,0 +1,60 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package looper
+
+import (
+	"github.com/emer/emergent/env"
+)
+
+// Set contains Stacks for each evaluation mode (e.g., "Train", "Test")
+type Set struct {
+	Stacks map[string]*Stack `desc:"the stacks, by mode"`
+	Modes  []string          `desc:"the modes, in order added"`
+}
+
+// NewStack adds a new Stack for given mode with loops at the given
+// time scales, in order from outer-most to inner-most, returning it
+func (set *Set) NewStack(mode string, times ...env.TimeScales) *Stack {
+	if set.Stacks == nil {
+		set.Stacks = make(map[string]*Stack)
+	}
+	st := NewStack(mode, times...)
+	if _, has := set.Stacks[mode]; !has {
+		set.Modes = append(set.Modes, mode)
+	}
+	set.Stacks[mode] = st
+	return st
+}
+
+// Stack returns the stack for given mode, nil if not found
+func (set *Set) Stack(mode string) *Stack {
+	return set.Stacks[mode]
+}
+
+// Init initializes all the stacks
+func (set *Set) Init() {
+	for _, m := range set.Modes {
+		set.Stacks[m].Init()
+	}
+}
+
+// IsRunning returns true if any of the stacks is running
+func (set *Set) IsRunning() bool {
+	for _, st := range set.Stacks {
+		if st.IsRunning() {
+			return true
+		}
+	}
+	return false
+}
+
+// Stop stops all the stacks that are running
+func (set *Set) Stop() {
+	for _, st := range set.Stacks {
+		if st.IsRunning() {
+			st.Stop()
+		}
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package looper
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/emer/emergent/env"
+)
+
+// Stack contains the nested Loops for one evaluation mode (e.g., "Train"),
+// in order from outer-most to inner-most.  Running can be stopped at any point,
+// and resumes exactly where it left off on the next Run or Step call.
+// Run and Step are typically called in a separate goroutine from the GUI,
+// and Stop can be called from any goroutine.
+type Stack struct {
+	Mode   string                   `desc:"evaluation mode for this stack, e.g., Train, Test"`
+	Order  []env.TimeScales         `desc:"order of the loops, from outer-most to inner-most"`
+	Loops  map[env.TimeScales]*Loop `desc:"the loops, by time scale"`
+	OnStop NamedFuncs               `desc:"functions called whenever running stops, due to Stop, stepping, or completion -- e.g., to update the GUI"`
+
+	mu       sync.Mutex
+	running  bool
+	stopReq  bool
+	stepTime env.TimeScales
+	stepN    int
+	stepCtr  int
+	stepping bool
+	doneCh   chan struct{} // closed when the current run completes
+}
+
+// NewStack returns a new Stack for given mode with loops at the given
+// time scales, in order from outer-most to inner-most
+func NewStack(mode string, times ...env.TimeScales) *Stack {
+	st := &Stack{Mode: mode}
+	st.Loops = make(map[env.TimeScales]*Loop)
+	for _, t := range times {
+		st.AddLoop(t)
+	}
+	return st
+}
+
+// AddLoop adds a new inner-most loop at given time scale, returning it
+func (st *Stack) AddLoop(t env.TimeScales) *Loop {
+	if st.Loops == nil {
+		st.Loops = make(map[env.TimeScales]*Loop)
+	}
+	lp := &Loop{Time: t}
+	st.Order = append(st.Order, t)
+	st.Loops[t] = lp
+	return lp
+}
+
+// Loop returns the loop at given time scale, nil if not present
+func (st *Stack) Loop(t env.TimeScales) *Loop {
+	return st.Loops[t]
+}
+
+// Init initializes all the loops, resetting counters to 0, and calling
+// their OnInit functions.  Must not be called while running.
+func (st *Stack) Init() {
+	for _, t := range st.Order {
+		st.Loops[t].Init()
+	}
+}
+
+// IsRunning returns true if the stack is currently running
+func (st *Stack) IsRunning() bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.running
+}
+
+// Stop requests that running stop at the start of the next iteration of any loop.
+// Can be called from any goroutine.  Running resumes from that point with
+// the next Run or Step call.  Has no effect if not running -- use GoRun or
+// GoStep to start running in a separate goroutine, so that a Stop right
+// after starting is not lost.
+func (st *Stack) Stop() {
+	st.mu.Lock()
+	if st.running {
+		st.stopReq = true
+	}
+	st.mu.Unlock()
+}
+
+// Run runs the loops until completion of the outer-most loop, or until Stop
+// is called.  Returns true if completed, false if stopped.  If already running,
+// returns false immediately.
+func (st *Stack) Run() bool {
+	if !st.start(false, 0, 0) {
+		return false
+	}
+	return st.run()
+}
+
+// Step runs the loops until n iterations of the loop at given time scale have
+// completed (or until completion, or Stop), returning true if completed.
+// e.g., Step(env.Trial, 1) runs one trial.
+func (st *Stack) Step(t env.TimeScales, n int) bool {
+	if !st.start(true, t, n) {
+		return false
+	}
+	return st.run()
+}
+
+// GoRun starts running the loops as in Run, in a separate goroutine,
+// returning false if already running.  The stack is running as soon as
+// GoRun returns, so IsRunning and Stop take effect immediately.
+func (st *Stack) GoRun() bool {
+	if !st.start(false, 0, 0) {
+		return false
+	}
+	go st.run()
+	return true
+}
+
+// GoStep starts stepping the loops as in Step, in a separate goroutine,
+// returning false if already running.  The stack is running as soon as
+// GoStep returns, so IsRunning and Stop take effect immediately.
+func (st *Stack) GoStep(t env.TimeScales, n int) bool {
+	if !st.start(true, t, n) {
+		return false
+	}
+	go st.run()
+	return true
+}
+
+// start marks the stack as running with given stepping parameters,
+// returning false if already running
+func (st *Stack) start(step bool, t env.TimeScales, n int) bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.running || len(st.Order) == 0 {
+		return false
+	}
+	st.running = true
+	st.doneCh = make(chan struct{})
+	st.stopReq = false
+	st.stepping = step
+	st.stepTime = t
+	st.stepN = n
+	st.stepCtr = 0
+	return true
+}
+
+// run runs the loops after start, returning true if completed
+func (st *Stack) run() bool {
+	done := st.runLevel(0)
+
+	st.mu.Lock()
+	st.running = false
+	st.stopReq = false
+	st.stepping = false
+	close(st.doneCh)
+	st.mu.Unlock()
+	st.OnStop.Run()
+	return done
+}
+
+// Wait waits until the stack is no longer running, e.g., after GoStep,
+// returning immediately if not running.  Can be called from any goroutine.
+func (st *Stack) Wait() {
+	st.mu.Lock()
+	ch := st.doneCh
+	running := st.running
+	st.mu.Unlock()
+	if running {
+		<-ch
+	}
+}
+
+// stopped returns true if a stop has been requested
+func (st *Stack) stopped() bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.stopReq
+}
+
+// stepDone is called at the end of each iteration of loop at given time scale,
+// and returns true if stepping is complete.
+func (st *Stack) stepDone(t env.TimeScales) bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if !st.stepping || st.stepTime != t {
+		return false
+	}
+	st.stepCtr++
+	if st.stepCtr >= st.stepN {
+		st.stopReq = true
+		return true
+	}
+	return false
+}
+
+// runLevel runs loop at given level in the Order, returning true if it
+// completed, and false if stopped.  State is retained so that calling
+// again resumes exactly where it left off.
+func (st *Stack) runLevel(lev int) bool {
+	lp := st.Loops[st.Order[lev]]
+	for {
+		if lp.done {
+			lp.done = false
+			return true
+		}
+		if st.stopped() {
+			return false
+		}
+		if !lp.started {
+			if lp.Max > 0 && lp.Ctr.Cur >= lp.Max {
+				lp.Ctr.Cur = 0
+				return true
+			}
+			lp.started = true
+			lp.Ctr.Same()
+			lp.Start.Run()
+		}
+		if lev+1 < len(st.Order) {
+			if !st.runLevel(lev + 1) {
+				return false
+			}
+		}
+		lp.Main.Run()
+		lp.End.Run()
+		lp.started = false
+		lp.Ctr.Incr()
+		if lp.IsDone.Any() || (lp.Max > 0 && lp.Ctr.Cur >= lp.Max) {
+			lp.Ctr.Prv = lp.Ctr.Cur
+			lp.Ctr.Cur = 0
+			lp.done = true
+		}
+		if st.stepDone(lp.Time) {
+			return false
+		}
+	}
+}
+
+// CountersString returns a string with the current counter values for all loops
+func (st *Stack) CountersString() string {
+	var b strings.Builder
+	b.WriteString(st.Mode + "\t")
+	for _, t := range st.Order {
+		fmt.Fprintf(&b, "%s:\t%d\t", t.String(), st.Loops[t].Ctr.Cur)
+	}
+	return b.String()
+}
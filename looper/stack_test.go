@@ -0,0 +1,175 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package looper
+
+import (
+	"testing"
+
+	"github.com/emer/emergent/env"
+)
+
+// testStack returns a Run, Epoch, Trial stack with given max counts,
+// recording the Main calls of the Trial loop in trls
+func testStack(trls *[]int) *Stack {
+	st := NewStack("Train", env.Run, env.Epoch, env.Trial)
+	st.Loop(env.Run).Max = 2
+	st.Loop(env.Epoch).Max = 3
+	st.Loop(env.Trial).Max = 4
+	st.Loop(env.Trial).Main.Add("Record", func() {
+		*trls = append(*trls, st.Loop(env.Trial).Cur())
+	})
+	st.Init()
+	return st
+}
+
+func TestStackRun(t *testing.T) {
+	var trls []int
+	st := testStack(&trls)
+	if !st.Run() {
+		t.Errorf("Run did not complete")
+	}
+	if len(trls) != 2*3*4 {
+		t.Errorf("trials run: %d != %d", len(trls), 2*3*4)
+	}
+	for _, tm := range st.Order {
+		if cur := st.Loop(tm).Cur(); cur != 0 {
+			t.Errorf("%s counter not reset after completion: %d", tm, cur)
+		}
+	}
+}
+
+func TestStackStep(t *testing.T) {
+	var trls []int
+	st := testStack(&trls)
+	if st.Step(env.Trial, 1) {
+		t.Errorf("Step Trial 1 completed the run")
+	}
+	if len(trls) != 1 || st.Loop(env.Trial).Cur() != 1 {
+		t.Errorf("Step Trial 1: trials run: %d, Trial counter: %d", len(trls), st.Loop(env.Trial).Cur())
+	}
+	st.Step(env.Trial, 2)
+	if len(trls) != 3 || st.Loop(env.Trial).Cur() != 3 {
+		t.Errorf("Step Trial 2: trials run: %d, Trial counter: %d", len(trls), st.Loop(env.Trial).Cur())
+	}
+	st.Step(env.Epoch, 1) // finishes the current epoch
+	if len(trls) != 4 || st.Loop(env.Epoch).Cur() != 1 || st.Loop(env.Trial).Cur() != 0 {
+		t.Errorf("Step Epoch 1: trials run: %d, counters: %s", len(trls), st.CountersString())
+	}
+	st.Step(env.Epoch, 1)
+	if len(trls) != 8 || st.Loop(env.Epoch).Cur() != 2 {
+		t.Errorf("Step Epoch 1: trials run: %d, counters: %s", len(trls), st.CountersString())
+	}
+	if !st.Run() {
+		t.Errorf("Run after Step did not complete")
+	}
+	if len(trls) != 2*3*4 {
+		t.Errorf("trials run after Step and Run: %d != %d", len(trls), 2*3*4)
+	}
+}
+
+func TestStackStop(t *testing.T) {
+	var trls []int
+	st := testStack(&trls)
+	st.Loop(env.Trial).End.Add("Stop", func() {
+		if len(trls) == 5 {
+			st.Stop()
+		}
+	})
+	if st.Run() {
+		t.Errorf("Run completed despite Stop")
+	}
+	if len(trls) != 5 || st.Loop(env.Epoch).Cur() != 1 || st.Loop(env.Trial).Cur() != 1 {
+		t.Errorf("Stop: trials run: %d, counters: %s", len(trls), st.CountersString())
+	}
+	if st.IsRunning() {
+		t.Errorf("still running after Stop")
+	}
+	if !st.Run() {
+		t.Errorf("Run after Stop did not complete")
+	}
+	if len(trls) != 2*3*4 {
+		t.Errorf("trials run after Stop and Run: %d != %d", len(trls), 2*3*4)
+	}
+}
+
+func TestStackStopIdle(t *testing.T) {
+	var trls []int
+	st := testStack(&trls)
+	st.Stop() // not running: no effect on the next Run
+	if !st.Run() {
+		t.Errorf("Run after Stop while idle did not complete")
+	}
+	if len(trls) != 2*3*4 {
+		t.Errorf("trials run: %d != %d", len(trls), 2*3*4)
+	}
+}
+
+func TestSetStopIdle(t *testing.T) {
+	var trls []int
+	set := &Set{}
+	train := set.NewStack("Train", env.Trial)
+	train.Loop(env.Trial).Max = 3
+	train.Loop(env.Trial).Main.Add("Record", func() { trls = append(trls, 0) })
+	test := set.NewStack("Test", env.Trial)
+	test.Loop(env.Trial).Max = 2
+	test.Loop(env.Trial).Main.Add("Record", func() { trls = append(trls, 1) })
+	set.Init()
+	set.Stop()
+	if !test.Run() || !train.Run() {
+		t.Errorf("Run after Set Stop while idle did not complete")
+	}
+	if len(trls) != 5 {
+		t.Errorf("trials run: %d != 5", len(trls))
+	}
+}
+
+func TestStackGoRunStop(t *testing.T) {
+	var trls []int
+	st := testStack(&trls)
+	stopped := make(chan bool, 1)
+	st.OnStop.Add("Done", func() { stopped <- true })
+	if !st.GoRun() {
+		t.Fatalf("GoRun did not start")
+	}
+	if !st.IsRunning() {
+		t.Errorf("not running after GoRun")
+	}
+	if st.GoRun() || st.Run() {
+		t.Errorf("started again while running")
+	}
+	st.Stop() // right after starting: must not be lost
+	<-stopped
+	if len(trls) != 0 {
+		t.Errorf("trials run after GoRun, Stop: %d != 0", len(trls))
+	}
+	if !st.GoStep(env.Trial, 2) {
+		t.Fatalf("GoStep did not start")
+	}
+	st.Wait()
+	if st.IsRunning() {
+		t.Errorf("running after Wait")
+	}
+	<-stopped
+	if len(trls) != 2 {
+		t.Errorf("trials run after GoStep: %d != 2", len(trls))
+	}
+}
+
+func TestStackIsDone(t *testing.T) {
+	var trls []int
+	st := testStack(&trls)
+	st.Loop(env.Epoch).IsDone.Add("Early", func() bool {
+		return st.Loop(env.Epoch).Cur() >= 2
+	})
+	if !st.Run() {
+		t.Errorf("Run did not complete")
+	}
+	if len(trls) != 2*2*4 {
+		t.Errorf("trials run with early stopping: %d != %d", len(trls), 2*2*4)
+	}
+	if prv := st.Loop(env.Epoch).Ctr.Prv; prv != 2 {
+		t.Errorf("Epoch Prv after early stopping: %d != 2", prv)
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"math"
+	"sync"
+)
+
+// RecSnap is a snapshot of the network values for one record, taken on the
+// simulation thread by NetData.Snapshot, and stored into the ring later by
+// NetData.RecordSnap, e.g., on a separate goroutine by AsyncRecorder.
+type RecSnap struct {
+	Ring    string               `desc:"name of the ring to record into (see NetView.AddRing) -- empty = main ring"`
+	Ctrs    string               `desc:"counters string"`
+	CtrVals map[string]int       `desc:"structured counter values -- a copy owned by the snapshot"`
+	Vals    map[string][]float32 `desc:"values for each layer, by name, for all the variables in NetData.Vars in order, each for all the units in the layer"`
+	Prjn    []float32            `desc:"per-projection stats for NetData.PrjnVars (see RecordPrjnStats)"`
+}
+
+// Snapshot copies the current values of all the variables from the network into
+// given snapshot, re-using its storage, along with the given counters string and
+// structured counter values (which are copied).  This is the only part of
+// recording that must happen synchronously with the simulation -- the snapshot
+// can then be stored with RecordSnap on another goroutine.
+func (nd *NetData) Snapshot(sn *RecSnap, ctrs string, vals map[string]int) {
+	sn.Ctrs = ctrs
+	sn.CtrVals = nil
+	if vals != nil {
+		sn.CtrVals = make(map[string]int, len(vals))
+		for k, v := range vals {
+			sn.CtrVals[k] = v
+		}
+	}
+	if sn.Vals == nil {
+		sn.Vals = make(map[string][]float32)
+	}
+	prjnlay := nd.Net.LayerByName(nd.PrjnLay)
+	vlen := len(nd.Vars)
+	nlay := nd.Net.NLayers()
+	for li := 0; li < nlay; li++ {
+		lay := nd.Net.Layer(li)
+		laynm := lay.Name()
+		nu := lay.Shape().Len()
+		buf := sn.Vals[laynm]
+		if len(buf) != vlen*nu {
+			buf = make([]float32, vlen*nu)
+			sn.Vals[laynm] = buf
+		}
+		for vi, vnm := range nd.Vars {
+			dvals := buf[vi*nu : (vi+1)*nu]
+			nd.unitVals(lay, vnm, prjnlay, &dvals)
+		}
+	}
+	sn.Prjn = sn.Prjn[:0]
+	if nvar := len(nd.PrjnVars); nvar > 0 {
+		pjs := nd.PrjnList()
+		if cap(sn.Prjn) < len(pjs)*nvar {
+			sn.Prjn = make([]float32, len(pjs)*nvar)
+		}
+		sn.Prjn = sn.Prjn[:len(pjs)*nvar]
+		nd.prjnStats(pjs, sn.Prjn)
+	}
+}
+
+// RecordSnap records the values from given snapshot (see Snapshot) as a new
+// record, as RecordCtrs does directly from the network.  Layers whose snapshot
+// values do not match the current network are left as they were.
+func (nd *NetData) RecordSnap(sn *RecSnap) {
+	nlay := nd.Net.NLayers()
+	if nlay == 0 {
+		return
+	}
+	nd.Config()
+	vlen := len(nd.Vars)
+	nd.Ring.Add(1)
+	lidx := nd.Ring.LastIdx()
+
+	nd.Counters[lidx] = sn.Ctrs
+	nd.CtrVals[lidx] = sn.CtrVals
+	nd.Bookmarks[lidx] = ""
+
+	mmidx := lidx * vlen
+	for vi := range nd.Vars {
+		nd.MinPer[mmidx+vi] = math.MaxFloat32
+		nd.MaxPer[mmidx+vi] = -math.MaxFloat32
+	}
+	for li := 0; li < nlay; li++ {
+		laynm := nd.Net.Layer(li).Name()
+		ld := nd.LayData[laynm]
+		nu := ld.NUnits
+		buf, ok := sn.Vals[laynm]
+		if !ok || len(buf) != vlen*nu {
+			continue
+		}
+		for vi := range nd.Vars {
+			dvals := buf[vi*nu : (vi+1)*nu]
+			valsMinMax(dvals, &nd.MinPer[mmidx+vi], &nd.MaxPer[mmidx+vi])
+			ld.SetBlock(lidx*vlen+vi, dvals)
+		}
+	}
+	if np := len(nd.PrjnNames) * len(nd.PrjnVars); np > 0 && len(sn.Prjn) == np {
+		copy(nd.PrjnData[lidx*np:(lidx+1)*np], sn.Prjn)
+	}
+	nd.UpdateVarRange()
+	if nd.Streamer != nil {
+		nd.Streamer.SendRec()
+	}
+}
+
+// AsyncRecorder records network data off of the simulation thread: Record
+// only takes a snapshot of the network values (see NetData.Snapshot) into one
+// of a fixed pool of buffers and hands it off to a recording goroutine, which
+// stores it into the ring.  If the recorder falls behind and all buffers are
+// in use, Record either waits for a free buffer (default) or, if Drop is set,
+// drops the record.  See NetView.StartAsync.
+type AsyncRecorder struct {
+	NBufs    int                        `desc:"number of snapshot buffers -- the number of records that can be pending before Record waits or drops"`
+	Drop     bool                       `desc:"drop records when all buffers are in use, instead of waiting for the recorder to catch up"`
+	NDropped int                        `inactive:"+" desc:"number of records that have been dropped"`
+	Target   func(ring string) *NetData `view:"-" desc:"returns the data ring to record into for given ring name (empty = main ring) -- called with the lock held"`
+	mu       sync.Mutex
+	pend     sync.WaitGroup
+	free     chan *RecSnap
+	recs     chan *RecSnap
+}
+
+// NewAsyncRecorder returns a new AsyncRecorder with given number of snapshot
+// buffers (min 1), recording into the rings returned by given target function,
+// and starts its recording goroutine.  Call Stop when done.
+func NewAsyncRecorder(nbufs int, drop bool, target func(ring string) *NetData) *AsyncRecorder {
+	if nbufs < 1 {
+		nbufs = 1
+	}
+	ar := &AsyncRecorder{NBufs: nbufs, Drop: drop, Target: target}
+	ar.free = make(chan *RecSnap, nbufs)
+	ar.recs = make(chan *RecSnap, nbufs)
+	for i := 0; i < nbufs; i++ {
+		ar.free <- &RecSnap{}
+	}
+	go ar.run()
+	return ar
+}
+
+// Record takes a snapshot of the network values using given data (for its
+// variables and selected projection unit), and hands it off to be recorded
+// into given ring (empty = main) with given counters.  Returns false if
+// the record was dropped because the recorder is behind (see Drop).
+func (ar *AsyncRecorder) Record(nd *NetData, ring, ctrs string, vals map[string]int) bool {
+	var sn *RecSnap
+	if ar.Drop {
+		select {
+		case sn = <-ar.free:
+		default:
+			ar.NDropped++
+			return false
+		}
+	} else {
+		sn = <-ar.free
+	}
+	nd.Snapshot(sn, ctrs, vals)
+	sn.Ring = ring
+	ar.pend.Add(1)
+	ar.recs <- sn
+	return true
+}
+
+// run is the recording goroutine
+func (ar *AsyncRecorder) run() {
+	for sn := range ar.recs {
+		ar.mu.Lock()
+		if nd := ar.Target(sn.Ring); nd != nil {
+			nd.RecordSnap(sn)
+		}
+		ar.mu.Unlock()
+		ar.free <- sn
+		ar.pend.Done()
+	}
+}
+
+// Flush waits until all pending records have been stored
+func (ar *AsyncRecorder) Flush() {
+	ar.pend.Wait()
+}
+
+// Lock locks the recorder so that no records are stored until Unlock,
+// e.g., while displaying the data
+func (ar *AsyncRecorder) Lock() {
+	ar.mu.Lock()
+}
+
+// Unlock unlocks the recorder after Lock
+func (ar *AsyncRecorder) Unlock() {
+	ar.mu.Unlock()
+}
+
+// Stop stores all pending records and stops the recording goroutine.
+// The recorder cannot be used after this.
+func (ar *AsyncRecorder) Stop() {
+	ar.Flush()
+	close(ar.recs)
+}
+
+// StartAsync starts recording asynchronously (see AsyncRecorder), so that
+// Record, RecordCtrs, and RecordRing only take a snapshot of the network on
+// the simulation thread, with given number of snapshot buffers, and whether
+// to drop records (instead of waiting) when the recorder falls behind.
+func (nv *NetView) StartAsync(nbufs int, drop bool) *AsyncRecorder {
+	nv.StopAsync()
+	nv.async = NewAsyncRecorder(nbufs, drop, func(ring string) *NetData {
+		if ring == "" {
+			return nv.mainData()
+		}
+		return nv.Ring(ring)
+	})
+	return nv.async
+}
+
+// StopAsync stores all pending records and returns to synchronous recording
+func (nv *NetView) StopAsync() {
+	if nv.async == nil {
+		return
+	}
+	nv.async.Stop()
+	nv.async = nil
+}
+
+// FlushAsync waits until all pending asynchronous records have been stored
+// (see StartAsync) -- does nothing if not recording asynchronously
+func (nv *NetView) FlushAsync() {
+	if nv.async != nil {
+		nv.async.Flush()
+	}
+}
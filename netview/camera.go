@@ -0,0 +1,71 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/mat32"
+)
+
+// CameraPreset is a canonical camera position for viewing the network,
+// looking at the center of the view from Pos, with given Up direction.
+type CameraPreset struct {
+	Name string     `desc:"name of the preset"`
+	Pos  mat32.Vec3 `desc:"camera position, in normalized view units (entire network view is unit sized, centered at the origin)"`
+	Up   mat32.Vec3 `desc:"up direction for the camera"`
+}
+
+// CameraPresets are the canonical camera presets available in the Viewbar
+// (and via SetCameraPreset): top (looking straight down on the layers), front
+// (looking at the layer stack edge-on, showing heights), side (from the right),
+// and iso (isometric, from above front right).  Presets are most useful
+// with Params.Ortho, which removes perspective distortion.
+var CameraPresets = []CameraPreset{
+	{"Top", mat32.Vec3{0, 3, 0}, mat32.Vec3{0, 0, -1}},
+	{"Front", mat32.Vec3{0, 0, 3}, mat32.Vec3{0, 1, 0}},
+	{"Side", mat32.Vec3{3, 0, 0}, mat32.Vec3{0, 1, 0}},
+	{"Iso", mat32.Vec3{1.75, 1.75, 1.75}, mat32.Vec3{0, 1, 0}},
+}
+
+// CameraPresetByName returns the camera preset with given name (case sensitive)
+func CameraPresetByName(name string) (*CameraPreset, error) {
+	for i := range CameraPresets {
+		if CameraPresets[i].Name == name {
+			return &CameraPresets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("NetView: camera preset: %v not found", name)
+}
+
+// SetCameraPreset sets the camera to the preset with given name
+// (Top, Front, Side, Iso -- see CameraPresets), and updates the display.
+func (nv *NetView) SetCameraPreset(name string) error {
+	cp, err := CameraPresetByName(name)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	vs := nv.Scene()
+	vs.Camera.Pose.Pos = cp.Pos
+	vs.Camera.LookAt(mat32.Vec3{0, 0, 0}, cp.Up)
+	vs.Camera.Ortho = nv.Params.Ortho
+	nv.CameraUpdated()
+	return nil
+}
+
+// SetOrtho sets whether the camera uses an orthographic projection instead
+// of perspective (see Params.Ortho), and updates the display.
+func (nv *NetView) SetOrtho(ortho bool) {
+	nv.Params.Ortho = ortho
+	vs := nv.Scene()
+	vs.Camera.Ortho = ortho
+	if ocb, ok := nv.Viewbar().ChildByName("ortho", 2).(*gi.CheckBox); ok && ocb.IsChecked() != ortho {
+		ocb.SetChecked(ortho)
+	}
+	nv.CameraUpdated()
+}
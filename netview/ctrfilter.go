@@ -0,0 +1,65 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CtrFilter selects records based on their structured counter values,
+// as recorded with RecordCtrs: a record matches if it has all of the
+// named counters with the given values.
+type CtrFilter map[string]int
+
+// ParseCtrFilter parses a filter from a space-separated list of Name=Val
+// expressions, e.g., "Trial=3 Cycle=99".  Returns nil for an empty string.
+func ParseCtrFilter(str string) (CtrFilter, error) {
+	flds := strings.Fields(str)
+	if len(flds) == 0 {
+		return nil, nil
+	}
+	cf := make(CtrFilter, len(flds))
+	for _, fl := range flds {
+		eq := strings.Index(fl, "=")
+		if eq <= 0 {
+			return nil, fmt.Errorf("NetView CtrFilter: expression: %q is not of the form Name=Val", fl)
+		}
+		v, err := strconv.Atoi(fl[eq+1:])
+		if err != nil {
+			return nil, fmt.Errorf("NetView CtrFilter: expression: %q value is not an integer: %v", fl, err)
+		}
+		cf[fl[:eq]] = v
+	}
+	return cf, nil
+}
+
+// Match returns true if given counter values match all of the filter values.
+// An empty filter matches everything.
+func (cf CtrFilter) Match(vals map[string]int) bool {
+	for k, v := range cf {
+		cv, ok := vals[k]
+		if !ok || cv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the filter in the format parsed by ParseCtrFilter
+func (cf CtrFilter) String() string {
+	keys := make([]string, 0, len(cf))
+	for k := range cf {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	strs := make([]string, len(keys))
+	for i, k := range keys {
+		strs[i] = fmt.Sprintf("%s=%d", k, cf[k])
+	}
+	return strings.Join(strs, " ")
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"github.com/emer/emergent/emer"
+	"github.com/emer/etable/etensor"
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/gi3d"
+	"github.com/goki/gi/mat32"
+	"github.com/goki/ki/ints"
+	"github.com/goki/ki/kit"
+)
+
+// DownMesh is a gi3d.Mesh that represents a very large layer in downsampled form
+// (see Params.DownThr), where each displayed cell aggregates (mean or max) a
+// Block x Block square of units.  4D layers are treated as a flat 2D grid of
+// all units (pool rows x unit rows by pool cols x unit cols).
+// The cells occupy the same footprint as the layer in the normal view.
+type DownMesh struct {
+	gi3d.MeshBase
+	Lay   emer.Layer    `desc:"layer that we render"`
+	Var   string        `desc:"variable that we render -- empty = the main NetView Var"`
+	Shape etensor.Shape `desc:"current shape that has been constructed -- if same, just update"`
+	Block int           `desc:"number of units along each side of the square block aggregated into each cell"`
+	NZ    int           `desc:"number of units along the Z (row) dimension, flattened for 4D"`
+	NX    int           `desc:"number of units along the X (column) dimension, flattened for 4D"`
+	View  *NetView      `desc:"netview that we're in"`
+	idxs  []int         `desc:"unit indexes for current cell"`
+}
+
+var KiT_DownMesh = kit.Types.AddType(&DownMesh{}, nil)
+
+// AddNewDownMeshVar adds DownMesh mesh to given scene for given layer,
+// displaying given variable (empty = main NetView Var), named via LayMeshName
+func AddNewDownMeshVar(sc *gi3d.Scene, nv *NetView, lay emer.Layer, vnm string) *DownMesh {
+	dm := &DownMesh{}
+	dm.View = nv
+	dm.Lay = lay
+	dm.Var = vnm
+	dm.Nm = LayMeshName(lay, vnm)
+	sc.AddMesh(dm)
+	return dm
+}
+
+// DownBlock returns the block size to use for downsampling given layer,
+// based on Params.DownBlock and DownThr
+func (nv *NetView) DownBlock(lay emer.Layer) int {
+	if nv.Params.DownBlock > 0 {
+		return nv.Params.DownBlock
+	}
+	n := lay.Shape().Len()
+	blk := 2
+	for nv.Params.DownThr > 0 && n > nv.Params.DownThr*blk*blk {
+		blk++
+	}
+	return blk
+}
+
+// FlatDims returns the number of units along the Z (rows) and X (columns)
+// dimensions of given shape, flattening 4D shapes into 2D
+func FlatDims(shp *etensor.Shape) (nz, nx int) {
+	if shp.NumDims() == 4 {
+		return shp.Dim(0) * shp.Dim(2), shp.Dim(1) * shp.Dim(3)
+	}
+	return shp.Dim(0), shp.Dim(1)
+}
+
+// FlatOffset returns the 1D unit index for given flattened z, x coordinates,
+// as in FlatDims
+func FlatOffset(shp *etensor.Shape, z, x int) int {
+	if shp.NumDims() == 4 {
+		nuz := shp.Dim(2)
+		nux := shp.Dim(3)
+		return shp.Offset([]int{z / nuz, x / nux, z % nuz, x % nux})
+	}
+	return shp.Offset([]int{z, x})
+}
+
+// CellVal returns the scaled value and color for given cell (block) coordinates,
+// aggregating over the units in the block
+func (dm *DownMesh) CellVal(cz, cx int) (scaled float32, clr gi.Color) {
+	nv := dm.View
+	vnm := dm.Var
+	if vnm == "" {
+		vnm = nv.Var
+	}
+	sz := cz * dm.Block
+	sx := cx * dm.Block
+	ez := ints.MinInt(sz+dm.Block, dm.NZ)
+	ex := ints.MinInt(sx+dm.Block, dm.NX)
+	dm.idxs = dm.idxs[:0]
+	for z := sz; z < ez; z++ {
+		for x := sx; x < ex; x++ {
+			dm.idxs = append(dm.idxs, FlatOffset(&dm.Shape, z, x))
+		}
+	}
+	return nv.AggValColor(dm.Lay, vnm, dm.idxs, nv.Params.DownMax)
+}
+
+// AggValColor returns the scaled value and color for the aggregate (mean, or
+// value with the maximum magnitude if useMax) of given variable over given units
+// (1D indexes) of given layer, at the current view record.
+func (nv *NetView) AggValColor(lay emer.Layer, vnm string, idxs []int, useMax bool) (scaled float32, clr gi.Color) {
+	laynm := lay.Name()
+	var agg float32
+	n := 0
+	for _, ui := range idxs {
+		v, ok := nv.Data.UnitVal(laynm, vnm, ui, nv.RecNo)
+		if !ok {
+			continue
+		}
+		if useMax {
+			if n == 0 || mat32.Abs(v) > mat32.Abs(agg) {
+				agg = v
+			}
+		} else {
+			agg += v
+		}
+		n++
+	}
+	if n == 0 {
+		clr.SetUInt8(0x20, 0x20, 0x20, 0x40)
+		return
+	}
+	if !useMax {
+		agg /= float32(n)
+	}
+	return nv.ValColor(lay, vnm, agg)
+}
+
+func (dm *DownMesh) Make(sc *gi3d.Scene) {
+	if dm.Lay == nil {
+		dm.Shape.SetShape(nil, nil, nil)
+		dm.Reset()
+	}
+	shp := dm.Lay.Shape()
+	dm.Reset()
+	dm.Shape.CopyShape(shp)
+	if dm.Shape.NumDims() < 2 {
+		return // nothing
+	}
+	dm.Block = dm.View.DownBlock(dm.Lay)
+	dm.NZ, dm.NX = FlatDims(&dm.Shape)
+	dm.MakeCells(true) // true = init
+}
+
+func (dm *DownMesh) Update(sc *gi3d.Scene) {
+	if dm.Shape.NumDims() < 2 {
+		return // nothing
+	}
+	dm.MakeCells(false) // false = not init
+	dm.SetVtxData(sc)
+	dm.SetColorData(sc)
+	dm.SetNormData(sc)
+	dm.Activate(sc)
+	dm.TransferVectors()
+}
+
+// MakeCells makes the geometry, one unit-bar per cell, with each cell
+// Block units wide and deep
+func (dm *DownMesh) MakeCells(init bool) {
+	dm.Trans = true
+	dm.Dynamic = true
+	blk := dm.Block
+	ncz := (dm.NZ + blk - 1) / blk
+	ncx := (dm.NX + blk - 1) / blk
+	fblk := float32(blk)
+
+	uw := dm.View.Params.UnitSize * fblk
+	uo := (1.0 - dm.View.Params.UnitSize)
+	segs := 1
+
+	vtxSz, idxSz := dm.PlaneSize(segs, segs)
+	nvtx := vtxSz * 5 * ncz * ncx
+	nidx := idxSz * 5 * ncz * ncx
+	dm.Alloc(nvtx, nidx, true)
+
+	pidx := 0 // plane index
+
+	setNorm := true // can change -- always set
+	setTex := init
+	setIdx := init
+
+	for czi := ncz - 1; czi >= 0; czi-- {
+		z0 := uo - fblk*float32(czi+1)
+		for cxi := 0; cxi < ncx; cxi++ {
+			poff := pidx * vtxSz * 5
+			ioff := pidx * idxSz * 5
+			x0 := uo + fblk*float32(cxi)
+			scaled, clr := dm.CellVal(czi, cxi)
+			ht := 0.5 * mat32.Abs(scaled)
+			if ht < MinUnitHeight {
+				ht = MinUnitHeight
+			}
+			if scaled >= 0 {
+				dm.SetPlane(poff, ioff, setNorm, setTex, setIdx, mat32.X, mat32.Y, -1, -1, uw, ht, x0, 0, z0, segs, segs, clr)                    // nz
+				dm.SetPlane(poff+1*vtxSz, ioff+1*idxSz, setNorm, setTex, setIdx, mat32.Z, mat32.Y, -1, -1, uw, ht, z0, 0, x0+uw, segs, segs, clr) // px
+				dm.SetPlane(poff+2*vtxSz, ioff+2*idxSz, setNorm, setTex, setIdx, mat32.Z, mat32.Y, 1, -1, uw, ht, z0, 0, x0, segs, segs, clr)     // nx
+				dm.SetPlane(poff+3*vtxSz, ioff+3*idxSz, setNorm, setTex, setIdx, mat32.X, mat32.Z, 1, 1, uw, uw, x0, z0, ht, segs, segs, clr)     // py <-
+				dm.SetPlane(poff+4*vtxSz, ioff+4*idxSz, setNorm, setTex, setIdx, mat32.X, mat32.Y, 1, -1, uw, ht, x0, 0, z0+uw, segs, segs, clr)  // pz
+			} else {
+				dm.SetPlane(poff, ioff, setNorm, setTex, setIdx, mat32.X, mat32.Y, 1, -1, uw, ht, x0, -ht, z0, segs, segs, clr)                    // nz = pz norm
+				dm.SetPlane(poff+1*vtxSz, ioff+1*idxSz, setNorm, setTex, setIdx, mat32.Z, mat32.Y, 1, -1, uw, ht, z0, -ht, x0+uw, segs, segs, clr) // px = nx norm
+				dm.SetPlane(poff+2*vtxSz, ioff+2*idxSz, setNorm, setTex, setIdx, mat32.Z, mat32.Y, 1, -1, uw, ht, z0, -ht, x0, segs, segs, clr)    // nx
+				dm.SetPlane(poff+3*vtxSz, ioff+3*idxSz, setNorm, setTex, setIdx, mat32.X, mat32.Z, 1, 1, uw, uw, x0, z0, -ht, segs, segs, clr)     // ny <-
+				dm.SetPlane(poff+4*vtxSz, ioff+4*idxSz, setNorm, setTex, setIdx, mat32.X, mat32.Y, 1, -1, uw, ht, x0, -ht, z0+uw, segs, segs, clr) // pz
+			}
+			pidx++
+		}
+	}
+
+	dm.BBox.SetBounds(mat32.Vec3{0, -0.5, -float32(dm.NZ)}, mat32.Vec3{float32(dm.NX), 0.5, 0})
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"log"
+	"math"
+	"path/filepath"
+
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+	"github.com/goki/gi/gi"
+)
+
+// ValsTable returns a table of the values of given variable (empty = current
+// Var) for all units in all visible layers, at the current view record (RecNo),
+// with columns Layer (name), Unit (1D index within the layer), and Value,
+// which is NaN for values that are not available.  Useful for quick ad-hoc
+// analysis of what is shown in the display.
+func (nv *NetView) ValsTable(vnm string) *etable.Table {
+	if vnm == "" {
+		vnm = nv.Var
+	}
+	sch := etable.Schema{
+		{Name: "Layer", Type: etensor.STRING},
+		{Name: "Unit", Type: etensor.INT64},
+		{Name: "Value", Type: etensor.FLOAT32},
+	}
+	dt := &etable.Table{}
+	dt.SetFromSchema(sch, 0)
+	dt.SetMetaData("name", vnm+"Vals")
+	dt.SetMetaData("desc", "NetView values of "+vnm+" at record: "+nv.Data.CounterRec(nv.RecNo))
+	if nv.Net == nil {
+		return dt
+	}
+	nlay := nv.Net.NLayers()
+	for li := 0; li < nlay; li++ {
+		lay := nv.Net.Layer(li)
+		laynm := lay.Name()
+		if !nv.LayVisible(laynm) {
+			continue
+		}
+		nu := lay.Shape().Len()
+		row := dt.Rows
+		dt.SetNumRows(row + nu)
+		for ui := 0; ui < nu; ui++ {
+			val, ok := nv.Data.UnitValRaw(laynm, vnm, ui, nv.RecNo)
+			if !ok {
+				val = float32(math.NaN())
+			}
+			dt.SetCellString("Layer", row+ui, laynm)
+			dt.SetCellFloat("Unit", row+ui, float64(ui))
+			dt.SetCellFloat("Value", row+ui, float64(val))
+		}
+	}
+	return dt
+}
+
+// SaveVals saves the values of the current variable for all visible layers
+// at the current view record (see ValsTable) to given file -- comma separated
+// if the file has a .csv extension, else tab separated.
+func (nv *NetView) SaveVals(filename gi.FileName) error {
+	dt := nv.ValsTable("")
+	delim := etable.Tab
+	if filepath.Ext(string(filename)) == ".csv" {
+		delim = etable.Comma
+	}
+	err := dt.SaveCSV(filename, delim, true)
+	if err != nil {
+		log.Println(err)
+	}
+	return err
+}
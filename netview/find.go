@@ -0,0 +1,183 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/chewxy/math32"
+	"github.com/goki/gi/gi"
+)
+
+// ValCond is a condition on unit values used for finding units, e.g.,
+// "> 0.9" or "== NaN" -- see ParseValCond.
+type ValCond struct {
+	Op  string  `desc:"comparison operator: one of == != > >= < <="`
+	Val float32 `desc:"value to compare against -- may be NaN, which only works with == and !="`
+}
+
+// ValCondOps are the valid comparison operators for ValCond,
+// in the order they must be tested in parsing
+var ValCondOps = []string{"==", "!=", ">=", "<=", ">", "<"}
+
+// ParseValCond parses a condition of the form "op val", e.g., "> 0.9",
+// "<= -1", "== NaN", or "!= 0".  A plain value (e.g., "NaN" or "0") is
+// treated as "== val".
+func ParseValCond(str string) (*ValCond, error) {
+	str = strings.TrimSpace(str)
+	vc := &ValCond{Op: "=="}
+	for _, op := range ValCondOps {
+		if strings.HasPrefix(str, op) {
+			vc.Op = op
+			str = strings.TrimSpace(str[len(op):])
+			break
+		}
+	}
+	if strings.EqualFold(str, "nan") {
+		if vc.Op != "==" && vc.Op != "!=" {
+			return nil, fmt.Errorf("NetView ValCond: NaN can only be used with == or !=, not: %s", vc.Op)
+		}
+		vc.Val = math32.NaN()
+		return vc, nil
+	}
+	v, err := strconv.ParseFloat(str, 32)
+	if err != nil {
+		return nil, fmt.Errorf("NetView ValCond: value: %q is not a number: %v", str, err)
+	}
+	vc.Val = float32(v)
+	return vc, nil
+}
+
+// Match returns true if given value matches the condition
+func (vc *ValCond) Match(val float32) bool {
+	if math32.IsNaN(vc.Val) {
+		if vc.Op == "!=" {
+			return !math32.IsNaN(val)
+		}
+		return math32.IsNaN(val)
+	}
+	switch vc.Op {
+	case "==":
+		return val == vc.Val
+	case "!=":
+		return val != vc.Val
+	case ">":
+		return val > vc.Val
+	case ">=":
+		return val >= vc.Val
+	case "<":
+		return val < vc.Val
+	case "<=":
+		return val <= vc.Val
+	}
+	return false
+}
+
+// String returns the condition in the format parsed by ParseValCond
+func (vc *ValCond) String() string {
+	if math32.IsNaN(vc.Val) {
+		return vc.Op + " NaN"
+	}
+	return fmt.Sprintf("%s %g", vc.Op, vc.Val)
+}
+
+// UnitMatch records a unit found by FindUnits
+type UnitMatch struct {
+	Lay string  `desc:"name of the layer"`
+	Idx int     `desc:"1D index of the unit within the layer"`
+	Val float32 `desc:"value of the unit"`
+}
+
+// FindCond returns the parsed Find condition, or nil if empty or invalid
+func (nv *NetView) FindCond() *ValCond {
+	if nv.Find == "" {
+		nv.findCond = nil
+		nv.findStr = ""
+		return nil
+	}
+	if nv.findStr == nv.Find {
+		return nv.findCond
+	}
+	vc, err := ParseValCond(nv.Find)
+	if err != nil {
+		log.Println(err)
+	}
+	nv.findCond = vc
+	nv.findStr = nv.Find
+	return vc
+}
+
+// SetFind sets the Find condition (e.g., "> 0.9" or "== NaN") used to
+// highlight all units whose value of the current variable matches it,
+// and updates the display.  An empty string turns off highlighting.
+// Returns an error if the condition cannot be parsed.
+func (nv *NetView) SetFind(cond string) error {
+	if cond != "" {
+		if _, err := ParseValCond(cond); err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+	nv.Find = cond
+	nv.Update()
+	return nil
+}
+
+// FindUnits returns all the units in visible layers whose value of the current
+// variable at the current view record matches given condition.
+func (nv *NetView) FindUnits(vc *ValCond) []UnitMatch {
+	var ms []UnitMatch
+	if nv.Net == nil {
+		return nil
+	}
+	nlay := nv.Net.NLayers()
+	for li := 0; li < nlay; li++ {
+		lay := nv.Net.Layer(li)
+		laynm := lay.Name()
+		if !nv.LayVisible(laynm) {
+			continue
+		}
+		nu := lay.Shape().Len()
+		for ui := 0; ui < nu; ui++ {
+			v, ok := nv.Data.UnitValRaw(laynm, nv.Var, ui, nv.RecNo)
+			if ok && vc.Match(v) {
+				ms = append(ms, UnitMatch{Lay: laynm, Idx: ui, Val: v})
+			}
+		}
+	}
+	return ms
+}
+
+// UnitFound returns true if the given unit matches the current Find condition
+// for given variable -- only the main Var is searched.
+func (nv *NetView) UnitFound(laynm, vnm string, idx1d int, recno int) bool {
+	if vnm != nv.Var {
+		return false
+	}
+	vc := nv.FindCond()
+	if vc == nil {
+		return false
+	}
+	v, ok := nv.Data.UnitValRaw(laynm, vnm, idx1d, recno)
+	return ok && vc.Match(v)
+}
+
+// UpdateFound updates the label showing the number of units matching
+// the Find condition
+func (nv *NetView) UpdateFound() {
+	tbar := nv.Toolbar()
+	nlbl, err := tbar.ChildByNameTry("nfound", 20)
+	if err != nil {
+		return
+	}
+	txt := ""
+	if vc := nv.FindCond(); vc != nil {
+		txt = fmt.Sprintf("%d found", len(nv.FindUnits(vc)))
+	}
+	nlbl.(*gi.Label).SetText(txt)
+}
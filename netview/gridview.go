@@ -0,0 +1,113 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"github.com/emer/etable/etensor"
+	"github.com/emer/etable/etview"
+	"github.com/goki/gi/gi"
+	"github.com/goki/ki/kit"
+)
+
+// GridView is the alternative 2D display of the network (Params.Grid2D),
+// showing each layer as a flat color grid (using etview.TensorGrid)
+// instead of the 3D bars, which is faster and more compact for deep networks
+// when only color readouts are needed.  Layers are listed from the
+// top of the network down, and values are from the current view record.
+type GridView struct {
+	gi.Frame
+	NetView *NetView                    `copy:"-" json:"-" xml:"-" view:"-" desc:"our netview"`
+	Tsrs    map[string]*etensor.Float32 `view:"-" desc:"tensor of values for each layer, keyed by layer name"`
+}
+
+var KiT_GridView = kit.Types.AddType(&GridView{}, nil)
+
+// Config configures a name label and TensorGrid for each visible layer
+func (gv *GridView) Config() {
+	nv := gv.NetView
+	gv.Lay = gi.LayoutVert
+	gv.SetProp("spacing", gi.StdDialogVSpaceUnits)
+	gv.SetStretchMax()
+	if !nv.HasLayers() {
+		gv.DeleteChildren(true)
+		return
+	}
+	if gv.Tsrs == nil {
+		gv.Tsrs = make(map[string]*etensor.Float32)
+	}
+	config := kit.TypeAndNameList{}
+	nlay := nv.Net.NLayers()
+	for li := nlay - 1; li >= 0; li-- {
+		lay := nv.Net.Layer(li)
+		if !nv.LayVisible(lay.Name()) {
+			continue
+		}
+		config.Add(gi.KiT_Label, lay.Name()+"-lbl")
+		config.Add(etview.KiT_TensorGrid, lay.Name())
+	}
+	mods, updt := gv.ConfigChildren(config, false)
+	if !mods {
+		updt = gv.UpdateStart()
+	}
+	for ki := 0; ki < len(gv.Kids); ki += 2 {
+		lb := gv.Child(ki).(*gi.Label)
+		tg := gv.Child(ki + 1).(*etview.TensorGrid)
+		laynm := tg.Name()
+		lb.SetText("<b>" + laynm + "</b>")
+		tsr, ok := gv.Tsrs[laynm]
+		if !ok {
+			tsr = &etensor.Float32{}
+			gv.Tsrs[laynm] = tsr
+		}
+		shp := nv.Net.LayerByName(laynm).Shape()
+		if !tsr.Shape.IsEqual(shp) {
+			tsr.SetShape(shp.Shp, shp.Strd, shp.Nms)
+		}
+		tg.SetTensor(tsr)
+	}
+	gv.UpdateEnd(updt)
+}
+
+// UpdateVals updates the layer grids with the values for the current
+// variable and record, using the current display range and color map
+func (gv *GridView) UpdateVals() {
+	nv := gv.NetView
+	if !nv.HasLayers() {
+		return
+	}
+	updt := gv.UpdateStart()
+	for ki := 1; ki < len(gv.Kids); ki += 2 {
+		tg := gv.Child(ki).(*etview.TensorGrid)
+		laynm := tg.Name()
+		tsr := gv.Tsrs[laynm]
+		lay := nv.Net.LayerByName(laynm)
+		if tsr == nil || lay == nil {
+			continue
+		}
+		vp := nv.VarParams[nv.Var]
+		tg.Disp.ColorMap = nv.Params.ColorMap
+		if vp != nil && vp.ColorMap != "" {
+			tg.Disp.ColorMap = vp.ColorMap
+		}
+		if vp != nil {
+			if lvp := vp.LayParams(laynm); lvp != nil {
+				vp = lvp
+			}
+			tg.Disp.Range.SetMin(float64(vp.Range.Min))
+			tg.Disp.Range.SetMax(float64(vp.Range.Max))
+		}
+		for ui := range tsr.Values {
+			raw, _, _ := nv.UnitValRec(lay, ui, nv.RecNo)
+			tsr.Values[ui] = raw
+		}
+		tg.UpdateSig()
+	}
+	gv.UpdateEnd(updt)
+}
+
+// GridView returns the 2D GridView used when Params.Grid2D is on
+func (nv *NetView) GridView() *GridView {
+	return nv.NetLay().ChildByName("grid", 2).(*GridView)
+}
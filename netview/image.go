@@ -0,0 +1,90 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"errors"
+	"image"
+	"image/png"
+	"log"
+	"os"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/mat32"
+)
+
+// SetCamera sets the camera position and the point that it looks at,
+// for programmatic control of the view (e.g., for RenderToImage snapshots).
+// Camera is oriented with Y as the up direction.
+func (nv *NetView) SetCamera(pos, lookAt mat32.Vec3) {
+	vs := nv.Scene()
+	vs.Camera.Pose.Pos = pos
+	vs.Camera.LookAt(lookAt, mat32.Vec3{0, 1, 0})
+	vs.UpdateSig()
+}
+
+// RenderToImage renders the given record number (-1 = latest, else in [0..Data.Ring.Len-1])
+// and returns a copy of the rendered image, without requiring any interaction.
+// The current record number being viewed is restored afterward.
+// The view must be part of a window (which provides the GPU context) but the window
+// does not need to be shown interactively -- for batch jobs on clusters without a
+// display, run under a virtual framebuffer (e.g., xvfb-run).
+// Use SetCamera to configure the view programmatically.
+func (nv *NetView) RenderToImage(rec int) (*image.RGBA, error) {
+	if !nv.HasLayers() || !nv.IsConfiged() {
+		return nil, errors.New("NetView.RenderToImage: view not configured with a network")
+	}
+	vs := nv.Scene()
+	if vs.Win == nil {
+		return nil, errors.New("NetView.RenderToImage: view is not in a window, so no GPU context is available")
+	}
+	prec := nv.RecNo
+	nv.RecNo = rec
+	updt := vs.UpdateStart()
+	nv.UpdateImpl()
+	vs.UpdateEnd(updt)
+	nv.RecNo = prec
+	return nv.renderImage()
+}
+
+// renderImage renders the scene in its current state and returns a copy of the image
+func (nv *NetView) renderImage() (*image.RGBA, error) {
+	vs := nv.Scene()
+	if !vs.Render() {
+		return nil, errors.New("NetView: scene could not be rendered")
+	}
+	img, err := vs.Image()
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return img, nil
+}
+
+// savePNG saves image to given file in PNG format
+func savePNG(filename string, img image.Image) error {
+	fp, err := os.Create(filename)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	defer fp.Close()
+	err = png.Encode(fp, img)
+	if err != nil {
+		log.Println(err)
+	}
+	return err
+}
+
+// SaveImage renders the given record number (-1 = latest) to an image
+// (see RenderToImage) and saves it to given file in PNG format.
+func (nv *NetView) SaveImage(filename gi.FileName, rec int) error {
+	img, err := nv.RenderToImage(rec)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return savePNG(string(filename), img)
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"log"
+	"math"
+	"os"
+
+	"github.com/goki/gi/gi"
+)
+
+// ImageDiffStats are summary statistics of the difference between two images,
+// with per-channel differences normalized to the 0-1 range.
+type ImageDiffStats struct {
+	NPixels  int     `desc:"total number of pixels compared"`
+	NDiff    int     `desc:"number of pixels where any channel differs by more than the tolerance"`
+	PctDiff  float32 `desc:"percent of pixels that differ (NDiff / NPixels * 100)"`
+	MaxDiff  float32 `desc:"maximum absolute difference in any channel of any pixel"`
+	MeanDiff float32 `desc:"mean absolute difference across all channels and pixels"`
+	RMSE     float32 `desc:"root mean squared difference across all channels and pixels"`
+}
+
+// String returns a one-line summary of the stats
+func (ds *ImageDiffStats) String() string {
+	return fmt.Sprintf("NDiff: %d / %d (%.3g%%)  MaxDiff: %.4g  MeanDiff: %.4g  RMSE: %.4g", ds.NDiff, ds.NPixels, ds.PctDiff, ds.MaxDiff, ds.MeanDiff, ds.RMSE)
+}
+
+// Same returns true if no pixels differ by more than the tolerance
+func (ds *ImageDiffStats) Same() bool {
+	return ds.NDiff == 0
+}
+
+// ImageDiff computes the difference between two images of the same size,
+// returning a difference image where each channel is the absolute difference
+// multiplied by gain (e.g., 1, or higher to make small differences visible),
+// on an opaque black background, along with summary stats.
+// Pixels with any channel differing by more than tol (0-1) are counted in NDiff.
+func ImageDiff(a, b image.Image, gain, tol float32) (*image.RGBA, ImageDiffStats, error) {
+	var ds ImageDiffStats
+	ab := a.Bounds()
+	bb := b.Bounds()
+	if ab.Dx() != bb.Dx() || ab.Dy() != bb.Dy() {
+		err := fmt.Errorf("NetView ImageDiff: image sizes differ: %v vs. %v", ab.Size(), bb.Size())
+		log.Println(err)
+		return nil, ds, err
+	}
+	diff := image.NewRGBA(image.Rect(0, 0, ab.Dx(), ab.Dy()))
+	var sum, ssq float64
+	for y := 0; y < ab.Dy(); y++ {
+		for x := 0; x < ab.Dx(); x++ {
+			ac := color.RGBAModel.Convert(a.At(ab.Min.X+x, ab.Min.Y+y)).(color.RGBA)
+			bc := color.RGBAModel.Convert(b.At(bb.Min.X+x, bb.Min.Y+y)).(color.RGBA)
+			av := [4]uint8{ac.R, ac.G, ac.B, ac.A}
+			bv := [4]uint8{bc.R, bc.G, bc.B, bc.A}
+			var dv [4]uint8
+			pxdiff := false
+			for ci := range av {
+				d := float32(av[ci])/255 - float32(bv[ci])/255
+				if d < 0 {
+					d = -d
+				}
+				sum += float64(d)
+				ssq += float64(d * d)
+				if d > ds.MaxDiff {
+					ds.MaxDiff = d
+				}
+				if d > tol {
+					pxdiff = true
+				}
+				gd := d * gain
+				if gd > 1 {
+					gd = 1
+				}
+				dv[ci] = uint8(gd * 255)
+			}
+			diff.SetRGBA(x, y, color.RGBA{dv[0], dv[1], dv[2], 255})
+			if pxdiff {
+				ds.NDiff++
+			}
+		}
+	}
+	ds.NPixels = ab.Dx() * ab.Dy()
+	if ds.NPixels > 0 {
+		n := float64(4 * ds.NPixels)
+		ds.PctDiff = 100 * float32(ds.NDiff) / float32(ds.NPixels)
+		ds.MeanDiff = float32(sum / n)
+		ds.RMSE = float32(math.Sqrt(ssq / n))
+	}
+	return diff, ds, nil
+}
+
+// OpenPNG opens an image from given file in PNG format, e.g., as saved by SaveImage
+func OpenPNG(filename string) (image.Image, error) {
+	fp, err := os.Open(filename)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	defer fp.Close()
+	img, err := png.Decode(fp)
+	if err != nil {
+		log.Println(err)
+	}
+	return img, err
+}
+
+// DiffImageFiles computes the difference between two saved PNG snapshots
+// (e.g., from SaveImage before and after a refactor), saving the difference
+// image (see ImageDiff) to given diff file in PNG format if non-empty,
+// and returning the summary stats.
+func DiffImageFiles(fileA, fileB, diffFile string, gain, tol float32) (ImageDiffStats, error) {
+	var ds ImageDiffStats
+	a, err := OpenPNG(fileA)
+	if err != nil {
+		return ds, err
+	}
+	b, err := OpenPNG(fileB)
+	if err != nil {
+		return ds, err
+	}
+	diff, ds, err := ImageDiff(a, b, gain, tol)
+	if err != nil {
+		return ds, err
+	}
+	if diffFile != "" {
+		err = savePNG(diffFile, diff)
+	}
+	return ds, err
+}
+
+// DiffRecs renders the two given record numbers (-1 = latest, else in
+// [0..Data.Ring.Len-1]) to images (see RenderToImage) and returns their
+// difference image (see ImageDiff) and summary stats.
+func (nv *NetView) DiffRecs(recA, recB int, gain, tol float32) (*image.RGBA, ImageDiffStats, error) {
+	a, err := nv.RenderToImage(recA)
+	if err != nil {
+		return nil, ImageDiffStats{}, err
+	}
+	b, err := nv.RenderToImage(recB)
+	if err != nil {
+		return nil, ImageDiffStats{}, err
+	}
+	return ImageDiff(a, b, gain, tol)
+}
+
+// SaveDiffImage renders the two given record numbers, and saves their
+// difference image (see DiffRecs) to given file in PNG format,
+// returning the summary stats.
+func (nv *NetView) SaveDiffImage(filename gi.FileName, recA, recB int, gain, tol float32) (ImageDiffStats, error) {
+	diff, ds, err := nv.DiffRecs(recA, recB, gain, tol)
+	if err != nil {
+		return ds, err
+	}
+	return ds, savePNG(string(filename), diff)
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/emer/emergent/emer"
+	"github.com/emer/etable/etensor"
+	"github.com/emer/etable/etview"
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki/kit"
+)
+
+// UnitInspector is a side panel in the NetView that shows all of the unit variables
+// for the currently selected unit (click on a unit to select it, when Params.Inspector is on),
+// along with the synaptic values (Params.PrjnVar, e.g., Wt) for each of its
+// receiving projections (shown in the shape of the sending layer) and
+// sending projections (shown in the shape of the receiving layer).
+// Unit values are from the current view record, while synaptic values are
+// the current values in the network.
+type UnitInspector struct {
+	gi.Frame
+	NetView *NetView           `copy:"-" json:"-" xml:"-" view:"-" desc:"our netview"`
+	LayName string             `desc:"name of layer containing unit being inspected -- empty if none"`
+	UnIdx   int                `desc:"1D index of unit being inspected"`
+	Wts     []*etensor.Float32 `view:"-" desc:"synaptic values for each recv then send projection"`
+}
+
+var KiT_UnitInspector = kit.Types.AddType(&UnitInspector{}, nil)
+
+// SetUnit sets the unit to inspect and updates the display
+func (ui *UnitInspector) SetUnit(laynm string, uidx int) {
+	ui.LayName = laynm
+	ui.UnIdx = uidx
+	ui.Config()
+}
+
+// Clear clears the unit being inspected
+func (ui *UnitInspector) Clear() {
+	ui.LayName = ""
+	ui.Config()
+}
+
+// Layer returns the layer being inspected, nil if none
+func (ui *UnitInspector) Layer() emer.Layer {
+	nv := ui.NetView
+	if ui.LayName == "" || nv == nil || nv.Net == nil {
+		return nil
+	}
+	return nv.Net.LayerByName(ui.LayName)
+}
+
+// Config configures the display for the current unit, creating the
+// unit values grid and one TensorGrid per projection
+func (ui *UnitInspector) Config() {
+	ui.Lay = gi.LayoutVert
+	ui.SetProp("spacing", gi.StdDialogVSpaceUnits)
+	ui.SetProp("vertical-align", gi.AlignTop)
+	ui.SetProp("min-width", units.NewEm(12))
+	lay := ui.Layer()
+	if lay == nil {
+		ui.DeleteChildren(true)
+		ui.SetInvisible()
+		return
+	}
+	ui.ClearInvisible()
+	config := kit.TypeAndNameList{}
+	config.Add(gi.KiT_Label, "title")
+	config.Add(gi.KiT_Frame, "vals")
+	nrp := lay.NRecvPrjns()
+	nsp := lay.NSendPrjns()
+	for pi := 0; pi < nrp; pi++ {
+		nm := "r:" + lay.RecvPrjn(pi).Name()
+		config.Add(gi.KiT_Label, nm+"-lbl")
+		config.Add(etview.KiT_TensorGrid, nm)
+	}
+	for pi := 0; pi < nsp; pi++ {
+		nm := "s:" + lay.SendPrjn(pi).Name()
+		config.Add(gi.KiT_Label, nm+"-lbl")
+		config.Add(etview.KiT_TensorGrid, nm)
+	}
+	mods, updt := ui.ConfigChildren(config, false)
+	if !mods {
+		updt = ui.UpdateStart()
+	}
+	if len(ui.Wts) != nrp+nsp {
+		ui.Wts = make([]*etensor.Float32, nrp+nsp)
+		for i := range ui.Wts {
+			ui.Wts[i] = &etensor.Float32{}
+		}
+	}
+	vl := ui.ChildByName("vals", 1).(*gi.Frame)
+	vl.Lay = gi.LayoutGrid
+	vl.SetProp("columns", 2)
+	vl.SetProp("spacing", 0)
+	pvar := ui.NetView.Params.PrjnVar
+	for pi := 0; pi < nrp; pi++ {
+		pj := lay.RecvPrjn(pi)
+		nm := "r:" + pj.Name()
+		lb := ui.ChildByName(nm+"-lbl", 0).(*gi.Label)
+		lb.SetText(fmt.Sprintf("<b>%s</b> from: %s", pvar, pj.SendLay().Name()))
+		tg := ui.ChildByName(nm, 0).(*etview.TensorGrid)
+		tg.SetTensor(ui.Wts[pi])
+	}
+	for pi := 0; pi < nsp; pi++ {
+		pj := lay.SendPrjn(pi)
+		nm := "s:" + pj.Name()
+		lb := ui.ChildByName(nm+"-lbl", 0).(*gi.Label)
+		lb.SetText(fmt.Sprintf("<b>%s</b> to: %s", pvar, pj.RecvLay().Name()))
+		tg := ui.ChildByName(nm, 0).(*etview.TensorGrid)
+		tg.SetTensor(ui.Wts[nrp+pi])
+	}
+	ui.UpdateVals()
+	ui.UpdateEnd(updt)
+}
+
+// UpdateVals updates the displayed values for the current unit
+func (ui *UnitInspector) UpdateVals() {
+	lay := ui.Layer()
+	if lay == nil || !ui.HasChildren() || len(ui.Wts) != lay.NRecvPrjns()+lay.NSendPrjns() {
+		return
+	}
+	nv := ui.NetView
+	updt := ui.UpdateStart()
+	tl := ui.Child(0).(*gi.Label)
+	tl.SetText(fmt.Sprintf("<b>%s</b> unit: %v", ui.LayName, lay.Shape().Index(ui.UnIdx)))
+
+	vl := ui.Child(1).(*gi.Frame)
+	var vars []string
+	for _, vn := range nv.Data.Vars {
+		if strings.HasPrefix(vn, "r.") || strings.HasPrefix(vn, "s.") {
+			continue
+		}
+		vars = append(vars, vn)
+	}
+	vcfg := kit.TypeAndNameList{}
+	for _, vn := range vars {
+		vcfg.Add(gi.KiT_Label, vn+"-nm")
+		vcfg.Add(gi.KiT_Label, vn)
+	}
+	vl.ConfigChildren(vcfg, false)
+	for i, vn := range vars {
+		nl := vl.Child(2 * i).(*gi.Label)
+		nl.SetText(vn)
+		vlb := vl.Child(2*i + 1).(*gi.Label)
+		vlb.Redrawable = true
+		val, ok := nv.Data.UnitVal(ui.LayName, vn, ui.UnIdx, nv.RecNo)
+		if ok {
+			vlb.SetText(fmt.Sprintf("%g", val))
+		} else {
+			vlb.SetText("n/a")
+		}
+	}
+
+	pvar := nv.Params.PrjnVar
+	nrp := lay.NRecvPrjns()
+	for pi := 0; pi < nrp; pi++ {
+		pj := lay.RecvPrjn(pi)
+		ui.prjnVals(ui.Wts[pi], pj, pj.SendLay(), pvar, true)
+	}
+	for pi := 0; pi < lay.NSendPrjns(); pi++ {
+		pj := lay.SendPrjn(pi)
+		ui.prjnVals(ui.Wts[nrp+pi], pj, pj.RecvLay(), pvar, false)
+	}
+	for _, kid := range ui.Kids {
+		if tg, ok := kid.(*etview.TensorGrid); ok {
+			tg.UpdateSig()
+		}
+	}
+	ui.UpdateEnd(updt)
+}
+
+// prjnVals sets the synaptic values for our unit through given projection
+// into given tensor, shaped as the other layer olay
+func (ui *UnitInspector) prjnVals(tsr *etensor.Float32, pj emer.Prjn, olay emer.Layer, pvar string, recv bool) {
+	oshp := olay.Shape()
+	if !tsr.Shape.IsEqual(oshp) {
+		tsr.SetShape(oshp.Shp, oshp.Strd, oshp.Nms)
+	}
+	for oi := range tsr.Values {
+		if recv {
+			tsr.Values[oi] = pj.SynVal(pvar, oi, ui.UnIdx)
+		} else {
+			tsr.Values[oi] = pj.SynVal(pvar, ui.UnIdx, oi)
+		}
+	}
+}
+
+// InspectUnit shows the values for given unit in the UnitInspector side panel.
+// Called when a unit is clicked, if Params.Inspector is on.
+func (nv *NetView) InspectUnit(laynm string, uidx int) {
+	ins := nv.Inspector()
+	ins.NetView = nv
+	ins.SetUnit(laynm, uidx)
+}
+
+// Inspector returns the UnitInspector side panel
+func (nv *NetView) Inspector() *UnitInspector {
+	return nv.NetLay().ChildByName("inspect", 3).(*UnitInspector)
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/oswin/key"
+	"github.com/goki/ki/ki"
+)
+
+// KeyMap maps key chords (e.g., "]", "Shift+Control+1") to the names of
+// NetView key actions (see KeyActions), for keyboard navigation of the view.
+// The NetView must have keyboard focus for the keys to be processed.
+type KeyMap map[key.Chord]string
+
+// KeyActions are the functions that can be bound to keys in a KeyMap,
+// keyed by name.  Additional actions can be added with AddKeyAction.
+var KeyActions = map[string]func(nv *NetView){
+	"RecBkwd":     func(nv *NetView) { nv.keyRec(nv.RecBkwd) },
+	"RecFwd":      func(nv *NetView) { nv.keyRec(nv.RecFwd) },
+	"RecFastBkwd": func(nv *NetView) { nv.keyRec(nv.RecFastBkwd) },
+	"RecFastFwd":  func(nv *NetView) { nv.keyRec(nv.RecFastFwd) },
+	"RecLatest":   func(nv *NetView) { nv.Playback.Pause(); nv.keyRec(nv.RecTrackLatest) },
+	"Play":        func(nv *NetView) { nv.Playback.NetView = nv; nv.Playback.Toggle(); nv.Update() },
+	"VarNext":     func(nv *NetView) { nv.CycleVar(1) },
+	"VarPrev":     func(nv *NetView) { nv.CycleVar(-1) },
+	"CamDefault":  func(nv *NetView) { nv.Scene().SetCamera("default"); nv.CameraUpdated() },
+}
+
+func init() {
+	for i := 1; i <= 9; i++ {
+		li := i - 1
+		KeyActions["ToggleLay"+strconv.Itoa(i)] = func(nv *NetView) { nv.ToggleLayNo(li) }
+	}
+	for _, cp := range CameraPresets {
+		cpnm := cp.Name
+		KeyActions["View"+cpnm] = func(nv *NetView) { nv.SetCameraPreset(cpnm) }
+	}
+	KeyActions["ToggleOrtho"] = func(nv *NetView) { nv.SetOrtho(!nv.Params.Ortho) }
+	for i := 1; i <= 4; i++ {
+		cam := strconv.Itoa(i)
+		KeyActions["Cam"+cam] = func(nv *NetView) { nv.keyCamera(cam, false) }
+		KeyActions["SaveCam"+cam] = func(nv *NetView) { nv.keyCamera(cam, true) }
+	}
+}
+
+// AddKeyAction adds a new named action that can be bound to keys in a KeyMap
+func AddKeyAction(name string, fun func(nv *NetView)) {
+	KeyActions[name] = fun
+}
+
+// DefaultKeyMap returns the default key map:
+//
+//	[ / ]                   record back / forward by 1
+//	Shift+[ / Shift+]       record back / forward by 10
+//	End                     track latest record
+//	p                       play / pause (see Playback)
+//	v / Shift+V             next / previous variable
+//	1..9                    toggle visibility of layer 1..9
+//	Control+1..4            restore saved camera 1..4
+//	Shift+Control+1..4      save camera 1..4
+//	Home                    default camera
+func DefaultKeyMap() KeyMap {
+	km := KeyMap{
+		"[":       "RecBkwd",
+		"]":       "RecFwd",
+		"Shift+[": "RecFastBkwd",
+		"Shift+]": "RecFastFwd",
+		"{":       "RecFastBkwd",
+		"}":       "RecFastFwd",
+		"End":     "RecLatest",
+		"p":       "Play",
+		"v":       "VarNext",
+		"Shift+V": "VarPrev",
+		"Home":    "CamDefault",
+	}
+	for i := 1; i <= 9; i++ {
+		km[key.Chord(strconv.Itoa(i))] = "ToggleLay" + strconv.Itoa(i)
+	}
+	for i := 1; i <= 4; i++ {
+		n := strconv.Itoa(i)
+		km[key.Chord("Control+"+n)] = "Cam" + n
+		km[key.Chord("Shift+Control+"+n)] = "SaveCam" + n
+	}
+	return km
+}
+
+// Set binds given key chord to given action name, checking that the action exists.
+func (km KeyMap) Set(kc key.Chord, action string) error {
+	if _, ok := KeyActions[action]; !ok {
+		err := fmt.Errorf("NetView KeyMap: action: %v not found in KeyActions", action)
+		log.Println(err)
+		return err
+	}
+	km[kc] = action
+	return nil
+}
+
+// String returns a listing of the key bindings, sorted by chord
+func (km KeyMap) String() string {
+	kcs := make([]string, 0, len(km))
+	for kc := range km {
+		kcs = append(kcs, string(kc))
+	}
+	sort.Strings(kcs)
+	str := ""
+	for _, kc := range kcs {
+		str += fmt.Sprintf("%-20s %s\n", kc, km[key.Chord(kc)])
+	}
+	return str
+}
+
+// KeyChord processes given key chord according to the KeyMap,
+// returning true if it was bound to an action
+func (nv *NetView) KeyChord(kc key.Chord) bool {
+	act, ok := nv.KeyMap[kc]
+	if !ok {
+		return false
+	}
+	fun, ok := KeyActions[act]
+	if !ok {
+		log.Printf("NetView KeyMap: action: %v bound to: %v not found in KeyActions\n", act, kc)
+		return false
+	}
+	fun(nv)
+	return true
+}
+
+// ConnectEvents2D connects the key chord events to the KeyMap actions
+func (nv *NetView) ConnectEvents2D() {
+	nv.Layout.ConnectEvents2D()
+	nv.ConnectEvent(oswin.KeyChordEvent, gi.HiPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+		nvv := recv.Embed(KiT_NetView).(*NetView)
+		kt := d.(*key.ChordEvent)
+		if nvv.KeyChord(kt.Chord()) {
+			kt.SetProcessed()
+		}
+	})
+}
+
+// CycleVar sets the current variable to the next (dir > 0) or previous
+// (dir < 0) one in the list of variables, wrapping around
+func (nv *NetView) CycleVar(dir int) {
+	nvar := len(nv.Vars)
+	if nvar == 0 {
+		return
+	}
+	ci := 0
+	for i, vn := range nv.Vars {
+		if vn == nv.Var {
+			ci = i
+			break
+		}
+	}
+	for n := 0; n < nvar; n++ {
+		ci = (ci + dir + nvar) % nvar
+		if nv.Vars[ci] != "" { // skip padding
+			nv.SetVar(nv.Vars[ci])
+			return
+		}
+	}
+}
+
+// ToggleLayNo toggles the visibility of the layer at given index in the network,
+// and updates the display
+func (nv *NetView) ToggleLayNo(li int) {
+	if nv.Net == nil || li >= nv.Net.NLayers() {
+		return
+	}
+	laynm := nv.Net.Layer(li).Name()
+	nv.SetLayVisible(laynm, nv.HideLays[laynm])
+	nv.Config()
+	nv.Update()
+}
+
+// keyRec calls given record navigation function and updates if changed
+func (nv *NetView) keyRec(fun func() bool) {
+	if fun() {
+		nv.Update()
+	}
+}
+
+// keyCamera saves or restores the given named camera
+func (nv *NetView) keyCamera(cam string, save bool) {
+	scc := nv.Scene()
+	if save {
+		scc.SaveCamera(cam)
+	} else if err := scc.SetCamera(cam); err != nil {
+		return
+	}
+	nv.CameraUpdated()
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/giv"
+	"github.com/goki/gi/mat32"
+	"github.com/goki/ki/ki"
+)
+
+// LabelStyle holds display options for the name label of a layer,
+// to avoid overlapping labels in dense networks.  See Params.LayLabels.
+type LabelStyle struct {
+	Hide   bool       `desc:"do not show the label"`
+	Size   float32    `min:"0" step:"0.01" desc:"size of the label -- 0 = Params.LayNmSize"`
+	Color  gi.Color   `desc:"color of the label text -- unset (nil) = default for Params.Scene"`
+	Flat   bool       `desc:"lay the label flat in the plane of the layer, instead of standing upright at its front edge"`
+	Offset mat32.Vec3 `desc:"offset of the label from its default position at the front left corner of the layer, in normalized view units (entire network view is unit sized)"`
+}
+
+// LayLabel returns the label style for given layer, or nil if it has the default style
+func (nv *Params) LayLabel(laynm string) *LabelStyle {
+	if nv.LayLabels == nil {
+		return nil
+	}
+	return nv.LayLabels[laynm]
+}
+
+// SetLayLabel returns the label style for given layer, creating it if needed,
+// for setting label options for that layer.  Call NetView.Config to apply.
+func (nv *Params) SetLayLabel(laynm string) *LabelStyle {
+	if ls := nv.LayLabel(laynm); ls != nil {
+		return ls
+	}
+	if nv.LayLabels == nil {
+		nv.LayLabels = make(map[string]*LabelStyle)
+	}
+	ls := &LabelStyle{}
+	nv.LayLabels[laynm] = ls
+	return ls
+}
+
+// StyleLabel applies the label style for its layer (see Params.LayLabels)
+// to given layer name label, whose layer group has given scale.
+func (nv *NetView) StyleLabel(txt *LayName, laynm string, gpScale mat32.Vec3) {
+	ls := nv.Params.LayLabel(laynm)
+	if ls == nil {
+		ls = &LabelStyle{}
+	}
+	if ls.Hide {
+		txt.SetInvisible()
+	} else {
+		txt.ClearInvisible()
+	}
+	sz := ls.Size
+	if sz == 0 {
+		sz = nv.Params.LayNmSize
+	}
+	txt.Pose.Scale = mat32.NewVec3Scalar(sz).Div(gpScale)
+	txt.Pose.Pos = ls.Offset.Div(gpScale)
+	if ls.Flat {
+		txt.Pose.SetAxisRotation(1, 0, 0, -90)
+	} else {
+		txt.Pose.SetAxisRotation(1, 0, 0, 0)
+	}
+	if ls.Color.IsNil() {
+		nv.SetTextColor(&txt.Text2D)
+	} else {
+		txt.SetProp("color", ls.Color)
+	}
+}
+
+// LabelMenu shows a context menu for the label of given layer, at given
+// window position, with options to edit the layer, its label style, and
+// to hide the label or the layer.
+func (nv *NetView) LabelMenu(laynm string, x, y int) {
+	var men gi.Menu
+	men.AddAction(gi.ActOpts{Label: "Layer Params..."}, nv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		nvv := recv.Embed(KiT_NetView).(*NetView)
+		if lay := nvv.Net.LayerByName(laynm); lay != nil {
+			giv.StructViewDialog(nvv.Viewport, lay, giv.DlgOpts{Title: laynm}, nil, nil)
+		}
+	})
+	men.AddAction(gi.ActOpts{Label: "Label Style..."}, nv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		nvv := recv.Embed(KiT_NetView).(*NetView)
+		ls := nvv.Params.SetLayLabel(laynm)
+		giv.StructViewDialog(nvv.Viewport, ls, giv.DlgOpts{Title: laynm + " Label"}, nvv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			nvv := recv.Embed(KiT_NetView).(*NetView)
+			nvv.Config()
+			nvv.Update()
+		})
+	})
+	men.AddAction(gi.ActOpts{Label: "Hide Label"}, nv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		nvv := recv.Embed(KiT_NetView).(*NetView)
+		nvv.Params.SetLayLabel(laynm).Hide = true
+		nvv.Config()
+		nvv.Update()
+	})
+	men.AddAction(gi.ActOpts{Label: "Hide Layer"}, nv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		nvv := recv.Embed(KiT_NetView).(*NetView)
+		nvv.SetLayVisible(laynm, false)
+		nvv.Config()
+		nvv.Update()
+	})
+	gi.PopupMenu(men, x, y, nv.Viewport, "netview-label-menu")
+}
@@ -0,0 +1,197 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"math"
+
+	"github.com/chewxy/math32"
+)
+
+// LayData maintains a record of all the data for a given layer.
+// Values are stored at the precision given by Bits (see NetData.Bits):
+// 32 = full float32 in Data, 16 = half-precision floats in Data16,
+// and 8 = values quantized within the range of each block (one variable
+// for one record) in Data8, with the block ranges in Min8, Max8.
+// Use Val and SetBlock to access the data at any precision.
+type LayData struct {
+	LayName string    `desc:"the layer name"`
+	NUnits  int       `desc:"cached number of units"`
+	Bits    int       `desc:"storage precision in bits per value: 32, 16, or 8"`
+	Data    []float32 `desc:"the full data, Ring.Max * len(Vars) * NUnits in that order, for 32 bit storage"`
+	Data16  []uint16  `desc:"the full data as float16 values, for 16 bit storage"`
+	Data8   []uint8   `desc:"the full data as 8 bit quantized values, for 8 bit storage"`
+	Min8    []float32 `desc:"min value for each block of NUnits values, for 8 bit storage"`
+	Max8    []float32 `desc:"max value for each block of NUnits values, for 8 bit storage"`
+}
+
+// nan8 is the 8 bit value representing NaN
+const nan8 = 255
+
+// Len returns the total number of values stored
+func (ld *LayData) Len() int {
+	switch ld.Bits {
+	case 16:
+		return len(ld.Data16)
+	case 8:
+		return len(ld.Data8)
+	default:
+		return len(ld.Data)
+	}
+}
+
+// Alloc allocates storage for given total number of values at given precision
+func (ld *LayData) Alloc(bits, ntot int) {
+	ld.Bits = bits
+	ld.Data, ld.Data16, ld.Data8, ld.Min8, ld.Max8 = nil, nil, nil, nil, nil
+	switch bits {
+	case 16:
+		ld.Data16 = make([]uint16, ntot)
+	case 8:
+		ld.Data8 = make([]uint8, ntot)
+		nblk := 0
+		if ld.NUnits > 0 {
+			nblk = ntot / ld.NUnits
+		}
+		ld.Min8 = make([]float32, nblk)
+		ld.Max8 = make([]float32, nblk)
+	default:
+		ld.Bits = 32
+		ld.Data = make([]float32, ntot)
+	}
+}
+
+// Val returns the value at given index in the data
+func (ld *LayData) Val(idx int) float32 {
+	switch ld.Bits {
+	case 16:
+		return Float16To32(ld.Data16[idx])
+	case 8:
+		q := ld.Data8[idx]
+		if q == nan8 {
+			return math32.NaN()
+		}
+		blk := idx / ld.NUnits
+		mn := ld.Min8[blk]
+		return mn + (ld.Max8[blk]-mn)*float32(q)/(nan8-1)
+	default:
+		return ld.Data[idx]
+	}
+}
+
+// SetBlock sets the values for given block (one variable for one record,
+// i.e., record index * len(Vars) + variable index), which must be NUnits long.
+func (ld *LayData) SetBlock(blk int, vals []float32) {
+	st := blk * ld.NUnits
+	switch ld.Bits {
+	case 16:
+		dv := ld.Data16[st : st+ld.NUnits]
+		for i, v := range vals {
+			dv[i] = Float32To16(v)
+		}
+	case 8:
+		var mn float32 = math.MaxFloat32
+		var mx float32 = -math.MaxFloat32
+		for _, v := range vals {
+			if !math32.IsNaN(v) {
+				mn = math32.Min(mn, v)
+				mx = math32.Max(mx, v)
+			}
+		}
+		if mn > mx {
+			mn, mx = 0, 0
+		}
+		ld.Min8[blk] = mn
+		ld.Max8[blk] = mx
+		rng := mx - mn
+		dv := ld.Data8[st : st+ld.NUnits]
+		for i, v := range vals {
+			switch {
+			case math32.IsNaN(v):
+				dv[i] = nan8
+			case rng == 0:
+				dv[i] = 0
+			default:
+				dv[i] = uint8(math32.Round((v - mn) / rng * (nan8 - 1)))
+			}
+		}
+	default:
+		copy(ld.Data[st:st+ld.NUnits], vals)
+	}
+}
+
+// Block returns the values for given block (see SetBlock) in given slice,
+// which is resized as needed, and returns it.  For 32 bit storage, the
+// actual storage is returned and should not be modified.
+func (ld *LayData) Block(blk int, vals []float32) []float32 {
+	st := blk * ld.NUnits
+	if ld.Bits == 32 || ld.Bits == 0 {
+		return ld.Data[st : st+ld.NUnits]
+	}
+	if cap(vals) < ld.NUnits {
+		vals = make([]float32, ld.NUnits)
+	}
+	vals = vals[:ld.NUnits]
+	for i := range vals {
+		vals[i] = ld.Val(st + i)
+	}
+	return vals
+}
+
+// Float32To16 converts a float32 to IEEE 754 half-precision (float16) bits,
+// with rounding to nearest, and saturation to infinity for large values.
+func Float32To16(f float32) uint16 {
+	b := math.Float32bits(f)
+	sign := uint16(b>>16) & 0x8000
+	exp := int((b>>23)&0xFF) - 127 + 15
+	mant := b & 0x7FFFFF
+	switch {
+	case (b>>23)&0xFF == 0xFF: // inf or nan
+		if mant != 0 {
+			return sign | 0x7E00
+		}
+		return sign | 0x7C00
+	case exp >= 0x1F: // overflow
+		return sign | 0x7C00
+	case exp <= 0: // subnormal or zero
+		if exp < -10 {
+			return sign
+		}
+		mant |= 0x800000
+		shift := uint(14 - exp)
+		h := uint16(mant >> shift)
+		if (mant>>(shift-1))&1 != 0 { // round
+			h++
+		}
+		return sign | h
+	}
+	h := sign | uint16(exp<<10) | uint16(mant>>13)
+	if mant&0x1000 != 0 { // round -- carry into exponent is correct
+		h++
+	}
+	return h
+}
+
+// Float16To32 converts IEEE 754 half-precision (float16) bits to a float32
+func Float16To32(h uint16) float32 {
+	sign := uint32(h&0x8000) << 16
+	exp := int32(h>>10) & 0x1F
+	mant := uint32(h & 0x3FF)
+	switch {
+	case exp == 0x1F: // inf or nan
+		return math.Float32frombits(sign | 0x7F800000 | mant<<13)
+	case exp == 0:
+		if mant == 0 {
+			return math.Float32frombits(sign)
+		}
+		exp = 1
+		for mant&0x400 == 0 { // normalize subnormal
+			mant <<= 1
+			exp--
+		}
+		mant &= 0x3FF
+	}
+	return math.Float32frombits(sign | uint32(exp+127-15)<<23 | mant<<13)
+}
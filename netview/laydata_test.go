@@ -0,0 +1,162 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"math"
+	"testing"
+)
+
+var (
+	inf32 = float32(math.Inf(1))
+	nan32 = float32(math.NaN())
+)
+
+func TestFloat32To16(t *testing.T) {
+	tests := []struct {
+		f float32
+		h uint16
+	}{
+		{0, 0x0000},
+		{float32(math.Copysign(0, -1)), 0x8000},
+		{1, 0x3C00},
+		{-2, 0xC000},
+		{0.5, 0x3800},
+		{1 + 3.0/4096, 0x3C01},                   // rounds up to 1 + 2^-10
+		{2047.9, 0x6800},                         // round carries into exponent: 2048
+		{65504, 0x7BFF},                          // max half
+		{65520, 0x7C00},                          // rounds above max: inf
+		{1e6, 0x7C00},                            // overflow: inf
+		{-1e6, 0xFC00},                           // overflow: -inf
+		{inf32, 0x7C00},                          // inf
+		{-inf32, 0xFC00},                         // -inf
+		{nan32, 0x7E00},                          // nan
+		{float32(math.Ldexp(1, -14)), 0x0400},    // min normal
+		{float32(math.Ldexp(1023, -24)), 0x03FF}, // max subnormal
+		{float32(math.Ldexp(1, -24)), 0x0001},    // min subnormal
+		{float32(math.Ldexp(3, -26)), 0x0001},    // rounds up to min subnormal
+		{float32(math.Ldexp(1, -26)), 0x0000},    // underflow: zero
+		{-float32(math.Ldexp(1, -26)), 0x8000},   // underflow: -zero
+	}
+	for _, tt := range tests {
+		if h := Float32To16(tt.f); h != tt.h {
+			t.Errorf("Float32To16(%g): %#04x != %#04x", tt.f, h, tt.h)
+		}
+	}
+}
+
+func TestFloat16To32(t *testing.T) {
+	tests := []struct {
+		h uint16
+		f float32
+	}{
+		{0x0000, 0},
+		{0x3C00, 1},
+		{0xC000, -2},
+		{0x3555, 0.333251953125},
+		{0x7BFF, 65504},
+		{0x0400, float32(math.Ldexp(1, -14))},
+		{0x03FF, float32(math.Ldexp(1023, -24))},
+		{0x0001, float32(math.Ldexp(1, -24))},
+		{0x7C00, inf32},
+		{0xFC00, -inf32},
+	}
+	for _, tt := range tests {
+		if f := Float16To32(tt.h); f != tt.f {
+			t.Errorf("Float16To32(%#04x): %g != %g", tt.h, f, tt.f)
+		}
+	}
+	if f := Float16To32(0x8000); f != 0 || !math.Signbit(float64(f)) {
+		t.Errorf("Float16To32(0x8000): %g != -0", f)
+	}
+	for _, h := range []uint16{0x7E00, 0x7C01, 0xFE00} {
+		if f := Float16To32(h); f == f {
+			t.Errorf("Float16To32(%#04x): %g != NaN", h, f)
+		}
+	}
+}
+
+// TestFloat16RoundTrip checks that every half value converts to float32
+// and back exactly, with NaN values staying NaN
+func TestFloat16RoundTrip(t *testing.T) {
+	for i := 0; i <= 0xFFFF; i++ {
+		h := uint16(i)
+		f := Float16To32(h)
+		r := Float32To16(f)
+		if h&0x7C00 == 0x7C00 && h&0x3FF != 0 { // nan
+			if r&0x7C00 != 0x7C00 || r&0x3FF == 0 || r&0x8000 != h&0x8000 {
+				t.Errorf("round trip nan %#04x: %#04x", h, r)
+			}
+			continue
+		}
+		if r != h {
+			t.Errorf("round trip %#04x: %g -> %#04x", h, f, r)
+		}
+	}
+}
+
+func TestLayDataBlock(t *testing.T) {
+	blks := [][]float32{
+		{0, 1, 0.5, -1},
+		{0.3, 0.3, 0.3, 0.3},         // constant
+		{nan32, 2, 4, nan32},         // nan
+		{nan32, nan32, nan32, nan32}, // all nan
+		{-1e-3, 1e3, 65504, 7},
+	}
+	for _, bits := range []int{32, 16, 8} {
+		ld := &LayData{LayName: "Input", NUnits: 4}
+		ld.Alloc(bits, len(blks)*ld.NUnits)
+		if ld.Bits != bits || ld.Len() != len(blks)*ld.NUnits {
+			t.Errorf("bits: %d Alloc: Bits: %d Len: %d", bits, ld.Bits, ld.Len())
+		}
+		for bi, vals := range blks {
+			ld.SetBlock(bi, vals)
+		}
+		var bvals []float32
+		for bi, vals := range blks {
+			var mn, mx float32
+			if bits == 8 {
+				mn, mx = ld.Min8[bi], ld.Max8[bi]
+			}
+			bvals = ld.Block(bi, bvals)
+			for i, v := range vals {
+				got := ld.Val(bi*ld.NUnits + i)
+				if bvals[i] != got && !(got != got && bvals[i] != bvals[i]) {
+					t.Errorf("bits: %d blk: %d unit: %d Block: %g != Val: %g", bits, bi, i, bvals[i], got)
+				}
+				if v != v {
+					if got == got {
+						t.Errorf("bits: %d blk: %d unit: %d: %g != NaN", bits, bi, i, got)
+					}
+					continue
+				}
+				var tol float32
+				switch bits {
+				case 16:
+					tol = float32(math.Abs(float64(v))) / 2048
+				case 8:
+					tol = (mx - mn) / 254 / 2
+				}
+				if d := got - v; d > tol || d < -tol {
+					t.Errorf("bits: %d blk: %d unit: %d: %g != %g (tol %g)", bits, bi, i, got, v, tol)
+				}
+			}
+		}
+	}
+	ld := &LayData{NUnits: 4}
+	ld.Alloc(8, 2*ld.NUnits)
+	ld.SetBlock(1, blks[1])
+	if ld.Min8[1] != 0.3 || ld.Max8[1] != 0.3 || ld.Val(5) != 0.3 {
+		t.Errorf("8 bit constant block: min: %g max: %g val: %g", ld.Min8[1], ld.Max8[1], ld.Val(5))
+	}
+	ld.SetBlock(0, blks[3])
+	if ld.Min8[0] != 0 || ld.Max8[0] != 0 {
+		t.Errorf("8 bit all nan block: min: %g max: %g", ld.Min8[0], ld.Max8[0])
+	}
+	ld.SetBlock(0, blks[2])
+	if ld.Data8[0] != nan8 || ld.Val(1) != 2 || ld.Val(2) != 4 {
+		t.Errorf("8 bit nan block: data: %v vals: %g %g", ld.Data8[:4], ld.Val(1), ld.Val(2))
+	}
+}
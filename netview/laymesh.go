@@ -7,6 +7,7 @@ package netview
 import (
 	"github.com/emer/emergent/emer"
 	"github.com/emer/etable/etensor"
+	"github.com/goki/gi/gi"
 	"github.com/goki/gi/gi3d"
 	"github.com/goki/gi/mat32"
 	"github.com/goki/ki/kit"
@@ -20,22 +21,43 @@ import (
 type LayMesh struct {
 	gi3d.MeshBase
 	Lay   emer.Layer    `desc:"layer that we render"`
+	Var   string        `desc:"variable that we render -- empty = the main NetView Var"`
 	Shape etensor.Shape `desc:"current shape that has been constructed -- if same, just update"`
 	View  *NetView      `desc:"netview that we're in"`
+	Slice *LaySlice     `desc:"if set, only this cross-section of the pools of a 4D layer is rendered -- see NetView.Slice"`
 }
 
 var KiT_LayMesh = kit.Types.AddType(&LayMesh{}, nil)
 
 // AddNewLayMesh adds LayMesh mesh to given scene for given layer
 func AddNewLayMesh(sc *gi3d.Scene, nv *NetView, lay emer.Layer) *LayMesh {
+	return AddNewLayMeshVar(sc, nv, lay, "")
+}
+
+// AddNewLayMeshVar adds LayMesh mesh to given scene for given layer,
+// displaying given variable (empty = main NetView Var), named via LayMeshName
+func AddNewLayMeshVar(sc *gi3d.Scene, nv *NetView, lay emer.Layer, vnm string) *LayMesh {
 	lm := &LayMesh{}
 	lm.View = nv
 	lm.Lay = lay
-	lm.Nm = lay.Name()
+	lm.Var = vnm
+	lm.Nm = LayMeshName(lay, vnm)
 	sc.AddMesh(lm)
 	return lm
 }
 
+// UnitVal returns the raw value, scaled value, and color representation
+// for given unit in our layer, for our variable at the current view record
+func (lm *LayMesh) UnitVal(idx []int) (raw, scaled float32, clr gi.Color) {
+	if lm.Slice != nil {
+		idx = lm.Slice.LayIdx(idx)
+	}
+	if lm.Var == "" {
+		return lm.View.UnitVal(lm.Lay, idx)
+	}
+	return lm.View.UnitValVar(lm.Lay, lm.Var, lm.Lay.Shape().Offset(idx), lm.View.RecNo)
+}
+
 func (lm *LayMesh) Make(sc *gi3d.Scene) {
 	if lm.Lay == nil {
 		lm.Shape.SetShape(nil, nil, nil)
@@ -44,6 +66,11 @@ func (lm *LayMesh) Make(sc *gi3d.Scene) {
 	shp := lm.Lay.Shape()
 	lm.Reset()
 	lm.Shape.CopyShape(shp)
+	if lm.Slice != nil {
+		if _, npz, _, npx, ok := lm.Slice.Range(lm.Lay); ok {
+			lm.Shape.SetShape([]int{npz, npx, shp.Dim(2), shp.Dim(3)}, nil, nil)
+		}
+	}
 
 	if lm.Shape.NumDims() == 0 {
 		return // nothing
@@ -106,7 +133,7 @@ func (lm *LayMesh) Make2D(init bool) {
 			poff := pidx * vtxSz * 5
 			ioff := pidx * idxSz * 5
 			x0 := uo + float32(xi)
-			_, scaled, clr := lm.View.UnitVal(lm.Lay, []int{zi, xi})
+			_, scaled, clr := lm.UnitVal([]int{zi, xi})
 			ht := 0.5 * mat32.Abs(scaled)
 			if ht < MinUnitHeight {
 				ht = MinUnitHeight
@@ -178,7 +205,7 @@ func (lm *LayMesh) Make4D(init bool) {
 					poff := pidx * vtxSz * 5
 					ioff := pidx * idxSz * 5
 					x0 := xp0 + xsc*(uo+float32(xui))
-					_, scaled, clr := lm.View.UnitVal(lm.Lay, []int{zpi, xpi, zui, xui})
+					_, scaled, clr := lm.UnitVal([]int{zpi, xpi, zui, xui})
 					ht := 0.5 * mat32.Abs(scaled)
 					if ht < MinUnitHeight {
 						ht = MinUnitHeight
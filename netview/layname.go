@@ -29,6 +29,11 @@ func (ln *LayName) ConnectEvents3D(sc *gi3d.Scene) {
 			return
 		}
 		nv := ln.NetView
+		if me.Button == mouse.Right {
+			nv.LabelMenu(ln.Text, me.Where.X, me.Where.Y)
+			me.SetProcessed()
+			return
+		}
 		lay := nv.Net.LayerByName(ln.Text)
 		if lay != nil {
 			giv.StructViewDialog(nv.Viewport, lay, giv.DlgOpts{Title: ln.Text}, nil, nil)
@@ -20,11 +20,20 @@ import (
 type LayObj struct {
 	gi3d.Object
 	LayName string   `desc:"name of the layer we represent"`
+	Var     string   `desc:"variable that we display -- empty = the main NetView Var"`
 	NetView *NetView `copy:"-" json:"-" xml:"-" view:"-" desc:"our netview"`
 }
 
 var KiT_LayObj = kit.Types.AddType(&LayObj{}, nil)
 
+// VarName returns the name of the variable that we display
+func (lo *LayObj) VarName() string {
+	if lo.Var == "" {
+		return lo.NetView.Var
+	}
+	return lo.Var
+}
+
 func (lo *LayObj) ConnectEvents3D(sc *gi3d.Scene) {
 	lo.ConnectEvent(sc.Win, oswin.MouseEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d interface{}) {
 		me := d.(*mouse.Event)
@@ -43,34 +52,74 @@ func (lo *LayObj) ConnectEvents3D(sc *gi3d.Scene) {
 		if !ok || pt.Z > 0 { // Z > 0 means clicked "in front" of plane -- where labels are
 			return
 		}
+		nv := lo.NetView
+		if nv.Params.Raster {
+			lay := nv.Net.LayerByName(lo.LayName)
+			if lay == nil {
+				return
+			}
+			ui, _, ok := nv.RasterUnitRec(lay, pt)
+			if !ok {
+				return
+			}
+			if nv.SynInspectOn(lo.LayName) {
+				nv.InspectSynapse(lo.LayName, ui)
+				me.SetProcessed()
+				return
+			}
+			nv.Data.PrjnUnIdx = ui
+			nv.Data.PrjnLay = lo.LayName
+			if nv.Params.Inspector {
+				nv.InspectUnit(lo.LayName, ui)
+			}
+			if nv.Params.TimePlot {
+				nv.PlotUnitTime(lo.LayName, ui)
+			}
+			nv.Record("") // requires new update
+			nv.Update()
+			me.SetProcessed()
+			return
+		}
 		lx := int(pt.X)
 		ly := -int(pt.Z)
 		// fmt.Printf("selected unit: %v, %v\n", lx, ly)
 		if lx < 0 || ly < 0 {
 			return
 		}
-		nv := lo.NetView
 		lay := nv.Net.LayerByName(lo.LayName)
 		if lay == nil {
 			return
 		}
 		lshp := lay.Shape()
+		uidx := 0
 		if lay.Is2D() {
 			idx := []int{ly, lx}
 			if !lshp.IdxIsValid(idx) {
 				return
 			}
-			nv.Data.PrjnUnIdx = lshp.Offset(idx)
+			uidx = lshp.Offset(idx)
 		} else if lay.Is4D() {
 			idx, ok := lay.Idx4DFrom2D(lx, ly)
 			if !ok {
 				return
 			}
-			nv.Data.PrjnUnIdx = lshp.Offset(idx)
+			uidx = lshp.Offset(idx)
 		} else {
 			return // not supported
 		}
+		if nv.SynInspectOn(lo.LayName) {
+			nv.InspectSynapse(lo.LayName, uidx)
+			me.SetProcessed()
+			return
+		}
+		nv.Data.PrjnUnIdx = uidx
 		nv.Data.PrjnLay = lo.LayName
+		if nv.Params.Inspector {
+			nv.InspectUnit(lo.LayName, nv.Data.PrjnUnIdx)
+		}
+		if nv.Params.TimePlot {
+			nv.PlotUnitTime(lo.LayName, nv.Data.PrjnUnIdx)
+		}
 		nv.Record("") // requires new update
 		nv.Update()
 		me.SetProcessed()
@@ -91,13 +140,28 @@ func (lo *LayObj) ConnectEvents3D(sc *gi3d.Scene) {
 		if !ok {
 			return
 		}
+		nv := lo.NetView
+		if nv.Params.Raster {
+			lay := nv.Net.LayerByName(lo.LayName)
+			if lay == nil {
+				return
+			}
+			ui, recno, ok := nv.RasterUnitRec(lay, pt)
+			if !ok {
+				return
+			}
+			val, _, _ := nv.UnitValVar(lay, lo.VarName(), ui, recno)
+			sval := fmt.Sprintf("unit: %d rec: %d =%g\n", ui, recno, val)
+			pos := me.Where
+			gi.PopupTooltip(sval, pos.X, pos.Y, sc.Win.Viewport, lo.LayName)
+			return
+		}
 		lx := int(pt.X)
 		ly := -int(pt.Z)
 		// fmt.Printf("selected unit: %v, %v\n", lx, ly)
 		if lx < 0 || ly < 0 {
 			return
 		}
-		nv := lo.NetView
 		lay := nv.Net.LayerByName(lo.LayName)
 		if lay == nil {
 			return
@@ -109,14 +173,14 @@ func (lo *LayObj) ConnectEvents3D(sc *gi3d.Scene) {
 			if !lshp.IdxIsValid(idx) {
 				return
 			}
-			val, _, _ := nv.UnitVal(lay, idx)
+			val, _, _ := nv.UnitValVar(lay, lo.VarName(), lshp.Offset(idx), nv.RecNo)
 			sval = fmt.Sprintf("[%d,%d]=%g\n", lx, ly, val)
 		} else if lay.Is4D() {
 			idx, ok := lay.Idx4DFrom2D(lx, ly)
 			if !ok {
 				return
 			}
-			val, _, _ := nv.UnitVal(lay, idx)
+			val, _, _ := nv.UnitValVar(lay, lo.VarName(), lshp.Offset(idx), nv.RecNo)
 			sval = fmt.Sprintf("[%d,%d][%d,%d]=%g\n", idx[1], idx[0], idx[3], idx[2], val)
 		} else {
 			return // not supported
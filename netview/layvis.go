@@ -0,0 +1,95 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/giv"
+	"github.com/goki/ki/ki"
+)
+
+// LayVisible returns true if given layer is visible in the view:
+// it must not be in HideLays, and must match Params.LayFilter if set.
+func (nv *NetView) LayVisible(laynm string) bool {
+	if nv.HideLays[laynm] {
+		return false
+	}
+	flt := strings.Fields(nv.Params.LayFilter)
+	if len(flt) == 0 {
+		return true
+	}
+	for _, pat := range flt {
+		if ok, _ := filepath.Match(pat, laynm); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// NVisLayers returns the number of visible layers (see LayVisible)
+func (nv *NetView) NVisLayers() int {
+	if nv.Net == nil {
+		return 0
+	}
+	n := 0
+	nlay := nv.Net.NLayers()
+	for li := 0; li < nlay; li++ {
+		if nv.LayVisible(nv.Net.Layer(li).Name()) {
+			n++
+		}
+	}
+	return n
+}
+
+// SetLayVisible sets whether given layer is visible in the view.
+// Does not update the display -- call Config and Update after setting.
+func (nv *NetView) SetLayVisible(laynm string, vis bool) {
+	if vis {
+		delete(nv.HideLays, laynm)
+		return
+	}
+	if nv.HideLays == nil {
+		nv.HideLays = make(map[string]bool)
+	}
+	nv.HideLays[laynm] = true
+}
+
+// ShowAllLays makes all layers visible (clears HideLays and Params.LayFilter)
+// and updates the display.
+func (nv *NetView) ShowAllLays() {
+	nv.HideLays = nil
+	nv.Params.LayFilter = ""
+	nv.Config()
+	nv.Update()
+}
+
+// LayersDialog opens a dialog with a checkbox for each layer to
+// select which layers are visible.
+func (nv *NetView) LayersDialog() {
+	if nv.Net == nil {
+		return
+	}
+	vis := make(map[string]bool)
+	nlay := nv.Net.NLayers()
+	for li := 0; li < nlay; li++ {
+		nm := nv.Net.Layer(li).Name()
+		vis[nm] = !nv.HideLays[nm]
+	}
+	giv.MapViewDialog(nv.Viewport, &vis, giv.DlgOpts{Title: nv.Nm + " Visible Layers", Prompt: "uncheck layers to hide them"}, nv.This(),
+		func(recv, send ki.Ki, sig int64, data interface{}) {
+			if sig != int64(gi.DialogAccepted) {
+				return
+			}
+			nvv := recv.Embed(KiT_NetView).(*NetView)
+			for nm, v := range vis {
+				nvv.SetLayVisible(nm, v)
+			}
+			nvv.Config()
+			nvv.Update()
+		})
+}
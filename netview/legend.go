@@ -0,0 +1,191 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"fmt"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/gi3d"
+	"github.com/goki/gi/mat32"
+	"github.com/goki/ki/kit"
+)
+
+// LegendMeshName is the name of the mesh used for the colorbar legend
+const LegendMeshName = "netview-legend"
+
+// LegendSegs is the number of color segments in the colorbar legend
+var LegendSegs = 64
+
+// LegendSize is the width and height of the colorbar legend,
+// in normalized view units (entire network view is unit sized)
+var LegendSize = mat32.Vec2{0.03, 0.5}
+
+// LegendMesh is a gi3d.Mesh that renders the colorbar of the legend for the
+// current variable (see Params.Legend), as a vertical stack of LegendSegs
+// upright quads from the minimum (bottom) to the maximum (top) of the display
+// range, colored with the color map for the current variable.
+type LegendMesh struct {
+	gi3d.MeshBase
+	View *NetView `desc:"netview that we're in"`
+}
+
+var KiT_LegendMesh = kit.Types.AddType(&LegendMesh{}, nil)
+
+// AddNewLegendMesh adds LegendMesh mesh to given scene
+func AddNewLegendMesh(sc *gi3d.Scene, nv *NetView) *LegendMesh {
+	lm := &LegendMesh{}
+	lm.View = nv
+	lm.Nm = LegendMeshName
+	sc.AddMesh(lm)
+	return lm
+}
+
+func (lm *LegendMesh) Make(sc *gi3d.Scene) {
+	lm.Reset()
+	lm.MakeBar(true)
+}
+
+func (lm *LegendMesh) Update(sc *gi3d.Scene) {
+	lm.MakeBar(false)
+	lm.SetColorData(sc)
+	lm.Activate(sc)
+	lm.TransferVectors()
+}
+
+// MakeBar constructs the colorbar geometry (if init), and sets the colors
+func (lm *LegendMesh) MakeBar(init bool) {
+	lm.Dynamic = true
+	nseg := LegendSegs
+	if init {
+		lm.Alloc(4*nseg, 6*nseg, true)
+	}
+	nv := lm.View
+	cmap := nv.VarColorMap(nv.VarParams[nv.Var])
+	w := LegendSize.X
+	h := LegendSize.Y / float32(nseg)
+	for si := 0; si < nseg; si++ {
+		clr := gi.Color{128, 128, 128, 255}
+		if cmap != nil {
+			clr = cmap.Map((float64(si) + 0.5) / float64(nseg))
+		}
+		r, g, b, _ := clr.ToNPFloat32()
+		vi := si * 4
+		y0 := float32(si) * h
+		for pi := 0; pi < 4; pi++ {
+			lm.Color.Set((vi+pi)*4, r, g, b, 1)
+		}
+		if init {
+			lm.Vtx.Set(vi*3, 0, y0, 0, w, y0, 0, w, y0+h, 0, 0, y0+h, 0)
+			lm.Norm.Set(vi*3, 0, 0, 1, 0, 0, 1, 0, 0, 1, 0, 0, 1)
+			lm.Tex.Set(vi*2, 0, 0, 1, 0, 1, 1, 0, 1)
+			uv := uint32(vi)
+			lm.Idx.Set(si*6, uv, uv+1, uv+2, uv, uv+2, uv+3)
+		}
+	}
+	lm.BBox.SetBounds(mat32.Vec3{0, 0, 0}, mat32.Vec3{w, LegendSize.Y, 0})
+}
+
+// LegendTicks returns the values and labels for the ticks of the colorbar
+// legend, evenly spaced across the display range of the current variable
+// (Params.LegendTicks, including min and max)
+func (nv *NetView) LegendTicks() ([]float32, []string) {
+	vp, ok := nv.VarParams[nv.Var]
+	if !ok {
+		return nil, nil
+	}
+	nt := nv.Params.LegendTicks
+	if nt < 2 {
+		nt = 2
+	}
+	mn := vp.Range.Min
+	mx := vp.Range.Max
+	vals := make([]float32, nt)
+	lbls := make([]string, nt)
+	for ti := range vals {
+		v := mn + (mx-mn)*float32(ti)/float32(nt-1)
+		vals[ti] = v
+		lbls[ti] = fmt.Sprintf("%.3g", v)
+	}
+	return vals, lbls
+}
+
+// LegendConfig configures the "Legend" group holding the colorbar legend for
+// the current variable, shown to the left of the network if Params.Legend is on.
+func (nv *NetView) LegendConfig() {
+	vs := nv.Scene()
+	lgGp, err := vs.ChildByNameTry("Legend", 1)
+	if err != nil {
+		lgGp = gi3d.AddNewGroup(vs, vs, "Legend")
+	}
+	lgConfig := kit.TypeAndNameList{}
+	nt := 0
+	if nv.Params.Legend && !nv.Params.Raster {
+		if vs.MeshByName(LegendMeshName) == nil {
+			AddNewLegendMesh(vs, nv)
+		}
+		lgConfig.Add(gi3d.KiT_Object, "bar")
+		lgConfig.Add(gi3d.KiT_Text2D, "var")
+		nt = nv.Params.LegendTicks
+		if nt < 2 {
+			nt = 2
+		}
+		for ti := 0; ti < nt; ti++ {
+			lgConfig.Add(gi3d.KiT_Text2D, fmt.Sprintf("tick%d", ti))
+		}
+	}
+	lgGp.ConfigChildren(lgConfig, false)
+	if nt == 0 {
+		return
+	}
+	lgGp.Pose.Pos.Set(-0.5-2*LegendSize.X, 0, 0)
+	bo := lgGp.Child(0).(*gi3d.Object)
+	bo.SetMeshName(vs, LegendMeshName)
+	bo.Mat.Color.SetUInt8(255, 255, 255, 255)
+	bo.Mat.CullBack = false
+	bo.Mat.CullFront = false
+	bo.Pose.Pos.Set(0, 0, 0)
+	for ci := 1; ci < lgGp.NumChildren(); ci++ {
+		txt := lgGp.Child(ci).(*gi3d.Text2D)
+		txt.Defaults(vs)
+		txt.Pose.Scale = mat32.NewVec3Scalar(nv.Params.LayNmSize)
+		nv.SetTextColor(txt)
+		if ci == 1 {
+			txt.Pose.Pos.Set(0, LegendSize.Y+nv.Params.LayNmSize, 0)
+			txt.SetProp("text-align", gi.AlignLeft)
+			txt.SetProp("vertical-align", gi.AlignBottom)
+		} else {
+			txt.Pose.Pos.Set(-0.5*LegendSize.X, LegendSize.Y*float32(ci-2)/float32(nt-1), 0)
+			txt.SetProp("text-align", gi.AlignRight)
+			txt.SetProp("vertical-align", gi.AlignMiddle)
+		}
+	}
+	nv.LegendUpdate()
+}
+
+// LegendUpdate updates the variable name and tick labels of the colorbar
+// legend -- the colors are updated by the LegendMesh.  Called in Update.
+func (nv *NetView) LegendUpdate() {
+	if !nv.Params.Legend || nv.Params.Raster {
+		return
+	}
+	vs := nv.Scene()
+	lgGp, err := vs.ChildByNameTry("Legend", 1)
+	if err != nil || lgGp.NumChildren() < 2 {
+		return
+	}
+	_, lbls := nv.LegendTicks()
+	if len(lbls) != lgGp.NumChildren()-2 {
+		return
+	}
+	if vt := lgGp.Child(1).(*gi3d.Text2D); vt.Text != nv.Var {
+		vt.SetText(vs, nv.Var)
+	}
+	for ti, lb := range lbls {
+		if tt := lgGp.Child(ti + 2).(*gi3d.Text2D); tt.Text != lb {
+			tt.SetText(vs, lb)
+		}
+	}
+}
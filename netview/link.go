@@ -0,0 +1,114 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+// Link links given other NetView with this one, in both directions, so that
+// the camera, variable selection, and record navigation stay synchronized,
+// e.g., for side-by-side A/B comparison of two model variants.  Records are
+// matched by their structured counter values (see RecordCtrs) when available,
+// and otherwise by record number.  Any number of views can be linked together.
+func (nv *NetView) Link(onv *NetView) {
+	if onv == nil || onv == nv {
+		return
+	}
+	nv.linkAdd(onv)
+	onv.linkAdd(nv)
+}
+
+// Unlink removes the link between given other NetView and this one
+func (nv *NetView) Unlink(onv *NetView) {
+	nv.linkDel(onv)
+	if onv != nil {
+		onv.linkDel(nv)
+	}
+}
+
+// UnlinkAll removes all links to this NetView
+func (nv *NetView) UnlinkAll() {
+	for len(nv.Linked) > 0 {
+		nv.Unlink(nv.Linked[0])
+	}
+}
+
+func (nv *NetView) linkAdd(onv *NetView) {
+	for _, lv := range nv.Linked {
+		if lv == onv {
+			return
+		}
+	}
+	nv.Linked = append(nv.Linked, onv)
+}
+
+func (nv *NetView) linkDel(onv *NetView) {
+	for i, lv := range nv.Linked {
+		if lv == onv {
+			nv.Linked = append(nv.Linked[:i], nv.Linked[i+1:]...)
+			return
+		}
+	}
+}
+
+// SyncLinked updates all Linked views to show the same variable (if they
+// have it), record, and camera as this one.  Called automatically by Update.
+func (nv *NetView) SyncLinked() {
+	if nv.linkSync || len(nv.Linked) == 0 {
+		return
+	}
+	nv.linkSync = true
+	defer func() { nv.linkSync = false }()
+	nv.SyncCamera()
+	cvals := nv.Data.CtrValsRec(nv.RecNo)
+	for _, lv := range nv.Linked {
+		if lv.linkSync || !lv.HasLayers() {
+			continue
+		}
+		lv.linkSync = true
+		if lv.Var != nv.Var {
+			if _, ok := lv.VarParams[nv.Var]; ok {
+				lv.Var = nv.Var
+				lv.VarsUpdate()
+				lv.VarScaleUpdate(lv.Var)
+			}
+		}
+		switch {
+		case nv.RecNo < 0:
+			lv.RecNo = -1
+		case cvals != nil && lv.RecJump(cvals):
+		default:
+			lv.RecNo = nv.RecNo
+			if lv.RecNo >= lv.Data.Ring.Len {
+				lv.RecNo = lv.Data.Ring.Len - 1
+			}
+		}
+		lv.Update()
+		lv.linkSync = false
+	}
+}
+
+// CameraUpdated updates the display after the camera has changed,
+// and synchronizes the camera of any Linked views (see SyncCamera).
+func (nv *NetView) CameraUpdated() {
+	nv.Scene().UpdateSig()
+	nv.SyncCamera()
+}
+
+// SyncCamera sets the camera of all Linked views to be the same as this one
+func (nv *NetView) SyncCamera() {
+	if len(nv.Linked) == 0 {
+		return
+	}
+	cam := &nv.Scene().Camera
+	for _, lv := range nv.Linked {
+		if !lv.IsConfiged() {
+			continue
+		}
+		lvs := lv.Scene()
+		lvs.Camera.Pose = cam.Pose
+		lvs.Camera.Target = cam.Target
+		lvs.Camera.UpDir = cam.UpDir
+		lvs.Camera.Ortho = cam.Ortho
+		lvs.UpdateSig()
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/gi3d"
+	"github.com/goki/gi/mat32"
+	"github.com/goki/ki/kit"
+)
+
+// UnitMark marks a set of units in a layer with a colored marker floating
+// above each unit, and an optional label, which persist across updates
+// of the view -- e.g., to tag target units or cells of interest.
+// See NetView.MarkUnits.
+type UnitMark struct {
+	Lay   string   `desc:"name of the layer containing the units"`
+	Idxs  []int    `desc:"1D indexes of the units within the layer"`
+	Color gi.Color `desc:"color of the markers"`
+	Label string   `desc:"label shown next to the first marked unit -- also used to identify the mark for ClearMark"`
+}
+
+// MarkMeshName is the name of the mesh used for unit markers
+const MarkMeshName = "NetViewMark"
+
+// MarkSize is the size of the unit markers, relative to the unit size,
+// and MarkHeight is their height above the layer, in normalized view units
+// (entire network view is unit sized).
+var (
+	MarkSize   = float32(0.6)
+	MarkHeight = float32(0.05)
+)
+
+// MarkUnits adds a persistent marker of given color over each of the given
+// units (1D indexes) in given layer, with given label (may be empty), and
+// updates the display.  Returns the new mark, or nil if layer not found.
+func (nv *NetView) MarkUnits(laynm string, idxs []int, clr gi.Color, label string) *UnitMark {
+	if nv.Net == nil || nv.Net.LayerByName(laynm) == nil {
+		log.Printf("NetView.MarkUnits: layer: %v not found\n", laynm)
+		return nil
+	}
+	um := &UnitMark{Lay: laynm, Idxs: append([]int(nil), idxs...), Color: clr, Label: label}
+	nv.Marks = append(nv.Marks, um)
+	nv.UpdateMarks()
+	return um
+}
+
+// ClearMark removes all marks with given label, and updates the display.
+// Returns true if any were removed.
+func (nv *NetView) ClearMark(label string) bool {
+	got := false
+	for i := len(nv.Marks) - 1; i >= 0; i-- {
+		if nv.Marks[i].Label == label {
+			nv.Marks = append(nv.Marks[:i], nv.Marks[i+1:]...)
+			got = true
+		}
+	}
+	if got {
+		nv.UpdateMarks()
+	}
+	return got
+}
+
+// ClearMarks removes all marks, and updates the display
+func (nv *NetView) ClearMarks() {
+	nv.Marks = nil
+	nv.UpdateMarks()
+}
+
+// UpdateMarks reconfigures the markers for the current Marks and
+// updates the display, if the view is configured.
+func (nv *NetView) UpdateMarks() {
+	if !nv.IsConfiged() || !nv.HasLayers() {
+		return
+	}
+	vs := nv.Scene()
+	updt := vs.UpdateStart()
+	nv.MarksConfig()
+	vs.UpdateEnd(updt)
+}
+
+// MarksConfig configures the "Marks" group holding the markers and labels
+// for Marks, positioned over the units in scene coordinates (see UnitPos).
+// Units in hidden layers are not marked.
+func (nv *NetView) MarksConfig() {
+	vs := nv.Scene()
+	mkGp, err := vs.ChildByNameTry("Marks", 1)
+	if err != nil {
+		mkGp = gi3d.AddNewGroup(vs, vs, "Marks")
+	}
+	if vs.MeshByName(MarkMeshName) == nil {
+		gi3d.AddNewBox(vs, MarkMeshName, 1, 1, 1)
+	}
+	mkConfig := kit.TypeAndNameList{}
+	for mi, um := range nv.Marks {
+		lay := nv.Net.LayerByName(um.Lay)
+		if lay == nil || !nv.LayVisible(um.Lay) {
+			continue
+		}
+		nu := lay.Shape().Len()
+		for _, ui := range um.Idxs {
+			if ui >= 0 && ui < nu {
+				mkConfig.Add(gi3d.KiT_Object, fmt.Sprintf("m%d_%s_%d", mi, um.Lay, ui))
+			}
+		}
+		if um.Label != "" {
+			mkConfig.Add(gi3d.KiT_Text2D, fmt.Sprintf("l%d_%s", mi, um.Lay))
+		}
+	}
+	mkGp.ConfigChildren(mkConfig, false)
+	ci := 0
+	for _, um := range nv.Marks {
+		lay := nv.Net.LayerByName(um.Lay)
+		if lay == nil || !nv.LayVisible(um.Lay) {
+			continue
+		}
+		shp := lay.Shape()
+		nu := shp.Len()
+		var lpos mat32.Vec3
+		first := true
+		msz := MarkSize * nv.Params.UnitSize
+		var msc mat32.Vec3
+		if lg := nv.LayerByName(um.Lay); lg != nil {
+			msc = lg.Pose.Scale.MulScalar(msz)
+			msc.Y = msc.X
+		}
+		for _, ui := range um.Idxs {
+			if ui < 0 || ui >= nu {
+				continue
+			}
+			pos := nv.UnitPos(lay, shp.Index(ui))
+			pos.Y += MarkHeight
+			mo := mkGp.Child(ci).(*gi3d.Object)
+			ci++
+			mo.SetMeshName(vs, MarkMeshName)
+			mo.Mat.Color = um.Color
+			mo.Pose.Pos = pos
+			mo.Pose.Scale = msc
+			if first {
+				lpos = pos
+				first = false
+			}
+		}
+		if um.Label != "" {
+			lb := mkGp.Child(ci).(*gi3d.Text2D)
+			ci++
+			lb.Defaults(vs)
+			lb.SetText(vs, um.Label)
+			lb.Pose.Pos = lpos.Add(mat32.Vec3{0, 2 * MarkHeight, 0})
+			lb.Pose.Scale = mat32.NewVec3Scalar(nv.Params.LayNmSize)
+			nv.SetTextColor(lb)
+			lb.SetProp("text-align", gi.AlignLeft)
+			lb.SetProp("vertical-align", gi.AlignBottom)
+		}
+	}
+}
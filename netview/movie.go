@@ -0,0 +1,83 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// Movie holds parameters and state for recording a movie of the NetView,
+// as a sequence of PNG frames written each time the view is updated.
+// The frames can be assembled into a movie using standard tools, e.g.:
+// ffmpeg -framerate 30 -i frame_%05d.png -pix_fmt yuv420p movie.mp4
+type Movie struct {
+	On        bool     `desc:"record movie frames -- each update of the view writes a new frame (subject to FrameSkip)"`
+	Dir       string   `desc:"directory to write frames into -- created if it does not exist"`
+	FrameSkip int      `min:"0" desc:"number of updates to skip between frames that are written -- 0 = write every update"`
+	Frame     int      `inactive:"+" desc:"number of the next frame to be written -- reset to 0 when recording is turned on"`
+	NUpdts    int      `inactive:"+" desc:"number of updates since last frame was written"`
+	NetView   *NetView `copy:"-" json:"-" xml:"-" view:"-" desc:"our netview"`
+}
+
+// Start starts recording frames into given directory, skipping given number
+// of updates between frames.  Frame numbering starts at 0.
+func (mv *Movie) Start(dir string, skip int) {
+	mv.Dir = dir
+	mv.FrameSkip = skip
+	mv.Frame = 0
+	mv.NUpdts = skip // write the first update
+	mv.On = true
+}
+
+// Stop stops recording frames
+func (mv *Movie) Stop() {
+	mv.On = false
+}
+
+// Update satisfies the gi.Updater interface, called after edits:
+// resets the frame counter when turned on.
+func (mv *Movie) Update() {
+	if mv.On && mv.Frame == 0 {
+		mv.NUpdts = mv.FrameSkip
+	}
+}
+
+// FrameFile returns the file name for given frame number
+func (mv *Movie) FrameFile(frame int) string {
+	return filepath.Join(mv.Dir, fmt.Sprintf("frame_%05d.png", frame))
+}
+
+// CaptureFrame is called from UpdateImpl when recording is on, and writes the next
+// frame, subject to FrameSkip.
+func (mv *Movie) CaptureFrame() {
+	if !mv.On || mv.NetView == nil {
+		return
+	}
+	if mv.NUpdts < mv.FrameSkip {
+		mv.NUpdts++
+		return
+	}
+	mv.NUpdts = 0
+	if mv.Dir != "" {
+		if err := os.MkdirAll(mv.Dir, 0755); err != nil {
+			log.Println(err)
+			mv.On = false
+			return
+		}
+	}
+	img, err := mv.NetView.renderImage()
+	if err != nil {
+		mv.On = false // don't keep trying
+		return
+	}
+	if savePNG(mv.FrameFile(mv.Frame), img) != nil {
+		mv.On = false
+		return
+	}
+	mv.Frame++
+}
@@ -13,17 +13,11 @@ import (
 	"github.com/emer/emergent/ringidx"
 )
 
-// LayData maintains a record of all the data for a given layer
-type LayData struct {
-	LayName string    `desc:"the layer name"`
-	NUnits  int       `desc:"cached number of units"`
-	Data    []float32 `desc:"the full data, Ring.Max * len(Vars) * NUnits in that order"`
-}
-
 // NetData maintains a record of all the network data that has been displayed
 // up to a given maximum number of records (updates), using efficient ring index logic
 // with no copying to store in fixed-sized buffers.
 type NetData struct {
+	Name      string              `desc:"name of this recording ring, e.g., for its timescale (Cycle, Trial) -- see NetView.AddRing"`
 	Net       emer.Network        `desc:"the network that we're viewing"`
 	PrjnLay   string              `desc:"name of the layer with unit for viewing projections (connection / synapse-level values)"`
 	PrjnUnIdx int                 `desc:"1D index of unit within PrjnLay for for viewing projections"`
@@ -36,6 +30,15 @@ type NetData struct {
 	MinVar    []float32           `desc:"min values for variable"`
 	MaxVar    []float32           `desc:"max values for variable"`
 	Counters  []string            `desc:"counter strings"`
+	CtrVals   []map[string]int    `desc:"structured counter values for each record (e.g., Run, Epoch, Trial, Cycle) as provided to RecordCtrs -- nil for records without them"`
+	PrjnVars  []string            `desc:"synapse variables (e.g., Wt, DWt) for which the mean absolute value across all synapses in each projection is recorded with each record, for the projection summary display (Params.PrjnStats) -- empty = none"`
+	PrjnNames []string            `desc:"labels of the projections for which PrjnVars stats are recorded, in PrjnList order"`
+	PrjnData  []float32           `desc:"the per-projection stats, Ring.Max * len(PrjnNames) * len(PrjnVars) in that order"`
+	Bookmarks []string            `desc:"bookmark name for each record, indexed by ring index like Counters -- empty if not bookmarked -- see Bookmark"`
+	Streamer  *Streamer           `json:"-" view:"-" desc:"if set, each new record is streamed to connected WebSocket clients -- see NewStreamer"`
+	Bits      int                 `def:"32" desc:"storage precision in bits per recorded value: 32 = full float32, 16 = half-precision float16, 8 = quantized within the range of each variable in each layer for each record -- lower precision allows proportionally more records (Ring.Max) in the same memory"`
+	tmp       []float32
+	synTmp    []float32
 }
 
 // Init initializes the main params and configures the data
@@ -55,6 +58,9 @@ func (nd *NetData) Config() {
 	if nd.Ring.Max == 0 {
 		nd.Ring.Max = 2
 	}
+	if nd.Bits != 16 && nd.Bits != 8 {
+		nd.Bits = 32
+	}
 	rmax := nd.Ring.Max
 	if nd.Ring.Len > rmax {
 		nd.Ring.Reset()
@@ -89,8 +95,8 @@ makeData:
 		ld.NUnits = lay.Shape().Len()
 		nu := ld.NUnits
 		ltot := vmax * nu
-		if len(ld.Data) != ltot {
-			ld.Data = make([]float32, ltot)
+		if ld.Len() != ltot || ld.Bits != nd.Bits {
+			ld.Alloc(nd.Bits, ltot)
 		}
 	}
 	if len(nd.MinPer) != vmax {
@@ -104,10 +110,25 @@ makeData:
 	if len(nd.Counters) != rmax {
 		nd.Counters = make([]string, rmax)
 	}
+	if len(nd.CtrVals) != rmax {
+		nd.CtrVals = make([]map[string]int, rmax)
+	}
+	if len(nd.Bookmarks) != rmax {
+		nd.Bookmarks = make([]string, rmax)
+	}
+	nd.PrjnStatsConfig()
 }
 
 // Record records the current full set of data from the network, and the given counters string
 func (nd *NetData) Record(ctrs string) {
+	nd.RecordCtrs(ctrs, nil)
+}
+
+// RecordCtrs records the current full set of data from the network, and the given
+// counters string, along with structured counter values (e.g., Run, Epoch, Trial, Cycle)
+// that can be used to find and filter records (see FindRecs, CtrFilter).
+// The vals map is copied.
+func (nd *NetData) RecordCtrs(ctrs string, vals map[string]int) {
 	nlay := nd.Net.NLayers()
 	if nlay == 0 {
 		return
@@ -118,6 +139,16 @@ func (nd *NetData) Record(ctrs string) {
 	lidx := nd.Ring.LastIdx()
 
 	nd.Counters[lidx] = ctrs
+	if vals != nil {
+		cv := make(map[string]int, len(vals))
+		for k, v := range vals {
+			cv[k] = v
+		}
+		nd.CtrVals[lidx] = cv
+	} else {
+		nd.CtrVals[lidx] = nil
+	}
+	nd.Bookmarks[lidx] = ""
 
 	prjnlay := nd.Net.LayerByName(nd.PrjnLay)
 
@@ -135,27 +166,53 @@ func (nd *NetData) Record(ctrs string) {
 		for vi, vnm := range nd.Vars {
 			mn := &nd.MinPer[mmidx+vi]
 			mx := &nd.MaxPer[mmidx+vi]
-			idx := lidx*nvu + vi*nu
-			dvals := ld.Data[idx : idx+nu]
-			if strings.HasPrefix(vnm, "r.") {
-				svar := vnm[2:]
-				lay.SendPrjnVals(&dvals, svar, prjnlay, nd.PrjnUnIdx)
-			} else if strings.HasPrefix(vnm, "s.") {
-				svar := vnm[2:]
-				lay.RecvPrjnVals(&dvals, svar, prjnlay, nd.PrjnUnIdx)
+			var dvals []float32
+			if nd.Bits == 32 {
+				idx := lidx*nvu + vi*nu
+				dvals = ld.Data[idx : idx+nu]
 			} else {
-				lay.UnitVals(&dvals, vnm)
-			}
-			for ui := range dvals {
-				vl := dvals[ui]
-				if !math32.IsNaN(vl) {
-					*mn = math32.Min(*mn, vl)
-					*mx = math32.Max(*mx, vl)
+				if cap(nd.tmp) < nu {
+					nd.tmp = make([]float32, nu)
 				}
+				dvals = nd.tmp[:nu]
+			}
+			nd.unitVals(lay, vnm, prjnlay, &dvals)
+			valsMinMax(dvals, mn, mx)
+			if nd.Bits != 32 {
+				ld.SetBlock(lidx*vlen+vi, dvals)
 			}
 		}
 	}
+	nd.RecordPrjnStats(lidx)
 	nd.UpdateVarRange()
+	if nd.Streamer != nil {
+		nd.Streamer.SendRec()
+	}
+}
+
+// unitVals gets the values of given variable for all the units in given layer
+// into dvals, including projection values (r. and s. variables) for the
+// unit selected for viewing projections in prjnlay
+func (nd *NetData) unitVals(lay emer.Layer, vnm string, prjnlay emer.Layer, dvals *[]float32) {
+	if strings.HasPrefix(vnm, "r.") {
+		svar := vnm[2:]
+		lay.SendPrjnVals(dvals, svar, prjnlay, nd.PrjnUnIdx)
+	} else if strings.HasPrefix(vnm, "s.") {
+		svar := vnm[2:]
+		lay.RecvPrjnVals(dvals, svar, prjnlay, nd.PrjnUnIdx)
+	} else {
+		lay.UnitVals(dvals, vnm)
+	}
+}
+
+// valsMinMax updates the min and max with the non-NaN values in dvals
+func valsMinMax(dvals []float32, mn, mx *float32) {
+	for _, vl := range dvals {
+		if !math32.IsNaN(vl) {
+			*mn = math32.Min(*mn, vl)
+			*mx = math32.Max(*mx, vl)
+		}
+	}
 }
 
 // UpdateVarRange updates the range for variables
@@ -191,6 +248,43 @@ func (nd *NetData) VarRange(vnm string) (float32, float32, bool) {
 	return nd.MinVar[vi], nd.MaxVar[vi], true
 }
 
+// VarRangeLays returns the min, max range for given variable across all records,
+// only for the layers where given function returns true.  This is more expensive
+// than VarRange, which is precomputed across all layers.
+// Returns false if not found or no data.
+func (nd *NetData) VarRangeLays(vnm string, incl func(laynm string) bool) (float32, float32, bool) {
+	if nd.Ring.Len == 0 {
+		return 0, 0, false
+	}
+	vi, ok := nd.VarIdxs[vnm]
+	if !ok {
+		return 0, 0, false
+	}
+	vlen := len(nd.Vars)
+	var mn float32 = math.MaxFloat32
+	var mx float32 = -math.MaxFloat32
+	got := false
+	for laynm, ld := range nd.LayData {
+		if !incl(laynm) {
+			continue
+		}
+		nu := ld.NUnits
+		nvu := vlen * nu
+		for ri := 0; ri < nd.Ring.Len; ri++ {
+			idx := nd.Ring.Idx(ri)*nvu + vi*nu
+			for ui := 0; ui < nu; ui++ {
+				vl := ld.Val(idx + ui)
+				if !math32.IsNaN(vl) {
+					mn = math32.Min(mn, vl)
+					mx = math32.Max(mx, vl)
+					got = true
+				}
+			}
+		}
+	}
+	return mn, mx, got
+}
+
 // RecIdx returns record index for given record number,
 // which is -1 for current (last) record, or in [0..Len-1] for prior records.
 func (nd *NetData) RecIdx(recno int) int {
@@ -211,6 +305,88 @@ func (nd *NetData) CounterRec(recno int) string {
 	return nd.Counters[ridx]
 }
 
+// CtrValsRec returns the structured counter values for given record,
+// which is -1 for current (last) record, or in [0..Len-1] for prior records.
+// Returns nil if none were recorded.
+func (nd *NetData) CtrValsRec(recno int) map[string]int {
+	if nd.Ring.Len == 0 || len(nd.CtrVals) != nd.Ring.Max {
+		return nil
+	}
+	ridx := nd.RecIdx(recno)
+	return nd.CtrVals[ridx]
+}
+
+// FindRecs returns the record numbers (in [0..Len-1], earliest first) of all
+// records whose structured counter values satisfy given function.
+// Records without counter values are passed a nil map.
+func (nd *NetData) FindRecs(match func(vals map[string]int) bool) []int {
+	var recs []int
+	for ri := 0; ri < nd.Ring.Len; ri++ {
+		if match(nd.CtrValsRec(ri)) {
+			recs = append(recs, ri)
+		}
+	}
+	return recs
+}
+
+// Bookmark sets a bookmark with given name on the current (last) record,
+// so it can be found later with BookmarkRec.  The bookmark is removed when
+// the record is overwritten in the ring.  An empty name removes any bookmark.
+func (nd *NetData) Bookmark(name string) {
+	if nd.Ring.Len == 0 || len(nd.Bookmarks) != nd.Ring.Max {
+		return
+	}
+	nd.Bookmarks[nd.Ring.LastIdx()] = name
+}
+
+// BookmarkRecs returns the names and record numbers (in [0..Len-1],
+// earliest first) of all bookmarked records.
+func (nd *NetData) BookmarkRecs() (names []string, recs []int) {
+	if len(nd.Bookmarks) != nd.Ring.Max {
+		return
+	}
+	for ri := 0; ri < nd.Ring.Len; ri++ {
+		if bm := nd.Bookmarks[nd.Ring.Idx(ri)]; bm != "" {
+			names = append(names, bm)
+			recs = append(recs, ri)
+		}
+	}
+	return
+}
+
+// BookmarkRec returns the record number (in [0..Len-1]) of the latest record
+// with given bookmark name.  Returns false if not found.
+func (nd *NetData) BookmarkRec(name string) (int, bool) {
+	if len(nd.Bookmarks) != nd.Ring.Max {
+		return 0, false
+	}
+	for ri := nd.Ring.Len - 1; ri >= 0; ri-- {
+		if nd.Bookmarks[nd.Ring.Idx(ri)] == name {
+			return ri, true
+		}
+	}
+	return 0, false
+}
+
+// UnitValRaw returns the raw value for given layer, variable name, unit index, and
+// record number, which is -1 for current (last) record, or in [0..Len-1] for prior
+// records, including NaN values.  Returns false if no such value was recorded.
+func (nd *NetData) UnitValRaw(laynm string, vnm string, uidx1d int, recno int) (float32, bool) {
+	if nd.Ring.Len == 0 {
+		return 0, false
+	}
+	vi, ok := nd.VarIdxs[vnm]
+	if !ok {
+		return 0, false
+	}
+	ld, ok := nd.LayData[laynm]
+	if !ok || uidx1d < 0 || uidx1d >= ld.NUnits {
+		return 0, false
+	}
+	nvu := len(nd.Vars) * ld.NUnits
+	return ld.Val(nd.RecIdx(recno)*nvu + vi*ld.NUnits + uidx1d), true
+}
+
 // UnitVal returns the value for given layer, variable name, unit index, and record number,
 // which is -1 for current (last) record, or in [0..Len-1] for prior records.
 // Returns false if value unavailable for any reason (including recorded as such as NaN).
@@ -231,7 +407,7 @@ func (nd *NetData) UnitVal(laynm string, vnm string, uidx1d int, recno int) (flo
 	nu := ld.NUnits
 	nvu := vlen * nu
 	idx := ridx*nvu + vi*nu + uidx1d
-	val := ld.Data[idx]
+	val := ld.Val(idx)
 	if math32.IsNaN(val) {
 		return 0, false
 	}
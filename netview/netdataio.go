@@ -0,0 +1,198 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/emer/emergent/ringidx"
+	"github.com/goki/gi/gi"
+)
+
+// netDataJSON is the file format for saving NetData.
+// Layer data is stored as little-endian float32 bytes (base64 in JSON),
+// which is compact and preserves NaN values that JSON cannot represent.
+type netDataJSON struct {
+	PrjnLay   string
+	PrjnUnIdx int
+	Vars      []string
+	Ring      ringidx.Idx
+	Layers    []layDataJSON
+	MinPer    []float32
+	MaxPer    []float32
+	Counters  []string
+	CtrVals   []map[string]int `json:",omitempty"`
+	Bookmarks []string         `json:",omitempty"`
+	PrjnVars  []string         `json:",omitempty"`
+	PrjnNames []string         `json:",omitempty"`
+	PrjnData  []float32        `json:",omitempty"`
+}
+
+type layDataJSON struct {
+	LayName string
+	NUnits  int
+	Data    []byte
+}
+
+// SaveJSON saves the full recorded history of network data (all variables, counters,
+// and ring state) to a JSON-formatted file, which can be reloaded with OpenJSON
+// and replayed in a NetView, without re-running the simulation.
+// If filename has .gz extension, then file is gzip compressed.
+func (nd *NetData) SaveJSON(filename gi.FileName) error {
+	fp, err := os.Create(string(filename))
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	defer fp.Close()
+	if filepath.Ext(string(filename)) == ".gz" {
+		gzw := gzip.NewWriter(fp)
+		defer gzw.Close()
+		return nd.WriteJSON(gzw)
+	}
+	return nd.WriteJSON(fp)
+}
+
+// OpenJSON opens full recorded history of network data from a JSON-formatted file,
+// as saved by SaveJSON.  The Net must already be set, and the layers in the file
+// must match those in the network.
+// If filename has .gz extension, then file is gzip uncompressed.
+func (nd *NetData) OpenJSON(filename gi.FileName) error {
+	fp, err := os.Open(string(filename))
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	defer fp.Close()
+	if filepath.Ext(string(filename)) == ".gz" {
+		gzr, err := gzip.NewReader(fp)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+		defer gzr.Close()
+		return nd.ReadJSON(gzr)
+	}
+	return nd.ReadJSON(fp)
+}
+
+// WriteJSON writes the full recorded history of network data to given writer
+// in JSON format
+func (nd *NetData) WriteJSON(w io.Writer) error {
+	df := &netDataJSON{PrjnLay: nd.PrjnLay, PrjnUnIdx: nd.PrjnUnIdx, Vars: nd.Vars, Ring: nd.Ring, MinPer: nd.MinPer, MaxPer: nd.MaxPer, Counters: nd.Counters, CtrVals: nd.CtrVals, Bookmarks: nd.Bookmarks}
+	if len(nd.PrjnVars) > 0 {
+		df.PrjnVars, df.PrjnNames = nd.PrjnVars, nd.PrjnNames
+		df.PrjnData = make([]float32, len(nd.PrjnData))
+		for i, v := range nd.PrjnData {
+			if math.IsNaN(float64(v)) {
+				v = 0 // JSON cannot represent NaN
+			}
+			df.PrjnData[i] = v
+		}
+	}
+	nlay := nd.Net.NLayers()
+	df.Layers = make([]layDataJSON, nlay)
+	for li := 0; li < nlay; li++ {
+		nm := nd.Net.Layer(li).Name()
+		ld := nd.LayData[nm]
+		ldf := &df.Layers[li]
+		ldf.LayName = nm
+		ldf.NUnits = ld.NUnits
+		n := ld.Len()
+		ldf.Data = make([]byte, 4*n)
+		for i := 0; i < n; i++ {
+			binary.LittleEndian.PutUint32(ldf.Data[4*i:], math.Float32bits(ld.Val(i)))
+		}
+	}
+	enc := json.NewEncoder(w)
+	err := enc.Encode(df)
+	if err != nil {
+		log.Println(err)
+	}
+	return err
+}
+
+// ReadJSON reads the full recorded history of network data from given reader
+// in JSON format.  The Net must already be set, and the layers must match
+// those in the network.  Values are stored at the current Bits precision.
+func (nd *NetData) ReadJSON(r io.Reader) error {
+	df := &netDataJSON{}
+	dec := json.NewDecoder(r)
+	err := dec.Decode(df)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	vlen := len(df.Vars)
+	rmax := df.Ring.Max
+	if nd.Net == nil || nd.Net.NLayers() != len(df.Layers) {
+		err = fmt.Errorf("NetData.ReadJSON: number of layers in file: %d does not match network", len(df.Layers))
+		log.Println(err)
+		return err
+	}
+	if len(df.MinPer) != rmax*vlen || len(df.MaxPer) != rmax*vlen || len(df.Counters) != rmax {
+		err = fmt.Errorf("NetData.ReadJSON: file data sizes are not consistent with Ring.Max: %d", rmax)
+		log.Println(err)
+		return err
+	}
+	lays := make(map[string]*LayData, len(df.Layers))
+	for li := range df.Layers {
+		ldf := &df.Layers[li]
+		lay := nd.Net.LayerByName(ldf.LayName)
+		if lay == nil || lay.Shape().Len() != ldf.NUnits || len(ldf.Data) != 4*rmax*vlen*ldf.NUnits {
+			err = fmt.Errorf("NetData.ReadJSON: layer: %s in file does not match network", ldf.LayName)
+			log.Println(err)
+			return err
+		}
+		ld := &LayData{LayName: ldf.LayName, NUnits: ldf.NUnits}
+		ld.Alloc(nd.Bits, len(ldf.Data)/4)
+		blk := make([]float32, ldf.NUnits)
+		for bi := 0; bi < rmax*vlen; bi++ {
+			for ui := range blk {
+				blk[ui] = math.Float32frombits(binary.LittleEndian.Uint32(ldf.Data[4*(bi*ldf.NUnits+ui):]))
+			}
+			ld.SetBlock(bi, blk)
+		}
+		lays[ldf.LayName] = ld
+	}
+	nd.PrjnLay = df.PrjnLay
+	nd.PrjnUnIdx = df.PrjnUnIdx
+	nd.Vars = df.Vars
+	nd.VarIdxs = make(map[string]int, vlen)
+	for vi, vn := range nd.Vars {
+		nd.VarIdxs[vn] = vi
+	}
+	nd.Ring = df.Ring
+	nd.LayData = lays
+	nd.MinPer = df.MinPer
+	nd.MaxPer = df.MaxPer
+	nd.Counters = df.Counters
+	nd.CtrVals = df.CtrVals
+	if len(nd.CtrVals) != rmax { // older files without structured counters
+		nd.CtrVals = make([]map[string]int, rmax)
+	}
+	nd.Bookmarks = df.Bookmarks
+	if len(nd.Bookmarks) != rmax {
+		nd.Bookmarks = make([]string, rmax)
+	}
+	if len(df.PrjnData) == rmax*len(df.PrjnNames)*len(df.PrjnVars) {
+		nd.PrjnVars, nd.PrjnNames, nd.PrjnData = df.PrjnVars, df.PrjnNames, df.PrjnData
+	} else {
+		nd.PrjnData = nil
+		nd.PrjnStatsConfig()
+	}
+	nd.MinVar = make([]float32, vlen)
+	nd.MaxVar = make([]float32, vlen)
+	nd.UpdateVarRange()
+	return nil
+}
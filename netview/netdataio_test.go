@@ -0,0 +1,254 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/emer/emergent/emer"
+	"github.com/emer/etable/etensor"
+)
+
+// testLay is a mock layer with only a name and shape
+type testLay struct {
+	emer.Layer
+	nm  string
+	shp etensor.Shape
+}
+
+func (ly *testLay) Name() string               { return ly.nm }
+func (ly *testLay) Shape() *etensor.Shape      { return &ly.shp }
+func (ly *testLay) NRecvPrjns() int            { return 0 }
+func (ly *testLay) RecvPrjn(idx int) emer.Prjn { return nil }
+
+// testNet is a mock network with only layers
+type testNet struct {
+	emer.Network
+	lays []*testLay
+}
+
+func (nt *testNet) NLayers() int             { return len(nt.lays) }
+func (nt *testNet) Layer(idx int) emer.Layer { return nt.lays[idx] }
+
+func (nt *testNet) LayerByName(name string) emer.Layer {
+	for _, ly := range nt.lays {
+		if ly.nm == name {
+			return ly
+		}
+	}
+	return nil
+}
+
+// newTestNet returns a network with layers of given names and 2D shapes
+func newTestNet(nms []string, shps [][]int) *testNet {
+	nt := &testNet{}
+	for i, nm := range nms {
+		ly := &testLay{nm: nm}
+		ly.shp.SetShape(shps[i], nil, nil)
+		nt.lays = append(nt.lays, ly)
+	}
+	return nt
+}
+
+// testNetData returns NetData with 2 of 3 records filled in for Input (2x2)
+// and Hidden (1x3) layers, at given storage precision, including NaN values
+func testNetData(bits int) *NetData {
+	nd := &NetData{Net: newTestNet([]string{"Input", "Hidden"}, [][]int{{2, 2}, {1, 3}}), Bits: bits}
+	nd.Vars = []string{"Act", "Ge"}
+	nd.Ring.Max = 3
+	nd.Ring.Add(2)
+	vlen := len(nd.Vars)
+	nblk := nd.Ring.Max * vlen
+	nd.LayData = map[string]*LayData{}
+	for _, ly := range nd.Net.(*testNet).lays {
+		ld := &LayData{LayName: ly.nm, NUnits: ly.shp.Len()}
+		ld.Alloc(bits, nblk*ld.NUnits)
+		vals := make([]float32, ld.NUnits)
+		for bi := 0; bi < nblk; bi++ {
+			for ui := range vals {
+				vals[ui] = float32(bi) - 0.37*float32(ui*ui)
+			}
+			if bi == 1 {
+				vals[0] = float32(math.NaN())
+			}
+			ld.SetBlock(bi, vals)
+		}
+		nd.LayData[ly.nm] = ld
+	}
+	nd.MinPer = make([]float32, nblk)
+	nd.MaxPer = make([]float32, nblk)
+	for i := range nd.MinPer {
+		nd.MinPer[i] = -float32(i)
+		nd.MaxPer[i] = float32(i)
+	}
+	nd.Counters = []string{"Trial: 0", "Trial: 1", ""}
+	nd.CtrVals = []map[string]int{{"Trial": 0}, {"Trial": 1}, nil}
+	nd.Bookmarks = []string{"", "second", ""}
+	return nd
+}
+
+// valTol returns the tolerance for values stored at given precision,
+// with a margin for float32 rounding
+func valTol(ld *LayData, idx int, v float32) float32 {
+	switch ld.Bits {
+	case 16:
+		return 1.01 * float32(math.Abs(float64(v))) / 2048
+	case 8:
+		blk := idx / ld.NUnits
+		return 1.01 * (ld.Max8[blk] - ld.Min8[blk]) / 254 / 2
+	}
+	return 0
+}
+
+func TestNetDataJSONRoundTrip(t *testing.T) {
+	for _, wbits := range []int{32, 16, 8} {
+		for _, rbits := range []int{32, 16, 8} {
+			nd := testNetData(wbits)
+			var b bytes.Buffer
+			if err := nd.WriteJSON(&b); err != nil {
+				t.Fatal(err)
+			}
+			rd := &NetData{Net: nd.Net, Bits: rbits}
+			if err := rd.ReadJSON(&b); err != nil {
+				t.Fatalf("write bits: %d read bits: %d: %v", wbits, rbits, err)
+			}
+			if rd.Ring != nd.Ring || rd.VarIdxs["Ge"] != 1 || len(rd.Vars) != 2 {
+				t.Errorf("write bits: %d read bits: %d: Ring: %v Vars: %v VarIdxs: %v", wbits, rbits, rd.Ring, rd.Vars, rd.VarIdxs)
+			}
+			if rd.Counters[1] != "Trial: 1" || rd.CtrVals[1]["Trial"] != 1 || rd.CtrVals[2] != nil || rd.Bookmarks[1] != "second" {
+				t.Errorf("write bits: %d read bits: %d: Counters: %v CtrVals: %v Bookmarks: %v", wbits, rbits, rd.Counters, rd.CtrVals, rd.Bookmarks)
+			}
+			if mn, mx, ok := rd.VarRange("Ge"); !ok || mn != -3 || mx != 3 {
+				t.Errorf("write bits: %d read bits: %d: VarRange: %g %g %v", wbits, rbits, mn, mx, ok)
+			}
+			for nm, ld := range nd.LayData {
+				rl := rd.LayData[nm]
+				if rl == nil || rl.Bits != rbits || rl.NUnits != ld.NUnits || rl.Len() != ld.Len() {
+					t.Errorf("write bits: %d read bits: %d: layer: %s: %+v", wbits, rbits, nm, rl)
+					continue
+				}
+				for i := 0; i < ld.Len(); i++ {
+					v, rv := ld.Val(i), rl.Val(i)
+					if v != v {
+						if rv == rv {
+							t.Errorf("write bits: %d read bits: %d: layer: %s idx: %d: %g != NaN", wbits, rbits, nm, i, rv)
+						}
+						continue
+					}
+					tol := valTol(rl, i, v)
+					if d := rv - v; d > tol || d < -tol {
+						t.Errorf("write bits: %d read bits: %d: layer: %s idx: %d: %g != %g", wbits, rbits, nm, i, rv, v)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestNetDataJSONPrjnData(t *testing.T) {
+	nd := testNetData(32)
+	nd.PrjnVars = []string{"Wt"}
+	nd.PrjnNames = []string{"InputToHidden"}
+	nd.PrjnData = []float32{0.5, float32(math.NaN()), 0.25}
+	var b bytes.Buffer
+	if err := nd.WriteJSON(&b); err != nil {
+		t.Fatal(err)
+	}
+	rd := &NetData{Net: nd.Net}
+	if err := rd.ReadJSON(&b); err != nil {
+		t.Fatal(err)
+	}
+	if len(rd.PrjnData) != 3 || rd.PrjnData[0] != 0.5 || rd.PrjnData[1] != 0 || rd.PrjnData[2] != 0.25 || rd.PrjnNames[0] != "InputToHidden" {
+		t.Errorf("PrjnData: %v PrjnNames: %v", rd.PrjnData, rd.PrjnNames)
+	}
+}
+
+// writeJSONMap writes given NetData and returns the JSON as a generic map,
+// for editing to test older or invalid files
+func writeJSONMap(t *testing.T, nd *NetData) map[string]interface{} {
+	var b bytes.Buffer
+	if err := nd.WriteJSON(&b); err != nil {
+		t.Fatal(err)
+	}
+	df := map[string]interface{}{}
+	if err := json.Unmarshal(b.Bytes(), &df); err != nil {
+		t.Fatal(err)
+	}
+	return df
+}
+
+// readJSONMap reads given generic JSON map into rd
+func readJSONMap(t *testing.T, rd *NetData, df map[string]interface{}) error {
+	b, err := json.Marshal(df)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rd.ReadJSON(bytes.NewReader(b))
+}
+
+func TestNetDataJSONOlder(t *testing.T) {
+	nd := testNetData(32)
+	nd.PrjnVars = []string{"Wt"}
+	nd.PrjnNames = []string{"InputToHidden"}
+	nd.PrjnData = []float32{0.5, 0.5, 0.5}
+	df := writeJSONMap(t, nd)
+	for _, k := range []string{"CtrVals", "Bookmarks", "PrjnVars", "PrjnNames", "PrjnData"} {
+		delete(df, k)
+	}
+	rd := &NetData{Net: nd.Net}
+	if err := readJSONMap(t, rd, df); err != nil {
+		t.Fatal(err)
+	}
+	if len(rd.CtrVals) != 3 || rd.CtrVals[1] != nil || len(rd.Bookmarks) != 3 || rd.Bookmarks[1] != "" {
+		t.Errorf("older file: CtrVals: %v Bookmarks: %v", rd.CtrVals, rd.Bookmarks)
+	}
+	if rd.PrjnData != nil || rd.PrjnNames != nil {
+		t.Errorf("older file: PrjnNames: %v PrjnData: %v", rd.PrjnNames, rd.PrjnData)
+	}
+	if rd.CounterRec(1) != "Trial: 1" || rd.LayData["Hidden"].Val(6) != 2 {
+		t.Errorf("older file: counters: %q val: %g", rd.CounterRec(1), rd.LayData["Hidden"].Val(6))
+	}
+}
+
+func TestNetDataJSONInvalid(t *testing.T) {
+	nd := testNetData(32)
+	tests := []struct {
+		name string
+		net  emer.Network
+		edit func(df map[string]interface{})
+	}{
+		{"no network", nil, nil},
+		{"fewer layers", newTestNet([]string{"Input"}, [][]int{{2, 2}}), nil},
+		{"layer name", newTestNet([]string{"Input", "Output"}, [][]int{{2, 2}, {1, 3}}), nil},
+		{"layer size", newTestNet([]string{"Input", "Hidden"}, [][]int{{2, 2}, {1, 4}}), nil},
+		{"counters", nd.Net, func(df map[string]interface{}) { df["Counters"] = []string{"Trial: 0"} }},
+		{"min per", nd.Net, func(df map[string]interface{}) { df["MinPer"] = []float32{0} }},
+		{"max per", nd.Net, func(df map[string]interface{}) { df["MaxPer"] = []float32{0} }},
+		{"ring max", nd.Net, func(df map[string]interface{}) { df["Ring"].(map[string]interface{})["Max"] = 4 }},
+		{"layer data", nd.Net, func(df map[string]interface{}) {
+			df["Layers"].([]interface{})[1].(map[string]interface{})["Data"] = ""
+		}},
+	}
+	for _, tt := range tests {
+		df := writeJSONMap(t, nd)
+		if tt.edit != nil {
+			tt.edit(df)
+		}
+		rd := &NetData{Net: tt.net}
+		if err := readJSONMap(t, rd, df); err == nil {
+			t.Errorf("%s: no error", tt.name)
+		}
+		if rd.LayData != nil || rd.Vars != nil {
+			t.Errorf("%s: data set despite error", tt.name)
+		}
+	}
+	rd := &NetData{Net: nd.Net}
+	if err := rd.ReadJSON(bytes.NewReader([]byte("{"))); err == nil {
+		t.Errorf("bad json: no error")
+	}
+}
@@ -10,11 +10,11 @@ package netview
 import (
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 
-	"github.com/chewxy/math32"
 	"github.com/emer/emergent/emer"
-	"github.com/emer/etable/minmax"
+	"github.com/emer/etable/eplot"
 	"github.com/goki/gi/gi"
 	"github.com/goki/gi/gi3d"
 	"github.com/goki/gi/giv"
@@ -31,6 +31,8 @@ type NetView struct {
 	gi.Layout
 	Net          emer.Network          `desc:"the network that we're viewing"`
 	Var          string                `desc:"current variable that we're viewing"`
+	HideLays     map[string]bool       `desc:"layers that are hidden in the view (and excluded from autoscaling) -- see also Params.LayFilter"`
+	SplitVars    []string              `desc:"additional variables to view simultaneously, each in its own copy of the network displayed side-by-side to the right of the main one (Shift+click on a variable to add or remove it)"`
 	Vars         []string              `desc:"the list of variables to view"`
 	VarParams    map[string]*VarParams `desc:"parameters for the list of variables to view"`
 	CurVarParams *VarParams            `json:"-" xml:"-" view:"-" desc:"current var params -- only valid during Update of display"`
@@ -38,7 +40,25 @@ type NetView struct {
 	ColorMap     *giv.ColorMap         `desc:"color map for mapping values to colors -- set by name in Params"`
 	RecNo        int                   `desc:"record number to display -- use -1 to always track latest, otherwise in range [0..Data.Ring.Len-1]"`
 	LastCtrs     string                `desc:"last non-empty counters string provided -- re-used if no new one"`
+	Marks        []*UnitMark           `desc:"persistent markers over specific units, e.g., target units -- see MarkUnits"`
+	Slice        LaySlice              `desc:"cross-section of the pools of a 4D layer to display expanded above the layer, with the rest of the layer dimmed -- see SetSlice"`
+	Find         string                `desc:"if non-empty, all units whose value of the current variable matches this condition (e.g., > 0.9, < -1, == NaN) are highlighted in Params.FindColor -- useful for finding runaway or dead units"`
+	RecFilter    string                `desc:"if non-empty, stepping through records (and Playback) only visits records whose structured counter values (see RecordCtrs) match this space-separated list of Name=Val expressions, e.g., Cycle=99 to show only end-of-trial records"`
 	Data         NetData               `desc:"contains all the network data with history"`
+	Rings        []*NetData            `desc:"additional recording rings at other timescales (e.g., every trial for the last epoch, while Data records every cycle for the last trial), recorded with RecordRing -- selecting one in the Viewbar ring dropdown swaps it with Data for navigation -- see AddRing"`
+	Movie        Movie                 `desc:"parameters and state for recording movie frames of the view"`
+	Playback     Playback              `desc:"parameters and state for automatically playing through the recorded history"`
+	Linked       []*NetView            `json:"-" xml:"-" view:"-" desc:"other views linked to this one, which are kept synchronized in camera, variable, and record -- see Link"`
+	KeyMap       KeyMap                `desc:"keyboard shortcuts for navigating the view, when it has focus -- see DefaultKeyMap and KeyActions"`
+	cfgSplitVars []string              `view:"-" desc:"SplitVars at last ViewConfig, to detect when meshes need to be remade"`
+	findStr      string                `view:"-" desc:"Find string that findCond was parsed from"`
+	findCond     *ValCond              `view:"-" desc:"parsed Find condition"`
+	bmNames      []string              `view:"-" desc:"bookmark names currently shown in the bookmarks dropdown"`
+	timePlot     *eplot.Plot2D         `view:"-" desc:"unit time-course plot, if open -- see PlotUnitTime"`
+	linkSync     bool                  `view:"-" desc:"true while synchronizing Linked views, to prevent loops"`
+	updtThr      updtThrottle          `copy:"-" json:"-" xml:"-" view:"-" desc:"state for limiting the GoUpdate rate to Params.MaxRate"`
+	async        *AsyncRecorder        `view:"-" desc:"asynchronous recorder, if recording asynchronously -- see StartAsync"`
+	mainRing     string                `view:"-" desc:"name of the main ring recorded by Record, which is in Data unless another ring is being navigated -- see AddRing"`
 }
 
 var KiT_NetView = kit.Types.AddType(&NetView{}, NetViewProps)
@@ -51,6 +71,12 @@ func AddNewNetView(parent ki.Ki, name string) *NetView {
 func (nv *NetView) Defaults() {
 	nv.Params.NetView = nv
 	nv.Params.Defaults()
+	nv.Movie.NetView = nv
+	nv.Playback.NetView = nv
+	nv.Playback.Defaults()
+	if nv.KeyMap == nil {
+		nv.KeyMap = DefaultKeyMap()
+	}
 	nv.ColorMap = giv.AvailColorMaps[string(nv.Params.ColorMap)]
 	nv.RecNo = -1
 }
@@ -60,6 +86,9 @@ func (nv *NetView) SetNet(net emer.Network) {
 	nv.Defaults()
 	nv.Net = net
 	nv.Data.Init(nv.Net, nv.Params.MaxRecs)
+	for _, nd := range nv.Rings {
+		nd.Init(nv.Net, nd.Ring.Max)
+	}
 	nv.Config()
 }
 
@@ -78,6 +107,16 @@ func (nv *NetView) SetMaxRecs(max int) {
 	nv.Data.Init(nv.Net, nv.Params.MaxRecs)
 }
 
+// SetDataBits sets the storage precision of recorded values in bits per value:
+// 32 (default), 16 (float16), or 8 (quantized per record) -- see NetData.Bits.
+// Lower precision allows a correspondingly larger MaxRecs in the same memory.
+// Resets the current data in the process.
+func (nv *NetView) SetDataBits(bits int) {
+	nv.Data.Bits = bits
+	nv.Data.LayData = nil
+	nv.Data.Init(nv.Net, nv.Params.MaxRecs)
+}
+
 // HasLayers returns true if network has any layers -- else no display
 func (nv *NetView) HasLayers() bool {
 	if nv.Net == nil || nv.Net.NLayers() == 0 {
@@ -89,22 +128,47 @@ func (nv *NetView) HasLayers() bool {
 // Record records the current state of the network, along with provided counters
 // string, which is displayed at the bottom of the view to show the current
 // state of the counters.  The NetView displays this recorded data when
-// Update is next called.
+// Update is next called.  Records into the main ring, even if another
+// ring (see AddRing) is currently being navigated.
 func (nv *NetView) Record(counters string) {
+	nv.RecordCtrs(counters, nil)
+}
+
+// RecordCtrs records the current state of the network, along with provided counters
+// string as in Record, and structured counter values (e.g., Run, Epoch, Trial, Cycle)
+// that can be used to jump to (RecJump) or filter (RecFilter) records.
+func (nv *NetView) RecordCtrs(counters string, vals map[string]int) {
 	if counters != "" {
 		nv.LastCtrs = counters
 	}
-	nv.Data.Record(nv.LastCtrs)
-	nv.RecTrackLatest() // if we make a new record, then user expectation is to track latest..
+	if nv.async != nil {
+		nv.async.Record(nv.mainData(), "", nv.LastCtrs, vals)
+		nv.RecTrackLatest()
+		return
+	}
+	nv.mainData().recordRing(nv, nv.LastCtrs, vals)
+}
+
+// Bookmark sets a bookmark with given name (e.g., "error trial 37") on the
+// most recent record, which can then be jumped to from the bookmarks
+// dropdown in the Viewbar, or with RecGoBookmark.  Call after Record.
+func (nv *NetView) Bookmark(name string) {
+	nv.FlushAsync()
+	nv.mainData().Bookmark(name)
 }
 
 // GoUpdate is the update call to make from another go routine
 // it does the proper blocking to coordinate with GUI updates
-// generated on the main GUI thread.
+// generated on the main GUI thread.  If Params.MaxRate is set,
+// updates coming faster than that rate are skipped (but the
+// latest record is still shown shortly thereafter).
 func (nv *NetView) GoUpdate() {
 	if !nv.IsVisible() || !nv.HasLayers() {
 		return
 	}
+	if nv.throttle() {
+		return
+	}
 	if nv.Viewport.IsUpdatingNode() {
 		return
 	}
@@ -119,6 +183,7 @@ func (nv *NetView) GoUpdate() {
 // Update updates the display based on current state of network.
 // This version is for calling within main window eventloop goroutine --
 // use GoUpdate version for calling outside of main goroutine.
+// Any Linked views are synchronized to this one (see SyncLinked).
 func (nv *NetView) Update() {
 	if !nv.IsVisible() || !nv.HasLayers() {
 		return
@@ -127,10 +192,15 @@ func (nv *NetView) Update() {
 	updt := vs.UpdateStart()
 	nv.UpdateImpl()
 	vs.UpdateEnd(updt)
+	nv.SyncLinked()
 }
 
 // UpdateImpl does the guts of updating -- backend for Update or GoUpdate
 func (nv *NetView) UpdateImpl() {
+	if nv.async != nil {
+		nv.async.Lock()
+		defer nv.async.Unlock()
+	}
 	vp, ok := nv.VarParams[nv.Var]
 	if !ok {
 		log.Printf("NetView: %v variable: %v not found\n", nv.Nm, nv.Var)
@@ -138,50 +208,80 @@ func (nv *NetView) UpdateImpl() {
 	}
 	nv.CurVarParams = vp
 
-	if !vp.Range.FixMin || !vp.Range.FixMax {
-		needUpdt := false
-		// need to autoscale
-		min, max, ok := nv.Data.VarRange(nv.Var)
-		if ok {
-			vp.MinMax.Set(min, max)
-			if !vp.Range.FixMin {
-				nmin := float32(minmax.NiceRoundNumber(float64(min), true)) // true = below
-				if vp.Range.Min != nmin {
-					vp.Range.Min = nmin
-					needUpdt = true
-				}
-			}
-			if !vp.Range.FixMax {
-				nmax := float32(minmax.NiceRoundNumber(float64(max), false)) // false = above
-				if vp.Range.Max != nmax {
-					vp.Range.Max = nmax
-					needUpdt = true
-				}
-			}
-			if vp.ZeroCtr && !vp.Range.FixMin && !vp.Range.FixMax {
-				bmax := math32.Max(math32.Abs(vp.Range.Max), math32.Abs(vp.Range.Min))
-				if !needUpdt {
-					if vp.Range.Max != bmax || vp.Range.Min != -bmax {
-						needUpdt = true
-					}
-				}
-				vp.Range.Max = bmax
-				vp.Range.Min = -bmax
-			}
-			if needUpdt {
-				nv.VarScaleUpdate(nv.Var)
-			}
+	if nv.VarAutoScale(vp) {
+		nv.VarScaleUpdate(nv.Var)
+	}
+	for _, sv := range nv.SplitVars {
+		if svp, ok := nv.VarParams[sv]; ok {
+			nv.VarAutoScale(svp)
+		}
+	}
+	if bv := nv.PrjnBothVar(nv.Var); bv != "" {
+		if bvp, ok := nv.VarParams[bv]; ok {
+			nv.VarAutoScale(bvp)
 		}
 	}
 
 	vs := nv.Scene()
 	laysGp, err := vs.ChildByNameTry("Layers", 0)
-	if err != nil || laysGp.NumChildren() != nv.Net.NLayers() {
+	if err != nil || laysGp.NumChildren() != nv.NVisLayers() || !nv.SplitsConfiged() {
 		nv.Config()
 	}
 	nv.SetCounters(nv.Data.CounterRec(nv.RecNo))
 	nv.UpdateRecNo()
+	nv.UpdateRings()
+	nv.UpdateBookmarks()
+	nv.UpdateFound()
+	if nv.Params.Inspector {
+		nv.Inspector().UpdateVals()
+	}
+	if nv.Params.Grid2D {
+		nv.GridView().UpdateVals()
+		return
+	}
+	nv.LegendUpdate()
 	vs.UpdateMeshes()
+	if nv.Movie.On {
+		nv.Movie.CaptureFrame()
+	}
+}
+
+// VarAutoScale updates the display range of given variable params
+// based on the recorded data, for any ends of the range that are not fixed,
+// including any per-layer overrides (LayRanges) which are scaled based on
+// the data in their layer only.  Returns true if the main range changed.
+func (nv *NetView) VarAutoScale(vp *VarParams) bool {
+	needUpdt := false
+	if !vp.Range.FixMin || !vp.Range.FixMax {
+		min, max, ok := nv.VarRange(vp.Var)
+		if ok {
+			needUpdt = vp.AutoScale(min, max)
+		}
+	}
+	for laynm, lvp := range vp.LayRanges {
+		if lvp.Range.FixMin && lvp.Range.FixMax {
+			continue
+		}
+		min, max, ok := nv.Data.VarRangeLays(vp.Var, func(lnm string) bool { return lnm == laynm })
+		if ok {
+			lvp.AutoScale(min, max)
+		}
+	}
+	return needUpdt
+}
+
+// VarRange returns the current min, max range of recorded data for given variable,
+// only including the visible layers (see LayVisible) that do not have
+// per-layer range overrides (VarParams.LayRanges).
+// Returns false if not found or no data.
+func (nv *NetView) VarRange(vnm string) (float32, float32, bool) {
+	vp := nv.VarParams[vnm]
+	if nv.NVisLayers() == nv.Net.NLayers() && (vp == nil || len(vp.LayRanges) == 0) {
+		return nv.Data.VarRange(vnm)
+	}
+	return nv.Data.VarRangeLays(vnm, func(laynm string) bool {
+		return nv.LayVisible(laynm) && (vp == nil || vp.LayParams(laynm) == nil)
+	})
 }
 
 // Config configures the overall view widget
@@ -216,10 +316,29 @@ func (nv *NetView) Config() {
 	vncfg := kit.TypeAndNameList{}
 	vncfg.Add(gi.KiT_Frame, "vars")
 	vncfg.Add(gi3d.KiT_Scene, "scene")
+	vncfg.Add(KiT_GridView, "grid")
+	vncfg.Add(KiT_UnitInspector, "inspect")
 	nlay.ConfigChildren(vncfg, false) // won't do update b/c of above updt
 
 	nv.VarsConfig()
 	nv.ViewConfig()
+	gv := nv.GridView()
+	gv.NetView = nv
+	if nv.Params.Grid2D {
+		nv.Scene().SetInvisible()
+		gv.ClearInvisible()
+		gv.Config()
+	} else {
+		gv.DeleteChildren(true)
+		gv.SetInvisible()
+		nv.Scene().ClearInvisible()
+	}
+	ins := nv.Inspector()
+	ins.NetView = nv
+	if !nv.Params.Inspector {
+		ins.LayName = ""
+	}
+	ins.Config()
 	nv.ToolbarConfig()
 	nv.ViewbarConfig()
 
@@ -227,6 +346,11 @@ func (nv *NetView) Config() {
 	ctrs.Redrawable = true
 	ctrs.SetText("Counters: ")
 
+	if nv.Params.PrjnStats {
+		nv.Data.PrjnVars = nv.Params.PrjnStatVars
+	} else {
+		nv.Data.PrjnVars = nil
+	}
 	nv.Data.Init(nv.Net, nv.Params.MaxRecs)
 	nv.UpdateEnd(updt)
 }
@@ -280,10 +404,134 @@ func (nv *NetView) UpdateRecNo() {
 	vbar := nv.Viewbar()
 	rlbl := vbar.ChildByName("rec", 10).(*gi.Label)
 	rlbl.SetText(fmt.Sprintf("%d", nv.RecNo))
+	pact := vbar.ChildByName("playback", 20).(*gi.Action)
+	if nv.Playback.Playing {
+		if pact.Text != "Pause" {
+			pact.SetText("Pause")
+			pact.SetIcon("pause")
+		}
+	} else if pact.Text != "Play" {
+		pact.SetText("Play")
+		pact.SetIcon("play")
+	}
+}
+
+// RecFilterVals returns the parsed RecFilter, or nil if empty or invalid
+func (nv *NetView) RecFilterVals() CtrFilter {
+	if nv.RecFilter == "" {
+		return nil
+	}
+	cf, err := ParseCtrFilter(nv.RecFilter)
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+	return cf
+}
+
+// RecStepFilter moves the view record by given number of records among
+// those matching given filter (negative = earlier).  Moving earlier from
+// the latest record (-1) starts from the last matching record.
+// Returns true if updated.
+func (nv *NetView) RecStepFilter(cf CtrFilter, n int) bool {
+	recs := nv.Data.FindRecs(cf.Match)
+	if len(recs) == 0 || n == 0 {
+		return false
+	}
+	var pos int
+	if n < 0 {
+		cur := nv.RecNo
+		if cur < 0 {
+			cur = nv.Data.Ring.Len
+		}
+		ei := sort.SearchInts(recs, cur) // number of matching records before cur
+		if ei == 0 {
+			return false
+		}
+		pos = ei + n
+		if pos < 0 {
+			pos = 0
+		}
+	} else {
+		if nv.RecNo < 0 {
+			return false
+		}
+		li := sort.SearchInts(recs, nv.RecNo+1) // first matching record after cur
+		if li >= len(recs) {
+			return false
+		}
+		pos = li + n - 1
+		if pos >= len(recs) {
+			pos = len(recs) - 1
+		}
+	}
+	nv.RecNo = recs[pos]
+	return true
+}
+
+// RecJump moves the view to the latest record whose structured counter values
+// (see RecordCtrs) match all of the given values.  Returns false if none found.
+func (nv *NetView) RecJump(vals map[string]int) bool {
+	recs := nv.Data.FindRecs(CtrFilter(vals).Match)
+	if len(recs) == 0 {
+		return false
+	}
+	nv.RecNo = recs[len(recs)-1]
+	return true
+}
+
+// RecGoBookmark moves the view to the latest record with given bookmark name
+// (see Bookmark).  Returns false if not found.
+func (nv *NetView) RecGoBookmark(name string) bool {
+	rec, ok := nv.Data.BookmarkRec(name)
+	if !ok {
+		return false
+	}
+	nv.Playback.Pause()
+	nv.RecNo = rec
+	return true
+}
+
+// UpdateBookmarks updates the bookmarks dropdown in the Viewbar with the
+// unique bookmark names in the current records, if they have changed.
+func (nv *NetView) UpdateBookmarks() {
+	names, _ := nv.Data.BookmarkRecs()
+	uniq := make([]string, 0, len(names))
+	has := make(map[string]bool, len(names))
+	for _, nm := range names {
+		if !has[nm] {
+			has[nm] = true
+			uniq = append(uniq, nm)
+		}
+	}
+	if len(uniq) == len(nv.bmNames) {
+		same := true
+		for i, nm := range uniq {
+			if nv.bmNames[i] != nm {
+				same = false
+				break
+			}
+		}
+		if same {
+			return
+		}
+	}
+	nv.bmNames = uniq
+	cb, ok := nv.Viewbar().ChildByName("bookmarks", 20).(*gi.ComboBox)
+	if !ok {
+		return
+	}
+	cb.ItemsFromStringList(uniq, false, 40)
+	if len(uniq) == 0 {
+		cb.SetText("Bookmarks")
+	}
 }
 
 // RecFastBkwd move view record 10 steps backward. Returns true if updated.
 func (nv *NetView) RecFastBkwd() bool {
+	if cf := nv.RecFilterVals(); cf != nil {
+		return nv.RecStepFilter(cf, -10)
+	}
 	if nv.RecNo == 0 {
 		return false
 	}
@@ -300,6 +548,9 @@ func (nv *NetView) RecFastBkwd() bool {
 
 // RecBkwd move view record 1 steps backward. Returns true if updated.
 func (nv *NetView) RecBkwd() bool {
+	if cf := nv.RecFilterVals(); cf != nil {
+		return nv.RecStepFilter(cf, -1)
+	}
 	if nv.RecNo == 0 {
 		return false
 	}
@@ -316,6 +567,9 @@ func (nv *NetView) RecBkwd() bool {
 
 // RecFwd move view record 1 step forward. Returns true if updated.
 func (nv *NetView) RecFwd() bool {
+	if cf := nv.RecFilterVals(); cf != nil {
+		return nv.RecStepFilter(cf, 1)
+	}
 	if nv.RecNo >= nv.Data.Ring.Len-1 {
 		nv.RecNo = nv.Data.Ring.Len - 1
 		return false
@@ -332,6 +586,9 @@ func (nv *NetView) RecFwd() bool {
 
 // RecFastFwd move view record 10 steps forward. Returns true if updated.
 func (nv *NetView) RecFastFwd() bool {
+	if cf := nv.RecFilterVals(); cf != nil {
+		return nv.RecStepFilter(cf, 10)
+	}
 	if nv.RecNo >= nv.Data.Ring.Len-1 {
 		nv.RecNo = nv.Data.Ring.Len - 1
 		return false
@@ -374,7 +631,8 @@ func NetFirstLayPrjn(net emer.Network) (emer.Layer, emer.Prjn) {
 	return lay0, nil
 }
 
-// NetVarsList returns the list of layer and prjn variables for given network.
+// NetVarsList returns the list of layer and prjn variables for given network,
+// grouped by their category (see emer.UnitVarCats).
 // layEven ensures that the number of layer variables is an even number if true
 // (used for display but not storage).
 func NetVarsList(net emer.Network, layEven bool) []string {
@@ -382,10 +640,10 @@ func NetVarsList(net emer.Network, layEven bool) []string {
 		return nil
 	}
 	lay, prjn := NetFirstLayPrjn(net)
-	unvars := lay.UnitVarNames()
+	unvars := emer.UnitVarCats(lay).Names()
 	var prjnvars []string
 	if prjn != nil {
-		prjnvars = prjn.SynVarNames()
+		prjnvars = emer.SynVarCats(prjn).Names()
 	}
 	ulen := len(unvars)
 	if layEven && ulen%2 != 0 { // make it an even number, for 2 column layout
@@ -441,7 +699,7 @@ func (nv *NetView) VarsUpdate() {
 	updt := vl.UpdateStart()
 	for _, vbi := range *vl.Children() {
 		vb := vbi.(*gi.Action)
-		if vb.Text == nv.Var {
+		if vb.Text == nv.Var || nv.IsSplitVar(vb.Text) {
 			vb.SetSelected()
 		} else {
 			vb.ClearSelected()
@@ -449,7 +707,7 @@ func (nv *NetView) VarsUpdate() {
 	}
 	tbar := nv.Toolbar()
 	cmap := tbar.ChildByName("cmap", 5).(*giv.ColorMapView)
-	cmap.Map = nv.ColorMap
+	cmap.Map = nv.VarColorMap(nv.VarParams[nv.Var])
 	cmap.UpdateSig()
 	vl.UpdateEnd(updt)
 }
@@ -535,7 +793,10 @@ func (nv *NetView) VarsConfig() {
 		vb.SetProp("max-width", -1)
 		vn := nv.Vars[i]
 		vb.SetText(vn)
-		if vn == nv.Var {
+		if vp, has := nv.VarParams[vn]; has && vp.Cat != "" {
+			vb.Tooltip = vp.Cat
+		}
+		if vn == nv.Var || nv.IsSplitVar(vn) {
 			vb.SetSelected()
 		} else {
 			vb.ClearSelected()
@@ -543,6 +804,10 @@ func (nv *NetView) VarsConfig() {
 		vb.ActionSig.Connect(nv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
 			nvv := recv.Embed(KiT_NetView).(*NetView)
 			vbv := send.(*gi.Action)
+			if key.HasAllModifierBits(nvv.Scene().Win.LastModBits, key.Shift) {
+				nvv.ToggleSplitVar(vbv.Text)
+				return
+			}
 			nvv.SetVar(vbv.Text)
 		})
 	}
@@ -559,18 +824,104 @@ func (nv *NetView) ViewConfig() {
 	}
 	if len(vs.Lights) == 0 {
 		nv.ViewDefaults()
+	} else {
+		nv.ApplyScene()
 	}
-	nlay := nv.Net.NLayers()
 	laysGp, err := vs.ChildByNameTry("Layers", 0)
 	if err != nil {
 		laysGp = gi3d.AddNewGroup(vs, vs, "Layers")
 	}
+	for li := 0; li < nv.Net.NLayers(); li++ {
+		lay := nv.Net.Layer(li)
+		lmesh := vs.MeshByName(lay.Name())
+		if lmesh == nil {
+			continue
+		}
+		if !nv.LayVisible(lay.Name()) || !nv.LayMeshTypeOk(lay, lmesh) {
+			vs.Meshes = nil // layer hidden or display mode changed -- remake all
+			break
+		}
+	}
+	for _, sv := range nv.cfgSplitVars {
+		if !nv.IsSplitVar(sv) {
+			vs.Meshes = nil // split var removed -- remake all
+			break
+		}
+	}
+	nv.cfgSplitVars = append(nv.cfgSplitVars[:0], nv.SplitVars...)
+	updt := nv.ConfigLayGroups(laysGp, "")
+	nv.SplitsConfig()
+	nv.PrjnsConfig()
+	nv.PrjnStatsConfig()
+	nv.MarksConfig()
+	nv.SliceConfig()
+	nv.LegendConfig()
+	vs.Camera.Ortho = nv.Params.Ortho
+	vs.InitMeshes()
+	laysGp.UpdateEnd(updt)
+}
+
+// LayMeshName returns the name of the mesh for given layer and variable,
+// where an empty variable is the main Var.
+func LayMeshName(lay emer.Layer, vnm string) string {
+	if vnm == "" {
+		return lay.Name()
+	}
+	return vnm + ":" + lay.Name()
+}
+
+// UseDownMesh returns true if given layer should be rendered using
+// a downsampled DownMesh, based on Params.DownThr
+func (nv *NetView) UseDownMesh(lay emer.Layer) bool {
+	return nv.Params.DownThr > 0 && lay.Shape().Len() > nv.Params.DownThr && lay.Shape().NumDims() >= 2 && !nv.UsePoolMesh(lay)
+}
+
+// UsePoolMesh returns true if given layer should be rendered using
+// a pool-level PoolMesh, based on Params.Pools
+func (nv *NetView) UsePoolMesh(lay emer.Layer) bool {
+	return nv.Params.Pools && lay.Shape().NumDims() == 4
+}
+
+// LayMeshTypeOk returns true if given existing mesh for given layer is of
+// the type required by the current display mode (Raster, Pools, DownThr)
+func (nv *NetView) LayMeshTypeOk(lay emer.Layer, lmesh gi3d.Mesh) bool {
+	switch lmesh.(type) {
+	case *RasterMesh:
+		return nv.Params.Raster
+	case *PoolMesh:
+		return !nv.Params.Raster && nv.UsePoolMesh(lay)
+	case *DownMesh:
+		return !nv.Params.Raster && nv.UseDownMesh(lay) && lmesh.(*DownMesh).Block == nv.DownBlock(lay)
+	default:
+		return !nv.Params.Raster && !nv.UsePoolMesh(lay) && !nv.UseDownMesh(lay)
+	}
+}
+
+// ConfigLayGroups configures one group per layer within given group, each
+// containing the LayObj and LayName, using meshes that display given variable
+// (empty = main Var).  Returns the update flag from configuring the children.
+func (nv *NetView) ConfigLayGroups(laysGp ki.Ki, vnm string) bool {
+	vs := nv.Scene()
+	nlay := nv.Net.NLayers()
 	layConfig := kit.TypeAndNameList{}
 	for li := 0; li < nlay; li++ {
 		lay := nv.Net.Layer(li)
-		lmesh := vs.MeshByName(lay.Name())
+		if !nv.LayVisible(lay.Name()) {
+			continue
+		}
+		mnm := LayMeshName(lay, vnm)
+		lmesh := vs.MeshByName(mnm)
 		if lmesh == nil {
-			AddNewLayMesh(vs, nv, lay)
+			switch {
+			case nv.Params.Raster:
+				AddNewRasterMeshVar(vs, nv, lay, vnm)
+			case nv.UsePoolMesh(lay):
+				AddNewPoolMeshVar(vs, nv, lay, vnm)
+			case nv.UseDownMesh(lay):
+				AddNewDownMeshVar(vs, nv, lay, vnm)
+			default:
+				AddNewLayMeshVar(vs, nv, lay, vnm)
+			}
 		}
 		layConfig.Add(gi3d.KiT_Group, lay.Name())
 	}
@@ -588,9 +939,9 @@ func (nv *NetView) ViewConfig() {
 	szc := mat32.Max(nsc.X, nsc.Y)
 	poff := mat32.NewVec3Scalar(0.5)
 	poff.Y = -0.5
-	for li, lgi := range *laysGp.Children() {
-		ly := nv.Net.Layer(li)
+	for _, lgi := range *laysGp.Children() {
 		lg := lgi.(*gi3d.Group)
+		ly := nv.Net.LayerByName(lg.Name())
 		lg.ConfigChildren(gpConfig, false) // won't do update b/c of above
 		lp := ly.Pos()
 		lp.Y = -lp.Y // reverse direction
@@ -602,8 +953,9 @@ func (nv *NetView) ViewConfig() {
 		lo := lg.Child(0).(*LayObj)
 		lo.Defaults()
 		lo.LayName = ly.Name()
+		lo.Var = vnm
 		lo.NetView = nv
-		lo.SetMeshName(vs, ly.Name())
+		lo.SetMeshName(vs, LayMeshName(ly, vnm))
 		lo.Mat.Color.SetUInt8(255, 100, 255, 128)
 		lo.Mat.Specular.SetUInt8(128, 128, 128, 255)
 		lo.Mat.CullBack = true
@@ -617,12 +969,123 @@ func (nv *NetView) ViewConfig() {
 		txt.Defaults(vs)
 		txt.NetView = nv
 		txt.SetText(vs, ly.Name())
-		txt.Pose.Scale = mat32.NewVec3Scalar(nv.Params.LayNmSize).Div(lg.Pose.Scale)
+		nv.StyleLabel(txt, ly.Name(), lg.Pose.Scale)
 		txt.SetProp("text-align", gi.AlignLeft)
 		txt.SetProp("vertical-align", gi.AlignTop)
 	}
-	vs.InitMeshes()
-	laysGp.UpdateEnd(updt)
+	return updt
+}
+
+// SplitSpacing is the spacing between side-by-side copies of the network
+// for SplitVars, where the network view is unit sized.
+var SplitSpacing = float32(1.2)
+
+// SplitsConfig configures the "Splits" group containing a copy of the network
+// for each of the SplitVars, positioned side-by-side to the right of the main view,
+// each with a label showing the variable name.
+func (nv *NetView) SplitsConfig() {
+	vs := nv.Scene()
+	spGp, err := vs.ChildByNameTry("Splits", 1)
+	if err != nil {
+		spGp = gi3d.AddNewGroup(vs, vs, "Splits")
+	}
+	spConfig := kit.TypeAndNameList{}
+	for _, sv := range nv.SplitVars {
+		spConfig.Add(gi3d.KiT_Group, sv)
+	}
+	spGp.ConfigChildren(spConfig, false)
+	svConfig := kit.TypeAndNameList{}
+	svConfig.Add(gi3d.KiT_Group, "Layers")
+	svConfig.Add(gi3d.KiT_Text2D, "var")
+	for si, sgi := range *spGp.Children() {
+		sv := nv.SplitVars[si]
+		sg := sgi.(*gi3d.Group)
+		sg.Pose.Pos.Set(float32(si+1)*SplitSpacing, 0, 0)
+		sg.ConfigChildren(svConfig, false)
+		nv.ConfigLayGroups(sg.Child(0), sv)
+		lb := sg.Child(1).(*gi3d.Text2D)
+		lb.Defaults(vs)
+		lb.SetText(vs, sv)
+		lb.Pose.Pos.Set(-0.5, 0.5, 0.5)
+		lb.Pose.Scale = mat32.NewVec3Scalar(2 * nv.Params.LayNmSize)
+		nv.SetTextColor(lb)
+		lb.SetProp("text-align", gi.AlignLeft)
+		lb.SetProp("vertical-align", gi.AlignTop)
+	}
+}
+
+// SplitsConfiged returns true if the Splits group is configured
+// for the current SplitVars
+func (nv *NetView) SplitsConfiged() bool {
+	vs := nv.Scene()
+	spGp, err := vs.ChildByNameTry("Splits", 1)
+	if err != nil {
+		return len(nv.SplitVars) == 0
+	}
+	if spGp.NumChildren() != len(nv.SplitVars) {
+		return false
+	}
+	for si, sv := range nv.SplitVars {
+		if spGp.Child(si).Name() != sv {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSplitVar returns true if given variable is among the SplitVars
+func (nv *NetView) IsSplitVar(vnm string) bool {
+	for _, sv := range nv.SplitVars {
+		if sv == vnm {
+			return true
+		}
+	}
+	return false
+}
+
+// SetSplitVars sets the additional variables to view simultaneously,
+// each in its own side-by-side copy of the network, and updates the display.
+func (nv *NetView) SetSplitVars(vars ...string) {
+	nv.SplitVars = vars
+	nv.Config()
+	nv.Update()
+}
+
+// ToggleSplitVar adds given variable to SplitVars if not already there,
+// or removes it if it is, and updates the display.
+func (nv *NetView) ToggleSplitVar(vnm string) {
+	if vnm == nv.Var {
+		return
+	}
+	for si, sv := range nv.SplitVars {
+		if sv == vnm {
+			nv.SetSplitVars(append(nv.SplitVars[:si:si], nv.SplitVars[si+1:]...)...)
+			return
+		}
+	}
+	nv.SetSplitVars(append(nv.SplitVars, vnm)...)
+}
+
+// PrjnsConfig configures the "Prjns" group holding the connection lines
+// for the selected unit (see Params.PrjnLines), which are drawn directly in
+// scene coordinates.
+func (nv *NetView) PrjnsConfig() {
+	vs := nv.Scene()
+	if vs.MeshByName(PrjnMeshName) == nil {
+		AddNewPrjnMesh(vs, nv)
+	}
+	pjGp, err := vs.ChildByNameTry("Prjns", 1)
+	if err != nil {
+		pjGp = gi3d.AddNewGroup(vs, vs, "Prjns")
+	}
+	pjConfig := kit.TypeAndNameList{}
+	pjConfig.Add(gi3d.KiT_Object, "lines")
+	pjGp.ConfigChildren(pjConfig, false)
+	po := pjGp.Child(0).(*gi3d.Object)
+	po.SetMeshName(vs, PrjnMeshName)
+	po.Mat.Color.SetUInt8(255, 255, 255, 255)
+	po.Mat.CullBack = false // lines are flat and should be visible from both sides
+	po.Mat.CullFront = false
 }
 
 // ViewDefaults are the default 3D view params
@@ -634,12 +1097,7 @@ func (nv *NetView) ViewDefaults() {
 	// 	vs.Camera.Pose.Pos.Set(0, 1, 2.75) // more "head on" for larger / deeper networks
 	vs.Camera.Near = 0.1
 	vs.Camera.LookAt(mat32.Vec3{0, 0, 0}, mat32.Vec3{0, 1, 0})
-	vs.BgColor.SetUInt8(255, 255, 255, 255) // white
-	gi3d.AddNewAmbientLight(vs, "ambient", 0.3, gi3d.DirectSun)
-	dir := gi3d.AddNewDirLight(vs, "dirUp", 0.3, gi3d.DirectSun)
-	dir.Pos.Set(0, 1, 0)
-	dir = gi3d.AddNewDirLight(vs, "dirBack", 0.6, gi3d.DirectSun)
-	dir.Pos.Set(0, 1, -2.5)
+	nv.ApplyScene() // background and lights
 	// point := gi3d.AddNewPointLight(vs, "point", 1, gi3d.DirectSun)
 	// point.Pos.Set(0, 2, 5)
 	// spot := gi3d.AddNewSpotLight(vs, "spot", 1, gi3d.DirectSun)
@@ -650,18 +1108,34 @@ func (nv *NetView) ViewDefaults() {
 // UnitVal returns the raw value, scaled value, and color representation
 // for given unit of given layer scaled is in range -1..1
 func (nv *NetView) UnitVal(lay emer.Layer, idx []int) (raw, scaled float32, clr gi.Color) {
-	hasval := true
 	idx1d := lay.Shape().Offset(idx)
-	raw, hasval = nv.Data.UnitVal(lay.Name(), nv.Var, idx1d, nv.RecNo)
+	return nv.UnitValRec(lay, idx1d, nv.RecNo)
+}
 
-	if nv.CurVarParams == nil || nv.CurVarParams.Var != nv.Var {
-		ok := false
-		nv.CurVarParams, ok = nv.VarParams[nv.Var]
-		if !ok {
-			return
+// UnitValRec returns the raw value, scaled value, and color representation
+// for given unit (1D index) of given layer, at given record number
+// (-1 = latest, else in [0..Data.Ring.Len-1]).  Scaled is in range -1..1
+func (nv *NetView) UnitValRec(lay emer.Layer, idx1d int, recno int) (raw, scaled float32, clr gi.Color) {
+	return nv.UnitValVar(lay, nv.Var, idx1d, recno)
+}
+
+// UnitValVar returns the raw value, scaled value, and color representation
+// for given variable and unit (1D index) of given layer, at given record number
+// (-1 = latest, else in [0..Data.Ring.Len-1]).  Scaled is in range -1..1
+func (nv *NetView) UnitValVar(lay emer.Layer, vnm string, idx1d int, recno int) (raw, scaled float32, clr gi.Color) {
+	hasval := true
+	raw, hasval = nv.Data.UnitVal(lay.Name(), vnm, idx1d, recno)
+	if !hasval {
+		if bv := nv.PrjnBothVar(vnm); bv != "" {
+			if raw, hasval = nv.Data.UnitVal(lay.Name(), bv, idx1d, recno); hasval {
+				vnm = bv
+			}
 		}
 	}
 	if !hasval {
+		if _, ok := nv.VarParams[vnm]; !ok {
+			return
+		}
 		scaled = 0
 		if lay.Name() == nv.Data.PrjnLay && idx1d == nv.Data.PrjnUnIdx {
 			clr.SetUInt8(0x20, 0x80, 0x20, 0x80)
@@ -669,23 +1143,113 @@ func (nv *NetView) UnitVal(lay emer.Layer, idx []int) (raw, scaled float32, clr
 			clr.SetUInt8(0x20, 0x20, 0x20, 0x40)
 		}
 	} else {
-		clp := nv.CurVarParams.Range.ClipVal(raw)
-		norm := nv.CurVarParams.Range.NormVal(clp)
-		var op float32
-		if nv.CurVarParams.ZeroCtr {
-			scaled = float32(2*norm - 1)
-			op = (nv.Params.ZeroAlpha + (1-nv.Params.ZeroAlpha)*mat32.Abs(scaled))
-		} else {
-			scaled = float32(norm)
-			op = (nv.Params.ZeroAlpha + (1-nv.Params.ZeroAlpha)*0.8) // no meaningful alpha -- just set at 80\%
-		}
-		clr = nv.ColorMap.Map(float64(norm))
+		scaled, clr = nv.ValColor(lay, vnm, raw)
+	}
+	if nv.Slice.On(lay.Name()) && lay.Shape().NumDims() == 4 && !nv.Slice.InSlice(lay.Shape().Index(idx1d)) {
 		r, g, b, a := clr.ToNPFloat32()
-		clr.SetNPFloat32(r, g, b, a*op)
+		clr.SetNPFloat32(r, g, b, a*nv.Slice.DimAlpha)
+	}
+	if nv.UnitFound(lay.Name(), vnm, idx1d, recno) {
+		clr = nv.Params.FindColor
+	}
+	return
+}
+
+// ValColor returns the scaled value (in range -1..1) and color representation
+// for given raw value of given variable in given layer, using the current
+// display range (including any per-layer override) and color map for that variable.
+func (nv *NetView) ValColor(lay emer.Layer, vnm string, raw float32) (scaled float32, clr gi.Color) {
+	vp := nv.CurVarParams
+	if vp == nil || vp.Var != vnm {
+		ok := false
+		vp, ok = nv.VarParams[vnm]
+		if !ok {
+			return
+		}
+		if vnm == nv.Var {
+			nv.CurVarParams = vp
+		}
+	}
+	cmap := nv.VarColorMap(vp)
+	if lvp := vp.LayParams(lay.Name()); lvp != nil {
+		vp = lvp
+	}
+	clp := vp.Range.ClipVal(raw)
+	norm := vp.Range.NormVal(clp)
+	var op float32
+	if vp.ZeroCtr {
+		scaled = float32(2*norm - 1)
+		op = (nv.Params.ZeroAlpha + (1-nv.Params.ZeroAlpha)*mat32.Abs(scaled))
+	} else {
+		scaled = float32(norm)
+		op = (nv.Params.ZeroAlpha + (1-nv.Params.ZeroAlpha)*0.8) // no meaningful alpha -- just set at 80\%
 	}
+	clr = cmap.Map(float64(norm))
+	r, g, b, a := clr.ToNPFloat32()
+	clr.SetNPFloat32(r, g, b, a*op)
 	return
 }
 
+// VarColorMap returns the color map to use for given variable params:
+// the variable's own ColorMap if set and available, else the default
+// ColorMap from Params.
+func (nv *NetView) VarColorMap(vp *VarParams) *giv.ColorMap {
+	if vp != nil && vp.ColorMap != "" {
+		if cmap, ok := giv.AvailColorMaps[string(vp.ColorMap)]; ok {
+			return cmap
+		}
+	}
+	if vp != nil && nv.Params.PrjnBoth && strings.HasPrefix(vp.Var, "s.") {
+		if cmap, ok := giv.AvailColorMaps[string(nv.Params.SendColorMap)]; ok {
+			return cmap
+		}
+	}
+	return nv.ColorMap
+}
+
+// PrjnBothVar returns the complementary projection variable for given
+// variable in Params.PrjnBoth mode: s.X for r.X and vice-versa, which is
+// displayed for units without a value for the given variable.
+// Returns "" if not in PrjnBoth mode or not a projection variable.
+func (nv *NetView) PrjnBothVar(vnm string) string {
+	if !nv.Params.PrjnBoth || len(vnm) < 3 {
+		return ""
+	}
+	switch vnm[:2] {
+	case "r.":
+		return "s." + vnm[2:]
+	case "s.":
+		return "r." + vnm[2:]
+	}
+	return ""
+}
+
+// RegisterColorMap adds given color map to the list of available color maps
+// (giv.AvailColorMaps) under its Name, so that it can be selected by name,
+// e.g., in Params.ColorMap or VarParams.ColorMap, and in the toolbar chooser.
+func RegisterColorMap(cmap *giv.ColorMap) {
+	giv.AvailColorMaps[cmap.Name] = cmap
+}
+
+// NewColorMap creates and registers a new color map with given name
+// that interpolates between given colors, in order from lowest to highest value.
+func NewColorMap(name string, clrs ...gi.Color) *giv.ColorMap {
+	cmap := &giv.ColorMap{Name: name, Colors: clrs}
+	RegisterColorMap(cmap)
+	return cmap
+}
+
+// SetVarColorMap sets the color map to use for given variable, by name
+// (empty = use the default Params.ColorMap).
+func (nv *NetView) SetVarColorMap(vnm string, cmap giv.ColorMapName) {
+	vp, ok := nv.VarParams[vnm]
+	if !ok {
+		log.Printf("NetView: %v variable: %v not found\n", nv.Nm, vnm)
+		return
+	}
+	vp.ColorMap = cmap
+}
+
 // ConfigLabels ensures that given label gi3d.Text2D objects are created and initialized
 // in a top-level group called Labels.  Use LabelByName() to get a given label, and
 // LayerByName() to get a Layer group, whose Pose can be copied to put a label in
@@ -765,6 +1329,17 @@ func (nv *NetView) ToolbarConfig() {
 			nvv := recv.Embed(KiT_NetView).(*NetView)
 			giv.StructViewDialog(nvv.Viewport, &nvv.Params, giv.DlgOpts{Title: nvv.Nm + " Params"}, nil, nil)
 		})
+	tbar.AddAction(gi.ActOpts{Label: "Movie", Tooltip: "record a movie of the view, as a sequence of PNG frames written to a directory each time the display is updated -- turn On and set the directory here"}, nv.This(),
+		func(recv, send ki.Ki, sig int64, data interface{}) {
+			nvv := recv.Embed(KiT_NetView).(*NetView)
+			nvv.Movie.NetView = nvv
+			giv.StructViewDialog(nvv.Viewport, &nvv.Movie, giv.DlgOpts{Title: nvv.Nm + " Movie"}, nil, nil)
+		})
+	tbar.AddAction(gi.ActOpts{Label: "Layers", Icon: "info", Tooltip: "select which layers are visible -- see also Params.LayFilter"}, nv.This(),
+		func(recv, send ki.Ki, sig int64, data interface{}) {
+			nvv := recv.Embed(KiT_NetView).(*NetView)
+			nvv.LayersDialog()
+		})
 	tbar.AddSeparator("file")
 	tbar.AddAction(gi.ActOpts{Label: "Save Wts", Icon: "file-save"}, nv.This(),
 		func(recv, send ki.Ki, sig int64, data interface{}) {
@@ -776,6 +1351,21 @@ func (nv *NetView) ToolbarConfig() {
 			nvv := recv.Embed(KiT_NetView).(*NetView)
 			giv.CallMethod(nvv, "OpenWeights", nvv.Viewport) // this auto prompts for filename using file chooser
 		})
+	tbar.AddAction(gi.ActOpts{Label: "Save Data", Icon: "file-save", Tooltip: "save full recorded history of network data to file, for later replay"}, nv.This(),
+		func(recv, send ki.Ki, sig int64, data interface{}) {
+			nvv := recv.Embed(KiT_NetView).(*NetView)
+			giv.CallMethod(nvv, "SaveData", nvv.Viewport) // this auto prompts for filename using file chooser
+		})
+	tbar.AddAction(gi.ActOpts{Label: "Open Data", Icon: "file-open", Tooltip: "open recorded history of network data from file, for replay"}, nv.This(),
+		func(recv, send ki.Ki, sig int64, data interface{}) {
+			nvv := recv.Embed(KiT_NetView).(*NetView)
+			giv.CallMethod(nvv, "OpenData", nvv.Viewport) // this auto prompts for filename using file chooser
+		})
+	tbar.AddAction(gi.ActOpts{Label: "Save Vals", Icon: "file-save", Tooltip: "save values of the current variable for all visible layers at the current record to a table file (layer, unit index, value), for quick analysis"}, nv.This(),
+		func(recv, send ki.Ki, sig int64, data interface{}) {
+			nvv := recv.Embed(KiT_NetView).(*NetView)
+			giv.CallMethod(nvv, "SaveVals", nvv.Viewport) // this auto prompts for filename using file chooser
+		})
 	tbar.AddAction(gi.ActOpts{Label: "Non Def Params", Icon: "info", Tooltip: "shows all the parameters that are not at default values -- useful for setting params"}, nv.This(),
 		func(recv, send ki.Ki, sig int64, data interface{}) {
 			nvv := recv.Embed(KiT_NetView).(*NetView)
@@ -793,6 +1383,12 @@ func (nv *NetView) ToolbarConfig() {
 		vp.Defaults()
 	}
 
+	tbar.AddAction(gi.ActOpts{Label: "Lay Ranges", Icon: "info", Tooltip: "edit per-layer overrides of the display range for the current variable -- add an entry keyed by layer name, and set its range (or leave Fix Min / Max off to autoscale that layer separately)"}, nv.This(),
+		func(recv, send ki.Ki, sig int64, data interface{}) {
+			nvv := recv.Embed(KiT_NetView).(*NetView)
+			nvv.LayRangesDialog()
+		})
+
 	tbar.AddSeparator("cbar")
 	mncb := gi.AddNewCheckBox(tbar, "mncb")
 	mncb.Text = "Min"
@@ -830,13 +1426,18 @@ func (nv *NetView) ToolbarConfig() {
 	cmap.SetProp("min-width", units.NewEm(4))
 	cmap.SetStretchMaxHeight()
 	cmap.SetStretchMaxWidth()
-	cmap.Tooltip = "Color map for translating values into colors -- click to select alternative."
+	cmap.Tooltip = "Color map for translating values into colors for the current variable -- click to select alternative.  Hold Shift when selecting to set the default for all variables without their own color map (Params.ColorMap)."
 	cmap.ColorMapSig.Connect(nv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
 		nvv := recv.Embed(KiT_NetView).(*NetView)
 		cmm := send.(*giv.ColorMapView)
 		if cmm.Map != nil {
-			nvv.Params.ColorMap = giv.ColorMapName(cmm.Map.Name)
-			nvv.ColorMap = cmm.Map
+			vpp, ok := nvv.VarParams[nvv.Var]
+			if ok && !key.HasAllModifierBits(nvv.Scene().Win.LastModBits, key.Shift) {
+				vpp.ColorMap = giv.ColorMapName(cmm.Map.Name)
+			} else {
+				nvv.Params.ColorMap = giv.ColorMapName(cmm.Map.Name)
+				nvv.ColorMap = cmm.Map
+			}
 			nvv.Update()
 		}
 	})
@@ -888,6 +1489,42 @@ func (nv *NetView) ToolbarConfig() {
 			}
 		}
 	})
+
+	tbar.AddSeparator("find")
+	ftf := gi.AddNewTextField(tbar, "find")
+	ftf.SetText(nv.Find)
+	ftf.SetProp("min-width", units.NewEm(6))
+	ftf.Tooltip = "highlight all units whose value of the current variable matches this condition, e.g., > 0.9, < -1, == NaN -- empty = off"
+	ftf.TextFieldSig.Connect(nv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig == int64(gi.TextFieldDone) {
+			nvv := recv.Embed(KiT_NetView).(*NetView)
+			nvv.SetFind(send.(*gi.TextField).Text())
+		}
+	})
+	nlbl := gi.AddNewLabel(tbar, "nfound", "")
+	nlbl.Redrawable = true
+	nlbl.Tooltip = "number of units matching the find condition"
+
+	tbar.AddSeparator("slice")
+	stf := gi.AddNewTextField(tbar, "slice")
+	stf.SetText(nv.Slice.String())
+	stf.SetProp("min-width", units.NewEm(6))
+	stf.Tooltip = "show a cross-section of the pools of a 4D layer expanded above the layer, with the rest dimmed: Layer[y,x] with pool row y and column x, either of which can be * for all, e.g., V1[2,*] -- empty = off"
+	stf.TextFieldSig.Connect(nv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig == int64(gi.TextFieldDone) {
+			nvv := recv.Embed(KiT_NetView).(*NetView)
+			ls, err := ParseLaySlice(send.(*gi.TextField).Text())
+			if err != nil {
+				log.Println(err)
+				return
+			}
+			if ls.Lay == "" {
+				nvv.ClearSlice()
+			} else {
+				nvv.SetSlice(ls.Lay, ls.PoolY, ls.PoolX)
+			}
+		}
+	})
 }
 
 func (nv *NetView) ViewbarConfig() {
@@ -900,45 +1537,65 @@ func (nv *NetView) ViewbarConfig() {
 		func(recv, send ki.Ki, sig int64, data interface{}) {
 			nvv := recv.Embed(KiT_NetView).(*NetView)
 			nvv.Scene().SetCamera("default")
-			nvv.Scene().UpdateSig()
+			nvv.Scene().Camera.Ortho = nvv.Params.Ortho
+			nvv.CameraUpdated()
 		})
 	tbar.AddAction(gi.ActOpts{Icon: "zoom-in", Tooltip: "zoom in"}, nv.This(),
 		func(recv, send ki.Ki, sig int64, data interface{}) {
 			nvv := recv.Embed(KiT_NetView).(*NetView)
 			nvv.Scene().Camera.Zoom(-.05)
-			nvv.Scene().UpdateSig()
+			nvv.CameraUpdated()
 		})
 	tbar.AddAction(gi.ActOpts{Icon: "zoom-out", Tooltip: "zoom out"}, nv.This(),
 		func(recv, send ki.Ki, sig int64, data interface{}) {
 			nvv := recv.Embed(KiT_NetView).(*NetView)
 			nvv.Scene().Camera.Zoom(.05)
-			nvv.Scene().UpdateSig()
+			nvv.CameraUpdated()
 		})
+	tbar.AddSeparator("view")
+	ocb := gi.AddNewCheckBox(tbar, "ortho")
+	ocb.Text = "Ortho"
+	ocb.Tooltip = "use an orthographic camera projection instead of perspective, so layers at different depths are shown at the same scale"
+	ocb.SetChecked(nv.Params.Ortho)
+	ocb.ButtonSig.Connect(nv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig == int64(gi.ButtonToggled) {
+			nvv := recv.Embed(KiT_NetView).(*NetView)
+			nvv.SetOrtho(send.(*gi.CheckBox).IsChecked())
+		}
+	})
+	for _, cp := range CameraPresets {
+		cpnm := cp.Name
+		tbar.AddAction(gi.ActOpts{Label: cpnm, Tooltip: "set the camera to the canonical " + cpnm + " view"}, nv.This(),
+			func(recv, send ki.Ki, sig int64, data interface{}) {
+				nvv := recv.Embed(KiT_NetView).(*NetView)
+				nvv.SetCameraPreset(cpnm)
+			})
+	}
 	tbar.AddSeparator("rot")
 	gi.AddNewLabel(tbar, "rot", "Rot:")
 	tbar.AddAction(gi.ActOpts{Icon: "wedge-left"}, nv.This(),
 		func(recv, send ki.Ki, sig int64, data interface{}) {
 			nvv := recv.Embed(KiT_NetView).(*NetView)
 			nvv.Scene().Camera.Orbit(5, 0)
-			nvv.Scene().UpdateSig()
+			nvv.CameraUpdated()
 		})
 	tbar.AddAction(gi.ActOpts{Icon: "wedge-up"}, nv.This(),
 		func(recv, send ki.Ki, sig int64, data interface{}) {
 			nvv := recv.Embed(KiT_NetView).(*NetView)
 			nvv.Scene().Camera.Orbit(0, 5)
-			nvv.Scene().UpdateSig()
+			nvv.CameraUpdated()
 		})
 	tbar.AddAction(gi.ActOpts{Icon: "wedge-down"}, nv.This(),
 		func(recv, send ki.Ki, sig int64, data interface{}) {
 			nvv := recv.Embed(KiT_NetView).(*NetView)
 			nvv.Scene().Camera.Orbit(0, -5)
-			nvv.Scene().UpdateSig()
+			nvv.CameraUpdated()
 		})
 	tbar.AddAction(gi.ActOpts{Icon: "wedge-right"}, nv.This(),
 		func(recv, send ki.Ki, sig int64, data interface{}) {
 			nvv := recv.Embed(KiT_NetView).(*NetView)
 			nvv.Scene().Camera.Orbit(-5, 0)
-			nvv.Scene().UpdateSig()
+			nvv.CameraUpdated()
 		})
 	tbar.AddSeparator("pan")
 	gi.AddNewLabel(tbar, "pan", "Pan:")
@@ -946,25 +1603,25 @@ func (nv *NetView) ViewbarConfig() {
 		func(recv, send ki.Ki, sig int64, data interface{}) {
 			nvv := recv.Embed(KiT_NetView).(*NetView)
 			nvv.Scene().Camera.Pan(-.2, 0)
-			nvv.Scene().UpdateSig()
+			nvv.CameraUpdated()
 		})
 	tbar.AddAction(gi.ActOpts{Icon: "wedge-up"}, nv.This(),
 		func(recv, send ki.Ki, sig int64, data interface{}) {
 			nvv := recv.Embed(KiT_NetView).(*NetView)
 			nvv.Scene().Camera.Pan(0, .2)
-			nvv.Scene().UpdateSig()
+			nvv.CameraUpdated()
 		})
 	tbar.AddAction(gi.ActOpts{Icon: "wedge-down"}, nv.This(),
 		func(recv, send ki.Ki, sig int64, data interface{}) {
 			nvv := recv.Embed(KiT_NetView).(*NetView)
 			nvv.Scene().Camera.Pan(0, -.2)
-			nvv.Scene().UpdateSig()
+			nvv.CameraUpdated()
 		})
 	tbar.AddAction(gi.ActOpts{Icon: "wedge-right"}, nv.This(),
 		func(recv, send ki.Ki, sig int64, data interface{}) {
 			nvv := recv.Embed(KiT_NetView).(*NetView)
 			nvv.Scene().Camera.Pan(.2, 0)
-			nvv.Scene().UpdateSig()
+			nvv.CameraUpdated()
 		})
 	tbar.AddSeparator("save")
 	gi.AddNewLabel(tbar, "save", "Save:")
@@ -982,7 +1639,7 @@ func (nv *NetView) ViewbarConfig() {
 				}
 			}
 			fmt.Printf("Camera %s: %v\n", cam, scc.Camera.GenGoSet(""))
-			scc.UpdateSig()
+			nvv.CameraUpdated()
 		})
 	tbar.AddAction(gi.ActOpts{Label: "2", Icon: "save", Tooltip: "first click (or + Shift) saves current view, second click restores to saved state"}, nv.This(),
 		func(recv, send ki.Ki, sig int64, data interface{}) {
@@ -998,7 +1655,7 @@ func (nv *NetView) ViewbarConfig() {
 				}
 			}
 			fmt.Printf("Camera %s: %v\n", cam, scc.Camera.GenGoSet(""))
-			scc.UpdateSig()
+			nvv.CameraUpdated()
 		})
 	tbar.AddAction(gi.ActOpts{Label: "3", Icon: "save", Tooltip: "first click (or + Shift) saves current view, second click restores to saved state"}, nv.This(),
 		func(recv, send ki.Ki, sig int64, data interface{}) {
@@ -1014,7 +1671,7 @@ func (nv *NetView) ViewbarConfig() {
 				}
 			}
 			fmt.Printf("Camera %s: %v\n", cam, scc.Camera.GenGoSet(""))
-			scc.UpdateSig()
+			nvv.CameraUpdated()
 		})
 	tbar.AddAction(gi.ActOpts{Label: "4", Icon: "save", Tooltip: "first click (or + Shift) saves current view, second click restores to saved state"}, nv.This(),
 		func(recv, send ki.Ki, sig int64, data interface{}) {
@@ -1030,7 +1687,7 @@ func (nv *NetView) ViewbarConfig() {
 				}
 			}
 			fmt.Printf("Camera %s: %v\n", cam, scc.Camera.GenGoSet(""))
-			scc.UpdateSig()
+			nvv.CameraUpdated()
 		})
 	tbar.AddSeparator("time")
 	tlbl := gi.AddNewLabel(tbar, "time", "Time:")
@@ -1055,6 +1712,7 @@ func (nv *NetView) ViewbarConfig() {
 	tbar.AddAction(gi.ActOpts{Icon: "play", Tooltip: "move to latest and always display latest (-1)"}, nv.This(),
 		func(recv, send ki.Ki, sig int64, data interface{}) {
 			nvv := recv.Embed(KiT_NetView).(*NetView)
+			nvv.Playback.Pause()
 			if nvv.RecTrackLatest() {
 				nvv.Update()
 			}
@@ -1073,6 +1731,80 @@ func (nv *NetView) ViewbarConfig() {
 				nvv.Update()
 			}
 		})
+	ftf := gi.AddNewTextField(tbar, "filter")
+	ftf.SetText(nv.RecFilter)
+	ftf.SetProp("min-width", units.NewEm(8))
+	ftf.Tooltip = "only step through records whose counter values (see RecordCtrs) match this space-separated list of Name=Val expressions, e.g., Cycle=99 -- empty = all records"
+	ftf.TextFieldSig.Connect(nv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig == int64(gi.TextFieldDone) {
+			nvv := recv.Embed(KiT_NetView).(*NetView)
+			tf := send.(*gi.TextField)
+			if _, err := ParseCtrFilter(tf.Text()); err != nil {
+				log.Println(err)
+				return
+			}
+			nvv.RecFilter = tf.Text()
+			if cf := nvv.RecFilterVals(); cf != nil && nvv.RecNo >= 0 && !cf.Match(nvv.Data.CtrValsRec(nvv.RecNo)) {
+				if nvv.RecStepFilter(cf, -1) || nvv.RecStepFilter(cf, 1) {
+					nvv.Update()
+				}
+			}
+		}
+	})
+	rgcb := gi.AddNewComboBox(tbar, "ring")
+	rgcb.SetText("Ring")
+	rgcb.Tooltip = "select which recording ring (timescale) to navigate -- additional rings are added by the simulation by calling AddRing and recorded with RecordRing"
+	rgcb.ComboSig.Connect(nv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		nvv := recv.Embed(KiT_NetView).(*NetView)
+		nm, ok := data.(string)
+		if !ok {
+			return
+		}
+		if nvv.SetRing(nm) == nil {
+			nvv.Update()
+		}
+	})
+	bmcb := gi.AddNewComboBox(tbar, "bookmarks")
+	bmcb.SetText("Bookmarks")
+	bmcb.Tooltip = "jump to a bookmarked record -- bookmarks are set by the simulation by calling Bookmark after Record"
+	bmcb.ComboSig.Connect(nv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		nvv := recv.Embed(KiT_NetView).(*NetView)
+		nm, ok := data.(string)
+		if !ok {
+			return
+		}
+		if nvv.RecGoBookmark(nm) {
+			nvv.Update()
+		}
+	})
+	tbar.AddSeparator("play")
+	tbar.AddAction(gi.ActOpts{Name: "playback", Label: "Play", Icon: "play", Tooltip: "play through the recorded history from the current record, automatically advancing at the given frames per second -- click again to pause"}, nv.This(),
+		func(recv, send ki.Ki, sig int64, data interface{}) {
+			nvv := recv.Embed(KiT_NetView).(*NetView)
+			nvv.Playback.NetView = nvv
+			nvv.Playback.Toggle()
+			nvv.Update()
+		})
+	lpcb := gi.AddNewCheckBox(tbar, "loop")
+	lpcb.Text = "Loop"
+	lpcb.Tooltip = "loop back to the start of the recorded history when reaching the end, instead of stopping"
+	lpcb.SetChecked(nv.Playback.Loop)
+	lpcb.ButtonSig.Connect(nv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig == int64(gi.ButtonToggled) {
+			nvv := recv.Embed(KiT_NetView).(*NetView)
+			nvv.Playback.Loop = send.(*gi.CheckBox).IsChecked()
+		}
+	})
+	fpsb := gi.AddNewSpinBox(tbar, "fps")
+	fpsb.SetMin(0.1)
+	fpsb.SetMax(100)
+	fpsb.SetValue(nv.Playback.FPS)
+	fpsb.Tooltip = "playback speed, in records (frames) per second"
+	fpsb.SpinBoxSig.Connect(nv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		nvv := recv.Embed(KiT_NetView).(*NetView)
+		nvv.Playback.FPS = send.(*gi.SpinBox).Value
+		nvv.Playback.Update()
+	})
 }
 
 // SaveWeights saves the network weights -- when called with giv.CallMethod
@@ -1087,6 +1819,50 @@ func (nv *NetView) OpenWeights(filename gi.FileName) {
 	nv.Net.OpenWtsJSON(filename)
 }
 
+// SaveData saves the full recorded history of network data (see NetData.SaveJSON)
+// -- when called with giv.CallMethod it will auto-prompt for filename
+func (nv *NetView) SaveData(filename gi.FileName) {
+	nv.Data.SaveJSON(filename)
+}
+
+// OpenData opens recorded history of network data previously saved with SaveData,
+// for replaying in the view -- when called with giv.CallMethod it will auto-prompt
+// for filename
+func (nv *NetView) OpenData(filename gi.FileName) {
+	err := nv.Data.OpenJSON(filename)
+	if err != nil {
+		return
+	}
+	nv.Params.MaxRecs = nv.Data.Ring.Max
+	nv.RecNo = -1
+	nv.Update()
+}
+
+// LayRangesDialog opens a dialog for editing the per-layer display range
+// overrides for the current variable (VarParams.LayRanges)
+func (nv *NetView) LayRangesDialog() {
+	vp, ok := nv.VarParams[nv.Var]
+	if !ok {
+		return
+	}
+	if vp.LayRanges == nil {
+		vp.LayRanges = make(map[string]*VarParams)
+	}
+	giv.MapViewDialog(nv.Viewport, &vp.LayRanges, giv.DlgOpts{Title: nv.Nm + " " + nv.Var + " Layer Ranges", Prompt: "per-layer display ranges, keyed by layer name"}, nv.This(),
+		func(recv, send ki.Ki, sig int64, data interface{}) {
+			nvv := recv.Embed(KiT_NetView).(*NetView)
+			for laynm, lvp := range vp.LayRanges {
+				if lvp == nil {
+					lvp = &VarParams{}
+					vp.LayRanges[laynm] = lvp
+				}
+				lvp.Var = vp.Var
+				lvp.Defaults()
+			}
+			nvv.Update()
+		})
+}
+
 // ShowNonDefaultParams shows a dialog of all the parameters that
 // are not at their default values in the network.  Useful for setting params.
 func (nv *NetView) ShowNonDefaultParams() string {
@@ -1126,5 +1902,32 @@ var NetViewProps = ki.Props{
 				}},
 			},
 		}},
+		{"SaveData", ki.Props{
+			"desc": "save full recorded history of network data to file, for later replay",
+			"icon": "file-save",
+			"Args": ki.PropSlice{
+				{"File Name", ki.Props{
+					"ext": ".netdata,.netdata.gz",
+				}},
+			},
+		}},
+		{"OpenData", ki.Props{
+			"desc": "open recorded history of network data from file, for replay",
+			"icon": "file-open",
+			"Args": ki.PropSlice{
+				{"File Name", ki.Props{
+					"ext": ".netdata,.netdata.gz",
+				}},
+			},
+		}},
+		{"SaveVals", ki.Props{
+			"desc": "save values of the current variable for all visible layers at the current record to a table file (comma separated for .csv, else tab separated)",
+			"icon": "file-save",
+			"Args": ki.PropSlice{
+				{"File Name", ki.Props{
+					"ext": ".tsv,.csv",
+				}},
+			},
+		}},
 	},
 }
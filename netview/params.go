@@ -9,18 +9,48 @@ import (
 	"reflect"
 	"strconv"
 
+	"github.com/chewxy/math32"
 	"github.com/emer/etable/minmax"
+	"github.com/goki/gi/gi"
 	"github.com/goki/gi/giv"
 )
 
 // Params holds parameters controlling how the view is rendered
 type Params struct {
-	MaxRecs   int              `min:"1" desc:"maximum number of records to store to enable rewinding through prior states"`
-	UnitSize  float32          `min:"0.1" max:"1" step:"0.1" def:"0.9" desc:"size of a single unit, where 1 = full width and no space.. .9 default"`
-	LayNmSize float32          `min:"0.01" max:".1" step:"0.01" def:"0.05" desc:"size of the layer name labels -- entire network view is unit sized"`
-	ColorMap  giv.ColorMapName `desc:"name of color map to use"`
-	ZeroAlpha float32          `min:"0" max:"1" step:"0.1" def:"0.4" desc:"opacity (0-1) of zero values -- greater magnitude values become increasingly opaque on either side of this minimum"`
-	NetView   *NetView         `copy:"-" json:"-" xml:"-" view:"-" desc:"our netview, for update method"`
+	MaxRecs      int                    `min:"1" desc:"maximum number of records to store to enable rewinding through prior states"`
+	UnitSize     float32                `min:"0.1" max:"1" step:"0.1" def:"0.9" desc:"size of a single unit, where 1 = full width and no space.. .9 default"`
+	LayNmSize    float32                `min:"0.01" max:".1" step:"0.01" def:"0.05" desc:"size of the layer name labels -- entire network view is unit sized"`
+	LayLabels    map[string]*LabelStyle `desc:"per-layer label styles (size, color, orientation, offset, hide), by layer name, for avoiding overlapping labels in dense networks -- layers not listed use the default style -- also set via the context menu (right click) on a label"`
+	ColorMap     giv.ColorMapName       `desc:"name of color map to use"`
+	ZeroAlpha    float32                `min:"0" max:"1" step:"0.1" def:"0.4" desc:"opacity (0-1) of zero values -- greater magnitude values become increasingly opaque on either side of this minimum"`
+	Pools        bool                   `desc:"display 4D layers with one bar per pool, showing the mean (or max, see PoolMax) of the variable across the units in the pool, instead of every unit -- makes pool-level (e.g., inhibitory) dynamics visible in large networks"`
+	PoolMax      bool                   `desc:"in Pools mode, display the value with the maximum magnitude in each pool, instead of the mean"`
+	DownThr      int                    `min:"0" desc:"layers with more than this many units are displayed downsampled, aggregating blocks of DownBlock x DownBlock units into a single displayed cell, so that huge layers do not dominate render time -- 0 = never downsample"`
+	DownBlock    int                    `min:"0" desc:"size of the (square) blocks of units aggregated into each displayed cell when downsampling (see DownThr) -- 0 = automatically choose the smallest block size that results in no more than DownThr cells"`
+	DownMax      bool                   `desc:"when downsampling, display the value with the maximum magnitude in each block, instead of the mean"`
+	Grid2D       bool                   `desc:"display each layer as a flat 2D color grid instead of the 3D view -- faster and more compact for deep networks when only color readouts are needed"`
+	LayFilter    string                 `desc:"if non-empty, only layers whose names match one of these space-separated patterns (e.g., V1 IT* Out?) are shown -- see also NetView.HideLays and the Layers toolbar action"`
+	Raster       bool                   `desc:"display layers in raster mode, where the X axis of each layer shows time (recorded history) and the Z axis shows all the units in the layer -- shows the activity (e.g., spiking) history at a glance"`
+	RasterRecs   int                    `min:"1" def:"100" desc:"number of most recent records (time steps) to display in Raster mode, ending at the current record"`
+	Ortho        bool                   `desc:"use an orthographic camera projection instead of perspective, so that layers at different depths are displayed at the same scale -- useful with the camera presets (Top, Front, Side, Iso) in the Viewbar"`
+	Scene        ScenePresets           `desc:"lighting and background preset for the 3D view: white (standard), dark (dark background with brighter lights and light text), or high-contrast (for projectors and publication figures)"`
+	Legend       bool                   `desc:"show a colorbar legend for the current variable to the left of the network, with the display range and tick values, so that screenshots are self-documenting"`
+	LegendTicks  int                    `min:"2" def:"5" desc:"number of tick values shown on the colorbar legend, including the min and max"`
+	FindColor    gi.Color               `desc:"color used to highlight units matching the NetView Find condition"`
+	Inspector    bool                   `desc:"show a side panel with all the unit variables and synaptic values (PrjnVar) of the unit that is clicked on"`
+	TimePlot     bool                   `desc:"when a unit is clicked, show a plot of the current variable for that unit across all of the recorded history, in a separate window"`
+	SynInspect   bool                   `desc:"when viewing a projection variable (r. or s.) for the selected unit, clicking on a unit in another layer shows the values of all the synapse variables between the two units, instead of selecting it"`
+	PrjnLines    bool                   `desc:"draw 3D connection lines between the selected unit (click on a unit to select) and all of the units it receives from and sends to, colored by PrjnVar"`
+	PrjnVar      string                 `desc:"synapse variable to use for coloring the connection lines (e.g., Wt) -- uses the display range of the corresponding r. and s. variables"`
+	PrjnThr      float32                `min:"0" def:"0" desc:"connections with absolute values of PrjnVar below this threshold are not drawn"`
+	PrjnWidth    float32                `min:"0" def:"0.002" desc:"width of the connection lines, in normalized view units (entire network view is unit sized)"`
+	PrjnBoth     bool                   `desc:"when viewing a projection variable (r. or s.) for the selected unit, show both its receiving weights (r.) on the layers it receives from and its sending weights (s.) on the layers it sends to, at the same time -- the variable selected determines which is shown for layers that do both, and sending values are colored with SendColorMap (unless the s. variable has its own color map)"`
+	SendColorMap giv.ColorMapName       `desc:"color map used for the sending (s.) values in PrjnBoth mode, to distinguish them from the receiving (r.) values"`
+	PrjnStats    bool                   `desc:"show a small heatmap plane between each pair of connected layers, with one cell for each of PrjnStatVars showing its mean absolute value across all synapses in the projection, relative to the max across projections and records -- shows which pathways are learning at a glance"`
+	PrjnStatVars []string               `desc:"synapse variables to record and display for PrjnStats, left to right in each plane"`
+	PrjnStatSize float32                `min:"0" def:"0.04" desc:"size of each cell in the PrjnStats planes, in normalized view units (entire network view is unit sized)"`
+	MaxRate      float32                `min:"0" def:"0" desc:"maximum rate of display updates per second from GoUpdate (e.g., 30) -- calls coming faster than this are skipped, so that calling GoUpdate every cycle does not slow down the simulation, while all Records are still stored for review -- 0 = no limit"`
+	NetView      *NetView               `copy:"-" json:"-" xml:"-" view:"-" desc:"our netview, for update method"`
 }
 
 func (nv *Params) Defaults() {
@@ -36,6 +66,30 @@ func (nv *Params) Defaults() {
 	if nv.ZeroAlpha == 0 {
 		nv.ZeroAlpha = 0.4
 	}
+	if nv.RasterRecs == 0 {
+		nv.RasterRecs = 100
+	}
+	if nv.LegendTicks == 0 {
+		nv.LegendTicks = 5
+	}
+	if nv.PrjnVar == "" {
+		nv.PrjnVar = "Wt"
+	}
+	if nv.PrjnWidth == 0 {
+		nv.PrjnWidth = 0.002
+	}
+	if nv.PrjnStatVars == nil {
+		nv.PrjnStatVars = []string{"Wt", "DWt"}
+	}
+	if nv.PrjnStatSize == 0 {
+		nv.PrjnStatSize = 0.04
+	}
+	if nv.FindColor.IsNil() {
+		nv.FindColor.SetUInt8(0, 255, 0, 255)
+	}
+	if nv.SendColorMap == "" {
+		nv.SendColorMap = giv.ColorMapName("Viridis")
+	}
 	if nv.ColorMap == "" {
 		nv.ColorMap = giv.ColorMapName("ColdHot")
 	}
@@ -51,10 +105,13 @@ func (nv *Params) Update() {
 
 // VarParams holds parameters for display of each variable
 type VarParams struct {
-	Var     string         `desc:"name of the variable"`
-	ZeroCtr bool           `desc:"keep Min - Max centered around 0, and use negative heights for units -- else use full min-max range for height (no negative heights)"`
-	Range   minmax.Range32 `view:"inline" desc:"range to display"`
-	MinMax  minmax.F32     `view:"inline" desc:"if not using fixed range, this is the actual range of data"`
+	Var       string                `desc:"name of the variable"`
+	Cat       string                `desc:"category of the variable, from its cat property (see emer.UnitVarCats)"`
+	ColorMap  giv.ColorMapName      `desc:"name of color map to use for this variable -- if empty, the default Params.ColorMap is used"`
+	ZeroCtr   bool                  `desc:"keep Min - Max centered around 0, and use negative heights for units -- else use full min-max range for height (no negative heights)"`
+	Range     minmax.Range32        `view:"inline" desc:"range to display"`
+	MinMax    minmax.F32            `view:"inline" desc:"if not using fixed range, this is the actual range of data"`
+	LayRanges map[string]*VarParams `desc:"optional per-layer overrides of the display range for this variable, keyed by layer name -- layers with overrides are scaled independently and excluded from the autoscaling of the main Range"`
 }
 
 // Defaults sets default values if otherwise not set
@@ -107,6 +164,12 @@ func (vp *VarParams) SetProps(pstr string) {
 			vp.Range.FixMax = true
 		}
 	}
+	if tv, ok := rstr.Lookup("cat"); ok {
+		vp.Cat = tv
+	}
+	if tv, ok := rstr.Lookup("colormap"); ok {
+		vp.ColorMap = giv.ColorMapName(tv)
+	}
 	if tv, ok := rstr.Lookup("zeroctr"); ok {
 		if tv == "+" {
 			vp.ZeroCtr = true
@@ -115,3 +178,81 @@ func (vp *VarParams) SetProps(pstr string) {
 		}
 	}
 }
+
+// LayParams returns the per-layer override params for given layer, or nil if none
+func (vp *VarParams) LayParams(laynm string) *VarParams {
+	if vp.LayRanges == nil {
+		return nil
+	}
+	return vp.LayRanges[laynm]
+}
+
+// SetLayRange sets a per-layer override of the display range for given layer,
+// with fixed min and max values.
+func (vp *VarParams) SetLayRange(laynm string, min, max float32) *VarParams {
+	lvp := vp.newLayParams(laynm)
+	lvp.ZeroCtr = (min == -max)
+	lvp.Range.SetMin(min)
+	lvp.Range.SetMax(max)
+	return lvp
+}
+
+// SetLayAutoScale sets a per-layer override of the display range for given layer,
+// which is automatically scaled to the range of data in that layer only.
+func (vp *VarParams) SetLayAutoScale(laynm string) *VarParams {
+	lvp := vp.newLayParams(laynm)
+	lvp.Range.FixMin = false
+	lvp.Range.FixMax = false
+	return lvp
+}
+
+// DeleteLayRange removes any per-layer override for given layer
+func (vp *VarParams) DeleteLayRange(laynm string) {
+	delete(vp.LayRanges, laynm)
+}
+
+// newLayParams returns the per-layer override for given layer,
+// creating it if needed as a copy of our own params
+func (vp *VarParams) newLayParams(laynm string) *VarParams {
+	if lvp := vp.LayParams(laynm); lvp != nil {
+		return lvp
+	}
+	if vp.LayRanges == nil {
+		vp.LayRanges = make(map[string]*VarParams)
+	}
+	lvp := &VarParams{Var: vp.Var, ZeroCtr: vp.ZeroCtr, Range: vp.Range}
+	vp.LayRanges[laynm] = lvp
+	return lvp
+}
+
+// AutoScale updates the display Range based on given min, max range of the data,
+// for any ends of the range that are not fixed.  Returns true if the range changed.
+func (vp *VarParams) AutoScale(min, max float32) bool {
+	needUpdt := false
+	vp.MinMax.Set(min, max)
+	if !vp.Range.FixMin {
+		nmin := float32(minmax.NiceRoundNumber(float64(min), true)) // true = below
+		if vp.Range.Min != nmin {
+			vp.Range.Min = nmin
+			needUpdt = true
+		}
+	}
+	if !vp.Range.FixMax {
+		nmax := float32(minmax.NiceRoundNumber(float64(max), false)) // false = above
+		if vp.Range.Max != nmax {
+			vp.Range.Max = nmax
+			needUpdt = true
+		}
+	}
+	if vp.ZeroCtr && !vp.Range.FixMin && !vp.Range.FixMax {
+		bmax := math32.Max(math32.Abs(vp.Range.Max), math32.Abs(vp.Range.Min))
+		if !needUpdt {
+			if vp.Range.Max != bmax || vp.Range.Min != -bmax {
+				needUpdt = true
+			}
+		}
+		vp.Range.Max = bmax
+		vp.Range.Min = -bmax
+	}
+	return needUpdt
+}
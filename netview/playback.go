@@ -0,0 +1,157 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"sync"
+	"time"
+)
+
+// Playback holds parameters and state for automatically stepping the view
+// through the recorded history (RecNo) at a given frame rate, so that the
+// dynamics can be watched as an animation.
+type Playback struct {
+	FPS     float32  `min:"0.1" def:"10" desc:"number of records (frames) to advance per second"`
+	Step    int      `min:"1" def:"1" desc:"number of records to advance per frame -- larger values play faster without requiring a higher frame rate"`
+	Loop    bool     `desc:"loop back to the start of the recorded history when reaching the end, instead of stopping"`
+	Playing bool     `inactive:"+" desc:"true if currently playing"`
+	NetView *NetView `copy:"-" json:"-" xml:"-" view:"-" desc:"our netview"`
+	stop    chan struct{}
+	mu      sync.Mutex
+}
+
+// Defaults sets default values if otherwise not set
+func (pb *Playback) Defaults() {
+	if pb.FPS == 0 {
+		pb.FPS = 10
+	}
+	if pb.Step == 0 {
+		pb.Step = 1
+	}
+}
+
+// Interval returns the time between frames based on FPS
+func (pb *Playback) Interval() time.Duration {
+	fps := pb.FPS
+	if fps <= 0 {
+		fps = 10
+	}
+	return time.Duration(float64(time.Second) / float64(fps))
+}
+
+// Play starts playing from the current record, or from the start if
+// currently tracking the latest record (-1) or at the end.
+// Does nothing if already playing.
+func (pb *Playback) Play() {
+	pb.mu.Lock()
+	defer pb.mu.Unlock()
+	if pb.Playing || pb.NetView == nil {
+		return
+	}
+	nv := pb.NetView
+	if nv.Data.Ring.Len == 0 {
+		return
+	}
+	pb.Defaults()
+	if nv.RecNo < 0 || nv.RecNo >= nv.Data.Ring.Len-1 {
+		nv.RecNo = 0
+	}
+	pb.Playing = true
+	pb.stop = make(chan struct{})
+	go pb.run(pb.stop)
+}
+
+// Pause stops playing, leaving the view at the current record
+func (pb *Playback) Pause() {
+	pb.mu.Lock()
+	defer pb.mu.Unlock()
+	if !pb.Playing {
+		return
+	}
+	pb.Playing = false
+	close(pb.stop)
+	pb.stop = nil
+}
+
+// Toggle toggles between Play and Pause
+func (pb *Playback) Toggle() {
+	if pb.Playing {
+		pb.Pause()
+	} else {
+		pb.Play()
+	}
+}
+
+// Advance moves the view forward by Step records, wrapping around
+// to the start if Loop is on.  Returns false if at the end and not looping.
+func (pb *Playback) Advance() bool {
+	nv := pb.NetView
+	last := nv.Data.Ring.Len - 1
+	if last < 0 {
+		return false
+	}
+	step := pb.Step
+	if step < 1 {
+		step = 1
+	}
+	if cf := nv.RecFilterVals(); cf != nil {
+		if nv.RecStepFilter(cf, step) {
+			return true
+		}
+		if !pb.Loop {
+			return false
+		}
+		nv.RecNo = 0
+		if cf.Match(nv.Data.CtrValsRec(0)) {
+			return true
+		}
+		return nv.RecStepFilter(cf, 1)
+	}
+	if nv.RecNo < 0 {
+		nv.RecNo = 0
+		return true
+	}
+	if nv.RecNo >= last {
+		if !pb.Loop {
+			return false
+		}
+		nv.RecNo = 0
+		return true
+	}
+	nv.RecNo += step
+	if nv.RecNo > last {
+		nv.RecNo = last
+	}
+	return true
+}
+
+// run is the playback goroutine, advancing and updating the view each frame
+// until stopped or the end is reached.
+func (pb *Playback) run(stop chan struct{}) {
+	tick := time.NewTicker(pb.Interval())
+	defer tick.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-tick.C:
+			if !pb.Advance() {
+				pb.Pause()
+				pb.NetView.GoUpdate() // updates play button state
+				return
+			}
+			pb.NetView.GoUpdate()
+		}
+	}
+}
+
+// Update satisfies the gi.Updater interface, called after edits
+func (pb *Playback) Update() {
+	pb.Defaults()
+	if pb.Playing { // restart to pick up new frame rate
+		pb.Pause()
+		pb.Play()
+	}
+}
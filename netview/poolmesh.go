@@ -0,0 +1,154 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"github.com/emer/emergent/emer"
+	"github.com/emer/etable/etensor"
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/gi3d"
+	"github.com/goki/gi/mat32"
+	"github.com/goki/ki/kit"
+)
+
+// PoolMesh is a gi3d.Mesh that represents a 4D layer at the pool level
+// (see Params.Pools), with one bar per pool showing the mean (or max, see
+// Params.PoolMax) of the variable across the units in the pool.
+// Each bar occupies the footprint of its pool in the normal (LayMesh) view.
+type PoolMesh struct {
+	gi3d.MeshBase
+	Lay   emer.Layer    `desc:"layer that we render"`
+	Var   string        `desc:"variable that we render -- empty = the main NetView Var"`
+	Shape etensor.Shape `desc:"current shape that has been constructed -- if same, just update"`
+	View  *NetView      `desc:"netview that we're in"`
+	idxs  []int         `desc:"unit indexes for current pool"`
+}
+
+var KiT_PoolMesh = kit.Types.AddType(&PoolMesh{}, nil)
+
+// AddNewPoolMeshVar adds PoolMesh mesh to given scene for given layer,
+// displaying given variable (empty = main NetView Var), named via LayMeshName
+func AddNewPoolMeshVar(sc *gi3d.Scene, nv *NetView, lay emer.Layer, vnm string) *PoolMesh {
+	pm := &PoolMesh{}
+	pm.View = nv
+	pm.Lay = lay
+	pm.Var = vnm
+	pm.Nm = LayMeshName(lay, vnm)
+	sc.AddMesh(pm)
+	return pm
+}
+
+// PoolVal returns the scaled value and color for given pool,
+// aggregating over the units in the pool
+func (pm *PoolMesh) PoolVal(zpi, xpi int) (scaled float32, clr gi.Color) {
+	nv := pm.View
+	vnm := pm.Var
+	if vnm == "" {
+		vnm = nv.Var
+	}
+	nuz := pm.Shape.Dim(2)
+	nux := pm.Shape.Dim(3)
+	pm.idxs = pm.idxs[:0]
+	for zui := 0; zui < nuz; zui++ {
+		for xui := 0; xui < nux; xui++ {
+			pm.idxs = append(pm.idxs, pm.Shape.Offset([]int{zpi, xpi, zui, xui}))
+		}
+	}
+	return nv.AggValColor(pm.Lay, vnm, pm.idxs, nv.Params.PoolMax)
+}
+
+func (pm *PoolMesh) Make(sc *gi3d.Scene) {
+	if pm.Lay == nil {
+		pm.Shape.SetShape(nil, nil, nil)
+		pm.Reset()
+	}
+	shp := pm.Lay.Shape()
+	pm.Reset()
+	pm.Shape.CopyShape(shp)
+	if pm.Shape.NumDims() != 4 {
+		return // nothing
+	}
+	pm.MakePools(true) // true = init
+}
+
+func (pm *PoolMesh) Update(sc *gi3d.Scene) {
+	if pm.Shape.NumDims() != 4 {
+		return // nothing
+	}
+	pm.MakePools(false) // false = not init
+	pm.SetVtxData(sc)
+	pm.SetColorData(sc)
+	pm.SetNormData(sc)
+	pm.Activate(sc)
+	pm.TransferVectors()
+}
+
+// MakePools makes the geometry, one bar per pool, using the same
+// pool layout as LayMesh.Make4D
+func (pm *PoolMesh) MakePools(init bool) {
+	pm.Trans = true
+	pm.Dynamic = true
+	npz := pm.Shape.Dim(0) // p = pool
+	npx := pm.Shape.Dim(1)
+	nuz := pm.Shape.Dim(2) // u = unit
+	nux := pm.Shape.Dim(3)
+
+	fnpz := float32(npz)
+	fnpx := float32(npx)
+	fnuz := float32(nuz)
+	fnux := float32(nux)
+
+	usz := pm.View.Params.UnitSize
+	uo := (1.0 - usz) // offset = space
+
+	xsc := (fnpx * fnux) / ((fnpx-1)*uo + (fnpx * fnux))
+	zsc := (fnpz * fnuz) / ((fnpz-1)*uo + (fnpz * fnuz))
+
+	xpw := xsc * (fnux - uo) // pool width
+	zpw := zsc * (fnuz - uo)
+
+	segs := 1
+
+	vtxSz, idxSz := pm.PlaneSize(segs, segs)
+	nvtx := vtxSz * 5 * npz * npx
+	nidx := idxSz * 5 * npz * npx
+	pm.Alloc(nvtx, nidx, true)
+
+	pidx := 0 // plane index
+
+	setNorm := true // can change -- always set
+	setTex := init
+	setIdx := init
+
+	for zpi := npz - 1; zpi >= 0; zpi-- {
+		z0 := zsc*(-float32(zpi)*(uo+fnuz)) - zpw
+		for xpi := 0; xpi < npx; xpi++ {
+			poff := pidx * vtxSz * 5
+			ioff := pidx * idxSz * 5
+			x0 := xsc * (float32(xpi)*uo + float32(xpi)*fnux + uo)
+			scaled, clr := pm.PoolVal(zpi, xpi)
+			ht := 0.5 * mat32.Abs(scaled)
+			if ht < MinUnitHeight {
+				ht = MinUnitHeight
+			}
+			if scaled >= 0 {
+				pm.SetPlane(poff, ioff, setNorm, setTex, setIdx, mat32.X, mat32.Y, -1, -1, xpw, ht, x0, 0, z0, segs, segs, clr)                     // nz
+				pm.SetPlane(poff+1*vtxSz, ioff+1*idxSz, setNorm, setTex, setIdx, mat32.Z, mat32.Y, -1, -1, zpw, ht, z0, 0, x0+xpw, segs, segs, clr) // px
+				pm.SetPlane(poff+2*vtxSz, ioff+2*idxSz, setNorm, setTex, setIdx, mat32.Z, mat32.Y, 1, -1, zpw, ht, z0, 0, x0, segs, segs, clr)      // nx
+				pm.SetPlane(poff+3*vtxSz, ioff+3*idxSz, setNorm, setTex, setIdx, mat32.X, mat32.Z, 1, 1, xpw, zpw, x0, z0, ht, segs, segs, clr)     // py <-
+				pm.SetPlane(poff+4*vtxSz, ioff+4*idxSz, setNorm, setTex, setIdx, mat32.X, mat32.Y, 1, -1, xpw, ht, x0, 0, z0+zpw, segs, segs, clr)  // pz
+			} else {
+				pm.SetPlane(poff, ioff, setNorm, setTex, setIdx, mat32.X, mat32.Y, 1, -1, xpw, ht, x0, -ht, z0, segs, segs, clr)                     // nz = pz norm
+				pm.SetPlane(poff+1*vtxSz, ioff+1*idxSz, setNorm, setTex, setIdx, mat32.Z, mat32.Y, 1, -1, zpw, ht, z0, -ht, x0+xpw, segs, segs, clr) // px = nx norm
+				pm.SetPlane(poff+2*vtxSz, ioff+2*idxSz, setNorm, setTex, setIdx, mat32.Z, mat32.Y, 1, -1, zpw, ht, z0, -ht, x0, segs, segs, clr)     // nx
+				pm.SetPlane(poff+3*vtxSz, ioff+3*idxSz, setNorm, setTex, setIdx, mat32.X, mat32.Z, 1, 1, xpw, zpw, x0, z0, -ht, segs, segs, clr)     // ny <-
+				pm.SetPlane(poff+4*vtxSz, ioff+4*idxSz, setNorm, setTex, setIdx, mat32.X, mat32.Y, 1, -1, xpw, ht, x0, -ht, z0+zpw, segs, segs, clr) // pz
+			}
+			pidx++
+		}
+	}
+
+	pm.BBox.SetBounds(mat32.Vec3{0, -0.5, -fnpz * fnuz}, mat32.Vec3{fnpx * fnux, 0.5, 0})
+}
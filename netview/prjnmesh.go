@@ -0,0 +1,239 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"github.com/chewxy/math32"
+	"github.com/emer/emergent/emer"
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/gi3d"
+	"github.com/goki/gi/mat32"
+	"github.com/goki/ki/kit"
+)
+
+// PrjnMeshName is the name of the mesh used for projection connection lines
+const PrjnMeshName = "netview-prjn-lines"
+
+// PrjnLine is one connection line to render, in scene coordinates
+type PrjnLine struct {
+	St  mat32.Vec3 `desc:"starting point (sending unit)"`
+	Ed  mat32.Vec3 `desc:"ending point (receiving unit)"`
+	Clr gi.Color   `desc:"color for the line"`
+}
+
+// PrjnMesh is a gi3d.Mesh that renders the connections of the currently-selected
+// unit (NetData.PrjnLay, PrjnUnIdx) as thin lines between the sending and receiving
+// units, colored by the synapse variable in Params.PrjnVar.
+// Both the receiving connections (into the selected unit) and the sending connections
+// (out of the selected unit) are drawn.  The geometry is directly in scene coordinates,
+// so the object holding it should have an identity Pose.
+type PrjnMesh struct {
+	gi3d.MeshBase
+	View   *NetView   `desc:"netview that we're in"`
+	Lines  []PrjnLine `desc:"current lines being rendered"`
+	NAlloc int        `desc:"number of lines currently allocated"`
+}
+
+var KiT_PrjnMesh = kit.Types.AddType(&PrjnMesh{}, nil)
+
+// AddNewPrjnMesh adds PrjnMesh mesh to given scene
+func AddNewPrjnMesh(sc *gi3d.Scene, nv *NetView) *PrjnMesh {
+	pm := &PrjnMesh{}
+	pm.View = nv
+	pm.Nm = PrjnMeshName
+	sc.AddMesh(pm)
+	return pm
+}
+
+func (pm *PrjnMesh) Make(sc *gi3d.Scene) {
+	pm.Reset()
+	pm.Lines = pm.View.PrjnLines()
+	pm.MakeLines(true)
+}
+
+func (pm *PrjnMesh) Update(sc *gi3d.Scene) {
+	pm.Lines = pm.View.PrjnLines()
+	nl := len(pm.Lines)
+	if nl == 0 {
+		nl = 1
+	}
+	init := nl != pm.NAlloc
+	pm.MakeLines(init)
+	pm.SetVtxData(sc)
+	pm.SetColorData(sc)
+	pm.SetNormData(sc)
+	if init {
+		pm.SetTexData(sc)
+		pm.SetIdxData(sc)
+	}
+	pm.Activate(sc)
+	if init {
+		pm.TransferAll()
+	} else {
+		pm.TransferVectors()
+	}
+}
+
+// MakeLines constructs the line geometry, as one flat quad per line,
+// oriented to face upward as much as possible.  If there are no lines,
+// a single degenerate quad is made to keep the buffers valid.
+func (pm *PrjnMesh) MakeLines(init bool) {
+	pm.Trans = true
+	pm.Dynamic = true
+	nl := len(pm.Lines)
+	na := nl
+	if na == 0 {
+		na = 1
+	}
+	if init || na != pm.NAlloc {
+		pm.Alloc(4*na, 6*na, true)
+		pm.NAlloc = na
+		init = true
+	}
+	wd := 0.5 * pm.View.Params.PrjnWidth
+	var bmin, bmax mat32.Vec3
+	for li := 0; li < na; li++ {
+		var ln PrjnLine
+		if li < nl {
+			ln = pm.Lines[li]
+		}
+		dir := ln.Ed.Sub(ln.St)
+		perp := dir.Cross(mat32.Vec3{0, 1, 0})
+		if perp.Length() < 1.0e-6 {
+			perp = mat32.Vec3{1, 0, 0}
+		}
+		perp = perp.Normal().MulScalar(wd)
+		norm := perp.Cross(dir)
+		if norm.Length() < 1.0e-6 {
+			norm = mat32.Vec3{0, 1, 0}
+		}
+		norm = norm.Normal()
+		r, g, b, a := ln.Clr.ToNPFloat32()
+		pts := [4]mat32.Vec3{ln.St.Sub(perp), ln.St.Add(perp), ln.Ed.Add(perp), ln.Ed.Sub(perp)}
+		vi := li * 4
+		for pi, pt := range pts {
+			pm.Vtx.Set((vi+pi)*3, pt.X, pt.Y, pt.Z)
+			pm.Norm.Set((vi+pi)*3, norm.X, norm.Y, norm.Z)
+			pm.Color.Set((vi+pi)*4, r, g, b, a)
+			if li == 0 && pi == 0 {
+				bmin, bmax = pt, pt
+			} else {
+				bmin.SetMin(pt)
+				bmax.SetMax(pt)
+			}
+		}
+		if init {
+			pm.Tex.Set(vi*2, 0, 0, 1, 0, 1, 1, 0, 1)
+			uv := uint32(vi)
+			pm.Idx.Set(li*6, uv, uv+1, uv+2, uv, uv+2, uv+3)
+		}
+	}
+	pm.BBox.SetBounds(bmin, bmax)
+}
+
+// UnitPos returns the position in scene coordinates of the top center of the
+// given unit in given layer, which is where projection lines attach.
+// Uses the same geometry as the LayMesh and the layer Pose set in ViewConfig.
+func (nv *NetView) UnitPos(lay emer.Layer, idx []int) mat32.Vec3 {
+	var lp mat32.Vec3
+	shp := lay.Shape()
+	usz := nv.Params.UnitSize
+	uo := (1.0 - usz)
+	if shp.NumDims() == 4 {
+		fnpz := float32(shp.Dim(0))
+		fnpx := float32(shp.Dim(1))
+		fnuz := float32(shp.Dim(2))
+		fnux := float32(shp.Dim(3))
+		xsc := (fnpx * fnux) / ((fnpx-1)*uo + (fnpx * fnux))
+		zsc := (fnpz * fnuz) / ((fnpz-1)*uo + (fnpz * fnuz))
+		zp0 := zsc * (-float32(idx[0]) * (uo + fnuz))
+		xp0 := xsc * (float32(idx[1])*uo + float32(idx[1])*fnux)
+		lp.Z = zp0 + zsc*(uo-float32(idx[2]+1)) + 0.5*zsc*usz
+		lp.X = xp0 + xsc*(uo+float32(idx[3])) + 0.5*xsc*usz
+	} else if shp.NumDims() == 2 {
+		lp.Z = uo - float32(idx[0]+1) + 0.5*usz
+		lp.X = uo + float32(idx[1]) + 0.5*usz
+	}
+	lg := nv.LayerByName(lay.Name())
+	if lg == nil {
+		return lp
+	}
+	return lg.Pose.Pos.Add(lp.Mul(lg.Pose.Scale))
+}
+
+// PrjnLines returns the list of projection lines to render for the currently
+// selected unit (NetData.PrjnLay, PrjnUnIdx), if Params.PrjnLines is on.
+// Receiving connections use the "r." VarParams for the Params.PrjnVar synapse
+// variable, and sending connections use the "s." VarParams.
+// Connections with absolute value below Params.PrjnThr are skipped.
+func (nv *NetView) PrjnLines() []PrjnLine {
+	if !nv.Params.PrjnLines || nv.Net == nil || nv.Data.PrjnLay == "" {
+		return nil
+	}
+	lay := nv.Net.LayerByName(nv.Data.PrjnLay)
+	if lay == nil || !nv.LayVisible(lay.Name()) {
+		return nil
+	}
+	ui := nv.Data.PrjnUnIdx
+	shp := lay.Shape()
+	if ui < 0 || ui >= shp.Len() {
+		return nil
+	}
+	upos := nv.UnitPos(lay, shp.Index(ui))
+	var lns []PrjnLine
+	for pi := 0; pi < lay.NRecvPrjns(); pi++ {
+		pj := lay.RecvPrjn(pi)
+		if pj.IsOff() {
+			continue
+		}
+		lns = nv.appendPrjnLines(lns, pj, pj.SendLay(), "r.", upos, ui, true)
+	}
+	for pi := 0; pi < lay.NSendPrjns(); pi++ {
+		pj := lay.SendPrjn(pi)
+		if pj.IsOff() {
+			continue
+		}
+		lns = nv.appendPrjnLines(lns, pj, pj.RecvLay(), "s.", upos, ui, false)
+	}
+	return lns
+}
+
+// appendPrjnLines adds lines for all connections of given unit through given projection,
+// where olay is the other layer and recv indicates if the unit is the receiver.
+func (nv *NetView) appendPrjnLines(lns []PrjnLine, pj emer.Prjn, olay emer.Layer, pfx string, upos mat32.Vec3, ui int, recv bool) []PrjnLine {
+	if !nv.LayVisible(olay.Name()) {
+		return lns
+	}
+	vp, ok := nv.VarParams[pfx+nv.Params.PrjnVar]
+	if !ok {
+		return lns
+	}
+	cmap := nv.VarColorMap(vp)
+	oshp := olay.Shape()
+	no := oshp.Len()
+	for oi := 0; oi < no; oi++ {
+		var val float32
+		if recv {
+			val = pj.SynVal(nv.Params.PrjnVar, oi, ui)
+		} else {
+			val = pj.SynVal(nv.Params.PrjnVar, ui, oi)
+		}
+		if math32.IsNaN(val) || mat32.Abs(val) < nv.Params.PrjnThr {
+			continue
+		}
+		opos := nv.UnitPos(olay, oshp.Index(oi))
+		clp := vp.Range.ClipVal(val)
+		norm := vp.Range.NormVal(clp)
+		clr := cmap.Map(float64(norm))
+		ln := PrjnLine{Clr: clr}
+		if recv {
+			ln.St, ln.Ed = opos, upos
+		} else {
+			ln.St, ln.Ed = upos, opos
+		}
+		lns = append(lns, ln)
+	}
+	return lns
+}
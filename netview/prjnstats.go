@@ -0,0 +1,324 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"math"
+
+	"github.com/chewxy/math32"
+	"github.com/emer/emergent/emer"
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/gi3d"
+	"github.com/goki/gi/mat32"
+	"github.com/goki/ki/kit"
+)
+
+// PrjnList returns the list of all projections in the network, in the order
+// used for the per-projection stats: for each layer, its receiving projections.
+func (nd *NetData) PrjnList() []emer.Prjn {
+	var pjs []emer.Prjn
+	nlay := nd.Net.NLayers()
+	for li := 0; li < nlay; li++ {
+		lay := nd.Net.Layer(li)
+		for pi := 0; pi < lay.NRecvPrjns(); pi++ {
+			pjs = append(pjs, lay.RecvPrjn(pi))
+		}
+	}
+	return pjs
+}
+
+// PrjnStatsConfig configures the storage for per-projection stats (see PrjnVars),
+// only re-allocating if needed.  Called by Config.
+func (nd *NetData) PrjnStatsConfig() {
+	if len(nd.PrjnVars) == 0 {
+		nd.PrjnNames = nil
+		nd.PrjnData = nil
+		return
+	}
+	pjs := nd.PrjnList()
+	if len(nd.PrjnNames) != len(pjs) {
+		nd.PrjnNames = make([]string, len(pjs))
+	}
+	for pi, pj := range pjs {
+		nd.PrjnNames[pi] = pj.Label()
+	}
+	ptot := nd.Ring.Max * len(pjs) * len(nd.PrjnVars)
+	if len(nd.PrjnData) != ptot {
+		nd.PrjnData = make([]float32, ptot)
+		for i := range nd.PrjnData {
+			nd.PrjnData[i] = math32.NaN()
+		}
+	}
+}
+
+// RecordPrjnStats records the mean absolute value of each of the PrjnVars
+// across all synapses in each projection, at given ring index.
+// Variables not available in a projection are recorded as NaN.
+func (nd *NetData) RecordPrjnStats(ridx int) {
+	nvar := len(nd.PrjnVars)
+	if nvar == 0 {
+		return
+	}
+	pjs := nd.PrjnList()
+	if len(pjs) != len(nd.PrjnNames) {
+		return
+	}
+	st := ridx * len(pjs) * nvar
+	nd.prjnStats(pjs, nd.PrjnData[st:st+len(pjs)*nvar])
+}
+
+// prjnStats computes the PrjnVars stats for given projections into dst,
+// which must be len(pjs) * len(PrjnVars)
+func (nd *NetData) prjnStats(pjs []emer.Prjn, dst []float32) {
+	nvar := len(nd.PrjnVars)
+	for pi, pj := range pjs {
+		for vi, vnm := range nd.PrjnVars {
+			val := math32.NaN()
+			if err := pj.SynVals(&nd.synTmp, vnm); err == nil {
+				sum := float32(0)
+				n := 0
+				for _, sv := range nd.synTmp {
+					if !math32.IsNaN(sv) {
+						sum += math32.Abs(sv)
+						n++
+					}
+				}
+				if n > 0 {
+					val = sum / float32(n)
+				}
+			}
+			dst[pi*nvar+vi] = val
+		}
+	}
+}
+
+// PrjnStat returns the recorded stat for given projection (index into PrjnNames)
+// and variable (index into PrjnVars), for given record number, which is -1 for
+// current (last) record, or in [0..Len-1] for prior records.
+// Returns false if not available (including NaN).
+func (nd *NetData) PrjnStat(pi, vi int, recno int) (float32, bool) {
+	nvar := len(nd.PrjnVars)
+	npj := len(nd.PrjnNames)
+	if nd.Ring.Len == 0 || pi < 0 || pi >= npj || vi < 0 || vi >= nvar || len(nd.PrjnData) != nd.Ring.Max*npj*nvar {
+		return 0, false
+	}
+	val := nd.PrjnData[nd.RecIdx(recno)*npj*nvar+pi*nvar+vi]
+	if math32.IsNaN(val) {
+		return 0, false
+	}
+	return val, true
+}
+
+// PrjnStatMax returns the maximum recorded stat for given variable (index into
+// PrjnVars) across all projections and records.  Returns false if none.
+func (nd *NetData) PrjnStatMax(vi int) (float32, bool) {
+	var mx float32 = -math.MaxFloat32
+	got := false
+	for ri := 0; ri < nd.Ring.Len; ri++ {
+		for pi := range nd.PrjnNames {
+			if val, ok := nd.PrjnStat(pi, vi, ri); ok {
+				mx = math32.Max(mx, val)
+				got = true
+			}
+		}
+	}
+	return mx, got
+}
+
+//////////////////////////////////////////////////////////////////////////////
+//  PrjnStatMesh
+
+// PrjnStatMeshName is the name of the mesh used for projection summary planes
+const PrjnStatMeshName = "netview-prjn-stats"
+
+// PrjnStatCell is one cell of a projection summary plane, in scene coordinates
+type PrjnStatCell struct {
+	Pos mat32.Vec3 `desc:"center of the cell"`
+	Clr gi.Color   `desc:"color of the cell"`
+}
+
+// PrjnStatMesh is a gi3d.Mesh that renders a small flat heatmap plane between
+// each pair of connected layers, with one cell for each of the NetData.PrjnVars
+// (left to right), colored by the mean absolute value of that variable across
+// all synapses in the projection, relative to the maximum across all projections
+// and records.  This gives a quick sense of which pathways are learning (e.g., DWt)
+// without inspecting individual weights.  The geometry is directly in scene
+// coordinates, so the object holding it should have an identity Pose.
+type PrjnStatMesh struct {
+	gi3d.MeshBase
+	View   *NetView       `desc:"netview that we're in"`
+	Cells  []PrjnStatCell `desc:"current cells being rendered"`
+	NAlloc int            `desc:"number of cells currently allocated"`
+}
+
+var KiT_PrjnStatMesh = kit.Types.AddType(&PrjnStatMesh{}, nil)
+
+// AddNewPrjnStatMesh adds PrjnStatMesh mesh to given scene
+func AddNewPrjnStatMesh(sc *gi3d.Scene, nv *NetView) *PrjnStatMesh {
+	pm := &PrjnStatMesh{}
+	pm.View = nv
+	pm.Nm = PrjnStatMeshName
+	sc.AddMesh(pm)
+	return pm
+}
+
+func (pm *PrjnStatMesh) Make(sc *gi3d.Scene) {
+	pm.Reset()
+	pm.Cells = pm.View.PrjnStatCells()
+	pm.MakeCells(true)
+}
+
+func (pm *PrjnStatMesh) Update(sc *gi3d.Scene) {
+	pm.Cells = pm.View.PrjnStatCells()
+	nc := len(pm.Cells)
+	if nc == 0 {
+		nc = 1
+	}
+	init := nc != pm.NAlloc
+	pm.MakeCells(init)
+	pm.SetVtxData(sc)
+	pm.SetColorData(sc)
+	pm.SetNormData(sc)
+	if init {
+		pm.SetTexData(sc)
+		pm.SetIdxData(sc)
+	}
+	pm.Activate(sc)
+	if init {
+		pm.TransferAll()
+	} else {
+		pm.TransferVectors()
+	}
+}
+
+// MakeCells constructs the cell geometry, as one upward-facing square per cell,
+// of size Params.PrjnStatSize.  If there are no cells, a single degenerate
+// square is made to keep the buffers valid.
+func (pm *PrjnStatMesh) MakeCells(init bool) {
+	pm.Trans = true
+	pm.Dynamic = true
+	nc := len(pm.Cells)
+	na := nc
+	if na == 0 {
+		na = 1
+	}
+	if init || na != pm.NAlloc {
+		pm.Alloc(4*na, 6*na, true)
+		pm.NAlloc = na
+		init = true
+	}
+	hsz := 0.5 * pm.View.Params.PrjnStatSize
+	var bmin, bmax mat32.Vec3
+	for ci := 0; ci < na; ci++ {
+		var cl PrjnStatCell
+		sz := float32(0)
+		if ci < nc {
+			cl = pm.Cells[ci]
+			sz = hsz
+		}
+		r, g, b, a := cl.Clr.ToNPFloat32()
+		pts := [4]mat32.Vec3{
+			cl.Pos.Add(mat32.Vec3{-sz, 0, sz}),
+			cl.Pos.Add(mat32.Vec3{sz, 0, sz}),
+			cl.Pos.Add(mat32.Vec3{sz, 0, -sz}),
+			cl.Pos.Add(mat32.Vec3{-sz, 0, -sz}),
+		}
+		vi := ci * 4
+		for pi, pt := range pts {
+			pm.Vtx.Set((vi+pi)*3, pt.X, pt.Y, pt.Z)
+			pm.Norm.Set((vi+pi)*3, 0, 1, 0)
+			pm.Color.Set((vi+pi)*4, r, g, b, a)
+			if ci == 0 && pi == 0 {
+				bmin, bmax = pt, pt
+			} else {
+				bmin.SetMin(pt)
+				bmax.SetMax(pt)
+			}
+		}
+		if init {
+			pm.Tex.Set(vi*2, 0, 0, 1, 0, 1, 1, 0, 1)
+			uv := uint32(vi)
+			pm.Idx.Set(ci*6, uv, uv+1, uv+2, uv, uv+2, uv+3)
+		}
+	}
+	pm.BBox.SetBounds(bmin, bmax)
+}
+
+// LayCenter returns the position in scene coordinates of the top center
+// of given layer, based on the positions of its first and last units.
+func (nv *NetView) LayCenter(lay emer.Layer) mat32.Vec3 {
+	shp := lay.Shape()
+	st := nv.UnitPos(lay, shp.Index(0))
+	ed := nv.UnitPos(lay, shp.Index(shp.Len()-1))
+	return st.Add(ed).MulScalar(0.5)
+}
+
+// PrjnStatCells returns the cells of the projection summary planes to render,
+// if Params.PrjnStats is on, for the current record.  Each plane is centered
+// midway between the sending and receiving layers (or just above the layer for
+// self projections), with one cell per NetData.PrjnVars.
+func (nv *NetView) PrjnStatCells() []PrjnStatCell {
+	nd := &nv.Data
+	nvar := len(nd.PrjnVars)
+	if !nv.Params.PrjnStats || nv.Net == nil || nvar == 0 {
+		return nil
+	}
+	pjs := nd.PrjnList()
+	if len(pjs) != len(nd.PrjnNames) {
+		return nil
+	}
+	maxs := make([]float32, nvar)
+	for vi := range maxs {
+		if mx, ok := nd.PrjnStatMax(vi); ok && mx > 0 {
+			maxs[vi] = mx
+		}
+	}
+	csz := nv.Params.PrjnStatSize
+	var cls []PrjnStatCell
+	for pi, pj := range pjs {
+		slay := pj.SendLay()
+		rlay := pj.RecvLay()
+		if pj.IsOff() || !nv.LayVisible(slay.Name()) || !nv.LayVisible(rlay.Name()) {
+			continue
+		}
+		ctr := nv.LayCenter(slay).Add(nv.LayCenter(rlay)).MulScalar(0.5)
+		if slay == rlay {
+			ctr.Y += 2 * csz
+		}
+		ctr.X -= 0.5 * csz * float32(nvar-1)
+		for vi := 0; vi < nvar; vi++ {
+			cl := PrjnStatCell{Pos: ctr.Add(mat32.Vec3{csz * float32(vi), 0, 0})}
+			val, ok := nd.PrjnStat(pi, vi, nv.RecNo)
+			if !ok || maxs[vi] == 0 {
+				cl.Clr.SetUInt8(128, 128, 128, 128)
+			} else {
+				cl.Clr = nv.ColorMap.Map(float64(0.5 + 0.5*val/maxs[vi]))
+			}
+			cls = append(cls, cl)
+		}
+	}
+	return cls
+}
+
+// PrjnStatsConfig configures the "PrjnStats" group holding the projection
+// summary planes (see PrjnStatCells).
+func (nv *NetView) PrjnStatsConfig() {
+	vs := nv.Scene()
+	if vs.MeshByName(PrjnStatMeshName) == nil {
+		AddNewPrjnStatMesh(vs, nv)
+	}
+	psGp, err := vs.ChildByNameTry("PrjnStats", 1)
+	if err != nil {
+		psGp = gi3d.AddNewGroup(vs, vs, "PrjnStats")
+	}
+	psConfig := kit.TypeAndNameList{}
+	psConfig.Add(gi3d.KiT_Object, "planes")
+	psGp.ConfigChildren(psConfig, false)
+	po := psGp.Child(0).(*gi3d.Object)
+	po.SetMeshName(vs, PrjnStatMeshName)
+	po.Mat.Color.SetUInt8(255, 255, 255, 255)
+	po.Mat.CullBack = false // planes are flat and should be visible from both sides
+	po.Mat.CullFront = false
+}
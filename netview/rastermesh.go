@@ -0,0 +1,189 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"github.com/emer/emergent/emer"
+	"github.com/emer/etable/etensor"
+	"github.com/goki/gi/gi3d"
+	"github.com/goki/gi/mat32"
+	"github.com/goki/ki/kit"
+)
+
+// RasterMesh is a gi3d.Mesh that represents a layer in raster mode (Params.Raster),
+// where the X axis shows time (the most recent Params.RasterRecs records in NetData,
+// with the current record on the right) and the Z axis shows the units of the layer,
+// in 1D (flat) index order with unit 0 in front.
+// The raster is scaled to occupy the same footprint as the layer in the normal view.
+type RasterMesh struct {
+	gi3d.MeshBase
+	Lay   emer.Layer    `desc:"layer that we render"`
+	Var   string        `desc:"variable that we render -- empty = the main NetView Var"`
+	Shape etensor.Shape `desc:"current shape that has been constructed -- if same, just update"`
+	NRecs int           `desc:"number of records (time steps) that have been constructed"`
+	View  *NetView      `desc:"netview that we're in"`
+}
+
+var KiT_RasterMesh = kit.Types.AddType(&RasterMesh{}, nil)
+
+// AddNewRasterMesh adds RasterMesh mesh to given scene for given layer
+func AddNewRasterMesh(sc *gi3d.Scene, nv *NetView, lay emer.Layer) *RasterMesh {
+	return AddNewRasterMeshVar(sc, nv, lay, "")
+}
+
+// AddNewRasterMeshVar adds RasterMesh mesh to given scene for given layer,
+// displaying given variable (empty = main NetView Var), named via LayMeshName
+func AddNewRasterMeshVar(sc *gi3d.Scene, nv *NetView, lay emer.Layer, vnm string) *RasterMesh {
+	rm := &RasterMesh{}
+	rm.View = nv
+	rm.Lay = lay
+	rm.Var = vnm
+	rm.Nm = LayMeshName(lay, vnm)
+	sc.AddMesh(rm)
+	return rm
+}
+
+func (rm *RasterMesh) Make(sc *gi3d.Scene) {
+	if rm.Lay == nil {
+		rm.Shape.SetShape(nil, nil, nil)
+		rm.Reset()
+	}
+	shp := rm.Lay.Shape()
+	rm.Reset()
+	rm.Shape.CopyShape(shp)
+	rm.NRecs = rm.View.Params.RasterRecs
+	if rm.Shape.NumDims() == 0 || rm.NRecs == 0 {
+		return // nothing
+	}
+	rm.MakeRaster(true) // true = init
+}
+
+func (rm *RasterMesh) Update(sc *gi3d.Scene) {
+	if rm.Shape.NumDims() == 0 || rm.NRecs == 0 {
+		return // nothing
+	}
+	rm.MakeRaster(false) // false = not init
+	rm.SetVtxData(sc)
+	rm.SetColorData(sc)
+	rm.SetNormData(sc)
+	rm.Activate(sc)
+	rm.TransferVectors()
+}
+
+// MakeRaster makes the raster geometry, one unit-bar per unit per record
+func (rm *RasterMesh) MakeRaster(init bool) {
+	rm.Trans = true
+	rm.Dynamic = true
+	nv := rm.View
+	nu := rm.Shape.Len()
+	nr := rm.NRecs
+	fnx, fnz := LayDisplaySize(&rm.Shape)
+
+	xsc := fnx / float32(nr)
+	zsc := fnz / float32(nu)
+	usz := nv.Params.UnitSize
+	uo := (1.0 - usz)
+	xuw := xsc * usz
+	zuw := zsc * usz
+
+	segs := 1
+
+	vtxSz, idxSz := rm.PlaneSize(segs, segs)
+	nvtx := vtxSz * 5 * nr * nu
+	nidx := idxSz * 5 * nr * nu
+	rm.Alloc(nvtx, nidx, true)
+
+	pidx := 0 // plane index
+
+	setNorm := true // can change -- always set
+	setTex := init
+	setIdx := init
+
+	end := nv.RecNo
+	if end < 0 {
+		end = nv.Data.Ring.Len - 1
+	}
+	vnm := rm.Var
+	if vnm == "" {
+		vnm = nv.Var
+	}
+
+	for ui := nu - 1; ui >= 0; ui-- {
+		z0 := zsc * (uo - float32(ui+1))
+		for ri := 0; ri < nr; ri++ {
+			poff := pidx * vtxSz * 5
+			ioff := pidx * idxSz * 5
+			x0 := xsc * (uo + float32(ri))
+			recno := end - (nr - 1 - ri)
+			_, scaled, clr := nv.UnitValVar(rm.Lay, vnm, ui, recno)
+			if recno < 0 {
+				scaled = 0
+				clr.SetUInt8(0x20, 0x20, 0x20, 0x20)
+			}
+			ht := 0.5 * mat32.Abs(scaled)
+			if ht < MinUnitHeight {
+				ht = MinUnitHeight
+			}
+			if scaled >= 0 {
+				rm.SetPlane(poff, ioff, setNorm, setTex, setIdx, mat32.X, mat32.Y, -1, -1, xuw, ht, x0, 0, z0, segs, segs, clr)                     // nz
+				rm.SetPlane(poff+1*vtxSz, ioff+1*idxSz, setNorm, setTex, setIdx, mat32.Z, mat32.Y, -1, -1, zuw, ht, z0, 0, x0+xuw, segs, segs, clr) // px
+				rm.SetPlane(poff+2*vtxSz, ioff+2*idxSz, setNorm, setTex, setIdx, mat32.Z, mat32.Y, 1, -1, zuw, ht, z0, 0, x0, segs, segs, clr)      // nx
+				rm.SetPlane(poff+3*vtxSz, ioff+3*idxSz, setNorm, setTex, setIdx, mat32.X, mat32.Z, 1, 1, xuw, zuw, x0, z0, ht, segs, segs, clr)     // py <-
+				rm.SetPlane(poff+4*vtxSz, ioff+4*idxSz, setNorm, setTex, setIdx, mat32.X, mat32.Y, 1, -1, xuw, ht, x0, 0, z0+zuw, segs, segs, clr)  // pz
+			} else {
+				rm.SetPlane(poff, ioff, setNorm, setTex, setIdx, mat32.X, mat32.Y, 1, -1, xuw, ht, x0, -ht, z0, segs, segs, clr)                     // nz = pz norm
+				rm.SetPlane(poff+1*vtxSz, ioff+1*idxSz, setNorm, setTex, setIdx, mat32.Z, mat32.Y, 1, -1, zuw, ht, z0, -ht, x0+xuw, segs, segs, clr) // px = nx norm
+				rm.SetPlane(poff+2*vtxSz, ioff+2*idxSz, setNorm, setTex, setIdx, mat32.Z, mat32.Y, 1, -1, zuw, ht, z0, -ht, x0, segs, segs, clr)     // nx
+				rm.SetPlane(poff+3*vtxSz, ioff+3*idxSz, setNorm, setTex, setIdx, mat32.X, mat32.Z, 1, 1, xuw, zuw, x0, z0, -ht, segs, segs, clr)     // ny <-
+				rm.SetPlane(poff+4*vtxSz, ioff+4*idxSz, setNorm, setTex, setIdx, mat32.X, mat32.Y, 1, -1, xuw, ht, x0, -ht, z0+zuw, segs, segs, clr) // pz
+			}
+			pidx++
+		}
+	}
+
+	rm.BBox.SetBounds(mat32.Vec3{0, -0.5, -fnz}, mat32.Vec3{fnx, 0.5, 0})
+}
+
+// LayDisplaySize returns the size of the layer along the X and Z axes
+// of the display, in unit (1) increments per unit, for 2D or 4D shapes.
+func LayDisplaySize(shp *etensor.Shape) (fnx, fnz float32) {
+	switch shp.NumDims() {
+	case 4:
+		fnx = float32(shp.Dim(1) * shp.Dim(3))
+		fnz = float32(shp.Dim(0) * shp.Dim(2))
+	case 2:
+		fnx = float32(shp.Dim(1))
+		fnz = float32(shp.Dim(0))
+	}
+	return
+}
+
+// RasterUnitRec returns the 1D unit index and record number at given point
+// in the layer's local coordinates (as computed in LayObj picking),
+// in raster mode.  Returns false if not valid.
+func (nv *NetView) RasterUnitRec(lay emer.Layer, pt mat32.Vec3) (ui, recno int, ok bool) {
+	shp := lay.Shape()
+	nu := shp.Len()
+	nr := nv.Params.RasterRecs
+	if nu == 0 || nr == 0 {
+		return
+	}
+	fnx, fnz := LayDisplaySize(shp)
+	ri := int(pt.X * float32(nr) / fnx)
+	ui = int(-pt.Z * float32(nu) / fnz)
+	if ri < 0 || ri >= nr || ui < 0 || ui >= nu {
+		return
+	}
+	end := nv.RecNo
+	if end < 0 {
+		end = nv.Data.Ring.Len - 1
+	}
+	recno = end - (nr - 1 - ri)
+	if recno < 0 {
+		return
+	}
+	ok = true
+	return
+}
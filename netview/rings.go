@@ -0,0 +1,168 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/goki/gi/gi"
+)
+
+// MainRingName is the default name of the main recording ring in Data,
+// set when the first additional ring is added with AddRing
+var MainRingName = "Main"
+
+// AddRing adds an additional recording ring with given name and maximum
+// number of records, for recording at a different timescale than the main
+// ring in Data (e.g., every trial for the last epoch, while Data records every
+// cycle for the last trial).  Records are added to it with RecordRing, and
+// it can be navigated instead of Data by selecting it in the Viewbar ring
+// dropdown (or SetRing).  Must be called after SetNet.
+func (nv *NetView) AddRing(name string, max int) *NetData {
+	if nd := nv.Ring(name); nd != nil {
+		return nd
+	}
+	if nv.Data.Name == "" {
+		nv.Data.Name = MainRingName
+	}
+	if nv.mainRing == "" {
+		nv.mainRing = nv.Data.Name
+	}
+	nd := &NetData{Name: name, Bits: nv.Data.Bits, PrjnVars: nv.Data.PrjnVars}
+	nd.Init(nv.Net, max)
+	nv.Rings = append(nv.Rings, nd)
+	return nd
+}
+
+// Ring returns the recording ring with given name: either Data (if it is the
+// ring currently being navigated) or one of the other Rings.  Returns nil if
+// not found.
+func (nv *NetView) Ring(name string) *NetData {
+	if nv.Data.Name == name {
+		return &nv.Data
+	}
+	for _, nd := range nv.Rings {
+		if nd.Name == name {
+			return nd
+		}
+	}
+	return nil
+}
+
+// RingNames returns the names of all the recording rings,
+// starting with the one currently being navigated (in Data)
+func (nv *NetView) RingNames() []string {
+	nms := make([]string, 0, len(nv.Rings)+1)
+	nms = append(nms, nv.Data.Name)
+	for _, nd := range nv.Rings {
+		nms = append(nms, nd.Name)
+	}
+	return nms
+}
+
+// mainData returns the main recording ring, recorded by Record
+func (nv *NetView) mainData() *NetData {
+	if nv.mainRing == "" {
+		return &nv.Data
+	}
+	if nd := nv.Ring(nv.mainRing); nd != nil {
+		return nd
+	}
+	return &nv.Data
+}
+
+// RecordRing records the current state of the network into the ring with
+// given name (see AddRing), along with provided counters string and
+// structured counter values (can be nil), as in RecordCtrs.
+// The unit selected for viewing projections is that of the navigated ring.
+func (nv *NetView) RecordRing(name, counters string, vals map[string]int) error {
+	nd := nv.Ring(name)
+	if nd == nil {
+		err := fmt.Errorf("NetView.RecordRing: ring: %v not found", name)
+		log.Println(err)
+		return err
+	}
+	if nv.async != nil {
+		nv.async.Record(&nv.Data, name, counters, vals)
+		if nd == &nv.Data {
+			nv.RecTrackLatest()
+		}
+		return nil
+	}
+	nd.recordRing(nv, counters, vals)
+	return nil
+}
+
+// recordRing records into this ring for given view, tracking the latest
+// record in the view if this is the ring being navigated
+func (nd *NetData) recordRing(nv *NetView, counters string, vals map[string]int) {
+	if nd != &nv.Data {
+		nd.PrjnLay = nv.Data.PrjnLay
+		nd.PrjnUnIdx = nv.Data.PrjnUnIdx
+	}
+	nd.RecordCtrs(counters, vals)
+	if nd == &nv.Data {
+		nv.RecTrackLatest() // if we make a new record, then user expectation is to track latest..
+	}
+}
+
+// SetRing sets the recording ring with given name to be the one navigated in
+// the view, by swapping it with the current one in Data, and tracks its latest
+// record.  Recording continues into each ring as before.
+func (nv *NetView) SetRing(name string) error {
+	if nv.Data.Name == name {
+		return nil
+	}
+	ri := -1
+	for i, nd := range nv.Rings {
+		if nd.Name == name {
+			ri = i
+			break
+		}
+	}
+	if ri < 0 {
+		err := fmt.Errorf("NetView.SetRing: ring: %v not found", name)
+		log.Println(err)
+		return err
+	}
+	if nv.async != nil {
+		nv.async.Lock()
+		defer nv.async.Unlock()
+	}
+	nd := nv.Rings[ri]
+	nd.PrjnLay = nv.Data.PrjnLay
+	nd.PrjnUnIdx = nv.Data.PrjnUnIdx
+	nv.Data, *nd = *nd, nv.Data
+	if nv.Data.Streamer != nil {
+		nv.Data.Streamer.Data = &nv.Data
+	}
+	if nd.Streamer != nil {
+		nd.Streamer.Data = nd
+	}
+	nv.RecNo = -1
+	nv.VarScaleUpdate(nv.Var)
+	nv.UpdateRings()
+	return nil
+}
+
+// UpdateRings updates the ring dropdown in the Viewbar with the
+// current ring names
+func (nv *NetView) UpdateRings() {
+	cb, ok := nv.Viewbar().ChildByName("ring", 20).(*gi.ComboBox)
+	if !ok {
+		return
+	}
+	if len(nv.Rings) == 0 {
+		cb.SetInactive()
+	} else {
+		cb.SetActive()
+	}
+	nms := nv.RingNames()
+	if len(nms) != len(cb.Items) || cb.CurVal != nv.Data.Name {
+		cb.ItemsFromStringList(nms, false, 40)
+		cb.SetCurVal(nv.Data.Name)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/gi3d"
+	"github.com/goki/ki/kit"
+)
+
+// ScenePresets are the lighting / background presets for the 3D view,
+// selected by Params.Scene
+type ScenePresets int32
+
+//go:generate stringer -type=ScenePresets
+
+var KiT_ScenePresets = kit.Enums.AddEnum(ScenePresetsN, false, nil)
+
+func (ev ScenePresets) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *ScenePresets) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// The scene presets
+const (
+	// SceneWhite is the standard white background
+	SceneWhite ScenePresets = iota
+
+	// SceneDark is a dark background with brighter lights and light text,
+	// for dark desktop themes
+	SceneDark
+
+	// SceneContrast is a white background with stronger lights,
+	// for projectors and publication figures
+	SceneContrast
+
+	ScenePresetsN
+)
+
+// SceneStyle holds the background, text color, and light intensities for a ScenePresets
+type SceneStyle struct {
+	Bg      gi.Color `desc:"background color of the scene"`
+	Text    gi.Color `desc:"color of the text labels in the scene"`
+	Ambient float32  `desc:"intensity of the ambient light"`
+	Up      float32  `desc:"intensity of the directional light from above"`
+	Back    float32  `desc:"intensity of the directional light from above and behind the camera"`
+}
+
+// SceneStyles are the styles for each of the ScenePresets
+var SceneStyles = [ScenePresetsN]SceneStyle{
+	SceneWhite:    {Bg: gi.Color{255, 255, 255, 255}, Text: gi.Color{0, 0, 0, 255}, Ambient: 0.3, Up: 0.3, Back: 0.6},
+	SceneDark:     {Bg: gi.Color{32, 32, 32, 255}, Text: gi.Color{230, 230, 230, 255}, Ambient: 0.4, Up: 0.4, Back: 0.8},
+	SceneContrast: {Bg: gi.Color{255, 255, 255, 255}, Text: gi.Color{0, 0, 0, 255}, Ambient: 0.5, Up: 0.5, Back: 0.9},
+}
+
+// Style returns the SceneStyle for this preset
+func (sp ScenePresets) Style() *SceneStyle {
+	if sp < 0 || sp >= ScenePresetsN {
+		sp = SceneWhite
+	}
+	return &SceneStyles[sp]
+}
+
+// ApplyScene sets the background and lights of the 3D view
+// according to Params.Scene -- called by ViewDefaults and ViewConfig
+func (nv *NetView) ApplyScene() {
+	vs := nv.Scene()
+	ss := nv.Params.Scene.Style()
+	vs.BgColor = ss.Bg
+	gi3d.AddNewAmbientLight(vs, "ambient", ss.Ambient, gi3d.DirectSun)
+	dir := gi3d.AddNewDirLight(vs, "dirUp", ss.Up, gi3d.DirectSun)
+	dir.Pos.Set(0, 1, 0)
+	dir = gi3d.AddNewDirLight(vs, "dirBack", ss.Back, gi3d.DirectSun)
+	dir.Pos.Set(0, 1, -2.5)
+}
+
+// SetScene sets the lighting / background preset for the 3D view
+// (see Params.Scene), and updates the display
+func (nv *NetView) SetScene(sp ScenePresets) {
+	nv.Params.Scene = sp
+	nv.Config()
+	nv.Update()
+}
+
+// SetTextColor sets the color of given text label in the 3D view
+// according to Params.Scene
+func (nv *NetView) SetTextColor(txt *gi3d.Text2D) {
+	txt.SetProp("color", nv.Params.Scene.Style().Text)
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/emer/emergent/emer"
+	"github.com/goki/gi/gi3d"
+	"github.com/goki/gi/mat32"
+	"github.com/goki/ki/kit"
+)
+
+// SliceMeshName is the name of the mesh used for the expanded LaySlice
+const SliceMeshName = "netview-slice"
+
+// LaySlice specifies a cross-section of the pools of a 4D layer: a single
+// pool row (PoolY), pool column (PoolX), or a single pool (both), which is
+// displayed expanded above the layer, with the rest of the layer dimmed,
+// so that values in large convolutional-style layers can actually be read.
+type LaySlice struct {
+	Lay      string  `desc:"name of the 4D layer to slice -- empty = no slice"`
+	PoolY    int     `desc:"pool row (outer Y dimension) to show -- -1 = all rows"`
+	PoolX    int     `desc:"pool column (outer X dimension) to show -- -1 = all columns"`
+	Zoom     float32 `min:"0" desc:"scale of the expanded slice relative to the layer -- 0 = automatically fit within the footprint of the layer (at least 2x)"`
+	Height   float32 `min:"0" def:"0.2" desc:"height of the expanded slice above the layer, in normalized view units (entire network view is unit sized)"`
+	DimAlpha float32 `min:"0" max:"1" def:"0.2" desc:"opacity multiplier for the units of the layer outside of the slice"`
+}
+
+// Defaults sets default values if otherwise not set
+func (ls *LaySlice) Defaults() {
+	if ls.Height == 0 {
+		ls.Height = 0.2
+	}
+	if ls.DimAlpha == 0 {
+		ls.DimAlpha = 0.2
+	}
+}
+
+// ParseLaySlice parses a slice specification of the form Layer[y,x],
+// where y is the pool row and x the pool column, either of which can be *
+// for all, e.g., V1[2,*] is pool row 2, V1[*,3] is pool column 3, and
+// V1[2,3] is a single pool.  An empty string returns an empty slice.
+func ParseLaySlice(str string) (LaySlice, error) {
+	ls := LaySlice{PoolY: -1, PoolX: -1}
+	str = strings.TrimSpace(str)
+	if str == "" {
+		return ls, nil
+	}
+	lb := strings.Index(str, "[")
+	if lb <= 0 || !strings.HasSuffix(str, "]") {
+		return ls, fmt.Errorf("NetView slice: %q must be of the form Layer[y,x], e.g., V1[2,*]", str)
+	}
+	ls.Lay = strings.TrimSpace(str[:lb])
+	dims := strings.Split(str[lb+1:len(str)-1], ",")
+	if len(dims) != 2 {
+		return ls, fmt.Errorf("NetView slice: %q must have 2 pool indexes, e.g., V1[2,*]", str)
+	}
+	for di, ds := range dims {
+		ds = strings.TrimSpace(ds)
+		if ds == "*" || ds == "" {
+			continue
+		}
+		v, err := strconv.Atoi(ds)
+		if err != nil || v < 0 {
+			return ls, fmt.Errorf("NetView slice: %q pool index: %q must be * or a non-negative integer", str, ds)
+		}
+		if di == 0 {
+			ls.PoolY = v
+		} else {
+			ls.PoolX = v
+		}
+	}
+	return ls, nil
+}
+
+// String returns the slice in the format parsed by ParseLaySlice
+func (ls *LaySlice) String() string {
+	if ls.Lay == "" {
+		return ""
+	}
+	ds := func(v int) string {
+		if v < 0 {
+			return "*"
+		}
+		return strconv.Itoa(v)
+	}
+	return fmt.Sprintf("%s[%s,%s]", ls.Lay, ds(ls.PoolY), ds(ls.PoolX))
+}
+
+// On returns true if the slice is active for given layer
+func (ls *LaySlice) On(laynm string) bool {
+	return ls.Lay != "" && ls.Lay == laynm
+}
+
+// Range returns the starting pool row and number of rows, and starting pool
+// column and number of columns, of the slice within given 4D layer shape.
+// Returns false if the shape is not 4D or the slice is out of range.
+func (ls *LaySlice) Range(lay emer.Layer) (pz0, npz, px0, npx int, ok bool) {
+	shp := lay.Shape()
+	if shp.NumDims() != 4 {
+		return
+	}
+	pz0, npz = 0, shp.Dim(0)
+	px0, npx = 0, shp.Dim(1)
+	if ls.PoolY >= 0 {
+		if ls.PoolY >= npz {
+			return
+		}
+		pz0, npz = ls.PoolY, 1
+	}
+	if ls.PoolX >= 0 {
+		if ls.PoolX >= npx {
+			return
+		}
+		px0, npx = ls.PoolX, 1
+	}
+	ok = true
+	return
+}
+
+// InSlice returns true if the unit at given 4D index is within the slice
+func (ls *LaySlice) InSlice(idx []int) bool {
+	return (ls.PoolY < 0 || idx[0] == ls.PoolY) && (ls.PoolX < 0 || idx[1] == ls.PoolX)
+}
+
+// LayIdx returns the 4D layer index for given index within the slice shape
+func (ls *LaySlice) LayIdx(idx []int) []int {
+	li := []int{idx[0], idx[1], idx[2], idx[3]}
+	if ls.PoolY >= 0 {
+		li[0] += ls.PoolY
+	}
+	if ls.PoolX >= 0 {
+		li[1] += ls.PoolX
+	}
+	return li
+}
+
+// SetSlice sets the layer cross-section to display (see LaySlice), for given
+// 4D layer, pool row, and pool column (-1 = all), and updates the display.
+func (nv *NetView) SetSlice(laynm string, poolY, poolX int) error {
+	lay := nv.Net.LayerByName(laynm)
+	if lay == nil || lay.Shape().NumDims() != 4 {
+		err := fmt.Errorf("NetView.SetSlice: layer: %v not found or not 4D", laynm)
+		log.Println(err)
+		return err
+	}
+	nv.Slice.Lay, nv.Slice.PoolY, nv.Slice.PoolX = laynm, poolY, poolX
+	if _, _, _, _, ok := nv.Slice.Range(lay); !ok {
+		nv.Slice.Lay = ""
+		err := fmt.Errorf("NetView.SetSlice: pool indexes: %d, %d out of range for layer: %v", poolY, poolX, laynm)
+		log.Println(err)
+		return err
+	}
+	nv.UpdateSlice()
+	return nil
+}
+
+// ClearSlice turns off the layer cross-section display
+func (nv *NetView) ClearSlice() {
+	nv.Slice.Lay = ""
+	nv.UpdateSlice()
+}
+
+// UpdateSlice remakes the slice display and updates the view
+func (nv *NetView) UpdateSlice() {
+	if !nv.IsConfiged() || !nv.HasLayers() {
+		return
+	}
+	vs := nv.Scene()
+	updt := vs.UpdateStart()
+	nv.SliceConfig()
+	vs.InitMeshes()
+	vs.UpdateEnd(updt)
+	nv.Update()
+}
+
+// SliceConfig configures the "Slice" group holding the expanded LaySlice
+// display, positioned above its layer, which must already be configured.
+func (nv *NetView) SliceConfig() {
+	vs := nv.Scene()
+	slGp, err := vs.ChildByNameTry("Slice", 1)
+	if err != nil {
+		slGp = gi3d.AddNewGroup(vs, vs, "Slice")
+	}
+	ls := &nv.Slice
+	ls.Defaults()
+	lay := nv.Net.LayerByName(ls.Lay)
+	lg := nv.LayerByName(ls.Lay)
+	slConfig := kit.TypeAndNameList{}
+	var npz, npx int
+	ok := false
+	if lay != nil && lg != nil && nv.LayVisible(ls.Lay) && !nv.Params.Raster {
+		_, npz, _, npx, ok = ls.Range(lay)
+	}
+	if ok {
+		slConfig.Add(gi3d.KiT_Object, "slice")
+	}
+	slGp.ConfigChildren(slConfig, false)
+	if !ok {
+		return
+	}
+	lm, isLm := vs.MeshByName(SliceMeshName).(*LayMesh)
+	if !isLm {
+		lm = &LayMesh{View: nv, Slice: ls}
+		lm.Nm = SliceMeshName
+		vs.AddMesh(lm)
+	}
+	lm.Lay = lay
+	shp := lay.Shape()
+	lw := float32(shp.Dim(1) * shp.Dim(3)) // full layer width, height in local units
+	lh := float32(shp.Dim(0) * shp.Dim(2))
+	sw := float32(npx * shp.Dim(3))
+	sh := float32(npz * shp.Dim(2))
+	zoom := ls.Zoom
+	if zoom <= 0 {
+		zoom = mat32.Max(mat32.Min(lw/sw, lh/sh), 2)
+	}
+	so := slGp.Child(0).(*gi3d.Object)
+	so.SetMeshName(vs, SliceMeshName)
+	so.Mat.Color.SetUInt8(255, 100, 255, 128)
+	so.Mat.Specular.SetUInt8(128, 128, 128, 255)
+	so.Mat.CullBack = true
+	so.Mat.CullFront = false
+	off := mat32.Vec3{0.5 * (lw - zoom*sw), 0, -0.5 * (lh - zoom*sh)}
+	so.Pose.Pos = lg.Pose.Pos.Add(off.Mul(lg.Pose.Scale))
+	so.Pose.Pos.Y += ls.Height
+	so.Pose.Scale = lg.Pose.Scale.Mul(mat32.Vec3{zoom, 1, zoom})
+}
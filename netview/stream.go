@@ -0,0 +1,292 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// Streamer streams NetData records over WebSocket connections, so that a
+// browser-based (or other remote) viewer can display the live network state
+// without a GoGi window.  It is an http.Handler that can be registered on
+// any http.ServeMux (e.g., the simsrv Server Mux), and is called from
+// NetData.Record for each new record once attached with NewStreamer.
+//
+// Protocol: all messages from the server are WebSocket messages,
+// with JSON text messages having a Type field:
+//
+//	config: sent on connect, and by SendConfig (e.g., when the network changes):
+//	  {"Type":"config","Vars":[...],"Layers":[{"Name":"Input","Shape":[5,5]},...]}
+//	rec: sent for each record:
+//	  {"Type":"rec","Rec":N,"Counters":"...","CtrVals":{"Trial":3},
+//	   "Vals":{"Input":{"Act":[...]},...}}
+//	  where NaN values are sent as null.  In Binary mode, Vals is omitted and
+//	  the rec message is immediately followed by a binary message containing
+//	  the values as little-endian float32, for each layer (in config order),
+//	  for each variable (in config order), for all units in the layer.
+//
+// Messages from the client are ignored, other than ping and close.
+// Only WebSocket protocol version 13 is supported, and browser connections
+// from other web sites are rejected unless their origin is in Origins.
+// Each client has a buffer of Buffer messages -- if the client falls behind,
+// further records are dropped for that client until it catches up.
+type Streamer struct {
+	Vars    []string `desc:"variables to stream -- empty = all recorded variables"`
+	Binary  bool     `desc:"send unit values as binary messages of little-endian float32 values, instead of JSON"`
+	Buffer  int      `def:"16" min:"1" desc:"number of messages buffered per client before records are dropped for that client"`
+	Origins []string `desc:"allowed Origins for cross-site WebSocket connections from browsers, e.g., http://localhost:8080, or * for any -- same-origin and non-browser connections are always allowed, and other web pages are otherwise rejected, so that they cannot read the network state via the user's browser"`
+	Data    *NetData `view:"-" desc:"the data being streamed"`
+	NRecs   int      `inactive:"+" desc:"number of records streamed"`
+	clients map[*streamClient]struct{}
+	mu      sync.Mutex
+}
+
+// streamMsg is one WebSocket message to send
+type streamMsg struct {
+	op   byte
+	data []byte
+}
+
+// streamClient is one connected client
+type streamClient struct {
+	ws      *wsConn
+	msgs    chan streamMsg
+	Dropped int
+}
+
+// streamLay is the layer info in the config message
+type streamLay struct {
+	Name  string
+	Shape []int
+}
+
+// streamConfig is the config message
+type streamConfig struct {
+	Type   string
+	Vars   []string
+	Layers []streamLay
+}
+
+// streamRec is the rec message
+type streamRec struct {
+	Type     string
+	Rec      int
+	Counters string
+	CtrVals  map[string]int                 `json:",omitempty"`
+	Vals     map[string]map[string]JSONVals `json:",omitempty"`
+}
+
+// JSONVals is a list of unit values that encodes values that are not
+// available (NaN) or infinite as null in JSON
+type JSONVals []float32
+
+// MarshalJSON writes the values as a JSON array, with null for NaN and Inf values
+func (jv JSONVals) MarshalJSON() ([]byte, error) {
+	b := make([]byte, 0, 8*len(jv)+2)
+	b = append(b, '[')
+	for i, v := range jv {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			b = append(b, "null"...)
+		} else {
+			b = strconv.AppendFloat(b, float64(v), 'g', -1, 32)
+		}
+	}
+	b = append(b, ']')
+	return b, nil
+}
+
+// NewStreamer returns a new Streamer attached to given NetData,
+// so that each new record is streamed to all connected clients.
+func NewStreamer(nd *NetData) *Streamer {
+	st := &Streamer{Data: nd}
+	st.Defaults()
+	nd.Streamer = st
+	return st
+}
+
+// Defaults sets default values if otherwise not set or invalid
+func (st *Streamer) Defaults() {
+	if st.Buffer <= 0 {
+		st.Buffer = 16
+	}
+}
+
+// NClients returns the number of connected clients
+func (st *Streamer) NClients() int {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return len(st.clients)
+}
+
+// StreamVars returns the variables to stream: Vars if set, else all recorded
+func (st *Streamer) StreamVars() []string {
+	if len(st.Vars) > 0 {
+		return st.Vars
+	}
+	return st.Data.Vars
+}
+
+// ConfigMsg returns the config message for the current data
+func (st *Streamer) ConfigMsg() []byte {
+	nd := st.Data
+	cf := &streamConfig{Type: "config", Vars: st.StreamVars()}
+	if nd.Net != nil {
+		nlay := nd.Net.NLayers()
+		for li := 0; li < nlay; li++ {
+			lay := nd.Net.Layer(li)
+			cf.Layers = append(cf.Layers, streamLay{Name: lay.Name(), Shape: lay.Shape().Shp})
+		}
+	}
+	b, err := json.Marshal(cf)
+	if err != nil {
+		log.Println(err)
+	}
+	return b
+}
+
+// RecMsgs returns the message(s) for given record number
+// (-1 = latest, else in [0..Ring.Len-1])
+func (st *Streamer) RecMsgs(recno int) []streamMsg {
+	nd := st.Data
+	vars := st.StreamVars()
+	rc := &streamRec{Type: "rec", Rec: nd.Ring.Len - 1, Counters: nd.CounterRec(recno), CtrVals: nd.CtrValsRec(recno)}
+	if recno >= 0 {
+		rc.Rec = recno
+	}
+	nlay := nd.Net.NLayers()
+	var bin []byte
+	if !st.Binary {
+		rc.Vals = make(map[string]map[string]JSONVals, nlay)
+	}
+	for li := 0; li < nlay; li++ {
+		laynm := nd.Net.Layer(li).Name()
+		ld, ok := nd.LayData[laynm]
+		if !ok {
+			continue
+		}
+		var lvals map[string]JSONVals
+		if !st.Binary {
+			lvals = make(map[string]JSONVals, len(vars))
+			rc.Vals[laynm] = lvals
+		}
+		for _, vnm := range vars {
+			vals := make([]float32, ld.NUnits)
+			for ui := range vals {
+				v, ok := nd.UnitValRaw(laynm, vnm, ui, recno)
+				if !ok {
+					v = float32(math.NaN())
+				}
+				vals[ui] = v
+			}
+			if st.Binary {
+				var b4 [4]byte
+				for _, v := range vals {
+					binary.LittleEndian.PutUint32(b4[:], math.Float32bits(v))
+					bin = append(bin, b4[:]...)
+				}
+			} else {
+				lvals[vnm] = vals
+			}
+		}
+	}
+	b, err := json.Marshal(rc)
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+	msgs := []streamMsg{{op: wsText, data: b}}
+	if st.Binary {
+		msgs = append(msgs, streamMsg{op: wsBinary, data: bin})
+	}
+	return msgs
+}
+
+// SendRec sends the latest record to all connected clients -- called
+// automatically by NetData.Record.  Does nothing if no clients are connected.
+func (st *Streamer) SendRec() {
+	if st.NClients() == 0 {
+		return
+	}
+	msgs := st.RecMsgs(-1)
+	st.NRecs++
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	for cl := range st.clients {
+		if len(cl.msgs)+len(msgs) > cap(cl.msgs) {
+			cl.Dropped++ // backpressure: drop this record for this client
+			continue
+		}
+		for _, m := range msgs {
+			cl.msgs <- m
+		}
+	}
+}
+
+// SendConfig sends the config message to all connected clients,
+// e.g., after the network has changed
+func (st *Streamer) SendConfig() {
+	if st.NClients() == 0 {
+		return
+	}
+	msg := streamMsg{op: wsText, data: st.ConfigMsg()}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	for cl := range st.clients {
+		select {
+		case cl.msgs <- msg:
+		default:
+			cl.Dropped++
+		}
+	}
+}
+
+// ServeHTTP handles a WebSocket connection request, streaming records
+// to the client until it disconnects.
+func (st *Streamer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ws, err := wsUpgrade(w, r, st.Origins)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	st.Defaults()
+	cl := &streamClient{ws: ws, msgs: make(chan streamMsg, st.Buffer)}
+	cl.msgs <- streamMsg{op: wsText, data: st.ConfigMsg()}
+	st.mu.Lock()
+	if st.clients == nil {
+		st.clients = make(map[*streamClient]struct{})
+	}
+	st.clients[cl] = struct{}{}
+	st.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case m := <-cl.msgs:
+				if err := ws.WriteMsg(m.op, m.data); err != nil {
+					ws.Close()
+					return
+				}
+			}
+		}
+	}()
+	ws.ReadLoop() // returns when client closes or errors
+	close(done)
+	st.mu.Lock()
+	delete(st.clients, cl)
+	st.mu.Unlock()
+	ws.Close()
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/emer/emergent/emer"
+	"github.com/goki/gi/gi"
+)
+
+// SynInfo holds the values of all the synapse variables for one synapse,
+// as shown by InspectSynapse
+type SynInfo struct {
+	Prjn     string    `desc:"name of the projection containing the synapse"`
+	SendLay  string    `desc:"name of the sending layer"`
+	SendIdx  int       `desc:"1D index of the sending unit"`
+	SendIdxs []int     `desc:"shape index of the sending unit"`
+	RecvLay  string    `desc:"name of the receiving layer"`
+	RecvIdx  int       `desc:"1D index of the receiving unit"`
+	RecvIdxs []int     `desc:"shape index of the receiving unit"`
+	Vars     []string  `desc:"names of the synapse variables"`
+	Vals     []float32 `desc:"values of the synapse variables, in same order as Vars"`
+}
+
+// String returns a multi-line summary of the synapse
+func (si *SynInfo) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %s %v (%d) -> %s %v (%d)\n", si.Prjn, si.SendLay, si.SendIdxs, si.SendIdx, si.RecvLay, si.RecvIdxs, si.RecvIdx)
+	for i, vn := range si.Vars {
+		fmt.Fprintf(&b, "\t%s\t%g\n", vn, si.Vals[i])
+	}
+	return b.String()
+}
+
+// SynapseInfo returns the synapse values for all the synapses between the
+// currently selected unit (Data.PrjnLay, Data.PrjnUnIdx, i.e., the unit
+// whose projection values are displayed for r. and s. variables) and given
+// unit (1D index) in given other layer, one for each projection connecting them.
+// For r. variables the other unit is taken as the sender, and for s. variables
+// as the receiver -- otherwise both directions are included.
+// Synapse values are the current values in the network.
+func (nv *NetView) SynapseInfo(laynm string, uidx int) ([]*SynInfo, error) {
+	slay := nv.Net.LayerByName(nv.Data.PrjnLay)
+	olay := nv.Net.LayerByName(laynm)
+	if slay == nil || olay == nil {
+		err := fmt.Errorf("NetView.SynapseInfo: selected layer: %v or layer: %v not found", nv.Data.PrjnLay, laynm)
+		log.Println(err)
+		return nil, err
+	}
+	recv := !strings.HasPrefix(nv.Var, "s.")
+	send := !strings.HasPrefix(nv.Var, "r.")
+	var sis []*SynInfo
+	if recv { // selected unit receives from other unit
+		for pi := 0; pi < slay.NRecvPrjns(); pi++ {
+			pj := slay.RecvPrjn(pi)
+			if pj.SendLay() == olay {
+				if si := synInfo(pj, uidx, nv.Data.PrjnUnIdx); si != nil {
+					sis = append(sis, si)
+				}
+			}
+		}
+	}
+	if send { // selected unit sends to other unit
+		for pi := 0; pi < slay.NSendPrjns(); pi++ {
+			pj := slay.SendPrjn(pi)
+			if pj.RecvLay() == olay {
+				if si := synInfo(pj, nv.Data.PrjnUnIdx, uidx); si != nil {
+					sis = append(sis, si)
+				}
+			}
+		}
+	}
+	if len(sis) == 0 {
+		return nil, fmt.Errorf("no synapses between: %s unit: %d and %s unit: %d", nv.Data.PrjnLay, nv.Data.PrjnUnIdx, laynm, uidx)
+	}
+	return sis, nil
+}
+
+// synInfo returns the SynInfo for given sending, receiving units in given
+// projection, or nil if they are not connected
+func synInfo(pj emer.Prjn, sidx, ridx int) *SynInfo {
+	vars := pj.SynVarNames()
+	si := &SynInfo{Prjn: pj.Name(), SendLay: pj.SendLay().Name(), SendIdx: sidx, RecvLay: pj.RecvLay().Name(), RecvIdx: ridx}
+	for _, vn := range vars {
+		val, err := pj.SynValTry(vn, sidx, ridx)
+		if err != nil {
+			return nil
+		}
+		si.Vars = append(si.Vars, vn)
+		si.Vals = append(si.Vals, val)
+	}
+	si.SendIdxs = pj.SendLay().Shape().Index(sidx)
+	si.RecvIdxs = pj.RecvLay().Shape().Index(ridx)
+	return si
+}
+
+// InspectSynapse shows in a dialog the values of all the synapse
+// variables (see SynapseInfo) between the currently selected unit and given
+// unit (1D index) in given other layer.  Called when a unit in another layer
+// is clicked while viewing a projection variable, if Params.SynInspect is on.
+func (nv *NetView) InspectSynapse(laynm string, uidx int) {
+	sis, err := nv.SynapseInfo(laynm, uidx)
+	var b strings.Builder
+	if err != nil {
+		b.WriteString(err.Error())
+	}
+	for _, si := range sis {
+		b.WriteString(si.String())
+	}
+	gi.PromptDialog(nv.Viewport, gi.DlgOpts{Title: "Synapse: " + laynm, Prompt: b.String()}, true, false, nil, nil)
+}
+
+// SynInspectOn returns true if clicking on a unit in given layer should
+// inspect its synapse with the selected unit (see InspectSynapse) instead of
+// selecting it: Params.SynInspect is on, a projection variable is being viewed,
+// and given layer is not the selected unit's layer.
+func (nv *NetView) SynInspectOn(laynm string) bool {
+	if !nv.Params.SynInspect || nv.Data.PrjnLay == "" || nv.Data.PrjnLay == laynm {
+		return false
+	}
+	return strings.HasPrefix(nv.Var, "r.") || strings.HasPrefix(nv.Var, "s.")
+}
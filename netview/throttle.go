@@ -0,0 +1,57 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"sync"
+	"time"
+)
+
+// updtThrottle holds the state for limiting the rate of GoUpdate
+// to Params.MaxRate
+type updtThrottle struct {
+	mu    sync.Mutex
+	last  time.Time
+	pend  bool
+	nskip int
+}
+
+// throttle returns true if an update should be skipped because it comes sooner
+// after the last one than allowed by Params.MaxRate, in which case a deferred
+// update is scheduled, so that the latest record is always shown eventually.
+// Records are stored as usual regardless -- only rendering is skipped.
+func (nv *NetView) throttle() bool {
+	if nv.Params.MaxRate <= 0 {
+		return false
+	}
+	ut := &nv.updtThr
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
+	intv := time.Duration(float64(time.Second) / float64(nv.Params.MaxRate))
+	since := time.Since(ut.last)
+	if since >= intv {
+		ut.last = time.Now()
+		return false
+	}
+	ut.nskip++
+	if !ut.pend {
+		ut.pend = true
+		time.AfterFunc(intv-since, func() {
+			ut.mu.Lock()
+			ut.pend = false
+			ut.mu.Unlock()
+			nv.GoUpdate()
+		})
+	}
+	return true
+}
+
+// SkippedUpdates returns the number of GoUpdate calls that have been
+// skipped due to Params.MaxRate
+func (nv *NetView) SkippedUpdates() int {
+	nv.updtThr.mu.Lock()
+	defer nv.updtThr.mu.Unlock()
+	return nv.updtThr.nskip
+}
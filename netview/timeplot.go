@@ -0,0 +1,83 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/emer/etable/eplot"
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+	"github.com/goki/gi/gi"
+)
+
+// UnitTimeTable returns a table of the values of given variable (empty = current
+// Var) for given unit (1D index) in given layer, across all records in Data,
+// earliest first, with columns Rec (record number), Counters (counters string),
+// and Value, which is NaN for values that are not available.
+func (nv *NetView) UnitTimeTable(laynm string, vnm string, uidx int) *etable.Table {
+	if vnm == "" {
+		vnm = nv.Var
+	}
+	sch := etable.Schema{
+		{Name: "Rec", Type: etensor.INT64},
+		{Name: "Counters", Type: etensor.STRING},
+		{Name: "Value", Type: etensor.FLOAT32},
+	}
+	dt := &etable.Table{}
+	nrec := nv.Data.Ring.Len
+	dt.SetFromSchema(sch, nrec)
+	dt.SetMetaData("name", fmt.Sprintf("%s_%d_%s", laynm, uidx, vnm))
+	dt.SetMetaData("desc", fmt.Sprintf("NetView values of %s for unit: %d in layer: %s across records", vnm, uidx, laynm))
+	for ri := 0; ri < nrec; ri++ {
+		val, ok := nv.Data.UnitValRaw(laynm, vnm, uidx, ri)
+		if !ok {
+			val = float32(math.NaN())
+		}
+		dt.SetCellFloat("Rec", ri, float64(ri))
+		dt.SetCellString("Counters", ri, nv.Data.CounterRec(ri))
+		dt.SetCellFloat("Value", ri, float64(val))
+	}
+	return dt
+}
+
+// PlotUnitTime opens a small window with a line plot of the current variable
+// for given unit (1D index) in given layer across all records in Data (see
+// UnitTimeTable), for inspecting the temporal dynamics of a single unit.
+// If the plot window for this view is already open, it is updated instead.
+// Called automatically when a unit is clicked if Params.TimePlot is on.
+func (nv *NetView) PlotUnitTime(laynm string, uidx int) *eplot.Plot2D {
+	if nv.Net == nil || nv.Net.LayerByName(laynm) == nil {
+		log.Printf("NetView.PlotUnitTime: layer: %v not found\n", laynm)
+		return nil
+	}
+	dt := nv.UnitTimeTable(laynm, nv.Var, uidx)
+	title := fmt.Sprintf("%s: %s unit %d", nv.Var, laynm, uidx)
+	wnm := "netview-unit-time-" + nv.Nm
+	if win, ok := gi.AllWindows.FindName(wnm); ok && nv.timePlot != nil {
+		nv.timePlot.Params.Title = title
+		nv.timePlot.SetTable(dt)
+		nv.timePlot.SetColParams("Value", eplot.On, eplot.FloatMin, 0, eplot.FloatMax, 0)
+		nv.timePlot.Update()
+		win.SetTitle(title)
+		win.OSWin.Raise()
+		return nv.timePlot
+	}
+	win := gi.NewWindow2D(wnm, title, 600, 400, true)
+	vp := win.WinViewport2D()
+	updt := vp.UpdateStart()
+	mfr := win.SetMainFrame()
+	plt := eplot.AddNewPlot2D(mfr, "plot")
+	plt.Params.Title = title
+	plt.Params.XAxisCol = "Rec"
+	plt.SetTable(dt)
+	plt.SetColParams("Value", eplot.On, eplot.FloatMin, 0, eplot.FloatMax, 0)
+	nv.timePlot = plt
+	vp.UpdateEndNoSig(updt)
+	win.GoStartEventLoop()
+	return plt
+}
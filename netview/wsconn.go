@@ -0,0 +1,195 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// WebSocket opcodes, per RFC 6455
+const (
+	wsText   = 1
+	wsBinary = 2
+	wsClose  = 8
+	wsPing   = 9
+	wsPong   = 10
+)
+
+// wsGUID is the magic string used in computing the handshake accept key
+const wsGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// wsConn is a minimal server-side WebSocket connection, sufficient for
+// streaming messages to a client: it supports sending text and binary
+// messages, and reads client frames only to respond to ping and close.
+type wsConn struct {
+	conn net.Conn
+	rw   *bufio.ReadWriter
+	wmu  sync.Mutex
+}
+
+// wsVersion is the only supported WebSocket protocol version, per RFC 6455
+const wsVersion = "13"
+
+// wsOriginOK returns true if the Origin header of given request is allowed:
+// requests without an Origin (i.e., not from a browser) and same-origin
+// requests (Origin host matches the request Host) are always allowed, and
+// otherwise the Origin must match one of the origins exactly (case
+// insensitive, e.g., "http://localhost:8080"), or origins must contain "*".
+// This prevents other web pages from connecting from the user's browser.
+func wsOriginOK(r *http.Request, origins []string) bool {
+	org := r.Header.Get("Origin")
+	if org == "" {
+		return true
+	}
+	u, err := url.Parse(org)
+	if err == nil && u.Host != "" && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	for _, o := range origins {
+		if o == "*" || strings.EqualFold(o, org) {
+			return true
+		}
+	}
+	return false
+}
+
+// wsUpgrade performs the WebSocket opening handshake on given request,
+// and returns the connection.  Requests from a cross-site Origin not in
+// origins are rejected (see wsOriginOK), as are protocol versions other
+// than 13.
+func wsUpgrade(w http.ResponseWriter, r *http.Request, origins []string) (*wsConn, error) {
+	if !strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade") || !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		http.Error(w, "websocket upgrade required", http.StatusBadRequest)
+		return nil, errors.New("netview: not a websocket upgrade request")
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != wsVersion {
+		w.Header().Set("Sec-WebSocket-Version", wsVersion)
+		http.Error(w, "unsupported websocket version", http.StatusUpgradeRequired)
+		return nil, fmt.Errorf("netview: unsupported Sec-WebSocket-Version: %q", r.Header.Get("Sec-WebSocket-Version"))
+	}
+	if !wsOriginOK(r, origins) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return nil, fmt.Errorf("netview: websocket Origin not allowed: %v", r.Header.Get("Origin"))
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		http.Error(w, "missing Sec-WebSocket-Key", http.StatusBadRequest)
+		return nil, errors.New("netview: missing Sec-WebSocket-Key")
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket not supported", http.StatusInternalServerError)
+		return nil, errors.New("netview: http.ResponseWriter does not support Hijack")
+	}
+	conn, rw, err := hj.Hijack()
+	if err != nil {
+		return nil, err
+	}
+	h := sha1.New()
+	io.WriteString(h, key+wsGUID)
+	accept := base64.StdEncoding.EncodeToString(h.Sum(nil))
+	fmt.Fprintf(rw, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n", accept)
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &wsConn{conn: conn, rw: rw}, nil
+}
+
+// WriteMsg writes a complete (unfragmented) message with given opcode
+func (wc *wsConn) WriteMsg(op byte, data []byte) error {
+	wc.wmu.Lock()
+	defer wc.wmu.Unlock()
+	var hdr [10]byte
+	hdr[0] = 0x80 | op // FIN
+	n := len(data)
+	hl := 2
+	switch {
+	case n < 126:
+		hdr[1] = byte(n)
+	case n <= 0xFFFF:
+		hdr[1] = 126
+		binary.BigEndian.PutUint16(hdr[2:], uint16(n))
+		hl = 4
+	default:
+		hdr[1] = 127
+		binary.BigEndian.PutUint64(hdr[2:], uint64(n))
+		hl = 10
+	}
+	if _, err := wc.rw.Write(hdr[:hl]); err != nil {
+		return err
+	}
+	if _, err := wc.rw.Write(data); err != nil {
+		return err
+	}
+	return wc.rw.Flush()
+}
+
+// ReadLoop reads client frames until the connection is closed or errors,
+// responding to pings and close -- other messages are ignored.
+func (wc *wsConn) ReadLoop() error {
+	var hdr [8]byte
+	for {
+		if _, err := io.ReadFull(wc.rw, hdr[:2]); err != nil {
+			return err
+		}
+		op := hdr[0] & 0x0F
+		masked := hdr[1]&0x80 != 0
+		n := uint64(hdr[1] & 0x7F)
+		switch n {
+		case 126:
+			if _, err := io.ReadFull(wc.rw, hdr[:2]); err != nil {
+				return err
+			}
+			n = uint64(binary.BigEndian.Uint16(hdr[:2]))
+		case 127:
+			if _, err := io.ReadFull(wc.rw, hdr[:8]); err != nil {
+				return err
+			}
+			n = binary.BigEndian.Uint64(hdr[:8])
+		}
+		var mask [4]byte
+		if masked {
+			if _, err := io.ReadFull(wc.rw, mask[:]); err != nil {
+				return err
+			}
+		}
+		if n > 1<<20 {
+			return errors.New("netview: websocket client message too large")
+		}
+		data := make([]byte, n)
+		if _, err := io.ReadFull(wc.rw, data); err != nil {
+			return err
+		}
+		if masked {
+			for i := range data {
+				data[i] ^= mask[i%4]
+			}
+		}
+		switch op {
+		case wsClose:
+			wc.WriteMsg(wsClose, nil)
+			return io.EOF
+		case wsPing:
+			wc.WriteMsg(wsPong, data)
+		}
+	}
+}
+
+// Close closes the underlying connection
+func (wc *wsConn) Close() error {
+	return wc.conn.Close()
+}
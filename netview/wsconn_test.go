@@ -0,0 +1,130 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWsUpgrade(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := wsUpgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		ws.WriteMsg(wsText, []byte("hi"))
+		ws.conn.Close()
+	}))
+	defer srv.Close()
+	conn, err := net.Dial("tcp", strings.TrimPrefix(srv.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	req := "GET / HTTP/1.1\r\nHost: " + strings.TrimPrefix(srv.URL, "http://") +
+		"\r\nOrigin: " + srv.URL +
+		"\r\nConnection: Upgrade\r\nUpgrade: websocket\r\nSec-WebSocket-Version: 13" +
+		"\r\nSec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n\r\n"
+	if _, err := conn.Write([]byte(req)); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Errorf("status: %d != %d", resp.StatusCode, http.StatusSwitchingProtocols)
+	}
+	// accept key for the sample nonce from RFC 6455
+	if acc := resp.Header.Get("Sec-WebSocket-Accept"); acc != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("Sec-WebSocket-Accept: %v", acc)
+	}
+}
+
+func TestWsUpgradeRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		origin  string
+		origins []string
+		code    int
+	}{
+		{"no version", "", "", nil, http.StatusUpgradeRequired},
+		{"old version", "8", "", nil, http.StatusUpgradeRequired},
+		{"cross origin", "13", "http://evil.example.com", nil, http.StatusForbidden},
+		{"origin not listed", "13", "http://evil.example.com", []string{"http://localhost:8080"}, http.StatusForbidden},
+		{"origin host prefix", "13", "http://localhost:8080.example.com", nil, http.StatusForbidden},
+	}
+	for _, ts := range tests {
+		r := httptest.NewRequest("GET", "http://localhost:8080/netstream", nil)
+		r.Header.Set("Connection", "Upgrade")
+		r.Header.Set("Upgrade", "websocket")
+		r.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+		if ts.version != "" {
+			r.Header.Set("Sec-WebSocket-Version", ts.version)
+		}
+		if ts.origin != "" {
+			r.Header.Set("Origin", ts.origin)
+		}
+		w := httptest.NewRecorder()
+		ws, err := wsUpgrade(w, r, ts.origins)
+		if err == nil || ws != nil {
+			t.Errorf("%s: upgrade was not rejected", ts.name)
+		}
+		if w.Code != ts.code {
+			t.Errorf("%s: status: %d != %d", ts.name, w.Code, ts.code)
+		}
+		if ts.code == http.StatusUpgradeRequired && w.Header().Get("Sec-WebSocket-Version") != "13" {
+			t.Errorf("%s: missing supported Sec-WebSocket-Version in response", ts.name)
+		}
+	}
+}
+
+func TestWsOriginOK(t *testing.T) {
+	tests := []struct {
+		origin  string
+		origins []string
+		ok      bool
+	}{
+		{"", nil, true},
+		{"http://localhost:8080", nil, true},
+		{"https://LOCALHOST:8080", nil, true},
+		{"http://localhost:9090", nil, false},
+		{"http://viewer.example.com", []string{"http://viewer.example.com"}, true},
+		{"http://viewer.example.com", []string{"https://viewer.example.com"}, false},
+		{"http://any.example.com", []string{"*"}, true},
+		{"null", nil, false},
+	}
+	for _, ts := range tests {
+		r := httptest.NewRequest("GET", "http://localhost:8080/netstream", nil)
+		if ts.origin != "" {
+			r.Header.Set("Origin", ts.origin)
+		}
+		if ok := wsOriginOK(r, ts.origins); ok != ts.ok {
+			t.Errorf("Origin %q, origins %v: %v != %v", ts.origin, ts.origins, ok, ts.ok)
+		}
+	}
+}
+
+func TestStreamerDefaults(t *testing.T) {
+	for _, buf := range []int{0, -1, -100} {
+		st := &Streamer{Buffer: buf}
+		st.Defaults()
+		if st.Buffer != 16 {
+			t.Errorf("Buffer %d: Defaults: %d != 16", buf, st.Buffer)
+		}
+	}
+	st := &Streamer{Buffer: 4}
+	st.Defaults()
+	if st.Buffer != 4 {
+		t.Errorf("Defaults changed valid Buffer: %d != 4", st.Buffer)
+	}
+}
@@ -7,11 +7,12 @@ package params
 import (
 	"fmt"
 	"log"
+	"path"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 
-	"github.com/goki/gi/gi"
 	"github.com/goki/ki/kit"
 )
 
@@ -38,14 +39,19 @@ func (pr *Params) Path(path string) string {
 // was set (it always prints an error message if it fails to set the
 // parameter at given path, and returns error if so).
 func (pr *Params) Apply(obj interface{}, setMsg bool) error {
-	objNm := ""
-	if stylr, has := obj.(Styler); has {
-		objNm = stylr.Name()
-	} else if lblr, has := obj.(gi.Labeler); has {
-		objNm = lblr.Label()
-	}
+	return pr.applyFrozen(obj, setMsg, nil)
+}
+
+// applyFrozen applies all parameter values to given object, skipping
+// those in given frozen params, with a logged notice
+func (pr *Params) applyFrozen(obj interface{}, setMsg bool, fz Frozen) error {
+	objNm := objName(obj)
 	var rerr error
 	for pt, v := range *pr {
+		if fz.IsFrozen(obj, pt) {
+			log.Printf("%v param path: %v is frozen, not set to value: %v\n", objNm, pt, v)
+			continue
+		}
 		path := pr.Path(pt)
 		err := SetParam(obj, path, v)
 		if err == nil {
@@ -64,18 +70,24 @@ func (pr *Params) Apply(obj interface{}, setMsg bool) error {
 
 // Apply checks if Sel selector applies to this object according to (.Class, #Name, Type)
 // using the params.Styler interface, and returns false if it does not.
-// The TargetType of the Params is always tested against the obj's type name first.
+// The TargetType of the Params is always tested against the obj's type name first,
+// and the Cond and CondFunc conditions (if set) last (see CondMatch).
 // If it does apply, or is not a Styler, then the Params values are set.
 // If setMsg is true, then a message is printed to confirm each parameter that is set.
 // It always prints a message if a parameter fails to be set, and returns an error.
 func (ps *Sel) Apply(obj interface{}, setMsg bool) (bool, error) {
+	return ps.applyFrozen(obj, setMsg, nil)
+}
+
+// applyFrozen is Apply, skipping params in given frozen params
+func (ps *Sel) applyFrozen(obj interface{}, setMsg bool, fz Frozen) (bool, error) {
 	if !ps.TargetTypeMatch(obj) {
 		return false, nil
 	}
-	if !ps.SelMatch(obj) {
+	if !ps.SelMatch(obj) || !ps.CondMatch(obj) {
 		return false, nil
 	}
-	err := ps.Params.Apply(obj, setMsg)
+	err := ps.Params.applyFrozen(obj, setMsg, fz)
 	return true, err
 }
 
@@ -96,28 +108,131 @@ func (ps *Sel) SelMatch(obj interface{}) bool {
 	if !has {
 		return true // default match if no styler..
 	}
-	return SelMatch(ps.Sel, stylr.Name(), stylr.Class(), stylr.TypeName())
+	return StylerMatch(ps.Sel, stylr)
 }
 
-// SelMatch returns true if Sel selector matches the target object properties
+// StylerMatch returns true if the selector matches given object, including
+// [Attr=Val] terms if it implements the StylerAttrs interface (see SelMatch)
+func StylerMatch(sel string, stylr Styler) bool {
+	attrs, _ := stylr.(StylerAttrs)
+	return selMatch(sel, stylr.Name(), stylr.Class(), stylr.TypeName(), attrs)
+}
+
+// SelMatch returns true if Sel selector matches the target object properties.
+// The selector can be a comma-separated list of terms, each of which is a
+// .Class, #Name, or Type, optionally containing wildcards (e.g., #Hidden*,
+// see path.Match for the syntax), and optionally negated with a ! prefix
+// (e.g., !#Output).  It matches if any of the non-negated terms match
+// (or there are only negated terms), and none of the negated terms match,
+// so "Layer, !#Output" matches all layers other than Output.
+// Terms can also have [Attr=Val] attribute constraints, alone or after the
+// .Class, #Name, or Type, e.g., Layer[Type=Hidden] or [Shape=4D], which
+// only match objects that implement the StylerAttrs interface (see
+// StylerMatch) -- [Attr~=Val] matches one of a space-separated list of
+// values (e.g., tags).  Values can have wildcards, but not commas.
 func SelMatch(sel string, name, cls, typ string) bool {
+	return selMatch(sel, name, cls, typ, nil)
+}
+
+// selMatch is the implementation of SelMatch, with optional attributes
+func selMatch(sel string, name, cls, typ string, attrs StylerAttrs) bool {
 	if sel == "" {
 		return false
 	}
-	if sel[0] == '.' { // class
-		return ClassMatch(sel[1:], cls)
+	if !strings.ContainsAny(sel, ",!*?[") { // fast path for simple selectors
+		return selTermMatch(sel, name, cls, typ, attrs)
+	}
+	npos := 0
+	posMatch := false
+	for _, trm := range strings.Split(sel, ",") {
+		trm = strings.TrimSpace(trm)
+		if trm == "" {
+			continue
+		}
+		if trm[0] == '!' {
+			if selTermMatch(strings.TrimSpace(trm[1:]), name, cls, typ, attrs) {
+				return false
+			}
+			continue
+		}
+		npos++
+		if !posMatch && selTermMatch(trm, name, cls, typ, attrs) {
+			posMatch = true
+		}
+	}
+	return posMatch || npos == 0
+}
+
+// attrRe matches [Attr=Val] and [Attr~=Val] attribute selector terms
+var attrRe = regexp.MustCompile(`\[\s*(\w+)\s*(~?=)\s*([^\]]*?)\s*\]`)
+
+// selTermMatch returns true if a single selector term (.Class, #Name, or
+// Type, possibly with wildcards and [Attr=Val] terms) matches the target
+// object properties
+func selTermMatch(trm string, name, cls, typ string, attrs StylerAttrs) bool {
+	if strings.Contains(trm, "=") {
+		ams := attrRe.FindAllStringSubmatch(trm, -1)
+		for _, am := range ams {
+			if !attrMatch(am[1], am[2], am[3], attrs) {
+				return false
+			}
+		}
+		if len(ams) > 0 {
+			trm = strings.TrimSpace(attrRe.ReplaceAllString(trm, ""))
+			if trm == "" {
+				return true
+			}
+		}
+	}
+	if trm == "" {
+		return false
+	}
+	if trm[0] == '.' { // class
+		return ClassMatch(trm[1:], cls)
+	}
+	if trm[0] == '#' { // name
+		return nameMatch(trm[1:], name)
+	}
+	return nameMatch(trm, typ) // type
+}
+
+// attrMatch returns true if given attribute of the object matches the
+// value pattern, using = for the whole value and ~= for one of the
+// space-separated values -- false if no attrs or the attribute is missing
+func attrMatch(attr, op, pat string, attrs StylerAttrs) bool {
+	if attrs == nil {
+		return false
+	}
+	val, has := attrs.StyleAttr(attr)
+	if !has {
+		return false
 	}
-	if sel[0] == '#' { // name
-		return name == sel[1:]
+	if op == "~=" {
+		return ClassMatch(pat, val)
+	}
+	return nameMatch(pat, val)
+}
+
+// nameMatch returns true if given name matches the selector pattern,
+// which can contain wildcards (see path.Match)
+func nameMatch(pat, name string) bool {
+	if !strings.ContainsAny(pat, "*?[") {
+		return name == pat
+	}
+	mt, err := path.Match(pat, name)
+	if err != nil {
+		log.Printf("params.SelMatch: bad selector pattern: %v: %v\n", pat, err)
+		return false
 	}
-	return typ == sel // type
+	return mt
 }
 
-// ClassMatch returns true if given class names -- handles space-separated multiple class names
+// ClassMatch returns true if given class names -- handles space-separated multiple class names.
+// The selector can contain wildcards (see path.Match).
 func ClassMatch(sel, cls string) bool {
 	clss := strings.Split(cls, " ")
 	for _, cl := range clss {
-		if strings.TrimSpace(cl) == sel {
+		if nameMatch(sel, strings.TrimSpace(cl)) {
 			return true
 		}
 	}
@@ -128,14 +243,14 @@ func ClassMatch(sel, cls string) bool {
 //  Sheet
 
 // Apply applies entire sheet to given object, using param.Sel's in order
-// see param.Sel.Apply() for details.
+// of Priority and then as listed (see Ordered) -- see param.Sel.Apply() for details.
 // returns true if any Sel's applied, and error if any errors.
 // If setMsg is true, then a message is printed to confirm each parameter that is set.
 // It always prints a message if a parameter fails to be set, and returns an error.
 func (ps *Sheet) Apply(obj interface{}, setMsg bool) (bool, error) {
 	applied := false
 	var rerr error
-	for _, sl := range *ps {
+	for _, sl := range ps.Ordered() {
 		app, err := sl.Apply(obj, setMsg)
 		if app {
 			applied = true
@@ -156,11 +271,18 @@ func (ps *Sheet) Apply(obj interface{}, setMsg bool) (bool, error) {
 // the target type should already have been identified and this should only
 // be called when there is an expectation of the path working.
 func FindParam(val reflect.Value, path string) (reflect.Value, error) {
+	fld, _, err := findParam(val, path)
+	return fld, err
+}
+
+// findParam is the implementation of FindParam, also returning the struct
+// field of the param, for its tags (e.g., min, max, def)
+func findParam(val reflect.Value, path string) (reflect.Value, *reflect.StructField, error) {
 	npv := kit.NonPtrValue(val)
 	if npv.Kind() != reflect.Struct {
 		err := fmt.Errorf("params.FindParam: object is not a struct: %v kind: %v -- params must be on structs, path: %v\n", npv.String(), npv.Kind(), path)
 		log.Println(err)
-		return npv, err
+		return npv, nil, err
 	}
 	paths := strings.Split(path, ".")
 	fnm := paths[0]
@@ -168,24 +290,55 @@ func FindParam(val reflect.Value, path string) (reflect.Value, error) {
 	if !fld.IsValid() {
 		err := fmt.Errorf("params.FindParam: could not find Field named: %v in struct: %v kind: %v, path: %v\n", fnm, npv.String(), npv.Kind(), path)
 		log.Println(err)
-		return fld, err
+		return fld, nil, err
 	}
 	if len(paths) == 1 {
-		return fld.Addr(), nil
+		sf, _ := npv.Type().FieldByName(fnm)
+		return fld.Addr(), &sf, nil
 	}
-	return FindParam(fld.Addr(), strings.Join(paths[1:], ".")) // need addr
+	return findParam(fld.Addr(), strings.Join(paths[1:], ".")) // need addr
 }
 
 // SetParam sets parameter at given path on given object to given value
 // converts the string param val as appropriate for target type.
+// ${VAR} references are replaced first (see SubstVal), values starting
+// with = are expressions evaluated on the object (see EvalExpr), and values
+// with unit suffixes (e.g., 10ms) are converted (see UnitVal).
+// Numeric values are checked against the min, max, and def tags of
+// the field according to RangeCheck (see CheckRange).
 // returns error if path not found or cannot set (always logged).
 func SetParam(obj interface{}, path string, val string) error {
-	fld, err := FindParam(reflect.ValueOf(obj), path)
+	fld, sf, err := findParam(reflect.ValueOf(obj), path)
+	if err != nil {
+		return err
+	}
+	return setParamVal(obj, fld, sf, path, val)
+}
+
+// setParamVal sets the parameter field (a pointer to the field value),
+// at given path, to given value, converting the string as appropriate,
+// and checking numeric values against the tags of struct field sf (can be nil).
+// ${VAR} references are replaced (see SubstVal), then expression
+// values (see ExprVal) are evaluated on given object, and numeric values
+// with unit suffixes are converted to the unit of the field (see UnitVal).
+func setParamVal(obj interface{}, fld reflect.Value, sf *reflect.StructField, path string, val string) error {
+	val, err := SubstVal(val)
+	if err != nil {
+		return err
+	}
+	val, err = ExprVal(val, obj)
 	if err != nil {
 		return err
 	}
 	npf := kit.NonPtrValue(fld)
 	switch npf.Kind() {
+	case reflect.Float64, reflect.Float32, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		val, err = UnitVal(val, sf, path)
+		if err != nil {
+			return err
+		}
+	}
+	switch npf.Kind() {
 	case reflect.String:
 		npf.SetString(val)
 	case reflect.Float64, reflect.Float32:
@@ -194,6 +347,10 @@ func SetParam(obj interface{}, path string, val string) error {
 			log.Println(err)
 			return err
 		}
+		r, err = CheckRange(sf, path, r)
+		if err != nil {
+			return err
+		}
 		npf.SetFloat(r)
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		r, err := strconv.ParseInt(val, 0, 64)
@@ -204,7 +361,11 @@ func SetParam(obj interface{}, path string, val string) error {
 				return err
 			}
 		} else {
-			npf.SetInt(r)
+			rf, err := CheckRange(sf, path, float64(r))
+			if err != nil {
+				return err
+			}
+			npf.SetInt(int64(rf))
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
 		r, err := strconv.ParseInt(val, 0, 64)
@@ -212,7 +373,11 @@ func SetParam(obj interface{}, path string, val string) error {
 			log.Println(err)
 			return err
 		}
-		npf.SetUint(uint64(r))
+		rf, err := CheckRange(sf, path, float64(r))
+		if err != nil {
+			return err
+		}
+		npf.SetUint(uint64(rf))
 	case reflect.Bool:
 		r, err := strconv.ParseBool(val)
 		if err != nil {
@@ -220,6 +385,8 @@ func SetParam(obj interface{}, path string, val string) error {
 			return err
 		}
 		npf.SetBool(r)
+	case reflect.Slice:
+		return setParamSlice(npf, sf, path, val)
 	default:
 		err := fmt.Errorf("params.SetParam: field is not of a numeric type -- only numeric types supported. value: %v, kind: %v, path: %v\n", npf.String(), npf.Kind(), path)
 		log.Println(err)
@@ -0,0 +1,47 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"testing"
+)
+
+func TestSelMatch(t *testing.T) {
+	type obj struct {
+		name, cls, typ string
+	}
+	objs := []obj{
+		{"Input", "", "Layer"},
+		{"Hidden1", "Hid", "Layer"},
+		{"Hidden2", "Hid Back", "Layer"},
+		{"Output", "Out", "Layer"},
+	}
+	tests := []struct {
+		sel string
+		trg []bool
+	}{
+		{"Layer", []bool{true, true, true, true}},
+		{"#Output", []bool{false, false, false, true}},
+		{".Hid", []bool{false, true, true, false}},
+		{"#Input, #Output", []bool{true, false, false, true}},
+		{"#Hidden*", []bool{false, true, true, false}},
+		{"#Hidden?", []bool{false, true, true, false}},
+		{".B*", []bool{false, false, true, false}},
+		{"!#Output", []bool{true, true, true, false}},
+		{"Layer, !#Output", []bool{true, true, true, false}},
+		{".Hid, !.Back", []bool{false, true, false, false}},
+		{"#Hidden*, #Output, !#Hidden2", []bool{false, true, false, true}},
+		{"Prjn", []bool{false, false, false, false}},
+		{"", []bool{false, false, false, false}},
+	}
+	for _, tst := range tests {
+		for i, ob := range objs {
+			mt := SelMatch(tst.sel, ob.name, ob.cls, ob.typ)
+			if mt != tst.trg[i] {
+				t.Errorf("sel: %q obj: %v match: %v != %v", tst.sel, ob.name, mt, tst.trg[i])
+			}
+		}
+	}
+}
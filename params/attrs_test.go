@@ -0,0 +1,46 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"testing"
+)
+
+type attrLayer struct {
+	provLayer
+	Attrs
+}
+
+func TestStylerAttrs(t *testing.T) {
+	hid := &attrLayer{provLayer{Nm: "Hidden"}, Attrs{"Type": "Hidden", "Shape": "4D", "Tags": "recurrent new"}}
+	out := &attrLayer{provLayer{Nm: "Output"}, Attrs{"Type": "Target", "Shape": "2D"}}
+	plain := &provLayer{Nm: "Plain"}
+	tests := []struct {
+		sel            string
+		hid, out, plan bool
+	}{
+		{"[Type=Hidden]", true, false, false},
+		{"Layer[Type=Hidden]", true, false, false},
+		{"Prjn[Type=Hidden]", false, false, false},
+		{"Layer[Shape=4D][Type=Hid*]", true, false, false},
+		{"[Tags~=new]", true, false, false},
+		{"[Tags=new]", false, false, false},
+		{"#Output, [Type = Hidden]", true, true, false},
+		{"Layer, ![Shape=2D]", true, false, true},
+		{"#Hid[0-9]", false, false, false},
+		{"Layer", true, true, true},
+	}
+	for _, ts := range tests {
+		if StylerMatch(ts.sel, hid) != ts.hid || StylerMatch(ts.sel, out) != ts.out || StylerMatch(ts.sel, plain) != ts.plan {
+			t.Errorf("sel: %q: hid: %v out: %v plain: %v", ts.sel, StylerMatch(ts.sel, hid), StylerMatch(ts.sel, out), StylerMatch(ts.sel, plain))
+		}
+	}
+	sl := &Sel{Sel: "[Type=Hidden]", Params: Params{"Layer.Gi": "2"}}
+	sl.Apply(hid, false)
+	sl.Apply(out, false)
+	if hid.Gi != 2 || out.Gi != 0 {
+		t.Errorf("apply: %v %v", hid.Gi, out.Gi)
+	}
+}
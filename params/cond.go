@@ -0,0 +1,121 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/goki/ki/kit"
+)
+
+// Matches returns true if this Sel applies to given object: its target type
+// matches, its selector matches, and its condition (Cond, CondFunc) holds.
+func (ps *Sel) Matches(obj interface{}) bool {
+	return ps.TargetTypeMatch(obj) && ps.SelMatch(obj) && ps.CondMatch(obj)
+}
+
+// CondMatch returns true if the Cond expression and the CondFunc
+// are true for given object (each is true if not set).
+// Errors in the Cond expression are logged, and return false.
+func (ps *Sel) CondMatch(obj interface{}) bool {
+	if ps.CondFunc != nil && !ps.CondFunc(obj) {
+		return false
+	}
+	if ps.Cond == "" {
+		return true
+	}
+	mt, err := EvalCond(ps.Cond, obj)
+	if err != nil {
+		return false
+	}
+	return mt
+}
+
+// condOps are the comparison operators in Cond expressions,
+// with longer ones first so they take precedence at the same position
+var condOps = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// EvalCond evaluates given condition expression on given object.  The
+// expression is a comparison of a param path (including the target type,
+// as in Params) to a value, e.g., "Layer.Typ == Hidden" or
+// "Layer.Inhib.Layer.Gi > 1.5", using ==, !=, <, <=, >, or >=, and multiple
+// comparisons can be combined with && and || (&& binds more tightly).
+// Numbers are compared numerically, and anything else as strings using
+// the default formatting of the value (e.g., enum names), for == and != only.
+func EvalCond(cond string, obj interface{}) (bool, error) {
+	for _, ors := range strings.Split(cond, "||") {
+		all := true
+		for _, ands := range strings.Split(ors, "&&") {
+			mt, err := evalCmp(strings.TrimSpace(ands), obj)
+			if err != nil {
+				err = fmt.Errorf("params.EvalCond: condition: %q: %v", cond, err)
+				log.Println(err)
+				return false, err
+			}
+			if !mt {
+				all = false
+				break
+			}
+		}
+		if all {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// evalCmp evaluates one comparison of a param path to a value
+func evalCmp(cmp string, obj interface{}) (bool, error) {
+	op := ""
+	oi := -1
+	for _, o := range condOps {
+		if i := strings.Index(cmp, o); i > 0 && (oi < 0 || i < oi) {
+			op, oi = o, i
+		}
+	}
+	if oi < 0 {
+		return false, fmt.Errorf("comparison: %q must be of the form path op value, with op one of: %v", cmp, condOps)
+	}
+	path := strings.TrimSpace(cmp[:oi])
+	val := strings.Trim(strings.TrimSpace(cmp[oi+len(op):]), `"`)
+	pe := strings.SplitN(path, ".", 2)
+	if len(pe) < 2 || pe[0] != objTypeName(obj) {
+		return false, fmt.Errorf("path: %q must start with the target type: %v", path, objTypeName(obj))
+	}
+	fld, _, err := findParam(reflect.ValueOf(obj), pe[1])
+	if err != nil {
+		return false, err
+	}
+	cur := fmt.Sprintf("%v", kit.NonPtrValue(fld).Interface())
+	cf, cerr := strconv.ParseFloat(cur, 64)
+	vf, verr := strconv.ParseFloat(val, 64)
+	if cerr == nil && verr == nil {
+		switch op {
+		case "==":
+			return cf == vf, nil
+		case "!=":
+			return cf != vf, nil
+		case "<":
+			return cf < vf, nil
+		case "<=":
+			return cf <= vf, nil
+		case ">":
+			return cf > vf, nil
+		default:
+			return cf >= vf, nil
+		}
+	}
+	switch op {
+	case "==":
+		return cur == val, nil
+	case "!=":
+		return cur != val, nil
+	}
+	return false, fmt.Errorf("comparison: %q: %v is only valid for numbers, and %v = %v", cmp, op, path, cur)
+}
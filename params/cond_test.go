@@ -0,0 +1,53 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"testing"
+)
+
+type condLayer struct {
+	provLayer
+	Typ   string
+	NDims int
+}
+
+func TestCond(t *testing.T) {
+	hid := &condLayer{provLayer: provLayer{Nm: "Hidden", Gi: 1.8}, Typ: "Hidden", NDims: 4}
+	hid2 := &condLayer{provLayer: provLayer{Nm: "Hidden2", Gi: 2}, Typ: "Hidden", NDims: 2}
+	out := &condLayer{provLayer: provLayer{Nm: "Output", Gi: 1.4}, Typ: "Target", NDims: 2}
+	conds := []struct {
+		cond string
+		trg  []bool
+	}{
+		{"Layer.Typ == Hidden", []bool{true, true, false}},
+		{`Layer.Typ != "Hidden"`, []bool{false, false, true}},
+		{"Layer.Typ == Hidden && Layer.NDims == 4", []bool{true, false, false}},
+		{"Layer.Gi >= 1.8", []bool{true, true, false}},
+		{"Layer.Gi<1.8 || Layer.NDims > 3", []bool{true, false, true}},
+	}
+	for _, cd := range conds {
+		for i, ly := range []*condLayer{hid, hid2, out} {
+			mt, err := EvalCond(cd.cond, ly)
+			if err != nil || mt != cd.trg[i] {
+				t.Errorf("cond: %q layer: %v: %v != %v err: %v", cd.cond, ly.Nm, mt, cd.trg[i], err)
+			}
+		}
+	}
+	for _, bad := range []string{"Layer.Typ", "Layer.Typ > Hidden", "Prjn.Typ == Hidden", "Layer.Foo == 1"} {
+		if _, err := EvalCond(bad, hid); err == nil {
+			t.Errorf("cond: %q should be an error", bad)
+		}
+	}
+
+	sl := &Sel{Sel: "Layer", Cond: "Layer.Typ == Hidden", Params: Params{"Layer.Gi": "1"}}
+	sl.CondFunc = func(obj interface{}) bool { return obj.(*condLayer).NDims == 4 }
+	for _, ly := range []*condLayer{hid, hid2, out} {
+		sl.Apply(ly, false)
+	}
+	if hid.Gi != 1 || hid2.Gi != 2 || out.Gi != 1.4 {
+		t.Errorf("apply: %v %v %v", hid.Gi, hid2.Gi, out.Gi)
+	}
+}
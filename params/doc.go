@@ -34,6 +34,8 @@ or according to different brain areas ("Hippo", "PFC", "BG", etc).
 Again, this is entirely at the discretion of the modeler and must be
 performed under explict program control, especially because order is so critical.
 
+Selectors
+
 Each params.Sheet consists of a collection of params.Sel elements which actually
 finally contain the parameters.  The Sel field specifies a CSS-style selector
 determining over what scope the parameters should be applied:
@@ -45,6 +47,29 @@ labels and thus receive multiple parameter settings, but again, order matters!)
 
 * #Name = a specific named object.
 
+These can be combined in a comma-separated list, which matches if any of
+the items match (e.g., "#Hidden1, #Hidden2"), and can contain wildcards as in
+path.Match (e.g., "#Hidden*" for all objects named Hidden-something).
+A ! prefix negates an item, e.g., "Layer, !#Output" applies to all layers
+other than Output, and "!.Back" to anything without the Back class.
+Objects implementing the optional params.StylerAttrs interface can also be
+selected by attributes such as the layer type, shape, or user-set tags, e.g.,
+"Layer[Type=Hidden]", "[Shape=4D]", or "[Tags~=new]" (see params.SelMatch).
+A Sel can also have a Cond condition on the values of the target, e.g.,
+"Layer.Typ == Hidden", or a CondFunc Go function, that must hold for it to apply.
+
+There is a params.Styler interface with methods that any Go type can implement
+to provide these different labels.  The emer.Network, .Layer, and .Prjn interfaces
+each implement this interface.  Otherwise, the Apply method will just directly
+apply params to a given struct type if it does not implement the Styler interface.
+
+For objects that each need systematically different values (e.g., position-
+dependent gains), a params.Flex holds a copy of a template object for each
+name, with its type, class, and attributes for selection, and params.FlexSheet
+generates a #Name Sel for each with values computed by a function.
+
+Order of application
+
 The order of application within a given Sheet is also critical -- typically
 put the most general Type params first, then .Class, then the most specific #Name
 cases, to achieve within a given Sheet the same logic of establishing Base params
@@ -52,23 +77,97 @@ for all types and then more specific overrides for special cases (e.g., an overa
 learning rate that appplies across all projections, but maybe a faster or slower
 one for a .Class or specific #Name'd projection).
 
-There is a params.Styler interface with methods that any Go type can implement
-to provide these different labels.  The emer.Network, .Layer, and .Prjn interfaces
-each implement this interface.
-
-Otherwise, the Apply method will just directly apply params to a given struct
-type if it does not implement the Styler interface.
-
-Parameter values are limited to float64 values *only*.  These can be specified
-using "enum" style const integer values, and can be applied to any numeric
-type (they will be automatically converted), but internally this is the only
-parameter value type, which greatly simplifies the overall interface, and handles
-the vast majority of use-cases (especially because named options are just integers
-and can be set as such).
-
-Finally, there are methods to show where params.Set's set the same parameter
+For a well-defined order regardless of conventions, each params.Sel can have
+a Priority -- Sels are applied in order of increasing priority, and otherwise
+in order -- and a params.Set can declare the Order of its Sheets, which
+params.Set.Apply uses to apply all of them to an object, with
+params.Set.Provenance reporting the Sheet and Sel that set the effective
+value of each param.
+
+Parameter values
+
+Each param is a path to a field on the target object (e.g., "Prjn.Learn.Lrate")
+with a value that is stored as a string, which is converted to the type of the
+field when applied.  Numbers can be specified using "enum" style const integer
+values, and are automatically converted to any numeric type.  Typed values can
+be set and retrieved with the typed methods (SetFloat, Float, etc), which use
+the same canonical formats as JSON: numbers, true / false, and lists of numbers
+as [v1,v2,...], which can be applied to slice fields.  In the JSON format,
+values in these formats are written as typed JSON values instead of strings,
+and either form is read.
+
+Values can also be computed when applied:
+
+* Values starting with = are expressions evaluated when applied, e.g.,
+"=0.5*Prjn.Learn.Lrate" or "=1/NLayers", using the current values of params on
+the target object and the variables in params.ExprVars (see params.EvalExpr).
+
+* Numbers can have unit suffixes, e.g., "10ms", "0.02s", or "5%", which are
+converted to the unit of the field from its unit:"ms" struct field tag (or the
+base unit of the dimension) -- see params.Units.
+
+* References of the form ${VAR} (or ${VAR:-default}) are replaced by the value
+of VAR in params.SubstVars or the environment, or in place with the Subst
+methods, so the same params can be reused across cluster jobs.
+
+Exploring parameters
+
+params.Search generates a params.Set for each combination of values in the
+full cross-product of lists of values for given param paths (grid search),
+named by the values (e.g., "Gi=1.8_Lrate=0.04"), and can run them sequentially
+via a callback, collecting the results, or iterate over them with Iter.
+params.RandSearch instead samples each param from a distribution (uniform,
+log-uniform, or choice), with a reproducible random seed for each sample,
+for higher-dimensional searches.
+
+For external optimizers, the Hypers on each params.Sel hold hyperparameter
+search metadata (tunable, prior, range, sigma) keyed by the same param paths
+as its Params, which params.Set.WriteHypersJSON exports along with the current
+values.  A params.Optimization runs any params.Optimizer (e.g., Bayesian
+optimization or CMA-ES backends) over these, which suggests the values for
+each trial given the results so far -- params.RandOptimizer is the
+random-search version.
+
+To test the robustness of results to parameter noise, a params.Jitter
+perturbs selected params (e.g., +/- 10% on all Gi values) with a fixed seed,
+generating the perturbed params.Set's for record-keeping.
+
+Changing parameters during a run
+
+For params that change over the course of training (e.g., learning rate
+decay), a params.Schedule maps counter values such as the epoch to param
+values, and its Apply method returns a params.Sheet for a given counter.
+
+A params.History records the values changed by each application of params
+(see emer.ApplyParamsHist), with Undo and a report of the net changes.
+Conversely, params.Snapshot (and emer.SnapshotParams) reads the current values
+of given param paths from live objects into a params.Sheet, e.g., to capture a
+hand-tuned state exactly, and the Frozen params of a params.Set or History
+(see params.Frozen.Freeze) protect given param paths on selected objects, so
+that subsequent applications through that Set or History skip them.
+
+Managing and comparing parameters
+
+Sheets composed from multiple sources can be combined with params.Sheet.Merge,
+with a policy for params set differently in both (override, keep the first,
+or error), which returns a report of the conflicts.
+
+Each params.Set records the params.Version of the program when it was saved,
+and when opened, Sets from older versions are upgraded using the Migrations
+registered with params.AddMigration (e.g., renamed paths, changed defaults).
+
+params.Sets.Lint (and emer.LintParams) checks that every selector matches at
+least one object, and every param path resolves to a field on those objects,
+returning structured diagnostics, e.g., for checking at sim startup.
+
+params.DiffFiles (and params.Sets.Diffs) returns the params that were added,
+removed, or changed between two versions of params, e.g., between experiments.
+There are also methods to show where params.Set's set the same parameter
 differently, and to compare with the default settings on a given object type
 using go struct field tags of the form def:"val1[,val2...]".
 
+params.Sets.WriteMarkdown and WriteCSV generate a table of every selector,
+path, value, description, and hyperparameter notes, e.g., for a parameter
+appendix in a paper.
 */
 package params
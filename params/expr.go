@@ -0,0 +1,258 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ExprVars are the variables available in param value expressions (see
+// EvalExpr), e.g., ExprVars["NLayers"] = float64(net.NLayers()), set by the
+// program before applying params that use them.
+var ExprVars = map[string]float64{}
+
+// exprFuncs are the functions available in param value expressions
+var exprFuncs = map[string]func(args []float64) (float64, error){
+	"min":  func(a []float64) (float64, error) { return exprFun2(a, math.Min) },
+	"max":  func(a []float64) (float64, error) { return exprFun2(a, math.Max) },
+	"pow":  func(a []float64) (float64, error) { return exprFun2(a, math.Pow) },
+	"exp":  func(a []float64) (float64, error) { return exprFun1(a, math.Exp) },
+	"log":  func(a []float64) (float64, error) { return exprFun1(a, math.Log) },
+	"sqrt": func(a []float64) (float64, error) { return exprFun1(a, math.Sqrt) },
+	"abs":  func(a []float64) (float64, error) { return exprFun1(a, math.Abs) },
+}
+
+func exprFun1(a []float64, fun func(x float64) float64) (float64, error) {
+	if len(a) != 1 {
+		return 0, fmt.Errorf("function takes 1 argument, not %d", len(a))
+	}
+	return fun(a[0]), nil
+}
+
+func exprFun2(a []float64, fun func(x, y float64) float64) (float64, error) {
+	if len(a) != 2 {
+		return 0, fmt.Errorf("function takes 2 arguments, not %d", len(a))
+	}
+	return fun(a[0], a[1]), nil
+}
+
+// IsExpr returns true if given param value is an expression,
+// which starts with = (see EvalExpr)
+func IsExpr(val string) bool {
+	return strings.HasPrefix(val, "=")
+}
+
+// ExprVal returns the param value to set on given object for given param
+// value: if it is an expression (see IsExpr), it is evaluated on the object
+// (see EvalExpr) and the result formatted as a number, and otherwise it is
+// returned unchanged.
+func ExprVal(val string, obj interface{}) (string, error) {
+	if !IsExpr(val) {
+		return val, nil
+	}
+	v, err := EvalExpr(val[1:], obj)
+	if err != nil {
+		return val, err
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64), nil
+}
+
+// EvalExpr evaluates given arithmetic expression for a param value on given
+// target object, e.g., "0.5*Prjn.Learn.Lrate" or "1/NLayers".  Expressions
+// can contain numbers, the operators + - * / with parentheses, the functions
+// min, max, pow (2 args) and exp, log, sqrt, abs (1 arg), the variables in
+// ExprVars, and param paths on the target (including the target type, as in
+// Params), which have their current value before the params are applied.
+func EvalExpr(expr string, obj interface{}) (float64, error) {
+	ep := &exprParser{obj: obj}
+	err := ep.tokenize(expr)
+	if err == nil {
+		var v float64
+		v, err = ep.sum()
+		if err == nil && ep.pos < len(ep.toks) {
+			err = fmt.Errorf("unexpected: %q", ep.toks[ep.pos])
+		}
+		if err == nil {
+			return v, nil
+		}
+	}
+	err = fmt.Errorf("params.EvalExpr: expression: %q: %v", expr, err)
+	log.Println(err)
+	return 0, err
+}
+
+// exprParser is a recursive-descent parser and evaluator for EvalExpr
+type exprParser struct {
+	toks []string
+	pos  int
+	obj  interface{}
+}
+
+// tokenize splits the expression into number, name, and operator tokens
+func (ep *exprParser) tokenize(expr string) error {
+	rs := []rune(expr)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || r == '.':
+			st := i
+			for i < len(rs) && (unicode.IsDigit(rs[i]) || rs[i] == '.' || rs[i] == 'e' || rs[i] == 'E' ||
+				((rs[i] == '-' || rs[i] == '+') && (rs[i-1] == 'e' || rs[i-1] == 'E'))) {
+				i++
+			}
+			ep.toks = append(ep.toks, string(rs[st:i]))
+		case unicode.IsLetter(r) || r == '_':
+			st := i
+			for i < len(rs) && (unicode.IsLetter(rs[i]) || unicode.IsDigit(rs[i]) || rs[i] == '_' || rs[i] == '.') {
+				i++
+			}
+			ep.toks = append(ep.toks, string(rs[st:i]))
+		case strings.ContainsRune("+-*/(),", r):
+			ep.toks = append(ep.toks, string(r))
+			i++
+		default:
+			return fmt.Errorf("invalid character: %q", r)
+		}
+	}
+	return nil
+}
+
+// peek returns the current token, or "" if at the end
+func (ep *exprParser) peek() string {
+	if ep.pos < len(ep.toks) {
+		return ep.toks[ep.pos]
+	}
+	return ""
+}
+
+// sum parses terms separated by + or -
+func (ep *exprParser) sum() (float64, error) {
+	v, err := ep.product()
+	if err != nil {
+		return 0, err
+	}
+	for op := ep.peek(); op == "+" || op == "-"; op = ep.peek() {
+		ep.pos++
+		r, err := ep.product()
+		if err != nil {
+			return 0, err
+		}
+		if op == "+" {
+			v += r
+		} else {
+			v -= r
+		}
+	}
+	return v, nil
+}
+
+// product parses factors separated by * or /
+func (ep *exprParser) product() (float64, error) {
+	v, err := ep.unary()
+	if err != nil {
+		return 0, err
+	}
+	for op := ep.peek(); op == "*" || op == "/"; op = ep.peek() {
+		ep.pos++
+		r, err := ep.unary()
+		if err != nil {
+			return 0, err
+		}
+		if op == "*" {
+			v *= r
+		} else {
+			v /= r
+		}
+	}
+	return v, nil
+}
+
+// unary parses an optionally negated factor
+func (ep *exprParser) unary() (float64, error) {
+	if ep.peek() == "-" {
+		ep.pos++
+		v, err := ep.unary()
+		return -v, err
+	}
+	return ep.factor()
+}
+
+// factor parses a number, parenthesized expression, function call,
+// variable, or param path
+func (ep *exprParser) factor() (float64, error) {
+	tok := ep.peek()
+	if tok == "" {
+		return 0, fmt.Errorf("unexpected end of expression")
+	}
+	ep.pos++
+	if tok == "(" {
+		v, err := ep.sum()
+		if err != nil {
+			return 0, err
+		}
+		if ep.peek() != ")" {
+			return 0, fmt.Errorf("missing )")
+		}
+		ep.pos++
+		return v, nil
+	}
+	r := []rune(tok)[0]
+	if unicode.IsDigit(r) || r == '.' {
+		return strconv.ParseFloat(tok, 64)
+	}
+	if !unicode.IsLetter(r) && r != '_' {
+		return 0, fmt.Errorf("unexpected: %q", tok)
+	}
+	if ep.peek() == "(" {
+		return ep.call(tok)
+	}
+	if v, has := ExprVars[tok]; has {
+		return v, nil
+	}
+	pe := strings.SplitN(tok, ".", 2)
+	if len(pe) == 2 && pe[0] == objTypeName(ep.obj) {
+		return GetParam(ep.obj, pe[1])
+	}
+	return 0, fmt.Errorf("%q is not a variable in ExprVars or a param path on the target type: %v", tok, objTypeName(ep.obj))
+}
+
+// call parses the arguments of a call to given function, and calls it
+func (ep *exprParser) call(fnm string) (float64, error) {
+	fun, has := exprFuncs[fnm]
+	if !has {
+		return 0, fmt.Errorf("unknown function: %q", fnm)
+	}
+	ep.pos++ // (
+	var args []float64
+	if ep.peek() != ")" {
+		for {
+			v, err := ep.sum()
+			if err != nil {
+				return 0, err
+			}
+			args = append(args, v)
+			if ep.peek() != "," {
+				break
+			}
+			ep.pos++
+		}
+	}
+	if ep.peek() != ")" {
+		return 0, fmt.Errorf("missing ) in call to: %v", fnm)
+	}
+	ep.pos++
+	v, err := fun(args)
+	if err != nil {
+		return 0, fmt.Errorf("%v: %v", fnm, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"testing"
+)
+
+type exprLearn struct {
+	Lrate float32
+}
+
+type exprPrjn struct {
+	Learn exprLearn
+	WtRel float64
+	NSyn  int
+}
+
+func TestExpr(t *testing.T) {
+	ExprVars["NLayers"] = 4
+	defer delete(ExprVars, "NLayers")
+	pj := &exprPrjn{Learn: exprLearn{Lrate: 0.04}}
+	exprs := []struct {
+		expr string
+		trg  float64
+	}{
+		{"1/NLayers", 0.25},
+		{"2 + 3*4", 14},
+		{"(2 + 3)*4", 20},
+		{"-2 - -3", 1},
+		{"1e-2 * 100", 1},
+		{"max(1, min(NLayers, 3)) + pow(2, 3)", 11},
+		{"sqrt(abs(-16))", 4},
+	}
+	for _, ex := range exprs {
+		v, err := EvalExpr(ex.expr, pj)
+		if err != nil || v != ex.trg {
+			t.Errorf("expr: %q = %v not %v err: %v", ex.expr, v, ex.trg, err)
+		}
+	}
+	if v, err := EvalExpr("0.5*exprPrjn.Learn.Lrate", pj); err != nil || float32(v) != 0.02 {
+		t.Errorf("param path: %v %v", v, err)
+	}
+	for _, bad := range []string{"1 +", "(1", "Foo", "1 $ 2", "min(1)", "nofun(1)", "Layer.Gi", "1 2"} {
+		if _, err := EvalExpr(bad, pj); err == nil {
+			t.Errorf("expr: %q should be an error", bad)
+		}
+	}
+
+	sl := &Sel{Sel: "exprPrjn", Params: Params{"exprPrjn.Learn.Lrate": "=0.5*exprPrjn.Learn.Lrate", "exprPrjn.WtRel": "=1/NLayers", "exprPrjn.NSyn": "=2*NLayers"}}
+	if _, err := sl.Apply(pj, false); err != nil {
+		t.Fatal(err)
+	}
+	if pj.Learn.Lrate != 0.02 || pj.WtRel != 0.25 || pj.NSyn != 8 {
+		t.Errorf("apply: %+v", pj)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Resolve returns the flattened version of the Set with given name, combined
+// with the Set that it Extends (recursively, so a set can extend one that itself
+// extends another).  For each Sheet, the Sels of the extended set come first,
+// followed by those of this set, so that the values in this set take precedence
+// when the sheet is applied.  The result is a new Set with copies of all the
+// Sels, with no Extends, and the Name and Desc of the named set.
+// Returns an error if a set is not found or there is a cycle of Extends.
+func (ps *Sets) Resolve(name string) (*Set, error) {
+	return ps.resolve(name, nil)
+}
+
+// resolve is the recursive implementation of Resolve, with the chain of
+// set names being resolved, for cycle detection
+func (ps *Sets) resolve(name string, chain []string) (*Set, error) {
+	for _, nm := range chain {
+		if nm == name {
+			err := fmt.Errorf("params.Sets: Extends cycle: %v -> %v", strings.Join(chain, " -> "), name)
+			log.Println(err)
+			return nil, err
+		}
+	}
+	st, err := ps.SetByNameTry(name)
+	if err != nil {
+		return nil, err
+	}
+	rs := &Set{Name: st.Name, Desc: st.Desc, Version: st.Version, Sheets: Sheets{}}
+	if st.Extends != "" {
+		bs, err := ps.resolve(st.Extends, append(chain, name))
+		if err != nil {
+			return nil, err
+		}
+		rs.Sheets = bs.Sheets
+		rs.Order = bs.Order
+	}
+	if len(st.Order) > 0 {
+		rs.Order = st.Order
+	}
+	for snm, sht := range st.Sheets {
+		rsht, ok := rs.Sheets[snm]
+		if !ok {
+			rsht = &Sheet{}
+			rs.Sheets[snm] = rsht
+		}
+		for _, sl := range *sht {
+			*rsht = append(*rsht, sl.Clone())
+		}
+	}
+	return rs, nil
+}
+
+// Clone returns a copy of this Sel, with a copy of its Params and Hypers
+func (ps *Sel) Clone() *Sel {
+	cs := &Sel{Sel: ps.Sel, Desc: ps.Desc, Cond: ps.Cond, CondFunc: ps.CondFunc, Priority: ps.Priority, Params: make(Params, len(ps.Params))}
+	for k, v := range ps.Params {
+		cs.Params[k] = v
+	}
+	if ps.Hypers != nil {
+		cs.Hypers = make(Hypers, len(ps.Hypers))
+		for k, h := range ps.Hypers {
+			ch := *h
+			cs.Hypers[k] = &ch
+		}
+	}
+	return cs
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"testing"
+)
+
+var extSets = Sets{
+	{Name: "Base", Desc: "base params", Sheets: Sheets{
+		"Network": &Sheet{
+			{Sel: "Layer", Params: Params{"Layer.Inhib.Layer.Gi": "1.8", "Layer.Act.Gbar.L": "0.2"}},
+			{Sel: "#Output", Params: Params{"Layer.Inhib.Layer.Gi": "1.4"}},
+		},
+	}},
+	{Name: "Lower", Desc: "lower inhib", Extends: "Base", Sheets: Sheets{
+		"Network": &Sheet{
+			{Sel: "Layer", Params: Params{"Layer.Inhib.Layer.Gi": "1.6"}},
+		},
+		"Sim": &Sheet{
+			{Sel: "Sim", Params: Params{"Sim.MaxEpcs": "50"}},
+		},
+	}},
+	{Name: "Lowest", Desc: "lowest inhib", Extends: "Lower", Sheets: Sheets{
+		"Network": &Sheet{
+			{Sel: "#Output", Params: Params{"Layer.Inhib.Layer.Gi": "1.2"}},
+		},
+	}},
+	{Name: "CycA", Extends: "CycB"},
+	{Name: "CycB", Extends: "CycA"},
+	{Name: "Missing", Extends: "NoSuchSet"},
+}
+
+func TestResolve(t *testing.T) {
+	rs, err := extSets.Resolve("Lowest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rs.Name != "Lowest" || rs.Extends != "" {
+		t.Errorf("resolved name: %v extends: %v", rs.Name, rs.Extends)
+	}
+	nsh := *rs.Sheets["Network"]
+	if len(nsh) != 4 {
+		t.Fatalf("resolved Network sheet has %d sels, not 4", len(nsh))
+	}
+	trg := []string{"Layer", "#Output", "Layer", "#Output"}
+	for i, sl := range nsh {
+		if sl.Sel != trg[i] {
+			t.Errorf("sel %d: %v != %v", i, sl.Sel, trg[i])
+		}
+	}
+	if nsh[3].Params["Layer.Inhib.Layer.Gi"] != "1.2" {
+		t.Errorf("last sel value: %v", nsh[3].Params["Layer.Inhib.Layer.Gi"])
+	}
+	if _, ok := rs.Sheets["Sim"]; !ok {
+		t.Errorf("Sim sheet from Lower not inherited")
+	}
+	nsh[0].Params["Layer.Act.Gbar.L"] = "0.1"
+	if (*extSets[0].Sheets["Network"])[0].Params["Layer.Act.Gbar.L"] != "0.2" {
+		t.Errorf("resolved set shares params with original")
+	}
+
+	if _, err := extSets.Resolve("CycA"); err == nil {
+		t.Errorf("cycle not detected")
+	}
+	if _, err := extSets.Resolve("Missing"); err == nil {
+		t.Errorf("missing Extends set not detected")
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+)
+
+// FlexVal is one named object in a Flex map, with its own copy of the
+// params object, and the type, class, and attributes used for selecting
+// it (it implements the Styler and StylerAttrs interfaces).
+type FlexVal struct {
+	Nm    string      `desc:"name of the object, for #Name selectors"`
+	Type  string      `desc:"type name of the object, which must match the target type of params (first element of param paths)"`
+	Cls   string      `desc:"space-separated class names, for .Class selectors"`
+	Attrs Attrs       `desc:"metadata attributes, for [Attr=Val] selectors"`
+	Obj   interface{} `desc:"the object that params are applied to -- a pointer to a struct"`
+}
+
+// TypeName satisfies the Styler interface
+func (fv *FlexVal) TypeName() string {
+	return fv.Type
+}
+
+// Class satisfies the Styler interface
+func (fv *FlexVal) Class() string {
+	return fv.Cls
+}
+
+// Name satisfies the Styler interface
+func (fv *FlexVal) Name() string {
+	return fv.Nm
+}
+
+// StyleAttr satisfies the StylerAttrs interface, returning the Attrs value
+func (fv *FlexVal) StyleAttr(attr string) (string, bool) {
+	return fv.Attrs.StyleAttr(attr)
+}
+
+// Flex is a map of named objects with per-object param values, generated
+// from a template object, for cases where each object needs systematically
+// different values (e.g., position-dependent gains) that a class selector
+// cannot express.  Initialize with the names and template using Init,
+// then apply Sheets (e.g., generated by FlexSheet) to it, and use the
+// resulting objects by name.
+type Flex map[string]*FlexVal
+
+// Init initializes the map with a FlexVal for each of given names, with
+// given type name and class, each with its own copy of given template object
+// (a pointer to a struct -- a shallow copy, so reference fields are shared).
+// Returns an error if the template is not a pointer to a struct (logged).
+func (fl *Flex) Init(names []string, typ, cls string, tmpl interface{}) error {
+	if *fl == nil {
+		*fl = make(Flex, len(names))
+	}
+	for _, nm := range names {
+		if _, err := fl.Add(nm, typ, cls, tmpl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Add adds a FlexVal with given name, type name, and class, with its own
+// copy of given template object (see Init), returning it
+func (fl *Flex) Add(name, typ, cls string, tmpl interface{}) (*FlexVal, error) {
+	tv := reflect.ValueOf(tmpl)
+	if tv.Kind() != reflect.Ptr || tv.Elem().Kind() != reflect.Struct {
+		err := fmt.Errorf("params.Flex: template object for %v is not a pointer to a struct: %T", name, tmpl)
+		log.Println(err)
+		return nil, err
+	}
+	if *fl == nil {
+		*fl = make(Flex)
+	}
+	ov := reflect.New(tv.Type().Elem())
+	ov.Elem().Set(tv.Elem())
+	fv := &FlexVal{Nm: name, Type: typ, Cls: cls, Obj: ov.Interface()}
+	(*fl)[name] = fv
+	return fv, nil
+}
+
+// Names returns the names of the values, sorted
+func (fl *Flex) Names() []string {
+	nms := make([]string, 0, len(*fl))
+	for nm := range *fl {
+		nms = append(nms, nm)
+	}
+	sort.Strings(nms)
+	return nms
+}
+
+// Obj returns the object for given name, or nil if not found
+func (fl *Flex) Obj(name string) interface{} {
+	fv, has := (*fl)[name]
+	if !has {
+		return nil
+	}
+	return fv.Obj
+}
+
+// Apply applies given sheet to each of the objects, using the name, type,
+// class, and attributes of the FlexVal for selection, as in Sheet.Apply.
+// Returns true if any Sels applied, and error if any params failed to be set.
+func (fl *Flex) Apply(sh *Sheet, setMsg bool) (bool, error) {
+	applied := false
+	var rerr error
+	for _, nm := range fl.Names() {
+		fv := (*fl)[nm]
+		for _, sl := range sh.Ordered() {
+			if sl.Params.TargetType() != fv.Type || !StylerMatch(sl.Sel, fv) || !sl.CondMatch(fv.Obj) {
+				continue
+			}
+			applied = true
+			if err := sl.Params.Apply(fv.Obj, setMsg); err != nil {
+				rerr = err
+			}
+		}
+	}
+	return applied, rerr
+}
+
+// FlexSheet returns a Sheet with a #Name Sel for each of given names, with
+// given param path (including the target type) set to the value returned by
+// given function for each one (by index), for systematically different values
+// per object (e.g., position-dependent gains), which can be applied to a Flex
+// or to any objects with those names (e.g., layers in a network).
+func FlexSheet(names []string, path string, fun func(idx int, name string) string) *Sheet {
+	sh := &Sheet{}
+	for i, nm := range names {
+		*sh = append(*sh, &Sel{Sel: "#" + nm, Desc: fmt.Sprintf("generated by params.FlexSheet for %s", path), Params: Params{path: fun(i, nm)}})
+	}
+	return sh
+}
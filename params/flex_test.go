@@ -0,0 +1,47 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"fmt"
+	"testing"
+)
+
+type flexGain struct {
+	Gain float32
+	Off  float32
+}
+
+func TestFlex(t *testing.T) {
+	var fl Flex
+	nms := []string{"V1", "V2", "V4"}
+	if err := fl.Init(nms, "Gain", "Visual", &flexGain{Gain: 1, Off: 0.1}); err != nil {
+		t.Fatal(err)
+	}
+	fl["V4"].Attrs = Attrs{"Area": "high"}
+	if err := fl.Init([]string{"Bad"}, "Gain", "", flexGain{}); err == nil {
+		t.Errorf("non-pointer template should be an error")
+	}
+	sh := FlexSheet(nms, "Gain.Gain", func(idx int, nm string) string {
+		return fmt.Sprintf("%g", 1+0.5*float64(idx))
+	})
+	*sh = append(*sh, &Sel{Sel: ".Visual", Params: Params{"Gain.Off": "0.2"}},
+		&Sel{Sel: "[Area=high]", Params: Params{"Gain.Off": "0.3"}},
+		&Sel{Sel: "Layer", Params: Params{"Layer.Gi": "2"}})
+	if app, err := fl.Apply(sh, false); !app || err != nil {
+		t.Errorf("apply: %v %v", app, err)
+	}
+	gs := []float32{1, 1.5, 2}
+	for i, nm := range fl.Names() {
+		g := fl.Obj(nm).(*flexGain)
+		off := float32(0.2)
+		if nm == "V4" {
+			off = 0.3
+		}
+		if g.Gain != gs[i] || g.Off != off {
+			t.Errorf("%v: %+v", nm, *g)
+		}
+	}
+}
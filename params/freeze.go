@@ -0,0 +1,74 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+// FrozenParam is a param path that is frozen, so that applying params does
+// not change it, e.g., to protect hand-tuned values during automated sweeps
+// or when applying someone else's sheet.  See Frozen.
+type FrozenParam struct {
+	Sel  string `desc:"selector for the objects the param is frozen on (see StylerMatch) -- empty for all objects"`
+	Path string `desc:"param path, including the target type, e.g., Layer.Inhib.Layer.Gi"`
+}
+
+// Frozen is a list of frozen params, which are skipped with a logged notice
+// when params are applied through the Set or History that holds it
+// (Set.Frozen, History.Frozen).  It is not shared across Sets or Histories,
+// so freezing params in one (e.g., for one network or sweep worker) does not
+// affect any others.  Like the rest of a Set, it must not be modified while
+// params are being applied from another goroutine.
+type Frozen []FrozenParam
+
+// Freeze freezes given param path (including the target type, e.g.,
+// Layer.Inhib.Layer.Gi) on the objects matching given selector (e.g.,
+// #Output, or empty for all objects), so subsequent applications of
+// params skip it.
+func (fz *Frozen) Freeze(sel, path string) {
+	if fz.isFrozenSel(sel, path) {
+		return
+	}
+	*fz = append(*fz, FrozenParam{Sel: sel, Path: path})
+}
+
+// Unfreeze unfreezes given param path and selector, as passed to Freeze
+func (fz *Frozen) Unfreeze(sel, path string) {
+	for i, fp := range *fz {
+		if fp.Sel == sel && fp.Path == path {
+			*fz = append((*fz)[:i], (*fz)[i+1:]...)
+			return
+		}
+	}
+}
+
+// UnfreezeAll unfreezes all params
+func (fz *Frozen) UnfreezeAll() {
+	*fz = nil
+}
+
+// isFrozenSel returns true if given selector and path have been frozen
+func (fz *Frozen) isFrozenSel(sel, path string) bool {
+	for _, fp := range *fz {
+		if fp.Sel == sel && fp.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFrozen returns true if given param path (including the target type)
+// is frozen on given object
+func (fz Frozen) IsFrozen(obj interface{}, path string) bool {
+	for _, fp := range fz {
+		if fp.Path != path {
+			continue
+		}
+		if fp.Sel == "" {
+			return true
+		}
+		if stylr, has := obj.(Styler); has && StylerMatch(fp.Sel, stylr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"testing"
+)
+
+func TestFreeze(t *testing.T) {
+	hid := &provLayer{Nm: "Hidden", Gi: 1}
+	out := &provLayer{Nm: "Output", Gi: 1}
+	set := &Set{Name: "Base", Sheets: Sheets{"Network": &Sheet{{Sel: "Layer", Params: Params{"Layer.Gi": "1.8"}}}}}
+
+	set.Frozen.Freeze("#Output", "Layer.Gi")
+	set.Frozen.Freeze("#Output", "Layer.Gi")
+	if len(set.Frozen) != 1 {
+		t.Errorf("duplicate freeze: %v", set.Frozen)
+	}
+	set.Apply(hid, false)
+	set.Apply(out, false)
+	if hid.Gi != 1.8 || out.Gi != 1 {
+		t.Errorf("frozen on Output: %v %v", hid.Gi, out.Gi)
+	}
+
+	// frozen params only affect the Set that holds them
+	other := &provLayer{Nm: "Output", Gi: 1}
+	set.Sheets["Network"].Apply(other, false)
+	if other.Gi != 1.8 {
+		t.Errorf("Set Frozen affected Sheet.Apply: %v", other.Gi)
+	}
+
+	hs := &History{}
+	hs.Frozen.Freeze("", "Layer.Gi")
+	hs.Apply("Exp", &Sheet{{Sel: "Layer", Params: Params{"Layer.Gi": "2"}}}, []interface{}{hid, out}, false)
+	if hid.Gi != 1.8 || out.Gi != 1 || len(hs.Diff()) != 0 {
+		t.Errorf("frozen on all: %v %v", hid.Gi, out.Gi)
+	}
+	hs2 := &History{}
+	hs2.Apply("Exp", &Sheet{{Sel: "Layer", Params: Params{"Layer.Gi": "2"}}}, []interface{}{hid}, false)
+	if hid.Gi != 2 {
+		t.Errorf("History Frozen affected another History: %v", hid.Gi)
+	}
+
+	set.Frozen.Unfreeze("#Output", "Layer.Gi")
+	set.Apply(out, false)
+	if len(set.Frozen) != 0 || out.Gi != 1.8 {
+		t.Errorf("unfreeze: %v %v", set.Frozen, out.Gi)
+	}
+	hs.Frozen.UnfreezeAll()
+	if len(hs.Frozen) != 0 {
+		t.Errorf("UnfreezeAll: %v", hs.Frozen)
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/goki/ki/kit"
+)
+
+// HistEntry records one application of a Sheet in a History: the param
+// values that changed as a result, with their previous values, so that
+// it can be undone.
+type HistEntry struct {
+	Name    string        `desc:"name of the sheet that was applied (e.g., Set:Sheet), for the report"`
+	Time    time.Time     `desc:"time when the sheet was applied"`
+	Changes []ParamChange `desc:"params that changed value, in order of application"`
+	objs    []interface{} // object for each change, for undo
+}
+
+// String returns a multi-line description of the entry
+func (he *HistEntry) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s  %s  changes: %d\n", he.Time.Format("15:04:05"), he.Name, len(he.Changes))
+	for _, pc := range he.Changes {
+		fmt.Fprintf(&b, "\t%s\n", pc.String())
+	}
+	return b.String()
+}
+
+// History records each application of params to a set of objects (e.g.,
+// the layers and projections of a network -- see emer.ApplyParamsHist),
+// including which values changed and their previous values, with Undo
+// support, so that interactive exploration of params is reversible.
+type History struct {
+	Entries []*HistEntry `desc:"entries for each Apply, in order"`
+	Max     int          `desc:"maximum number of entries to keep -- oldest are dropped after this (and can no longer be undone) -- 0 = no limit"`
+	Frozen  Frozen       `desc:"params that are skipped by Apply, e.g., to protect hand-tuned values"`
+}
+
+// Len returns the number of entries in the history
+func (hs *History) Len() int {
+	return len(hs.Entries)
+}
+
+// Reset clears the history
+func (hs *History) Reset() {
+	hs.Entries = nil
+}
+
+// Apply applies given sheet to given objects, in the same way as Sheet.Apply
+// on each object, recording the param values that change in a new entry
+// with given name.  Returns true if any Sels applied, and error if any
+// params failed to be set (always logged).  If setMsg is true, then a
+// message is printed to confirm each parameter that is set.
+// Params in hs.Frozen are skipped, with a logged notice.
+func (hs *History) Apply(name string, sh *Sheet, objs []interface{}, setMsg bool) (bool, error) {
+	he := &HistEntry{Name: name, Time: time.Now()}
+	applied := false
+	var rerr error
+	for _, obj := range objs {
+		onm := objName(obj)
+		for _, sl := range sh.Ordered() {
+			if !sl.Matches(obj) {
+				continue
+			}
+			applied = true
+			for pt, v := range sl.Params {
+				if hs.Frozen.IsFrozen(obj, pt) {
+					log.Printf("%v param path: %v is frozen, not set to value: %v\n", onm, pt, v)
+					continue
+				}
+				path := sl.Params.Path(pt)
+				fld, sf, err := findParam(reflect.ValueOf(obj), path)
+				if err != nil {
+					rerr = err
+					continue
+				}
+				old := paramValString(fld)
+				if err := setParamVal(obj, fld, sf, path, v); err != nil {
+					rerr = err
+					continue
+				}
+				if setMsg {
+					log.Printf("%v Set param path: %v to value: %v\n", onm, pt, v)
+				}
+				nw := paramValString(fld)
+				if nw == old {
+					continue
+				}
+				he.Changes = append(he.Changes, ParamChange{Obj: onm, Sel: sl.Sel, Path: pt, Old: old, New: nw})
+				he.objs = append(he.objs, obj)
+			}
+		}
+	}
+	hs.Entries = append(hs.Entries, he)
+	if hs.Max > 0 && len(hs.Entries) > hs.Max {
+		hs.Entries = hs.Entries[len(hs.Entries)-hs.Max:]
+	}
+	return applied, rerr
+}
+
+// paramValString returns the value of given param field
+// (a pointer to the field value) as a string
+func paramValString(fld reflect.Value) string {
+	return fmt.Sprintf("%v", kit.NonPtrValue(fld).Interface())
+}
+
+// Undo reverts the param changes of the last entry, restoring the previous
+// values in reverse order of application, and removes the entry, returning it
+// (nil if there are no entries).  Values that were changed subsequently by
+// other means are also reverted.
+func (hs *History) Undo() (*HistEntry, error) {
+	n := len(hs.Entries)
+	if n == 0 {
+		return nil, nil
+	}
+	he := hs.Entries[n-1]
+	hs.Entries = hs.Entries[:n-1]
+	var rerr error
+	for ci := len(he.Changes) - 1; ci >= 0; ci-- {
+		pc := &he.Changes[ci]
+		path := strings.SplitN(pc.Path, ".", 2)[1] // path without target type
+		if err := SetParam(he.objs[ci], path, pc.Old); err != nil {
+			rerr = err
+		}
+	}
+	return he, rerr
+}
+
+// UndoAll undoes all of the entries, restoring the values prior to the first one
+func (hs *History) UndoAll() error {
+	var rerr error
+	for len(hs.Entries) > 0 {
+		if _, err := hs.Undo(); err != nil {
+			rerr = err
+		}
+	}
+	return rerr
+}
+
+// Diff returns the net changes in param values across all entries, from the
+// value before the first change to the current value, for each object and
+// param, in order of first change.  Params that were changed back to their
+// original value are not included.
+func (hs *History) Diff() []ParamChange {
+	type objPath struct {
+		obj  interface{}
+		path string
+	}
+	var ord []objPath
+	net := map[objPath]*ParamChange{}
+	for _, he := range hs.Entries {
+		for ci := range he.Changes {
+			pc := he.Changes[ci]
+			key := objPath{he.objs[ci], pc.Path}
+			if nc, has := net[key]; has {
+				nc.Sel = pc.Sel
+				nc.New = pc.New
+				continue
+			}
+			net[key] = &pc
+			ord = append(ord, key)
+		}
+	}
+	var dif []ParamChange
+	for _, key := range ord {
+		if nc := net[key]; nc.Old != nc.New {
+			dif = append(dif, *nc)
+		}
+	}
+	return dif
+}
+
+// String returns a human-readable report of each entry, followed by
+// the net changes across all entries (see Diff)
+func (hs *History) String() string {
+	var b strings.Builder
+	for i, he := range hs.Entries {
+		fmt.Fprintf(&b, "%d: %s", i, he.String())
+	}
+	dif := hs.Diff()
+	fmt.Fprintf(&b, "Net changes: %d\n", len(dif))
+	for _, pc := range dif {
+		fmt.Fprintf(&b, "\t%s\n", pc.String())
+	}
+	return b.String()
+}
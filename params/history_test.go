@@ -0,0 +1,59 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"strings"
+	"testing"
+)
+
+type histInhib struct {
+	Gi float32
+	On bool
+}
+
+type histLayer struct {
+	Inhib histInhib
+	Lrate float64
+}
+
+func TestHistory(t *testing.T) {
+	ly := &histLayer{Inhib: histInhib{Gi: 1.8}, Lrate: 0.04}
+	objs := []interface{}{ly}
+	hs := &History{}
+	sh1 := &Sheet{{Sel: "histLayer", Params: Params{"histLayer.Inhib.Gi": "1.5", "histLayer.Lrate": "0.04"}}}
+	sh2 := &Sheet{{Sel: "histLayer", Params: Params{"histLayer.Inhib.Gi": "1.2", "histLayer.Inhib.On": "true"}}}
+	if app, err := hs.Apply("sh1", sh1, objs, false); !app || err != nil {
+		t.Fatalf("apply sh1: %v %v", app, err)
+	}
+	hs.Apply("sh2", sh2, objs, false)
+	if ly.Inhib.Gi != 1.2 || !ly.Inhib.On {
+		t.Errorf("not applied: %+v", ly)
+	}
+	if len(hs.Entries[0].Changes) != 1 { // Lrate did not change
+		t.Errorf("sh1 changes: %v", hs.Entries[0].Changes)
+	}
+	dif := hs.Diff()
+	if len(dif) != 2 || dif[0].Old != "1.8" || dif[0].New != "1.2" {
+		t.Errorf("diff: %v", dif)
+	}
+	if !strings.Contains(hs.String(), "Net changes: 2") {
+		t.Errorf("report:\n%s", hs.String())
+	}
+	he, err := hs.Undo()
+	if err != nil || he.Name != "sh2" {
+		t.Fatalf("undo: %v %v", he, err)
+	}
+	if ly.Inhib.Gi != 1.5 || ly.Inhib.On {
+		t.Errorf("undo sh2 failed: %+v", ly)
+	}
+	hs.Apply("sh2", sh2, objs, false)
+	if err := hs.UndoAll(); err != nil {
+		t.Fatal(err)
+	}
+	if ly.Inhib.Gi != 1.8 || ly.Inhib.On || ly.Lrate != 0.04 || hs.Len() != 0 {
+		t.Errorf("undo all failed: %+v", ly)
+	}
+}
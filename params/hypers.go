@@ -0,0 +1,139 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"sort"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/ki/indent"
+)
+
+// Hyper is the hyperparameter search metadata for one param: whether it
+// should be tuned, and the prior distribution and range to search over,
+// kept separate from the param values themselves.
+type Hyper struct {
+	Tune  bool        `desc:"this param should be tuned by hyperparameter search"`
+	Prior SampleDists `desc:"prior distribution to search over"`
+	Min   float64     `desc:"minimum value for Uniform and LogUniform priors"`
+	Max   float64     `desc:"maximum value for Uniform and LogUniform priors"`
+	Sigma float64     `desc:"standard deviation of changes to the value, for searches that perturb the current value (e.g., evolutionary or Gaussian process methods) -- 0 = not specified"`
+	Vals  []string    `desc:"values to choose among for the Choice prior"`
+}
+
+// Hypers is a parallel structure to Params holding the hyperparameter search
+// metadata for params, keyed by the same param paths (e.g., Layer.Inhib.Layer.Gi)
+type Hypers map[string]*Hyper
+
+// WriteGoCode writes hypers to corresponding Go initializer code.
+func (hy *Hypers) WriteGoCode(w io.Writer, depth int) {
+	w.Write([]byte("params.Hypers{\n"))
+	depth++
+	paths := make([]string, 0, len(*hy)) // alpha-sort paths for consistent output
+	for pt := range *hy {
+		paths = append(paths, pt)
+	}
+	sort.Strings(paths)
+	for _, pt := range paths {
+		h := (*hy)[pt]
+		w.Write(indent.TabBytes(depth))
+		w.Write([]byte(fmt.Sprintf("%q: {Tune: %v, Prior: params.%v, Min: %v, Max: %v, Sigma: %v", pt, h.Tune, h.Prior, h.Min, h.Max, h.Sigma)))
+		if len(h.Vals) > 0 {
+			w.Write([]byte(fmt.Sprintf(", Vals: %#v", h.Vals)))
+		}
+		w.Write([]byte("},\n"))
+	}
+	depth--
+	w.Write(indent.TabBytes(depth))
+	w.Write([]byte("}"))
+}
+
+// SetHyper returns the hyperparameter metadata for given param path,
+// creating it if needed (with Tune on), for setting the search options.
+func (ps *Sel) SetHyper(path string) *Hyper {
+	if h, has := ps.Hypers[path]; has {
+		return h
+	}
+	if ps.Hypers == nil {
+		ps.Hypers = make(Hypers)
+	}
+	h := &Hyper{Tune: true}
+	ps.Hypers[path] = h
+	return h
+}
+
+// HyperSpec is the full specification of one param for hyperparameter search,
+// including where it is in the Set and its current value, as exported in JSON
+// format for external optimizers (see Set.WriteHypersJSON)
+type HyperSpec struct {
+	Sheet string `desc:"name of the Sheet that the param is in"`
+	Sel   string `desc:"selector of the Sel that the param is in"`
+	Path  string `desc:"param path"`
+	Val   string `desc:"current value of the param in the Set"`
+	Hyper
+}
+
+// HyperSpecs returns the specs for the params in this Set that have
+// hyperparameter metadata, only those with Tune on unless all is true,
+// in order of sheet name, then Sels, then param path.
+func (ps *Set) HyperSpecs(all bool) []HyperSpec {
+	var hs []HyperSpec
+	snms := make([]string, 0, len(ps.Sheets))
+	for snm := range ps.Sheets {
+		snms = append(snms, snm)
+	}
+	sort.Strings(snms)
+	for _, snm := range snms {
+		for _, sl := range *ps.Sheets[snm] {
+			paths := make([]string, 0, len(sl.Hypers))
+			for pt := range sl.Hypers {
+				paths = append(paths, pt)
+			}
+			sort.Strings(paths)
+			for _, pt := range paths {
+				h := sl.Hypers[pt]
+				if !all && !h.Tune {
+					continue
+				}
+				hs = append(hs, HyperSpec{Sheet: snm, Sel: sl.Sel, Path: pt, Val: sl.Params[pt], Hyper: *h})
+			}
+		}
+	}
+	return hs
+}
+
+// WriteHypersJSON writes the specs for the params in this Set to be tuned by
+// hyperparameter search (see HyperSpecs) in JSON format, e.g., for
+// external optimizers, which can return values via the -set flag (see Overrides).
+func (ps *Set) WriteHypersJSON(w io.Writer) error {
+	b, err := json.MarshalIndent(ps.HyperSpecs(false), "", "  ")
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// SaveHypersJSON saves the specs for the params in this Set to be tuned by
+// hyperparameter search (see HyperSpecs) to a JSON-formatted file.
+func (ps *Set) SaveHypersJSON(filename gi.FileName) error {
+	b, err := json.MarshalIndent(ps.HyperSpecs(false), "", "  ")
+	if err != nil {
+		log.Println(err) // unlikely
+		return err
+	}
+	err = ioutil.WriteFile(string(filename), b, 0644)
+	if err != nil {
+		gi.PromptDialog(nil, gi.DlgOpts{Title: "Could not Save to File", Prompt: err.Error()}, true, false, nil, nil)
+		log.Println(err)
+	}
+	return err
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestHypers(t *testing.T) {
+	st := &Set{Name: "Base", Sheets: Sheets{
+		"Network": &Sheet{
+			{Sel: "Layer", Params: Params{"Layer.Inhib.Layer.Gi": "1.8"}},
+			{Sel: "Prjn", Params: Params{"Prjn.Learn.Lrate": "0.04", "Prjn.WtScale.Rel": "0.2"}},
+		},
+	}}
+	sh := *st.Sheets["Network"]
+	gi := sh[0].SetHyper("Layer.Inhib.Layer.Gi")
+	gi.Min, gi.Max, gi.Sigma = 1, 3, 0.2
+	lr := sh[1].SetHyper("Prjn.Learn.Lrate")
+	lr.Prior, lr.Min, lr.Max = LogUniformDist, 0.001, 0.1
+	sh[1].SetHyper("Prjn.WtScale.Rel").Tune = false
+
+	hs := st.HyperSpecs(false)
+	if len(hs) != 2 || hs[0].Path != "Layer.Inhib.Layer.Gi" || hs[0].Val != "1.8" || hs[1].Prior != LogUniformDist {
+		t.Errorf("tunable specs: %+v", hs)
+	}
+	if len(st.HyperSpecs(true)) != 3 {
+		t.Errorf("all specs: %d", len(st.HyperSpecs(true)))
+	}
+
+	var buf bytes.Buffer
+	if err := st.WriteHypersJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var js []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &js); err != nil {
+		t.Fatal(err)
+	}
+	if len(js) != 2 || js[1]["Sel"] != "Prjn" || js[1]["Max"] != 0.1 || js[0]["Sigma"] != 0.2 {
+		t.Errorf("json: %s", buf.String())
+	}
+
+	buf.Reset()
+	sh[0].WriteGoCode(&buf, 0)
+	if !strings.Contains(buf.String(), `"Layer.Inhib.Layer.Gi": {Tune: true, Prior: params.UniformDist, Min: 1, Max: 3, Sigma: 0.2},`) {
+		t.Errorf("go code:\n%s", buf.String())
+	}
+}
@@ -128,11 +128,24 @@ func (pr *Sel) SaveJSON(filename gi.FileName) error {
 
 // WriteGoCode writes params to corresponding Go initializer code.
 func (pr *Sel) WriteGoCode(w io.Writer, depth int) {
-	w.Write([]byte(fmt.Sprintf("Sel: %q, Desc: %q,\n", pr.Sel, pr.Desc)))
+	w.Write([]byte(fmt.Sprintf("Sel: %q, Desc: %q,", pr.Sel, pr.Desc)))
+	if pr.Cond != "" {
+		w.Write([]byte(fmt.Sprintf(" Cond: %q,", pr.Cond)))
+	}
+	if pr.Priority != 0 {
+		w.Write([]byte(fmt.Sprintf(" Priority: %d,", pr.Priority)))
+	}
+	w.Write([]byte("\n"))
 	depth++
 	w.Write(indent.TabBytes(depth))
 	w.Write([]byte("Params: "))
 	pr.Params.WriteGoCode(w, depth)
+	if len(pr.Hypers) > 0 {
+		w.Write([]byte(",\n"))
+		w.Write(indent.TabBytes(depth))
+		w.Write([]byte("Hypers: "))
+		pr.Hypers.WriteGoCode(w, depth)
+	}
 }
 
 // StringGoCode returns Go initializer code as a byte string.
@@ -306,11 +319,18 @@ func (pr *Set) OpenJSON(filename gi.FileName) error {
 		log.Println(err)
 		return err
 	}
-	return json.Unmarshal(b, pr)
+	err = json.Unmarshal(b, pr)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return pr.Migrate()
 }
 
-// SaveJSON saves params to a JSON-formatted file.
+// SaveJSON saves params to a JSON-formatted file, recording the current
+// params Version.
 func (pr *Set) SaveJSON(filename gi.FileName) error {
+	pr.Version = Version
 	b, err := json.MarshalIndent(pr, "", "  ")
 	if err != nil {
 		log.Println(err) // unlikely
@@ -326,7 +346,17 @@ func (pr *Set) SaveJSON(filename gi.FileName) error {
 
 // WriteGoCode writes params to corresponding Go initializer code.
 func (pr *Set) WriteGoCode(w io.Writer, depth int) {
-	w.Write([]byte(fmt.Sprintf("Name: %q, Desc: %q, Sheets: ", pr.Name, pr.Desc)))
+	w.Write([]byte(fmt.Sprintf("Name: %q, Desc: %q, ", pr.Name, pr.Desc)))
+	if pr.Version != 0 {
+		w.Write([]byte(fmt.Sprintf("Version: %d, ", pr.Version)))
+	}
+	if pr.Extends != "" {
+		w.Write([]byte(fmt.Sprintf("Extends: %q, ", pr.Extends)))
+	}
+	if len(pr.Order) > 0 {
+		w.Write([]byte(fmt.Sprintf("Order: %#v, ", pr.Order)))
+	}
+	w.Write([]byte("Sheets: "))
 	pr.Sheets.WriteGoCode(w, depth)
 }
 
@@ -363,11 +393,20 @@ func (pr *Sets) OpenJSON(filename gi.FileName) error {
 		log.Println(err)
 		return err
 	}
-	return json.Unmarshal(b, pr)
+	err = json.Unmarshal(b, pr)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return pr.Migrate()
 }
 
-// SaveJSON saves params to a JSON-formatted file.
+// SaveJSON saves params to a JSON-formatted file, recording the current
+// params Version in each Set.
 func (pr *Sets) SaveJSON(filename gi.FileName) error {
+	for _, st := range *pr {
+		st.Version = Version
+	}
 	b, err := json.MarshalIndent(pr, "", "  ")
 	if err != nil {
 		log.Println(err) // unlikely
@@ -678,6 +717,26 @@ var SetsProps = ki.Props{
 			"icon":        "go",
 			"show-return": true,
 		}},
+		{"SavePython", ki.Props{
+			"label": "Save Python As...",
+			"desc":  "save as a Python list of dictionaries in file, e.g., for external analysis",
+			"icon":  "file-save",
+			"Args": ki.PropSlice{
+				{"File Name", ki.Props{
+					"ext": ".py",
+				}},
+			},
+		}},
+		{"SaveMarkdown", ki.Props{
+			"label": "Save Table As...",
+			"desc":  "save a markdown table of every param, e.g., for a parameter appendix",
+			"icon":  "file-save",
+			"Args": ki.PropSlice{
+				{"File Name", ki.Props{
+					"ext": ".md",
+				}},
+			},
+		}},
 		{"sep-diffs", ki.BlankProp{}},
 		{"DiffsAll", ki.Props{
 			"desc":        "between all sets, reports where the same param path is being set to different values",
@@ -689,6 +748,16 @@ var SetsProps = ki.Props{
 			"icon":        "search",
 			"show-return": true,
 		}},
+		{"DiffsFile", ki.Props{
+			"desc":        "reports the params that were added, removed, or changed in the params in given file relative to these params",
+			"icon":        "search",
+			"show-return": true,
+			"Args": ki.PropSlice{
+				{"File Name", ki.Props{
+					"ext": ".params",
+				}},
+			},
+		}},
 		{"DiffsWithin", ki.Props{
 			"desc":        "reports all the cases where the same param path is being set to different values within different sheets in given set",
 			"icon":        "search",
@@ -0,0 +1,116 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JitterParam specifies the params to perturb in a Jitter, and by how much
+type JitterParam struct {
+	Sel   string  `desc:"selector of the Sels to perturb params in (wildcards as in path.Match) -- empty for all"`
+	Path  string  `desc:"param paths to perturb (wildcards as in path.Match, where * also matches across dots), e.g., *.Gi for all Gi params"`
+	Frac  float64 `desc:"size of the perturbation as a fraction of the value, e.g., 0.1 for +/- 10% uniform noise"`
+	Gauss bool    `desc:"use Gaussian noise with Frac as the standard deviation, instead of uniform noise in +/- Frac"`
+}
+
+// Matches returns true if this param applies to given Sel selector and path
+func (jp *JitterParam) Matches(sel, path string) bool {
+	if jp.Sel != "" && !nameMatch(jp.Sel, sel) {
+		return false
+	}
+	return nameMatch(jp.Path, path)
+}
+
+// Jitter perturbs selected numeric param values in a Set by random noise
+// with a fixed seed, e.g., to test the robustness of results to parameter
+// noise.  Each perturbed Set uses its own seed (Seed + index), so it can
+// be regenerated exactly, and is named and described with the seed for
+// record-keeping.  Values that are not plain numbers (e.g., expressions,
+// lists) are not perturbed, and integer params should not be selected.
+type Jitter struct {
+	Name   string         `desc:"name of the jitter, added to the names of the perturbed Sets"`
+	Seed   int64          `desc:"base random seed -- perturbed Set i uses Seed + i"`
+	Params []*JitterParam `desc:"params to perturb, in order -- the first that matches a param is used"`
+}
+
+// Add adds params to perturb by given fraction of their value (uniform
+// noise), with given selector and path patterns, returning it
+func (jt *Jitter) Add(sel, path string, frac float64) *JitterParam {
+	jp := &JitterParam{Sel: sel, Path: path, Frac: frac}
+	jt.Params = append(jt.Params, jp)
+	return jp
+}
+
+// param returns the JitterParam for given selector and path, nil if none
+func (jt *Jitter) param(sel, path string) *JitterParam {
+	for _, jp := range jt.Params {
+		if jp.Matches(sel, path) {
+			return jp
+		}
+	}
+	return nil
+}
+
+// Set returns a copy of given Set (which should be resolved -- see
+// Sets.Resolve) with the selected params perturbed, for given index.
+// Params are perturbed in a fixed order (Sheets by SheetOrder, Sels in
+// order, paths sorted), so the result depends only on the seed.
+func (jt *Jitter) Set(st *Set, idx int) *Set {
+	seed := jt.Seed + int64(idx)
+	rnd := rand.New(rand.NewSource(seed))
+	nm := fmt.Sprintf("%s_%03d", st.Name, idx)
+	jd := "jitter"
+	if jt.Name != "" {
+		nm = fmt.Sprintf("%s_%s_%03d", st.Name, jt.Name, idx)
+		jd += " " + jt.Name
+	}
+	js := &Set{Name: nm, Desc: fmt.Sprintf("%s (%s seed: %d)", st.Desc, jd, seed), Version: st.Version, Order: st.Order, Sheets: Sheets{}}
+	for _, snm := range st.SheetOrder() {
+		jsh := &Sheet{}
+		js.Sheets[snm] = jsh
+		for _, sl := range *st.Sheets[snm] {
+			jsl := sl.Clone()
+			*jsh = append(*jsh, jsl)
+			pts := make([]string, 0, len(jsl.Params))
+			for pt := range jsl.Params {
+				pts = append(pts, pt)
+			}
+			sort.Strings(pts)
+			for _, pt := range pts {
+				jp := jt.param(sl.Sel, pt)
+				if jp == nil {
+					continue
+				}
+				v, err := strconv.ParseFloat(strings.TrimSpace(jsl.Params[pt]), 64)
+				if err != nil {
+					continue
+				}
+				var nz float64
+				if jp.Gauss {
+					nz = rnd.NormFloat64() * jp.Frac
+				} else {
+					nz = (2*rnd.Float64() - 1) * jp.Frac
+				}
+				jsl.Params[pt] = strconv.FormatFloat(v*(1+nz), 'g', 6, 64)
+			}
+		}
+	}
+	return js
+}
+
+// Sets returns n perturbed copies of given Set (see Set), e.g., to run and
+// save along with the results
+func (jt *Jitter) Sets(st *Set, n int) Sets {
+	sts := make(Sets, n)
+	for i := 0; i < n; i++ {
+		sts[i] = jt.Set(st, i)
+	}
+	return sts
+}
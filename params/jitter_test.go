@@ -0,0 +1,54 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestJitter(t *testing.T) {
+	st := &Set{Name: "Base", Sheets: Sheets{
+		"Network": &Sheet{
+			{Sel: "Layer", Params: Params{"Layer.Inhib.Layer.Gi": "1.8", "Layer.Act.Gbar.L": "0.2"}},
+			{Sel: "#Output", Params: Params{"Layer.Inhib.Layer.Gi": "1.4", "Layer.Inhib.Pool.Gi": "=1.1*Layer.Inhib.Layer.Gi"}},
+		},
+	}}
+	jt := &Jitter{Name: "Gi10", Seed: 5}
+	jt.Add("", "*.Gi", 0.1)
+	sts := jt.Sets(st, 5)
+	if len(sts) != 5 || sts[2].Name != "Base_Gi10_002" {
+		t.Fatalf("sets: %v", sts[2].Name)
+	}
+	diff := false
+	for _, js := range sts {
+		sh := *js.Sheets["Network"]
+		gi, _ := strconv.ParseFloat(sh[0].Params["Layer.Inhib.Layer.Gi"], 64)
+		if gi < 1.8*0.9 || gi > 1.8*1.1 {
+			t.Errorf("%v: Gi out of range: %v", js.Name, gi)
+		}
+		if gi != 1.8 {
+			diff = true
+		}
+		if sh[0].Params["Layer.Act.Gbar.L"] != "0.2" || sh[1].Params["Layer.Inhib.Pool.Gi"] != "=1.1*Layer.Inhib.Layer.Gi" {
+			t.Errorf("%v: unselected params changed: %v %v", js.Name, sh[0].Params, sh[1].Params)
+		}
+	}
+	if !diff {
+		t.Errorf("no values perturbed")
+	}
+	if (*st.Sheets["Network"])[0].Params["Layer.Inhib.Layer.Gi"] != "1.8" {
+		t.Errorf("original set changed")
+	}
+	if re := jt.Set(st, 3); (*re.Sheets["Network"])[1].Params["Layer.Inhib.Layer.Gi"] != (*sts[3].Sheets["Network"])[1].Params["Layer.Inhib.Layer.Gi"] {
+		t.Errorf("not reproducible")
+	}
+	jo := &Jitter{Seed: 5}
+	jo.Add("#Output", "*.Gi", 0.1).Gauss = true
+	ost := jo.Set(st, 0)
+	if (*ost.Sheets["Network"])[0].Params["Layer.Inhib.Layer.Gi"] != "1.8" || ost.Name != "Base_000" {
+		t.Errorf("sel restriction: %v", ost.Name)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/goki/ki/kit"
+)
+
+// LintKinds are the kinds of problems found by Lint
+type LintKinds int32
+
+//go:generate stringer -type=LintKinds
+
+var KiT_LintKinds = kit.Enums.AddEnum(LintKindsN, false, nil)
+
+func (ev LintKinds) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *LintKinds) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// The lint kinds
+const (
+	// LintUnmatched is a selector that does not match any of the objects
+	LintUnmatched LintKinds = iota
+
+	// LintBadPath is a param path that does not resolve to a field on
+	// (some of) the objects that the selector matches
+	LintBadPath
+
+	// LintMixedTypes is a Sel with params for different target types
+	// (first element of the paths), which must all be the same
+	LintMixedTypes
+
+	LintKindsN
+)
+
+// LintDiag is one problem found by Lint
+type LintDiag struct {
+	Kind  LintKinds `desc:"kind of problem"`
+	Set   string    `desc:"name of the Set"`
+	Sheet string    `desc:"name of the Sheet"`
+	Sel   string    `desc:"selector of the Sel"`
+	Path  string    `desc:"param path, for LintBadPath"`
+	Objs  []string  `desc:"names of the objects that the path failed on, for LintBadPath"`
+}
+
+// String returns a one-line description of the problem
+func (ld *LintDiag) String() string {
+	loc := fmt.Sprintf("%s:%s: %s", ld.Set, ld.Sheet, ld.Sel)
+	switch ld.Kind {
+	case LintUnmatched:
+		return loc + ": selector does not match any object"
+	case LintBadPath:
+		return fmt.Sprintf("%s: %s: path not found on: %s", loc, ld.Path, strings.Join(ld.Objs, ", "))
+	default:
+		return loc + ": params have different target types"
+	}
+}
+
+// LintDiags is the list of problems found by Lint
+type LintDiags []LintDiag
+
+// String returns one line per problem
+func (lds LintDiags) String() string {
+	var b strings.Builder
+	for i := range lds {
+		b.WriteString(lds[i].String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Lint checks that the selector of every Sel in every Sheet of the Sets
+// matches at least one of given objects (e.g., all the layers and projections
+// of a network -- see emer.LintParams), ignoring Cond conditions, and that
+// every param path resolves to a field on each object that it matches,
+// without applying anything, returning the problems found (empty if none),
+// e.g., for checking at sim startup and in tests.  If sheets are given, only
+// those sheets are checked (e.g., Network, where other sheets such as Sim
+// apply to other objects) -- otherwise all are.
+func (ps *Sets) Lint(objs []interface{}, sheets ...string) LintDiags {
+	var lds LintDiags
+	for _, st := range *ps {
+		snms := make([]string, 0, len(st.Sheets))
+		for snm := range st.Sheets {
+			if len(sheets) > 0 && !lintSheet(snm, sheets) {
+				continue
+			}
+			snms = append(snms, snm)
+		}
+		sort.Strings(snms)
+		for _, snm := range snms {
+			for _, sl := range *st.Sheets[snm] {
+				lds = append(lds, sl.lint(objs, st.Name, snm)...)
+			}
+		}
+	}
+	return lds
+}
+
+// lintSheet returns true if given sheet name is in the list
+func lintSheet(snm string, sheets []string) bool {
+	for _, sh := range sheets {
+		if sh == snm {
+			return true
+		}
+	}
+	return false
+}
+
+// lint returns the Lint problems for this Sel, in given Set and Sheet
+func (ps *Sel) lint(objs []interface{}, set, sheet string) LintDiags {
+	var lds LintDiags
+	typ := ps.Params.TargetType()
+	pts := make([]string, 0, len(ps.Params))
+	for pt := range ps.Params {
+		pts = append(pts, pt)
+		if strings.Split(pt, ".")[0] != typ {
+			lds = append(lds, LintDiag{Kind: LintMixedTypes, Set: set, Sheet: sheet, Sel: ps.Sel})
+			return lds
+		}
+	}
+	sort.Strings(pts)
+	bad := make(map[string][]string)
+	matched := false
+	for _, obj := range objs {
+		if !ps.TargetTypeMatch(obj) || !ps.SelMatch(obj) {
+			continue
+		}
+		matched = true
+		for _, pt := range pts {
+			if _, _, err := findParam(reflect.ValueOf(obj), ps.Params.Path(pt)); err != nil {
+				bad[pt] = append(bad[pt], objName(obj))
+			}
+		}
+	}
+	if !matched {
+		lds = append(lds, LintDiag{Kind: LintUnmatched, Set: set, Sheet: sheet, Sel: ps.Sel})
+	}
+	for _, pt := range pts {
+		if ons, has := bad[pt]; has {
+			lds = append(lds, LintDiag{Kind: LintBadPath, Set: set, Sheet: sheet, Sel: ps.Sel, Path: pt, Objs: ons})
+		}
+	}
+	return lds
+}
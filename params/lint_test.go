@@ -0,0 +1,42 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	objs := []interface{}{&provLayer{Nm: "Hidden", Cls: "Hid"}, &provLayer{Nm: "Output"}}
+	sts := Sets{
+		{Name: "Base", Sheets: Sheets{
+			"Network": &Sheet{
+				{Sel: "Layer", Params: Params{"Layer.Gi": "1.8"}},
+				{Sel: "#Outptu", Params: Params{"Layer.Gi": "1.4"}},
+				{Sel: ".Hid", Params: Params{"Layer.Gi": "1.8", "Layer.Inhib.Gi": "2"}},
+				{Sel: "Layer", Params: Params{"Layer.Gi": "1.8", "Prjn.Lrate": "0.1"}},
+			},
+			"Sim": &Sheet{
+				{Sel: "Sim", Params: Params{"Sim.MaxEpcs": "100"}},
+			},
+		}},
+	}
+	lds := sts.Lint(objs, "Network")
+	if len(lds) != 3 {
+		t.Fatalf("diags:\n%s", lds.String())
+	}
+	if lds[0].Kind != LintUnmatched || lds[0].Sel != "#Outptu" {
+		t.Errorf("unmatched: %v", lds[0].String())
+	}
+	if lds[1].Kind != LintBadPath || lds[1].Path != "Layer.Inhib.Gi" || len(lds[1].Objs) != 1 || lds[1].Objs[0] != "Hidden" {
+		t.Errorf("bad path: %v", lds[1].String())
+	}
+	if lds[2].Kind != LintMixedTypes {
+		t.Errorf("mixed types: %v", lds[2].String())
+	}
+	if lds = sts.Lint(objs); len(lds) != 4 || lds[3].Sheet != "Sim" {
+		t.Errorf("all sheets:\n%s", lds.String())
+	}
+}
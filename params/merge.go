@@ -0,0 +1,147 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/goki/ki/kit"
+)
+
+// MergePolicies determine how conflicts are resolved in Sheet.Merge, where
+// both sheets set the same param path on the same selector to different values
+type MergePolicies int32
+
+//go:generate stringer -type=MergePolicies
+
+var KiT_MergePolicies = kit.Enums.AddEnum(MergePoliciesN, false, nil)
+
+func (ev MergePolicies) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *MergePolicies) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// The merge policies
+const (
+	// MergeOverride uses the value from the other (later) sheet
+	MergeOverride MergePolicies = iota
+
+	// MergeError returns an error if there are any conflicts, and does not merge
+	MergeError
+
+	// MergeKeepFirst keeps the value already in the sheet
+	MergeKeepFirst
+
+	MergePoliciesN
+)
+
+// MergeConflict records a param set to different values in Sheet.Merge
+type MergeConflict struct {
+	Sel      string `desc:"selector of the Sel, with its Cond condition if any"`
+	Path     string `desc:"param path"`
+	Val      string `desc:"value in the sheet merged into"`
+	OtherVal string `desc:"value in the other sheet"`
+	Kept     string `desc:"value after the merge according to the policy"`
+}
+
+// MergeConflicts is the report of conflicts from Sheet.Merge
+type MergeConflicts []MergeConflict
+
+// String returns one line per conflict
+func (mc MergeConflicts) String() string {
+	var b strings.Builder
+	for _, c := range mc {
+		b.WriteString(fmt.Sprintf("%s %s: %s vs. %s -> %s\n", c.Sel, c.Path, c.Val, c.OtherVal, c.Kept))
+	}
+	return b.String()
+}
+
+// selKey returns the key identifying Sels that are merged together:
+// the selector and its Cond condition
+func (ps *Sel) selKey() string {
+	if ps.Cond == "" {
+		return ps.Sel
+	}
+	return ps.Sel + " [" + ps.Cond + "]"
+}
+
+// Merge merges the Sels of the other sheet into this one.  Params of an
+// other Sel with the same selector and Cond as one (or more) in this sheet
+// are set in the last such Sel (or the one that already has the param), and
+// other Sels are appended as copies.  Where a param is already set to a
+// different value, the policy determines which value is used -- with
+// MergeError, the sheet is not changed and an error is returned.
+// Returns the conflicts in any case.
+func (ps *Sheet) Merge(other *Sheet, policy MergePolicies) (MergeConflicts, error) {
+	var mc MergeConflicts
+	for _, osl := range *other {
+		key := osl.selKey()
+		for pt, ov := range osl.Params {
+			if sl := ps.mergeSel(key, pt); sl != nil {
+				if v, has := sl.Params[pt]; has && v != ov {
+					kept := ov
+					if policy == MergeKeepFirst {
+						kept = v
+					}
+					mc = append(mc, MergeConflict{Sel: key, Path: pt, Val: v, OtherVal: ov, Kept: kept})
+				}
+			}
+		}
+	}
+	if policy == MergeError && len(mc) > 0 {
+		err := fmt.Errorf("params.Sheet.Merge: %d conflicts:\n%s", len(mc), mc.String())
+		log.Println(err)
+		return mc, err
+	}
+	for _, osl := range *other {
+		key := osl.selKey()
+		if ps.mergeSel(key, "") == nil {
+			*ps = append(*ps, osl.Clone())
+			continue
+		}
+		for pt, ov := range osl.Params {
+			sl := ps.mergeSel(key, pt)
+			if _, has := sl.Params[pt]; has && policy == MergeKeepFirst {
+				continue
+			}
+			if sl.Params == nil {
+				sl.Params = make(Params)
+			}
+			sl.Params[pt] = ov
+		}
+		for pt, oh := range osl.Hypers {
+			sl := ps.mergeSel(key, pt)
+			if _, has := sl.Hypers[pt]; has && policy == MergeKeepFirst {
+				continue
+			}
+			if sl.Hypers == nil {
+				sl.Hypers = make(Hypers)
+			}
+			ch := *oh
+			sl.Hypers[pt] = &ch
+		}
+	}
+	return mc, nil
+}
+
+// mergeSel returns the Sel in this sheet with given selKey to merge given
+// param path into: the last one that has the path, or else the last one
+// with the key -- nil if none
+func (ps *Sheet) mergeSel(key, path string) *Sel {
+	var last *Sel
+	for i := len(*ps) - 1; i >= 0; i-- {
+		sl := (*ps)[i]
+		if sl.selKey() != key {
+			continue
+		}
+		if _, has := sl.Params[path]; has {
+			return sl
+		}
+		if last == nil {
+			last = sl
+		}
+	}
+	return last
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"testing"
+)
+
+func mergeSheets() (*Sheet, *Sheet) {
+	sh := &Sheet{
+		{Sel: "Layer", Params: Params{"Layer.Inhib.Layer.Gi": "1.8", "Layer.Act.Gbar.L": "0.2"}},
+		{Sel: "#Output", Params: Params{"Layer.Inhib.Layer.Gi": "1.4"}},
+	}
+	oth := &Sheet{
+		{Sel: "Layer", Params: Params{"Layer.Inhib.Layer.Gi": "2.0", "Layer.Act.Gbar.L": "0.2", "Layer.Act.Init.Decay": "0"}},
+		{Sel: "Prjn", Params: Params{"Prjn.Learn.Lrate": "0.04"}},
+	}
+	return sh, oth
+}
+
+func TestSheetMerge(t *testing.T) {
+	sh, oth := mergeSheets()
+	mc, err := sh.Merge(oth, MergeOverride)
+	if err != nil || len(mc) != 1 {
+		t.Fatalf("conflicts: %v err: %v", mc, err)
+	}
+	if mc[0].Path != "Layer.Inhib.Layer.Gi" || mc[0].Val != "1.8" || mc[0].OtherVal != "2.0" || mc[0].Kept != "2.0" {
+		t.Errorf("conflict: %+v", mc[0])
+	}
+	if len(*sh) != 3 || (*sh)[2].Sel != "Prjn" {
+		t.Errorf("Prjn sel not appended: %d", len(*sh))
+	}
+	ly := (*sh)[0]
+	if ly.Params["Layer.Inhib.Layer.Gi"] != "2.0" || ly.Params["Layer.Act.Init.Decay"] != "0" {
+		t.Errorf("override: %v", ly.Params)
+	}
+	(*oth)[1].Params["Prjn.Learn.Lrate"] = "0.1"
+	if (*sh)[2].Params["Prjn.Learn.Lrate"] != "0.04" {
+		t.Errorf("appended Sel must be a copy")
+	}
+
+	sh, oth = mergeSheets()
+	mc, _ = sh.Merge(oth, MergeKeepFirst)
+	ly = (*sh)[0]
+	if len(mc) != 1 || mc[0].Kept != "1.8" || ly.Params["Layer.Inhib.Layer.Gi"] != "1.8" || ly.Params["Layer.Act.Init.Decay"] != "0" {
+		t.Errorf("keep first: %v %v", mc, ly.Params)
+	}
+
+	sh, oth = mergeSheets()
+	mc, err = sh.Merge(oth, MergeError)
+	if err == nil || len(mc) != 1 || len(*sh) != 2 || (*sh)[0].Params["Layer.Inhib.Layer.Gi"] != "1.8" {
+		t.Errorf("error policy should not merge: %v %d", err, len(*sh))
+	}
+	sh, _ = mergeSheets()
+	if _, err = sh.Merge(&Sheet{{Sel: "Layer", Params: Params{"Layer.Inhib.Layer.Gi": "1.8"}}}, MergeError); err != nil {
+		t.Errorf("same value is not a conflict: %v", err)
+	}
+}
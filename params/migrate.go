@@ -0,0 +1,132 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"fmt"
+	"log"
+)
+
+// Version is the current version of the params for this program, which the
+// sim should set, and increment whenever param paths or defaults change in a
+// way that requires params saved in older versions to be migrated.
+// It is recorded in the Version of each Set when saved, and Set's opened
+// from older versions are upgraded via the registered Migrations.
+var Version = 0
+
+// ValChange is a change in the value of a param made by a Migration
+type ValChange struct {
+	Path string `desc:"param path, e.g., Layer.Inhib.Layer.Gi"`
+	Old  string `desc:"old value to replace"`
+	New  string `desc:"new value to replace it with"`
+}
+
+// Migration upgrades params saved in one version (From) to the next one,
+// by renaming param paths, changing values (e.g., for a changed default or
+// units), and calling an arbitrary Func for anything else.
+type Migration struct {
+	From    int                 `desc:"version that this migrates from -- the result is version From+1"`
+	Desc    string              `desc:"description of the changes"`
+	Renames map[string]string   `desc:"map from old to new param paths, including the target type, e.g., Layer.Act.Gbar.L -> Layer.Act.Gbar.Leak"`
+	Vals    []ValChange         `desc:"param values to change, applied after the Renames, so using the new paths"`
+	Func    func(st *Set) error `view:"-" json:"-" desc:"optional function for any other changes, called last"`
+}
+
+// Migrations are the registered migrations, by the version they migrate from
+// -- use AddMigration to add.
+var Migrations = map[int]*Migration{}
+
+// AddMigration registers a migration from given version to the next one,
+// returning it for adding Renames and Vals changes
+func AddMigration(from int, desc string) *Migration {
+	mg := &Migration{From: from, Desc: desc}
+	Migrations[from] = mg
+	return mg
+}
+
+// Rename adds a rename of the old param path to the new one
+func (mg *Migration) Rename(oldPath, newPath string) *Migration {
+	if mg.Renames == nil {
+		mg.Renames = make(map[string]string)
+	}
+	mg.Renames[oldPath] = newPath
+	return mg
+}
+
+// ChangeVal adds a change of the value of given param path from old to new,
+// e.g., where the default has changed and the old default should now be
+// the new one.
+func (mg *Migration) ChangeVal(path, oldVal, newVal string) *Migration {
+	mg.Vals = append(mg.Vals, ValChange{Path: path, Old: oldVal, New: newVal})
+	return mg
+}
+
+// Migrate applies the migration to given Set, which is then at version From+1
+func (mg *Migration) Migrate(st *Set) error {
+	for _, sh := range st.Sheets {
+		for _, sl := range *sh {
+			for op, np := range mg.Renames {
+				if v, has := sl.Params[op]; has {
+					delete(sl.Params, op)
+					sl.Params[np] = v
+				}
+				if hy, has := sl.Hypers[op]; has {
+					delete(sl.Hypers, op)
+					sl.Hypers[np] = hy
+				}
+			}
+			for _, vc := range mg.Vals {
+				if v, has := sl.Params[vc.Path]; has && v == vc.Old {
+					sl.Params[vc.Path] = vc.New
+				}
+			}
+		}
+	}
+	if mg.Func != nil {
+		if err := mg.Func(st); err != nil {
+			err = fmt.Errorf("params.Migrate: Set: %v from version: %v error: %v", st.Name, mg.From, err)
+			log.Println(err)
+			return err
+		}
+	}
+	st.Version = mg.From + 1
+	return nil
+}
+
+// Migrate upgrades the Set from its Version to the current params Version,
+// applying each of the registered Migrations in order (versions without
+// a migration need no changes), and logging each one that is applied.
+// It is an error for the Set to be newer than the current Version.
+func (ps *Set) Migrate() error {
+	if ps.Version > Version {
+		err := fmt.Errorf("params.Set: %v version: %v is newer than the current params version: %v", ps.Name, ps.Version, Version)
+		log.Println(err)
+		return err
+	}
+	for ps.Version < Version {
+		mg, has := Migrations[ps.Version]
+		if !has {
+			ps.Version++
+			continue
+		}
+		if err := mg.Migrate(ps); err != nil {
+			return err
+		}
+		log.Printf("params.Set: %v migrated to version: %v: %v\n", ps.Name, ps.Version, mg.Desc)
+	}
+	return nil
+}
+
+// Migrate upgrades all the Sets to the current params Version -- see
+// Set.Migrate.  Returns the last error, if any.
+func (ps *Sets) Migrate() error {
+	var rerr error
+	for _, st := range *ps {
+		if err := st.Migrate(); err != nil {
+			rerr = err
+		}
+	}
+	return rerr
+}
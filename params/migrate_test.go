@@ -0,0 +1,78 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goki/gi/gi"
+)
+
+func TestMigrate(t *testing.T) {
+	defer func() {
+		Version = 0
+		Migrations = map[int]*Migration{}
+	}()
+	Version = 3
+	AddMigration(0, "Gbar.L renamed to Gbar.Leak").Rename("Layer.Act.Gbar.L", "Layer.Act.Gbar.Leak")
+	// no changes from 1 to 2
+	AddMigration(2, "Gbar.Leak default now 0.3").ChangeVal("Layer.Act.Gbar.Leak", "0.2", "0.3")
+
+	mk := func() *Set {
+		return &Set{Name: "Base", Sheets: Sheets{
+			"Network": &Sheet{
+				{Sel: "Layer", Params: Params{"Layer.Act.Gbar.L": "0.2", "Layer.Inhib.Layer.Gi": "2.0"},
+					Hypers: Hypers{"Layer.Act.Gbar.L": {Tune: true}}},
+			},
+		}}
+	}
+	st := mk()
+	if err := st.Migrate(); err != nil {
+		t.Error(err)
+	}
+	sl := (*st.Sheets["Network"])[0]
+	if st.Version != 3 || sl.Params["Layer.Act.Gbar.Leak"] != "0.3" || sl.Params["Layer.Inhib.Layer.Gi"] != "2.0" {
+		t.Errorf("migrated: %v %v", st.Version, sl.Params)
+	}
+	if _, has := sl.Params["Layer.Act.Gbar.L"]; has || sl.Hypers["Layer.Act.Gbar.Leak"] == nil {
+		t.Errorf("rename not migrated: %v %v", sl.Params, sl.Hypers)
+	}
+
+	st = mk()
+	st.Version = 1 // already renamed, so L is not touched, but vals change
+	st.Migrate()
+	sl = (*st.Sheets["Network"])[0]
+	if sl.Params["Layer.Act.Gbar.L"] != "0.2" || st.Version != 3 {
+		t.Errorf("migrated from 1: %v %v", st.Version, sl.Params)
+	}
+
+	st = mk()
+	st.Version = 4
+	if err := st.Migrate(); err == nil {
+		t.Errorf("newer version should be an error")
+	}
+
+	dir, err := ioutil.TempDir("", "params")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fn := gi.FileName(filepath.Join(dir, "sets.params"))
+	Version = 0
+	sts := Sets{mk()}
+	sts.SaveJSON(fn)
+	Version = 3
+	var ldsts Sets
+	if err := ldsts.OpenJSON(fn); err != nil {
+		t.Error(err)
+	}
+	sl = (*ldsts[0].Sheets["Network"])[0]
+	if ldsts[0].Version != 3 || sl.Params["Layer.Act.Gbar.Leak"] != "0.3" {
+		t.Errorf("opened: %v %v", ldsts[0].Version, sl.Params)
+	}
+}
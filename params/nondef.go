@@ -0,0 +1,127 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/goki/ki/kit"
+)
+
+// DefDiff is the current value of a param that differs from its default
+// value(s), as specified in the def:"val1[,val2...]" struct field tag
+type DefDiff struct {
+	Cur string `desc:"current value"`
+	Def string `desc:"default value(s), from the def tag"`
+}
+
+// DefDiffs maps param paths (including the target type) to the current
+// and default values of params that differ from their defaults -- see NonDefaults
+type DefDiffs map[string]DefDiff
+
+// NonDefaults returns the params of given object (e.g., a Layer or Prjn)
+// whose current value differs from all of the values in the def struct field
+// tag, keyed by param path starting with the target type name of the object.
+// Only fields with a def tag are included, in the object and the structs
+// it contains (not through pointers or slices).
+func NonDefaults(obj interface{}) DefDiffs {
+	dd := DefDiffs{}
+	nonDefaults(kit.NonPtrValue(reflect.ValueOf(obj)), objTypeName(obj), dd)
+	return dd
+}
+
+func nonDefaults(val reflect.Value, path string, dd DefDiffs) {
+	if val.Kind() != reflect.Struct {
+		return
+	}
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if sf.PkgPath != "" { // unexported
+			continue
+		}
+		fv := val.Field(i)
+		fpath := path + "." + sf.Name
+		defs, has := sf.Tag.Lookup("def")
+		if !has {
+			if fv.Kind() == reflect.Struct {
+				nonDefaults(fv, fpath, dd)
+			}
+			continue
+		}
+		if !IsDefault(fv, defs) {
+			dd[fpath] = DefDiff{Cur: fmt.Sprintf("%v", fv.Interface()), Def: defs}
+		}
+	}
+}
+
+// IsDefault returns true if given field value matches any of the
+// comma-separated default values (as in a def struct field tag),
+// comparing numbers at the precision of the field.
+func IsDefault(fv reflect.Value, defs string) bool {
+	cur := fmt.Sprintf("%v", fv.Interface())
+	for _, ds := range strings.Split(defs, ",") {
+		ds = strings.TrimSpace(ds)
+		if ds == cur {
+			return true
+		}
+		dv, err := strconv.ParseFloat(ds, 64)
+		if err != nil {
+			continue
+		}
+		switch fv.Kind() {
+		case reflect.Float32:
+			if float32(dv) == float32(fv.Float()) {
+				return true
+			}
+		case reflect.Float64:
+			if dv == fv.Float() {
+				return true
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if dv == float64(fv.Int()) {
+				return true
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if dv == float64(fv.Uint()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Paths returns the param paths in sorted order
+func (dd DefDiffs) Paths() []string {
+	paths := make([]string, 0, len(dd))
+	for pt := range dd {
+		paths = append(paths, pt)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Params returns the current values as Params, e.g., to re-save them as a Sheet
+func (dd DefDiffs) Params() Params {
+	pr := make(Params, len(dd))
+	for pt, df := range dd {
+		pr[pt] = df.Cur
+	}
+	return pr
+}
+
+// String returns a listing of the params, one per line, in sorted order
+func (dd DefDiffs) String() string {
+	var b strings.Builder
+	for _, pt := range dd.Paths() {
+		df := dd[pt]
+		fmt.Fprintf(&b, "%s: %s  (def: %s)\n", pt, df.Cur, df.Def)
+	}
+	return b.String()
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"testing"
+)
+
+type ndInhib struct {
+	Gi  float32 `def:"1.8,2"`
+	FB  float32 `def:"1"`
+	On  bool    `def:"true"`
+	Max int     `def:"10"`
+}
+
+type ndLayer struct {
+	Inhib ndInhib
+	Lrate float64 `def:"0.04"`
+	Name  string
+	ref   float32 `def:"1"`
+}
+
+func TestNonDefaults(t *testing.T) {
+	ly := &ndLayer{Inhib: ndInhib{Gi: 2, FB: 0.5, On: true, Max: 12}, Lrate: 0.04}
+	dd := NonDefaults(ly)
+	if len(dd) != 2 {
+		t.Fatalf("non defaults:\n%s", dd.String())
+	}
+	if df := dd["ndLayer.Inhib.FB"]; df.Cur != "0.5" || df.Def != "1" {
+		t.Errorf("FB: %+v", df)
+	}
+	if _, has := dd["ndLayer.Inhib.Max"]; !has {
+		t.Errorf("Max missing:\n%s", dd.String())
+	}
+	pr := dd.Params()
+	if pr["ndLayer.Inhib.Max"] != "12" {
+		t.Errorf("params: %v", pr)
+	}
+	nl := &ndLayer{}
+	sl := &Sel{Sel: "ndLayer", Params: pr}
+	if _, err := sl.Apply(nl, false); err != nil || nl.Inhib.FB != 0.5 || nl.Inhib.Max != 12 {
+		t.Errorf("apply: %v %+v", err, nl)
+	}
+}
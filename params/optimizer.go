@@ -0,0 +1,176 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"strings"
+)
+
+// OptTrial is one trial in an Optimization: the values of the params being
+// optimized, and the results of running with them
+type OptTrial struct {
+	Idx   int                `desc:"index of the trial, in order of running"`
+	Name  string             `desc:"run name (see Optimization.RunName)"`
+	Vals  []string           `desc:"the param values, in order of Optimization.Specs"`
+	Score float64            `desc:"score to minimize: the value of the Optimization Stat, negated if Maximize"`
+	Stats map[string]float64 `desc:"result stats as returned by the run function"`
+	Err   error              `desc:"error returned by the run function, if any -- Score is not valid"`
+}
+
+// Optimizer is the interface for hyperparameter search methods (e.g., random
+// search, Bayesian optimization, CMA-ES) that drive an Optimization, by
+// suggesting the param values for each trial based on the results so far.
+// See RandOptimizer for a reference implementation.
+type Optimizer interface {
+	// Init initializes the optimizer for given params to optimize, which have
+	// the prior distribution and range to search in (see HyperSpec)
+	Init(specs []HyperSpec) error
+
+	// Suggest returns the values to try in the next trial for each param, in
+	// order of the specs, given the history of trials so far (including those
+	// with errors, which have no valid Score)
+	Suggest(hist []*OptTrial) ([]string, error)
+
+	// Report reports the results of a trial with the suggested values
+	Report(trial *OptTrial)
+}
+
+// Optimization runs an Optimizer for a number of trials over the params to
+// be tuned in a Set (see Set.HyperSpecs), generating a params.Set for each
+// trial (to be applied after the Base set), and recording the results.
+type Optimization struct {
+	Name     string      `desc:"name of the optimization, used as the prefix of the run and Set names"`
+	Specs    []HyperSpec `desc:"params to optimize, e.g., from Set.HyperSpecs"`
+	Opt      Optimizer   `view:"-" desc:"the optimizer that suggests param values"`
+	Stat     string      `desc:"name of the stat returned by the run function that is optimized"`
+	Maximize bool        `desc:"maximize the Stat (e.g., percent correct) -- otherwise minimize it (e.g., error)"`
+	Trials   []*OptTrial `desc:"trials run so far, in order"`
+}
+
+// RunName returns the name for given trial index and values, consisting of
+// the Name, the index, and Label=Val for each param, separated by _
+func (op *Optimization) RunName(idx int, vals []string) string {
+	nms := make([]string, 0, len(vals)+2)
+	if op.Name != "" {
+		nms = append(nms, op.Name)
+	}
+	nms = append(nms, fmt.Sprintf("%03d", idx))
+	for pi, sp := range op.searchParams() {
+		nms = append(nms, sp.Name()+"="+vals[pi])
+	}
+	return strings.Join(nms, "_")
+}
+
+// searchParams returns the Specs as SearchParams, for generating Sets
+func (op *Optimization) searchParams() []*SearchParam {
+	sps := make([]*SearchParam, len(op.Specs))
+	for i := range op.Specs {
+		hs := &op.Specs[i]
+		sps[i] = &SearchParam{Sheet: hs.Sheet, Sel: hs.Sel, Path: hs.Path}
+	}
+	return sps
+}
+
+// Set returns the params.Set for given trial index and values, named by
+// RunName, with each param in its Sheet and Sel (see Search.Set)
+func (op *Optimization) Set(idx int, vals []string) *Set {
+	return searchSet(op.Name, op.RunName(idx, vals), op.searchParams(), vals)
+}
+
+// Run initializes the Optimizer (unless trials have already been run, in
+// which case it continues) and runs given number of trials, calling given
+// function sequentially for each with its index and params.Set, which
+// returns result stats including the Stat being optimized.
+// Errors are logged and recorded in the trial, and the last one is returned.
+func (op *Optimization) Run(n int, fun func(idx int, set *Set) (map[string]float64, error)) error {
+	if op.Opt == nil {
+		err := fmt.Errorf("params.Optimization: %v: Opt optimizer is not set", op.Name)
+		log.Println(err)
+		return err
+	}
+	if len(op.Trials) == 0 {
+		if err := op.Opt.Init(op.Specs); err != nil {
+			log.Println(err)
+			return err
+		}
+	}
+	var rerr error
+	for i := 0; i < n; i++ {
+		idx := len(op.Trials)
+		vals, err := op.Opt.Suggest(op.Trials)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+		st := op.Set(idx, vals)
+		tr := &OptTrial{Idx: idx, Name: st.Name, Vals: vals}
+		tr.Stats, tr.Err = fun(idx, st)
+		if tr.Err == nil {
+			sv, ok := tr.Stats[op.Stat]
+			if !ok {
+				tr.Err = fmt.Errorf("stat: %v not returned", op.Stat)
+			} else if op.Maximize {
+				tr.Score = -sv
+			} else {
+				tr.Score = sv
+			}
+		}
+		if tr.Err != nil {
+			tr.Err = fmt.Errorf("params.Optimization: %v run: %v error: %v", op.Name, st.Name, tr.Err)
+			log.Println(tr.Err)
+			rerr = tr.Err
+		}
+		op.Trials = append(op.Trials, tr)
+		op.Opt.Report(tr)
+	}
+	return rerr
+}
+
+// Best returns the trial with the best (lowest) Score, among those
+// without errors, or nil if there are none
+func (op *Optimization) Best() *OptTrial {
+	var best *OptTrial
+	for _, tr := range op.Trials {
+		if tr.Err == nil && (best == nil || tr.Score < best.Score) {
+			best = tr
+		}
+	}
+	return best
+}
+
+// RandOptimizer is the reference Optimizer, which samples each param
+// independently from its prior distribution (see RandParam), ignoring the
+// results.  Each trial uses its own random seed (Seed + trial index), as in
+// RandSearch.
+type RandOptimizer struct {
+	Seed   int64        `desc:"base random seed -- trial i uses Seed + i"`
+	Params []*RandParam `desc:"params to sample, from the specs"`
+}
+
+// Init sets the params to sample from the specs
+func (ro *RandOptimizer) Init(specs []HyperSpec) error {
+	ro.Params = make([]*RandParam, len(specs))
+	for i, hs := range specs {
+		ro.Params[i] = &RandParam{SearchParam: SearchParam{Sheet: hs.Sheet, Sel: hs.Sel, Path: hs.Path, Vals: hs.Vals}, Dist: hs.Prior, Min: hs.Min, Max: hs.Max}
+	}
+	return nil
+}
+
+// Suggest samples the values for the next trial
+func (ro *RandOptimizer) Suggest(hist []*OptTrial) ([]string, error) {
+	rnd := rand.New(rand.NewSource(ro.Seed + int64(len(hist))))
+	vals := make([]string, len(ro.Params))
+	for pi, rp := range ro.Params {
+		vals[pi] = rp.Sample(rnd)
+	}
+	return vals, nil
+}
+
+// Report does nothing, as random search does not use the results
+func (ro *RandOptimizer) Report(trial *OptTrial) {
+}
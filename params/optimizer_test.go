@@ -0,0 +1,51 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func TestOptimization(t *testing.T) {
+	specs := []HyperSpec{
+		{Sheet: "Network", Sel: "Layer", Path: "Layer.Inhib.Layer.Gi", Hyper: Hyper{Tune: true, Prior: UniformDist, Min: 1, Max: 3}},
+		{Sheet: "Network", Sel: "Prjn", Path: "Prjn.Learn.Lrate", Hyper: Hyper{Tune: true, Prior: LogUniformDist, Min: 0.001, Max: 0.1}},
+	}
+	op := &Optimization{Name: "Opt", Specs: specs, Opt: &RandOptimizer{Seed: 1}, Stat: "Err"}
+	err := op.Run(10, func(idx int, set *Set) (map[string]float64, error) {
+		gi, _ := strconv.ParseFloat((*set.Sheets["Network"])[0].Params["Layer.Inhib.Layer.Gi"], 64)
+		if idx == 3 {
+			return nil, fmt.Errorf("failed")
+		}
+		return map[string]float64{"Err": (gi - 2) * (gi - 2)}, nil
+	})
+	if err == nil {
+		t.Errorf("error from trial 3 not returned")
+	}
+	if len(op.Trials) != 10 || op.Trials[3].Err == nil {
+		t.Fatalf("trials: %d", len(op.Trials))
+	}
+	for _, tr := range op.Trials {
+		gi, _ := strconv.ParseFloat(tr.Vals[0], 64)
+		lr, _ := strconv.ParseFloat(tr.Vals[1], 64)
+		if gi < 1 || gi > 3 || lr < 0.001 || lr > 0.1 {
+			t.Errorf("trial: %d vals out of range: %v", tr.Idx, tr.Vals)
+		}
+	}
+	best := op.Best()
+	for _, tr := range op.Trials {
+		if tr.Err == nil && tr.Score < best.Score {
+			t.Errorf("best: %v not best: %v", best.Score, tr.Score)
+		}
+	}
+	op.Run(2, func(idx int, set *Set) (map[string]float64, error) {
+		return map[string]float64{"Err": 0}, nil
+	})
+	if len(op.Trials) != 12 || op.Best().Idx != 10 {
+		t.Errorf("continued run: %d best: %d", len(op.Trials), op.Best().Idx)
+	}
+}
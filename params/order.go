@@ -0,0 +1,107 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"sort"
+)
+
+// Ordered returns the Sels in order of application: sorted by increasing
+// Priority, and otherwise in the order listed, so that higher-priority Sels
+// are applied later and take precedence.  Returns the Sheet itself if all
+// have the same Priority.
+func (ps *Sheet) Ordered() Sheet {
+	same := true
+	for _, sl := range *ps {
+		if sl.Priority != (*ps)[0].Priority {
+			same = false
+			break
+		}
+	}
+	if same {
+		return *ps
+	}
+	ord := make(Sheet, len(*ps))
+	copy(ord, *ps)
+	sort.SliceStable(ord, func(i, j int) bool {
+		return ord[i].Priority < ord[j].Priority
+	})
+	return ord
+}
+
+// SheetOrder returns the names of the Sheets in order of application: those
+// in the declared Order first, followed by any others sorted by name
+func (ps *Set) SheetOrder() []string {
+	nms := make([]string, 0, len(ps.Sheets))
+	has := make(map[string]bool, len(ps.Sheets))
+	for _, nm := range ps.Order {
+		if _, ok := ps.Sheets[nm]; ok && !has[nm] {
+			nms = append(nms, nm)
+			has[nm] = true
+		}
+	}
+	var rest []string
+	for nm := range ps.Sheets {
+		if !has[nm] {
+			rest = append(rest, nm)
+		}
+	}
+	sort.Strings(rest)
+	return append(nms, rest...)
+}
+
+// SheetSel is a Sel along with the name of the Sheet it is in
+type SheetSel struct {
+	Sheet string `desc:"name of the Sheet"`
+	Sel   *Sel   `desc:"the Sel"`
+}
+
+// OrderedSels returns all of the Sels in the Set in order of application:
+// Sheets in SheetOrder, with the Sels of all the Sheets sorted by increasing
+// Priority, and otherwise in order, so the effective value of each param is
+// well-defined (see Apply and Provenance).
+func (ps *Set) OrderedSels() []SheetSel {
+	var ss []SheetSel
+	for _, snm := range ps.SheetOrder() {
+		for _, sl := range *ps.Sheets[snm] {
+			ss = append(ss, SheetSel{Sheet: snm, Sel: sl})
+		}
+	}
+	sort.SliceStable(ss, func(i, j int) bool {
+		return ss[i].Sel.Priority < ss[j].Sel.Priority
+	})
+	return ss
+}
+
+// Apply applies all of the Sheets in the Set to given object, with the Sels
+// in OrderedSels order (see Sel.Apply), for Sets where all the Sheets apply
+// to the same objects.  Returns true if any Sels applied, and error if any
+// params failed to be set.  Params in ps.Frozen are skipped, with a
+// logged notice.
+func (ps *Set) Apply(obj interface{}, setMsg bool) (bool, error) {
+	applied := false
+	var rerr error
+	for _, ss := range ps.OrderedSels() {
+		app, err := ss.Sel.applyFrozen(obj, setMsg, ps.Frozen)
+		if app {
+			applied = true
+		}
+		if err != nil {
+			rerr = err
+		}
+	}
+	return applied, rerr
+}
+
+// Provenance returns the record of which Sheet (named Set:Sheet) and Sel
+// sets each param on each of given objects when the Set is applied (see
+// Apply), where Provenance.Last is the effective value.
+func (ps *Set) Provenance(objs []interface{}) *Provenance {
+	pv := &Provenance{}
+	for _, ss := range ps.OrderedSels() {
+		pv.Record(ps.Name+":"+ss.Sheet, &Sheet{ss.Sel}, objs)
+	}
+	return pv
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"testing"
+)
+
+func TestOrder(t *testing.T) {
+	sh := &Sheet{
+		{Sel: "#Hidden", Priority: 1, Params: Params{"Layer.Gi": "2.2"}},
+		{Sel: "Layer", Params: Params{"Layer.Gi": "1.8"}},
+		{Sel: ".Hid", Params: Params{"Layer.Gi": "2.0"}},
+	}
+	hid := &provLayer{Nm: "Hidden", Cls: "Hid"}
+	sh.Apply(hid, false)
+	if hid.Gi != 2.2 {
+		t.Errorf("priority within sheet: %v", hid.Gi)
+	}
+	if ord := sh.Ordered(); ord[0].Sel != "Layer" || ord[2].Sel != "#Hidden" || (*sh)[0].Sel != "#Hidden" {
+		t.Errorf("ordered: %v %v %v", ord[0].Sel, ord[2].Sel, (*sh)[0].Sel)
+	}
+
+	st := &Set{Name: "Base", Order: []string{"Net2", "Net1"}, Sheets: Sheets{
+		"Net1": &Sheet{{Sel: "Layer", Params: Params{"Layer.Gi": "1.4"}}},
+		"Net2": &Sheet{{Sel: "Layer", Params: Params{"Layer.Gi": "1.6"}}},
+		"Aux":  &Sheet{{Sel: "#Hidden", Params: Params{"Layer.Gi": "1.2"}}},
+	}}
+	if so := st.SheetOrder(); len(so) != 3 || so[0] != "Net2" || so[2] != "Aux" {
+		t.Errorf("sheet order: %v", so)
+	}
+	st.Apply(hid, false)
+	if hid.Gi != 1.2 {
+		t.Errorf("set order: %v", hid.Gi)
+	}
+	(*st.Sheets["Net2"])[0].Priority = 1
+	st.Apply(hid, false)
+	if hid.Gi != 1.6 {
+		t.Errorf("set priority: %v", hid.Gi)
+	}
+	pv := st.Provenance([]interface{}{hid})
+	if last, ok := pv.Last("Hidden", "Layer.Gi"); !ok || last.Sheet != "Base:Net2" || last.Val != "1.6" {
+		t.Errorf("effective: %v", last)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+)
+
+// ParseOverride parses a param override of the form [Sel:]Path=Val, e.g.,
+// Layer.Inhib.Layer.Gi=1.8 or #Output:Layer.Inhib.Layer.Gi=1.4, where the
+// Path starts with the target type as usual, and the Sel selector defaults
+// to the target type (i.e., all objects of that type).
+func ParseOverride(ovr string) (sel, path, val string, err error) {
+	eq := strings.Index(ovr, "=")
+	if eq <= 0 {
+		err = fmt.Errorf("params.ParseOverride: %q must be of the form [Sel:]Path=Val, e.g., Layer.Inhib.Layer.Gi=1.8", ovr)
+		return
+	}
+	path = strings.TrimSpace(ovr[:eq])
+	val = strings.TrimSpace(ovr[eq+1:])
+	if ci := strings.Index(path, ":"); ci >= 0 {
+		sel = strings.TrimSpace(path[:ci])
+		path = strings.TrimSpace(path[ci+1:])
+	}
+	pe := strings.Split(path, ".")
+	if len(pe) < 2 || pe[0] == "" {
+		err = fmt.Errorf("params.ParseOverride: %q path must start with the target type, e.g., Layer.Inhib.Layer.Gi", ovr)
+		return
+	}
+	if sel == "" {
+		sel = pe[0]
+	}
+	return
+}
+
+// Overrides holds param overrides specified on the command line, to be
+// applied last, after all other params, e.g., for driving parameter sweeps
+// on a cluster without editing the source.  See AddFlags for the flags.
+// It implements the flag.Value interface for the -set flag.
+type Overrides struct {
+	File string   `desc:"JSON file containing a params.Sheet to apply before the Sets overrides (-params flag)"`
+	Sets []string `desc:"individual overrides of the form [Sel:]Path=Val -- see ParseOverride (-set flag, can be repeated)"`
+}
+
+// String satisfies flag.Value
+func (ov *Overrides) String() string {
+	return strings.Join(ov.Sets, " ")
+}
+
+// Set satisfies flag.Value, adding an override of the form [Sel:]Path=Val
+func (ov *Overrides) Set(s string) error {
+	if _, _, _, err := ParseOverride(s); err != nil {
+		return err
+	}
+	ov.Sets = append(ov.Sets, s)
+	return nil
+}
+
+// AddFlags adds the -set (repeatable) and -params flags to given flag set
+// (e.g., flag.CommandLine) -- call before flag.Parse.
+func (ov *Overrides) AddFlags(fs *flag.FlagSet) {
+	fs.Var(ov, "set", "param override of the form [Sel:]Path=Val, e.g., -set Layer.Inhib.Layer.Gi=1.8 or -set \"#Output:Layer.Inhib.Layer.Gi=1.4\" -- can be repeated, applied last in order")
+	fs.StringVar(&ov.File, "params", "", "JSON file with a params Sheet to apply last, before any -set overrides")
+}
+
+// Sheet returns the merged params.Sheet of all the overrides: the File sheet
+// if specified, followed by one Sel per Sets override, in order, so later
+// overrides take precedence.  Returns an empty sheet if there are none.
+func (ov *Overrides) Sheet() (*Sheet, error) {
+	sht := &Sheet{}
+	if ov.File != "" {
+		b, err := ioutil.ReadFile(ov.File)
+		if err != nil {
+			log.Println(err)
+			return sht, err
+		}
+		if err := json.Unmarshal(b, sht); err != nil {
+			err = fmt.Errorf("params.Overrides: %v: %v", ov.File, err)
+			log.Println(err)
+			return sht, err
+		}
+	}
+	for _, s := range ov.Sets {
+		sel, path, val, err := ParseOverride(s)
+		if err != nil {
+			log.Println(err)
+			return sht, err
+		}
+		*sht = append(*sht, &Sel{Sel: sel, Desc: "command-line override", Params: Params{path: val}})
+	}
+	return sht, nil
+}
+
+// Empty returns true if there are no overrides
+func (ov *Overrides) Empty() bool {
+	return ov.File == "" && len(ov.Sets) == 0
+}
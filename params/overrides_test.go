@@ -0,0 +1,33 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"flag"
+	"testing"
+)
+
+func TestOverrides(t *testing.T) {
+	var ov Overrides
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	ov.AddFlags(fs)
+	err := fs.Parse([]string{"--set", "Layer.Inhib.Layer.Gi=1.8", "-set", "#Output:Layer.Inhib.Layer.Gi=1.4"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sht, err := ov.Sheet()
+	if err != nil || len(*sht) != 2 {
+		t.Fatalf("Sheet: %v err: %v", sht, err)
+	}
+	if sl := (*sht)[1]; sl.Sel != "#Output" || sl.Params["Layer.Inhib.Layer.Gi"] != "1.4" {
+		t.Errorf("override 1: %v %v", sl.Sel, sl.Params)
+	}
+	if sl := (*sht)[0]; sl.Sel != "Layer" || sl.Params.TargetType() != "Layer" {
+		t.Errorf("override 0: %v %v", sl.Sel, sl.Params)
+	}
+	if err := fs.Parse([]string{"-set", "Gi=1.8"}); err == nil {
+		t.Errorf("expected error for path without target type")
+	}
+}
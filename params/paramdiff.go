@@ -0,0 +1,167 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/ki/kit"
+)
+
+// DiffKinds are the kinds of differences between two versions of params
+type DiffKinds int32
+
+//go:generate stringer -type=DiffKinds
+
+var KiT_DiffKinds = kit.Enums.AddEnum(DiffKindsN, false, nil)
+
+func (ev DiffKinds) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *DiffKinds) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// The diff kinds
+const (
+	// DiffAdded is a param that is only in the other (new) version
+	DiffAdded DiffKinds = iota
+
+	// DiffRemoved is a param that is only in the first (old) version
+	DiffRemoved
+
+	// DiffChanged is a param that has a different value in the two versions
+	DiffChanged
+
+	DiffKindsN
+)
+
+// ParamDiff is one difference between two versions of params (see Sets.Diffs)
+type ParamDiff struct {
+	Kind  DiffKinds `desc:"kind of difference"`
+	Set   string    `desc:"name of the Set"`
+	Sheet string    `desc:"name of the Sheet"`
+	Sel   string    `desc:"selector of the Sel, with its Cond condition if any, and its occurrence number if the selector is repeated in the Sheet, e.g., Layer (2)"`
+	Path  string    `desc:"param path"`
+	A     string    `desc:"value in the first (old) version -- empty if added"`
+	B     string    `desc:"value in the other (new) version -- empty if removed"`
+}
+
+// String returns a one-line description of the difference
+func (pd *ParamDiff) String() string {
+	loc := fmt.Sprintf("%s:%s: %s: %s", pd.Set, pd.Sheet, pd.Sel, pd.Path)
+	switch pd.Kind {
+	case DiffAdded:
+		return fmt.Sprintf("+ %s = %s", loc, pd.B)
+	case DiffRemoved:
+		return fmt.Sprintf("- %s = %s", loc, pd.A)
+	default:
+		return fmt.Sprintf("~ %s = %s -> %s", loc, pd.A, pd.B)
+	}
+}
+
+// ParamDiffs are the differences between two versions of params
+type ParamDiffs []ParamDiff
+
+// String returns a formatted report, with one line per difference:
+// + for added, - for removed, and ~ for changed
+func (pds ParamDiffs) String() string {
+	var b strings.Builder
+	for i := range pds {
+		b.WriteString(pds[i].String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// diffKey identifies a param in a version of params, for Sets.Diffs
+type diffKey struct {
+	set, sheet, sel, path string
+}
+
+// diffVals returns the values of all params in the Sets by diffKey, along
+// with the keys in order
+func (ps *Sets) diffVals() (map[diffKey]string, []diffKey) {
+	vals := make(map[diffKey]string)
+	var keys []diffKey
+	for _, st := range *ps {
+		snms := make([]string, 0, len(st.Sheets))
+		for snm := range st.Sheets {
+			snms = append(snms, snm)
+		}
+		sort.Strings(snms)
+		for _, snm := range snms {
+			nsel := make(map[string]int)
+			for _, sl := range *st.Sheets[snm] {
+				sk := sl.selKey()
+				nsel[sk]++
+				if n := nsel[sk]; n > 1 {
+					sk = fmt.Sprintf("%s (%d)", sk, n)
+				}
+				pts := make([]string, 0, len(sl.Params))
+				for pt := range sl.Params {
+					pts = append(pts, pt)
+				}
+				sort.Strings(pts)
+				for _, pt := range pts {
+					k := diffKey{st.Name, snm, sk, pt}
+					vals[k] = sl.Params[pt]
+					keys = append(keys, k)
+				}
+			}
+		}
+	}
+	return vals, keys
+}
+
+// Diffs returns the differences between these Sets and the other Sets
+// (e.g., an older and a newer version of the params of a sim): params that
+// were added, removed, or changed, by Set name, Sheet name, Sel selector,
+// and param path, in order of these Sets followed by those only in the other.
+func (ps *Sets) Diffs(ops *Sets) ParamDiffs {
+	avals, akeys := ps.diffVals()
+	bvals, bkeys := ops.diffVals()
+	var pds ParamDiffs
+	for _, k := range akeys {
+		av := avals[k]
+		bv, has := bvals[k]
+		switch {
+		case !has:
+			pds = append(pds, ParamDiff{Kind: DiffRemoved, Set: k.set, Sheet: k.sheet, Sel: k.sel, Path: k.path, A: av})
+		case av != bv:
+			pds = append(pds, ParamDiff{Kind: DiffChanged, Set: k.set, Sheet: k.sheet, Sel: k.sel, Path: k.path, A: av, B: bv})
+		}
+	}
+	for _, k := range bkeys {
+		if _, has := avals[k]; !has {
+			pds = append(pds, ParamDiff{Kind: DiffAdded, Set: k.set, Sheet: k.sheet, Sel: k.sel, Path: k.path, B: bvals[k]})
+		}
+	}
+	return pds
+}
+
+// DiffFiles returns the differences between the params Sets in JSON files
+// a and b (see Sets.Diffs), e.g., for reviewing changes between experiment
+// versions.  Returns an error if either file cannot be opened.
+func DiffFiles(a, b gi.FileName) (ParamDiffs, error) {
+	var as, bs Sets
+	if err := as.OpenJSON(a); err != nil {
+		return nil, err
+	}
+	if err := bs.OpenJSON(b); err != nil {
+		return nil, err
+	}
+	return as.Diffs(&bs), nil
+}
+
+// DiffsFile returns a report of the differences between these Sets and
+// those in given JSON file (see Sets.Diffs), where added params are those
+// only in the file.
+func (ps *Sets) DiffsFile(filename gi.FileName) string {
+	var fs Sets
+	if err := fs.OpenJSON(filename); err != nil {
+		return err.Error()
+	}
+	return ps.Diffs(&fs).String()
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goki/gi/gi"
+)
+
+func TestDiffFiles(t *testing.T) {
+	a := Sets{{Name: "Base", Sheets: Sheets{"Network": &Sheet{
+		{Sel: "Layer", Params: Params{"Layer.Inhib.Layer.Gi": "1.8", "Layer.Act.Gbar.L": "0.2"}},
+		{Sel: "Layer", Params: Params{"Layer.Act.Init.Decay": "0"}},
+	}}}}
+	b := Sets{{Name: "Base", Sheets: Sheets{"Network": &Sheet{
+		{Sel: "Layer", Params: Params{"Layer.Inhib.Layer.Gi": "2.0"}},
+		{Sel: "Layer", Params: Params{"Layer.Act.Init.Decay": "0"}},
+		{Sel: "#Output", Params: Params{"Layer.Inhib.Layer.Gi": "1.4"}},
+	}}}}
+	pds := a.Diffs(&b)
+	if len(pds) != 3 {
+		t.Fatalf("diffs:\n%s", pds.String())
+	}
+	if pds[0].Kind != DiffRemoved || pds[0].Path != "Layer.Act.Gbar.L" || pds[0].A != "0.2" {
+		t.Errorf("removed: %v", pds[0].String())
+	}
+	if pds[1].Kind != DiffChanged || pds[1].A != "1.8" || pds[1].B != "2.0" {
+		t.Errorf("changed: %v", pds[1].String())
+	}
+	if pds[2].Kind != DiffAdded || pds[2].Sel != "#Output" {
+		t.Errorf("added: %v", pds[2].String())
+	}
+	if len(a.Diffs(&a)) != 0 {
+		t.Errorf("no diffs with self")
+	}
+
+	dir, err := ioutil.TempDir("", "params")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	af := gi.FileName(filepath.Join(dir, "a.params"))
+	bf := gi.FileName(filepath.Join(dir, "b.params"))
+	a.SaveJSON(af)
+	b.SaveJSON(bf)
+	fds, err := DiffFiles(af, bf)
+	if err != nil || fds.String() != pds.String() {
+		t.Errorf("files: %v\n%s", err, fds.String())
+	}
+	if rep := a.DiffsFile(bf); !strings.Contains(rep, "~ Base:Network: Layer: Layer.Inhib.Layer.Gi = 1.8 -> 2.0") {
+		t.Errorf("report:\n%s", rep)
+	}
+}
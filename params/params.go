@@ -56,9 +56,13 @@ var KiT_Params = kit.Types.AddType(&Params{}, ParamsProps)
 // parameters, using standard css selector syntax (. prefix = class, # prefix = name,
 // and no prefix = type)
 type Sel struct {
-	Sel    string `desc:"selector for what to apply the parameters to, using standard css selector syntax: .Example applies to anything with a Class tag of 'Example', #Example applies to anything with a Name of 'Example', and Example with no prefix applies to anything of type 'Example'"`
-	Desc   string `width:"60" desc:"description of these parameter values -- what effect do they have?  what range was explored?  it is valuable to record this information as you explore the params."`
-	Params Params `desc:"parameter values to apply to whatever matches the selector"`
+	Sel      string                     `desc:"selector for what to apply the parameters to, using standard css selector syntax: .Example applies to anything with a Class tag of 'Example', #Example applies to anything with a Name of 'Example', and Example with no prefix applies to anything of type 'Example' -- can also be a comma-separated list of these (any match), with * wildcards (e.g., #Hidden*), ! negation (e.g., Layer, !#Output), and [Attr=Val] attributes (e.g., Layer[Type=Hidden], for objects implementing StylerAttrs)"`
+	Desc     string                     `width:"60" desc:"description of these parameter values -- what effect do they have?  what range was explored?  it is valuable to record this information as you explore the params."`
+	Params   Params                     `desc:"parameter values to apply to whatever matches the selector"`
+	Hypers   Hypers                     `json:",omitempty" desc:"hyperparameter search metadata for params, keyed by the same param paths as Params -- see Set.HyperSpecs"`
+	Cond     string                     `json:",omitempty" desc:"condition that must also hold on the target for the params to apply, e.g., Layer.Typ == Hidden -- see EvalCond for the syntax"`
+	CondFunc func(obj interface{}) bool `view:"-" json:"-" desc:"Go function that must also return true on the target object for the params to apply, for conditions beyond those possible in Cond (e.g., shape of a layer)"`
+	Priority int                        `json:",omitempty" desc:"priority for the order of application: Sels are applied in order of increasing priority, and otherwise in the order listed, so higher-priority Sels take precedence -- across all Sheets in Set.Apply (see Set.OrderedSels)"`
 }
 
 var KiT_Sel = kit.Types.AddType(&Sel{}, SelProps)
@@ -71,7 +75,7 @@ var KiT_Sel = kit.Types.AddType(&Sel{}, SelProps)
 //
 // The order of elements in the Sheet list is critical, as they are applied
 // in the order given by the list (slice), and thus later Sel's can override
-// those applied earlier.  Thus, you generally want to have more general Type-level
+// those applied earlier (unless they have a different Priority -- see Ordered).  Thus, you generally want to have more general Type-level
 // parameters listed first, and then subsequently more specific ones (.Class and #Name)
 //
 // This is the highest level of params that has an Apply method -- above this level
@@ -122,6 +126,8 @@ var KiT_Sheets = kit.Types.AddType(&Sheets{}, SheetsProps)
 // A good strategy is to have a "Base" set that has all the best parameters so far,
 // and then other sets can modify relative to that one.  It is up to the Sim code to
 // apply parameter sets in whatever order is desired.
+// A Set can declare that it Extends another Set, in which case Sets.Resolve
+// returns the combination of the two.
 //
 // Within a params.Set, multiple different params.Sheet's can be organized,
 // with each CSS-style sheet achieving a relatively complete parameter styling
@@ -135,9 +141,13 @@ var KiT_Sheets = kit.Types.AddType(&Sheets{}, SheetsProps)
 // a Go map structure, which specifically randomizes order, so simply iterating over them
 // and applying may produce unexpected results -- it is better to lookup by name.
 type Set struct {
-	Name   string `desc:"unique name of this set of parameters"`
-	Desc   string `width:"60" desc:"description of this param set -- when should it be used?  how is it different from the other sets?"`
-	Sheets Sheets `desc:"Sheet's grouped according to their target and / or function, e.g., "Network" for all the network params (or "Learn" vs. "Act" for more fine-grained), and "Sim" for overall simulation control parameters, "Env" for environment parameters, etc.  It is completely up to your program to lookup these names and apply them as appropriate"`
+	Name    string   `desc:"unique name of this set of parameters"`
+	Desc    string   `width:"60" desc:"description of this param set -- when should it be used?  how is it different from the other sets?"`
+	Version int      `desc:"version of the params when this set was saved -- sets opened from older versions are upgraded to the current params.Version using the registered Migrations"`
+	Extends string   `desc:"name of another Set in the same Sets that this one extends (e.g., Base) -- only the differences from that set need to be listed here, and Sets.Resolve returns the flattened result"`
+	Order   []string `json:",omitempty" desc:"declared order of application of the Sheets in Set.Apply and Set.OrderedSels -- any Sheets not listed are applied after these, in order of name"`
+	Sheets  Sheets   `desc:"Sheet's grouped according to their target and / or function, e.g., "Network" for all the network params (or "Learn" vs. "Act" for more fine-grained), and "Sim" for overall simulation control parameters, "Env" for environment parameters, etc.  It is completely up to your program to lookup these names and apply them as appropriate"`
+	Frozen  Frozen   `json:"-" view:"-" desc:"params that are skipped by Apply, e.g., to protect hand-tuned values during sweeps -- only affects this Set"`
 }
 
 var KiT_Set = kit.Types.AddType(&Set{}, SetProps)
@@ -0,0 +1,159 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ProvEntry records one setting of a param on an object -- see Provenance
+type ProvEntry struct {
+	Order int    `desc:"order in which the param was set, across all sheets recorded in the Provenance"`
+	Sheet string `desc:"name of the sheet (e.g., Set:Sheet)"`
+	Sel   string `desc:"selector of the Sel that set the param"`
+	Val   string `desc:"value that was set"`
+}
+
+// String returns a one-line description of the entry
+func (pe *ProvEntry) String() string {
+	return fmt.Sprintf("%d: %s: %s = %s", pe.Order, pe.Sheet, pe.Sel, pe.Val)
+}
+
+// Provenance records, for each object and param path, each Sheet and Sel that
+// set its value, in order, so that the Sel that determines the final value
+// can be identified, and conflicts between overlapping selectors debugged.
+// Use Record along with each application of params (see emer.ApplyParamsProv).
+type Provenance struct {
+	Objs  map[string]map[string][]ProvEntry `desc:"entries for each param path (including target type) for each object, by object name"`
+	order int
+}
+
+// Reset clears all entries
+func (pv *Provenance) Reset() {
+	pv.Objs = nil
+	pv.order = 0
+}
+
+// provObjName returns the name of given object for the provenance,
+// using the type name if it has no name
+func provObjName(obj interface{}) string {
+	if nm := objName(obj); nm != "" {
+		return nm
+	}
+	return objTypeName(obj)
+}
+
+// Record records the params that would be set by applying given sheet
+// (named e.g., Set:Sheet) to given objects, in the same order as Sheet.Apply,
+// without setting anything -- call along with the actual application.
+func (pv *Provenance) Record(name string, sh *Sheet, objs []interface{}) {
+	if pv.Objs == nil {
+		pv.Objs = make(map[string]map[string][]ProvEntry)
+	}
+	for _, obj := range objs {
+		onm := provObjName(obj)
+		for _, sl := range sh.Ordered() {
+			if !sl.Matches(obj) {
+				continue
+			}
+			om := pv.Objs[onm]
+			if om == nil {
+				om = make(map[string][]ProvEntry)
+				pv.Objs[onm] = om
+			}
+			pts := make([]string, 0, len(sl.Params))
+			for pt := range sl.Params {
+				pts = append(pts, pt)
+			}
+			sort.Strings(pts)
+			for _, pt := range pts {
+				om[pt] = append(om[pt], ProvEntry{Order: pv.order, Sheet: name, Sel: sl.Sel, Val: sl.Params[pt]})
+				pv.order++
+			}
+		}
+	}
+}
+
+// Entries returns all the entries for given object name and param path
+// (including target type), in order of setting
+func (pv *Provenance) Entries(obj, path string) []ProvEntry {
+	return pv.Objs[obj][path]
+}
+
+// Last returns the entry that last set the given param path (including
+// target type) on given object name, which determines its current value.
+// Returns false if it was never set.
+func (pv *Provenance) Last(obj, path string) (ProvEntry, bool) {
+	ents := pv.Entries(obj, path)
+	if len(ents) == 0 {
+		return ProvEntry{}, false
+	}
+	return ents[len(ents)-1], true
+}
+
+// objNames returns the object names in sorted order
+func (pv *Provenance) objNames() []string {
+	nms := make([]string, 0, len(pv.Objs))
+	for nm := range pv.Objs {
+		nms = append(nms, nm)
+	}
+	sort.Strings(nms)
+	return nms
+}
+
+// report returns a listing of entries for all params of all objects,
+// or only those that were set by more than one Sel to different values
+func (pv *Provenance) report(conflicts bool) string {
+	var b strings.Builder
+	for _, onm := range pv.objNames() {
+		om := pv.Objs[onm]
+		pts := make([]string, 0, len(om))
+		for pt := range om {
+			pts = append(pts, pt)
+		}
+		sort.Strings(pts)
+		hdr := false
+		for _, pt := range pts {
+			ents := om[pt]
+			if conflicts && !provConflict(ents) {
+				continue
+			}
+			if !hdr {
+				fmt.Fprintf(&b, "%s:\n", onm)
+				hdr = true
+			}
+			fmt.Fprintf(&b, "\t%s = %s\n", pt, ents[len(ents)-1].Val)
+			for i := range ents {
+				fmt.Fprintf(&b, "\t\t%s\n", ents[i].String())
+			}
+		}
+	}
+	return b.String()
+}
+
+// provConflict returns true if the entries set different values
+func provConflict(ents []ProvEntry) bool {
+	for i := 1; i < len(ents); i++ {
+		if ents[i].Val != ents[0].Val {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a report of every param of every object, with its
+// final value, and all of the entries that set it, in order
+func (pv *Provenance) String() string {
+	return pv.report(false)
+}
+
+// Conflicts returns a report of the params that were set to different
+// values by more than one Sel, with all of the entries that set them,
+// in order -- the last one determines the final value.
+func (pv *Provenance) Conflicts() string {
+	return pv.report(true)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"strings"
+	"testing"
+)
+
+type provLayer struct {
+	Nm, Cls string
+	Gi      float32
+}
+
+func (pl *provLayer) TypeName() string { return "Layer" }
+func (pl *provLayer) Class() string    { return pl.Cls }
+func (pl *provLayer) Name() string     { return pl.Nm }
+
+func TestProvenance(t *testing.T) {
+	objs := []interface{}{&provLayer{Nm: "Hidden", Cls: "Hid"}, &provLayer{Nm: "Output"}}
+	base := &Sheet{
+		{Sel: "Layer", Params: Params{"Layer.Gi": "1.8"}},
+		{Sel: "#Output", Params: Params{"Layer.Gi": "1.4"}},
+	}
+	exp := &Sheet{
+		{Sel: ".Hid", Params: Params{"Layer.Gi": "1.8"}},
+	}
+	pv := &Provenance{}
+	pv.Record("Base:Network", base, objs)
+	pv.Record("Exp:Network", exp, objs)
+
+	if ents := pv.Entries("Hidden", "Layer.Gi"); len(ents) != 2 || ents[1].Sheet != "Exp:Network" {
+		t.Errorf("hidden entries: %v", ents)
+	}
+	lst, ok := pv.Last("Output", "Layer.Gi")
+	if !ok || lst.Sel != "#Output" || lst.Val != "1.4" {
+		t.Errorf("output last: %v", lst)
+	}
+	if _, ok := pv.Last("Output", "Layer.Ge"); ok {
+		t.Errorf("unset param found")
+	}
+	cf := pv.Conflicts()
+	if !strings.Contains(cf, "Output:") || strings.Contains(cf, "Hidden:") {
+		t.Errorf("conflicts:\n%s", cf)
+	}
+	if !strings.Contains(pv.String(), "Hidden:") {
+		t.Errorf("report:\n%s", pv.String())
+	}
+}
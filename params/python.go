@@ -0,0 +1,190 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/ki/indent"
+)
+
+// PyIndent is the number of spaces per indent level in Python code
+const PyIndent = 4
+
+// PyVal returns given param value as a Python literal: numbers as numbers,
+// lists of numbers as lists, true / false as True / False, and anything
+// else as a string.
+func PyVal(val string) string {
+	switch val {
+	case "true":
+		return "True"
+	case "false":
+		return "False"
+	}
+	if tb, ok := typedJSON(val); ok && tb[0] == '[' { // list of numbers
+		return val
+	}
+	if _, err := strconv.ParseFloat(val, 64); err == nil && !strings.ContainsAny(strings.ToLower(val), "xpin_") { // exclude hex, inf, nan
+		return val
+	}
+	return fmt.Sprintf("%q", val)
+}
+
+// WritePython writes params as a Python dictionary literal.
+func (pr *Params) WritePython(w io.Writer, depth int) {
+	w.Write([]byte("{\n"))
+	depth++
+	paths := make([]string, 0, len(*pr)) // alpha-sort paths for consistent output
+	for pt := range *pr {
+		paths = append(paths, pt)
+	}
+	sort.Strings(paths)
+	for _, pt := range paths {
+		w.Write(indent.SpaceBytes(depth, PyIndent))
+		w.Write([]byte(fmt.Sprintf("%q: %s,\n", pt, PyVal((*pr)[pt]))))
+	}
+	depth--
+	w.Write(indent.SpaceBytes(depth, PyIndent))
+	w.Write([]byte("}"))
+}
+
+// WritePython writes params as a Python dictionary literal.
+func (pr *Sel) WritePython(w io.Writer, depth int) {
+	w.Write([]byte("{\n"))
+	depth++
+	w.Write(indent.SpaceBytes(depth, PyIndent))
+	w.Write([]byte(fmt.Sprintf("\"Sel\": %q,\n", pr.Sel)))
+	w.Write(indent.SpaceBytes(depth, PyIndent))
+	w.Write([]byte(fmt.Sprintf("\"Desc\": %q,\n", pr.Desc)))
+	if pr.Cond != "" {
+		w.Write(indent.SpaceBytes(depth, PyIndent))
+		w.Write([]byte(fmt.Sprintf("\"Cond\": %q,\n", pr.Cond)))
+	}
+	if pr.Priority != 0 {
+		w.Write(indent.SpaceBytes(depth, PyIndent))
+		w.Write([]byte(fmt.Sprintf("\"Priority\": %d,\n", pr.Priority)))
+	}
+	w.Write(indent.SpaceBytes(depth, PyIndent))
+	w.Write([]byte("\"Params\": "))
+	pr.Params.WritePython(w, depth)
+	w.Write([]byte(",\n"))
+	depth--
+	w.Write(indent.SpaceBytes(depth, PyIndent))
+	w.Write([]byte("}"))
+}
+
+// WritePython writes params as a Python list literal.
+func (pr *Sheet) WritePython(w io.Writer, depth int) {
+	w.Write([]byte("[\n"))
+	depth++
+	for _, sl := range *pr {
+		w.Write(indent.SpaceBytes(depth, PyIndent))
+		sl.WritePython(w, depth)
+		w.Write([]byte(",\n"))
+	}
+	depth--
+	w.Write(indent.SpaceBytes(depth, PyIndent))
+	w.Write([]byte("]"))
+}
+
+// WritePython writes params as a Python dictionary literal.
+func (pr *Sheets) WritePython(w io.Writer, depth int) {
+	w.Write([]byte("{\n"))
+	depth++
+	nms := make([]string, 0, len(*pr)) // alpha-sort names for consistent output
+	for nm := range *pr {
+		nms = append(nms, nm)
+	}
+	sort.Strings(nms)
+	for _, nm := range nms {
+		w.Write(indent.SpaceBytes(depth, PyIndent))
+		w.Write([]byte(fmt.Sprintf("%q: ", nm)))
+		(*pr)[nm].WritePython(w, depth)
+		w.Write([]byte(",\n"))
+	}
+	depth--
+	w.Write(indent.SpaceBytes(depth, PyIndent))
+	w.Write([]byte("}"))
+}
+
+// WritePython writes params as a Python dictionary literal.
+func (pr *Set) WritePython(w io.Writer, depth int) {
+	w.Write([]byte("{\n"))
+	depth++
+	w.Write(indent.SpaceBytes(depth, PyIndent))
+	w.Write([]byte(fmt.Sprintf("\"Name\": %q,\n", pr.Name)))
+	w.Write(indent.SpaceBytes(depth, PyIndent))
+	w.Write([]byte(fmt.Sprintf("\"Desc\": %q,\n", pr.Desc)))
+	if pr.Version != 0 {
+		w.Write(indent.SpaceBytes(depth, PyIndent))
+		w.Write([]byte(fmt.Sprintf("\"Version\": %d,\n", pr.Version)))
+	}
+	if pr.Extends != "" {
+		w.Write(indent.SpaceBytes(depth, PyIndent))
+		w.Write([]byte(fmt.Sprintf("\"Extends\": %q,\n", pr.Extends)))
+	}
+	if len(pr.Order) > 0 {
+		w.Write(indent.SpaceBytes(depth, PyIndent))
+		w.Write([]byte("\"Order\": ["))
+		for i, nm := range pr.Order {
+			if i > 0 {
+				w.Write([]byte(", "))
+			}
+			w.Write([]byte(fmt.Sprintf("%q", nm)))
+		}
+		w.Write([]byte("],\n"))
+	}
+	w.Write(indent.SpaceBytes(depth, PyIndent))
+	w.Write([]byte("\"Sheets\": "))
+	pr.Sheets.WritePython(w, depth)
+	w.Write([]byte(",\n"))
+	depth--
+	w.Write(indent.SpaceBytes(depth, PyIndent))
+	w.Write([]byte("}"))
+}
+
+// WritePython writes params as a Python list literal.
+func (pr *Sets) WritePython(w io.Writer, depth int) {
+	w.Write([]byte("[\n"))
+	depth++
+	for _, st := range *pr {
+		w.Write(indent.SpaceBytes(depth, PyIndent))
+		st.WritePython(w, depth)
+		w.Write([]byte(",\n"))
+	}
+	depth--
+	w.Write(indent.SpaceBytes(depth, PyIndent))
+	w.Write([]byte("]\n"))
+}
+
+// StringPython returns the params as a Python list literal, as a byte string.
+func (pr *Sets) StringPython() []byte {
+	var buf bytes.Buffer
+	pr.WritePython(&buf, 0)
+	return buf.Bytes()
+}
+
+// SavePython saves the params to a Python file, as a list literal
+// assigned to a variable named params, e.g., for external analysis.
+func (pr *Sets) SavePython(filename gi.FileName) error {
+	fp, err := os.Create(string(filename))
+	if err != nil {
+		gi.PromptDialog(nil, gi.DlgOpts{Title: "Could not Save to File", Prompt: err.Error()}, true, false, nil, nil)
+		log.Println(err)
+		return err
+	}
+	defer fp.Close()
+	fp.Write([]byte("# File generated by params.SavePython\n\nparams = "))
+	pr.WritePython(fp, 0)
+	return nil
+}
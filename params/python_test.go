@@ -0,0 +1,78 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+var pyTrg = `[
+    {
+        "Name": "Base",
+        "Desc": "best",
+        "Sheets": {
+            "Network": [
+                {
+                    "Sel": "Layer",
+                    "Desc": "",
+                    "Params": {
+                        "Layer.Gi": 1.8,
+                        "Layer.Name": "a \"b\"",
+                        "Layer.On": True,
+                    },
+                },
+            ],
+        },
+    },
+]
+`
+
+type schemaLayer struct {
+	Gi float32 `min:"0" def:"1.8" desc:"inhibition"`
+	On bool
+}
+
+func TestExport(t *testing.T) {
+	ps := Sets{
+		{Name: "Base", Desc: "best", Sheets: Sheets{
+			"Network": &Sheet{
+				{Sel: "Layer", Params: Params{"Layer.Gi": "1.8", "Layer.On": "true", "Layer.Name": `a "b"`}},
+			},
+		}},
+	}
+	py := string(ps.StringPython())
+	if py != pyTrg {
+		t.Errorf("python output:\n%s", py)
+	}
+	if PyVal("1e-3") != "1e-3" || PyVal("NaN") != `"NaN"` || PyVal("Inf") != `"Inf"` || PyVal("-2") != "-2" {
+		t.Errorf("PyVal failed")
+	}
+
+	ps2 := Sets{
+		{Name: "Base", Sheets: Sheets{
+			"Network": &Sheet{
+				{Sel: "schemaLayer", Params: Params{"schemaLayer.Gi": "1.8", "schemaLayer.Foo": "1"}},
+			},
+		}},
+	}
+	var buf bytes.Buffer
+	if err := ps2.WriteJSONSchema(&buf, []interface{}{&schemaLayer{}}); err != nil {
+		t.Fatal(err)
+	}
+	var sc map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &sc); err != nil {
+		t.Fatal(err)
+	}
+	pprops := sc["items"].(map[string]interface{})["properties"].(map[string]interface{})["Sheets"].(map[string]interface{})["additionalProperties"].(map[string]interface{})["items"].(map[string]interface{})["properties"].(map[string]interface{})["Params"].(map[string]interface{})["properties"].(map[string]interface{})
+	gi := pprops["schemaLayer.Gi"].(map[string]interface{})
+	if gi["x-goType"] != "float32" || gi["x-min"] != "0" || gi["description"] != "inhibition" {
+		t.Errorf("Gi schema: %v", gi)
+	}
+	if _, has := pprops["schemaLayer.Foo"].(map[string]interface{})["x-goType"]; has {
+		t.Errorf("Foo should not have type: %v", pprops["schemaLayer.Foo"])
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/goki/ki/kit"
+)
+
+// SampleDists are the distributions that param values can be sampled from
+// in a RandSearch
+type SampleDists int32
+
+//go:generate stringer -type=SampleDists
+
+var KiT_SampleDists = kit.Enums.AddEnum(SampleDistsN, false, nil)
+
+func (ev SampleDists) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *SampleDists) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// The sampling distributions
+const (
+	// UniformDist samples uniformly between Min and Max
+	UniformDist SampleDists = iota
+
+	// LogUniformDist samples uniformly in the log between Min and Max (which
+	// must be > 0), e.g., for learning rates that span orders of magnitude
+	LogUniformDist
+
+	// ChoiceDist samples one of the Vals with equal probability
+	ChoiceDist
+
+	SampleDistsN
+)
+
+// RandParam is one parameter to sample in a RandSearch: a param path within
+// a given Sheet and Sel selector (see SearchParam, whose Vals are the choices
+// for ChoiceDist), and the distribution to sample values from.
+type RandParam struct {
+	SearchParam
+	Dist SampleDists `desc:"distribution to sample from"`
+	Min  float64     `desc:"minimum value for Uniform and LogUniform distributions"`
+	Max  float64     `desc:"maximum value for Uniform and LogUniform distributions"`
+}
+
+// Sample returns a value sampled from the distribution using given random source
+func (rp *RandParam) Sample(rnd *rand.Rand) string {
+	switch rp.Dist {
+	case LogUniformDist:
+		lmin := math.Log(rp.Min)
+		lmax := math.Log(rp.Max)
+		return strconv.FormatFloat(math.Exp(lmin+rnd.Float64()*(lmax-lmin)), 'g', 4, 64)
+	case ChoiceDist:
+		if len(rp.Vals) == 0 {
+			return ""
+		}
+		return rp.Vals[rnd.Intn(len(rp.Vals))]
+	default:
+		return strconv.FormatFloat(rp.Min+rnd.Float64()*(rp.Max-rp.Min), 'g', 4, 64)
+	}
+}
+
+// RandSearch is a random search over param values, each sampled from its own
+// distribution, generating a params.Set for each of N samples.  Each sample
+// uses its own random seed (Seed + sample index), so any given sample can be
+// regenerated exactly, independent of the others.
+type RandSearch struct {
+	Name    string        `desc:"name of the search, used as the prefix of the run and Set names"`
+	Params  []*RandParam  `desc:"params to sample"`
+	N       int           `desc:"number of samples"`
+	Seed    int64         `desc:"base random seed -- sample i uses Seed + i"`
+	Results SearchResults `desc:"results of each run, recorded by Run"`
+}
+
+// Add adds a param to sample from given distribution between min and max,
+// returning it
+func (sr *RandSearch) Add(sheet, sel, path string, dist SampleDists, min, max float64) *RandParam {
+	rp := &RandParam{SearchParam: SearchParam{Sheet: sheet, Sel: sel, Path: path}, Dist: dist, Min: min, Max: max}
+	sr.Params = append(sr.Params, rp)
+	return rp
+}
+
+// AddChoice adds a param to sample from among given values, returning it
+func (sr *RandSearch) AddChoice(sheet, sel, path string, vals ...string) *RandParam {
+	rp := &RandParam{SearchParam: SearchParam{Sheet: sheet, Sel: sel, Path: path, Vals: vals}, Dist: ChoiceDist}
+	sr.Params = append(sr.Params, rp)
+	return rp
+}
+
+// SampleSeed returns the random seed for given sample index
+func (sr *RandSearch) SampleSeed(idx int) int64 {
+	return sr.Seed + int64(idx)
+}
+
+// Vals returns the param values for given sample index in [0..N-1],
+// in order of the Params -- always the same for a given Seed and index.
+func (sr *RandSearch) Vals(idx int) []string {
+	rnd := rand.New(rand.NewSource(sr.SampleSeed(idx)))
+	vals := make([]string, len(sr.Params))
+	for pi, rp := range sr.Params {
+		vals[pi] = rp.Sample(rnd)
+	}
+	return vals
+}
+
+// RunName returns the name for given sample index, consisting of the
+// search Name, the sample index, and Label=Val for each param, separated by _
+func (sr *RandSearch) RunName(idx int) string {
+	vals := sr.Vals(idx)
+	nms := make([]string, 0, len(vals)+2)
+	if sr.Name != "" {
+		nms = append(nms, sr.Name)
+	}
+	nms = append(nms, fmt.Sprintf("%03d", idx))
+	for pi, rp := range sr.Params {
+		nms = append(nms, rp.Name()+"="+vals[pi])
+	}
+	return strings.Join(nms, "_")
+}
+
+// Set returns the params.Set for given sample index, named by RunName,
+// with each param in its Sheet and Sel (see Search.Set).
+// The description includes the random seed of the sample.
+func (sr *RandSearch) Set(idx int) *Set {
+	sps := make([]*SearchParam, len(sr.Params))
+	for pi, rp := range sr.Params {
+		sps[pi] = &rp.SearchParam
+	}
+	st := searchSet(sr.Name, sr.RunName(idx), sps, sr.Vals(idx))
+	st.Desc += fmt.Sprintf(" (seed: %d)", sr.SampleSeed(idx))
+	return st
+}
+
+// Sets returns the params.Sets for all of the N samples
+func (sr *RandSearch) Sets() Sets {
+	sts := make(Sets, sr.N)
+	for i := 0; i < sr.N; i++ {
+		sts[i] = sr.Set(i)
+	}
+	return sts
+}
+
+// Iter returns an iterator over the Sets for all of the N samples,
+// generating each one as needed (see SetIter)
+func (sr *RandSearch) Iter() *SetIter {
+	return &SetIter{Idx: -1, N: sr.N, setFun: sr.Set, valsFun: sr.Vals}
+}
+
+// Run calls given function sequentially for each of the N samples,
+// as in Search.Run, recording Results.
+func (sr *RandSearch) Run(fun func(idx int, set *Set) (map[string]float64, error)) error {
+	var err error
+	sr.Results, err = runSearch(sr.Name, sr.N, sr.Set, sr.Vals, fun)
+	return err
+}
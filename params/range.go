@@ -0,0 +1,123 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/goki/ki/kit"
+)
+
+// RangeChecks are the ways that numeric param values are checked against the
+// min:"val" and max:"val" struct field tags when set (see RangeCheck)
+type RangeChecks int32
+
+//go:generate stringer -type=RangeChecks
+
+var KiT_RangeChecks = kit.Enums.AddEnum(RangeChecksN, false, nil)
+
+func (ev RangeChecks) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *RangeChecks) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// The range checks
+const (
+	// RangeNoCheck does not check values against the min and max tags
+	RangeNoCheck RangeChecks = iota
+
+	// RangeWarn logs a warning for out-of-range values, and sets them anyway
+	RangeWarn
+
+	// RangeError returns an error for out-of-range values, which are not set
+	RangeError
+
+	// RangeClamp clamps out-of-range values to the min or max, with a warning
+	RangeClamp
+
+	RangeChecksN
+)
+
+// RangeCheck determines how numeric param values are checked against the
+// min:"val" and max:"val" struct field tags of the param field when set
+// by SetParam or any of the Apply methods.  Checking is opt-in: the default
+// is RangeNoCheck, and e.g., RangeWarn can be set at startup while
+// developing params.
+var RangeCheck = RangeNoCheck
+
+// DefRatio is the factor by which a param value can differ from all of the
+// values in the def:"val1[,val2...]" struct field tag of the param field
+// before a warning is logged when set (unless RangeCheck is RangeNoCheck),
+// to catch typos such as an extra zero, e.g., 10.  0 = no check (default),
+// as values far from the defaults are common for learning rates and gains.
+var DefRatio = 0.0
+
+// CheckRange checks given value for the param at given path against the min,
+// max, and def tags of given struct field for the param, according to RangeCheck
+// and DefRatio, returning the value to set (clamped if RangeClamp), and an error
+// if it is out of range and RangeCheck is RangeError.
+func CheckRange(sf *reflect.StructField, path string, val float64) (float64, error) {
+	if RangeCheck == RangeNoCheck || sf == nil {
+		return val, nil
+	}
+	if mn, ok := tagFloat(sf, "min"); ok && val < mn {
+		return rangeErr(path, val, "min", mn)
+	}
+	if mx, ok := tagFloat(sf, "max"); ok && val > mx {
+		return rangeErr(path, val, "max", mx)
+	}
+	if DefRatio > 0 && val != 0 {
+		if defs, ok := sf.Tag.Lookup("def"); ok && !defNear(defs, val) {
+			log.Printf("params.CheckRange: path: %v value: %v is more than %vx different from default value(s): %v\n", path, val, DefRatio, defs)
+		}
+	}
+	return val, nil
+}
+
+// tagFloat returns the value of given struct field tag as a float, if present
+func tagFloat(sf *reflect.StructField, tag string) (float64, bool) {
+	ts, ok := sf.Tag.Lookup(tag)
+	if !ok {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(ts), 64)
+	return v, err == nil
+}
+
+// defNear returns true if given value is within DefRatio of any of the
+// comma-separated default values, or if none of them can be compared
+func defNear(defs string, val float64) bool {
+	ncmp := 0
+	for _, ds := range strings.Split(defs, ",") {
+		dv, err := strconv.ParseFloat(strings.TrimSpace(ds), 64)
+		if err != nil || dv == 0 {
+			continue
+		}
+		ncmp++
+		r := val / dv
+		if r > 0 && r <= DefRatio && 1/r <= DefRatio {
+			return true
+		}
+	}
+	return ncmp == 0
+}
+
+// rangeErr handles an out-of-range value according to RangeCheck
+func rangeErr(path string, val float64, lim string, lv float64) (float64, error) {
+	switch RangeCheck {
+	case RangeError:
+		err := fmt.Errorf("params.CheckRange: path: %v value: %v is out of range -- %v: %v", path, val, lim, lv)
+		log.Println(err)
+		return val, err
+	case RangeClamp:
+		log.Printf("params.CheckRange: path: %v value: %v is out of range -- clamped to %v: %v\n", path, val, lim, lv)
+		return lv, nil
+	default:
+		log.Printf("params.CheckRange: path: %v value: %v is out of range -- %v: %v\n", path, val, lim, lv)
+		return val, nil
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"testing"
+)
+
+type rangeObj struct {
+	Gi    float32 `min:"0" max:"5" def:"1.8,2"`
+	Lrate float64 `min:"0" def:"0.04"`
+	NCyc  int     `min:"1"`
+}
+
+func TestRangeCheck(t *testing.T) {
+	defer func(rc RangeChecks, dr float64) { RangeCheck, DefRatio = rc, dr }(RangeCheck, DefRatio)
+	ob := &rangeObj{Gi: 1.8, Lrate: 0.04, NCyc: 100}
+
+	if RangeCheck != RangeNoCheck || DefRatio != 0 {
+		t.Errorf("range checks are not opt-in: %v %v", RangeCheck, DefRatio)
+	}
+	if err := SetParam(ob, "Gi", "-1"); err != nil || ob.Gi != -1 {
+		t.Errorf("no check: %v %v", err, ob.Gi)
+	}
+	DefRatio = 10
+	RangeCheck = RangeWarn
+	if err := SetParam(ob, "Gi", "18"); err != nil || ob.Gi != 18 {
+		t.Errorf("warn: %v %v", err, ob.Gi)
+	}
+	RangeCheck = RangeError
+	if err := SetParam(ob, "Gi", "-1"); err == nil || ob.Gi != 18 {
+		t.Errorf("error: %v %v", err, ob.Gi)
+	}
+	if err := SetParam(ob, "NCyc", "0"); err == nil || ob.NCyc != 100 {
+		t.Errorf("error int: %v %v", err, ob.NCyc)
+	}
+	if err := SetParam(ob, "Lrate", "0.4"); err != nil || ob.Lrate != 0.4 { // def only warns
+		t.Errorf("def: %v %v", err, ob.Lrate)
+	}
+	RangeCheck = RangeClamp
+	if err := SetParam(ob, "Gi", "18"); err != nil || ob.Gi != 5 {
+		t.Errorf("clamp: %v %v", err, ob.Gi)
+	}
+	if err := SetParam(ob, "Lrate", "-0.1"); err != nil || ob.Lrate != 0 {
+		t.Errorf("clamp min: %v %v", err, ob.Lrate)
+	}
+
+	if !defNear("1.8,2", 2.5) || defNear("1.8,2", 25) || defNear("0.04", -0.04) || !defNear("0", 5) {
+		t.Errorf("defNear failed")
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SchedPoint is a param value starting at a given counter value in a SchedParam
+type SchedPoint struct {
+	Ctr int     `desc:"counter value (e.g., epoch) at which this value applies"`
+	Val float64 `desc:"param value at this counter"`
+}
+
+// SchedParam is one param whose value changes according to a counter
+// (e.g., epoch) in a Schedule, specified by a list of points.
+// Before the first point, the first value is used, and after the last,
+// the last value is used.
+type SchedParam struct {
+	Sel    string       `desc:"selector for the param, e.g., Prjn or #Output"`
+	Path   string       `desc:"param path, e.g., Prjn.Learn.Lrate"`
+	Interp bool         `desc:"linearly interpolate between points -- otherwise each value holds until the counter of the next point (step function)"`
+	Points []SchedPoint `desc:"values at given counters, in increasing order of counter"`
+}
+
+// At adds a point with given value at given counter, keeping the points
+// in order, returning the param so calls can be chained, e.g.,
+// sc.Add("Prjn", "Prjn.Learn.Lrate", false).At(0, 0.04).At(50, 0.02).At(100, 0.01)
+func (sp *SchedParam) At(ctr int, val float64) *SchedParam {
+	pi := sort.Search(len(sp.Points), func(i int) bool { return sp.Points[i].Ctr >= ctr })
+	if pi < len(sp.Points) && sp.Points[pi].Ctr == ctr {
+		sp.Points[pi].Val = val
+		return sp
+	}
+	sp.Points = append(sp.Points, SchedPoint{})
+	copy(sp.Points[pi+1:], sp.Points[pi:])
+	sp.Points[pi] = SchedPoint{Ctr: ctr, Val: val}
+	return sp
+}
+
+// Val returns the value of the param at given counter
+func (sp *SchedParam) Val(ctr int) float64 {
+	np := len(sp.Points)
+	if np == 0 {
+		return 0
+	}
+	pi := sort.Search(np, func(i int) bool { return sp.Points[i].Ctr > ctr }) // first point after ctr
+	if pi == 0 {
+		return sp.Points[0].Val
+	}
+	if pi == np {
+		return sp.Points[np-1].Val
+	}
+	pp := sp.Points[pi-1]
+	if !sp.Interp {
+		return pp.Val
+	}
+	np1 := sp.Points[pi]
+	frac := float64(ctr-pp.Ctr) / float64(np1.Ctr-pp.Ctr)
+	return pp.Val + frac*(np1.Val-pp.Val)
+}
+
+// Schedule specifies param values that change over the course of training
+// as a function of a counter such as the epoch, e.g., learning rate decay or
+// annealing of inhibition.  Apply generates a Sheet with the values for a
+// given counter, typically applied at the start of each epoch after the
+// other params, e.g.:
+//
+//	sc := &params.Schedule{Name: "LrateDecay"}
+//	sc.Add("Prjn", "Prjn.Learn.Lrate", false).At(0, 0.04).At(50, 0.02).At(100, 0.01)
+//	ss.Net.ApplyParams(sc.Apply(epc), false)
+type Schedule struct {
+	Name   string        `desc:"name of the schedule"`
+	Params []*SchedParam `desc:"scheduled params"`
+}
+
+// Add adds a scheduled param for given selector and path, with step or
+// interpolated values, returning it for adding points with At.
+func (sc *Schedule) Add(sel, path string, interp bool) *SchedParam {
+	sp := &SchedParam{Sel: sel, Path: path, Interp: interp}
+	sc.Params = append(sc.Params, sp)
+	return sp
+}
+
+// Apply returns a Sheet with the values of all the scheduled params at
+// given counter (e.g., epoch).  Params with the same selector and target
+// type are combined into one Sel, in order of their first appearance.
+func (sc *Schedule) Apply(ctr int) *Sheet {
+	sh := &Sheet{}
+	sels := map[string]*Sel{}
+	for _, sp := range sc.Params {
+		key := sp.Sel + ":" + strings.Split(sp.Path, ".")[0]
+		sl, has := sels[key]
+		if !has {
+			sl = &Sel{Sel: sp.Sel, Desc: "schedule " + sc.Name + " at: " + strconv.Itoa(ctr), Params: Params{}}
+			sels[key] = sl
+			*sh = append(*sh, sl)
+		}
+		sl.Params[sp.Path] = strconv.FormatFloat(sp.Val(ctr), 'g', -1, 64)
+	}
+	return sh
+}
+
+// Changed returns true if any of the scheduled param values differ
+// between the two given counter values, e.g., to only apply the
+// schedule when something has changed.
+func (sc *Schedule) Changed(prv, ctr int) bool {
+	for _, sp := range sc.Params {
+		if sp.Val(prv) != sp.Val(ctr) {
+			return true
+		}
+	}
+	return false
+}
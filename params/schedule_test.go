@@ -0,0 +1,44 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"testing"
+)
+
+func TestSchedule(t *testing.T) {
+	sc := &Schedule{Name: "Test"}
+	lr := sc.Add("Prjn", "Prjn.Learn.Lrate", false).At(50, 0.02).At(0, 0.04).At(100, 0.01)
+	gi := sc.Add("Layer", "Layer.Inhib.Layer.Gi", true).At(10, 2).At(20, 1)
+	sc.Add("Prjn", "Prjn.WtScale.Rel", false).At(0, 0.5)
+
+	lrs := map[int]float64{-1: 0.04, 0: 0.04, 49: 0.04, 50: 0.02, 99: 0.02, 100: 0.01, 1000: 0.01}
+	for ctr, trg := range lrs {
+		if v := lr.Val(ctr); v != trg {
+			t.Errorf("lrate at: %d = %v not %v", ctr, v, trg)
+		}
+	}
+	gis := map[int]float64{0: 2, 10: 2, 15: 1.5, 20: 1, 30: 1}
+	for ctr, trg := range gis {
+		if v := gi.Val(ctr); v != trg {
+			t.Errorf("gi at: %d = %v not %v", ctr, v, trg)
+		}
+	}
+
+	sh := sc.Apply(15)
+	if len(*sh) != 2 {
+		t.Fatalf("sheet has %d sels, not 2", len(*sh))
+	}
+	ps := (*sh)[0]
+	if ps.Sel != "Prjn" || ps.Params["Prjn.Learn.Lrate"] != "0.04" || ps.Params["Prjn.WtScale.Rel"] != "0.5" {
+		t.Errorf("prjn sel: %v %v", ps.Sel, ps.Params)
+	}
+	if (*sh)[1].Params["Layer.Inhib.Layer.Gi"] != "1.5" {
+		t.Errorf("layer sel: %v", (*sh)[1].Params)
+	}
+	if sc.Changed(20, 30) || !sc.Changed(49, 50) {
+		t.Errorf("Changed failed")
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/ki/kit"
+)
+
+// ParamPaths returns all of the param paths set in any of the Sets,
+// in sorted order
+func (ps *Sets) ParamPaths() []string {
+	pm := map[string]bool{}
+	for _, st := range *ps {
+		for _, sh := range st.Sheets {
+			for _, sl := range *sh {
+				for pt := range sl.Params {
+					pm[pt] = true
+				}
+			}
+		}
+	}
+	paths := make([]string, 0, len(pm))
+	for pt := range pm {
+		paths = append(paths, pt)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// objTypeName returns the type name of given object as used for the target
+// type of params, using the Styler interface if available
+func objTypeName(obj interface{}) string {
+	if stylr, has := obj.(Styler); has {
+		return stylr.TypeName()
+	}
+	return kit.NonPtrType(reflect.TypeOf(obj)).Name()
+}
+
+// ParamSchema returns the JSON schema for a param at given path (including
+// the target type), documenting its type, description, and range from the
+// struct field of the param in the first of given objects having the target
+// type, if any.  Param values are always strings in the JSON format.
+func ParamSchema(path string, objs []interface{}) map[string]interface{} {
+	sc := map[string]interface{}{"type": "string"}
+	pe := strings.SplitN(path, ".", 2)
+	if len(pe) < 2 {
+		return sc
+	}
+	for _, obj := range objs {
+		if objTypeName(obj) != pe[0] {
+			continue
+		}
+		fld, sf, err := findParam(reflect.ValueOf(obj), pe[1])
+		if err != nil {
+			break
+		}
+		sc["x-goType"] = kit.NonPtrValue(fld).Type().String()
+		if ds, ok := sf.Tag.Lookup("desc"); ok {
+			sc["description"] = ds
+		}
+		for _, tag := range []string{"min", "max", "def"} {
+			if tv, ok := sf.Tag.Lookup(tag); ok {
+				sc["x-"+tag] = tv
+			}
+		}
+		break
+	}
+	return sc
+}
+
+// JSONSchema returns a JSON schema (as nested maps) for the JSON format of
+// these Sets (see SaveJSON), documenting all of the param paths that are set,
+// with their types, descriptions, and ranges obtained from given objects
+// (e.g., emer.ParamObjs for a network, and the Sim) where available.
+func (ps *Sets) JSONSchema(objs []interface{}) map[string]interface{} {
+	pprops := map[string]interface{}{}
+	for _, pt := range ps.ParamPaths() {
+		pprops[pt] = ParamSchema(pt, objs)
+	}
+	str := map[string]interface{}{"type": "string"}
+	hyper := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"Tune":  map[string]interface{}{"type": "boolean"},
+			"Prior": map[string]interface{}{"type": "string", "enum": sampleDistNames()},
+			"Min":   map[string]interface{}{"type": "number"},
+			"Max":   map[string]interface{}{"type": "number"},
+			"Sigma": map[string]interface{}{"type": "number"},
+			"Vals":  map[string]interface{}{"type": "array", "items": str},
+		},
+	}
+	sel := map[string]interface{}{
+		"type":     "object",
+		"required": []string{"Sel", "Params"},
+		"properties": map[string]interface{}{
+			"Sel":      map[string]interface{}{"type": "string", "description": "selector: .Class, #Name, or Type"},
+			"Desc":     str,
+			"Cond":     map[string]interface{}{"type": "string", "description": "condition on the target: path op value, combined with && and ||"},
+			"Priority": map[string]interface{}{"type": "integer", "description": "Sels are applied in order of increasing priority"},
+			"Params": map[string]interface{}{
+				"type":                 "object",
+				"properties":           pprops,
+				"additionalProperties": str,
+			},
+			"Hypers": map[string]interface{}{
+				"type":                 "object",
+				"additionalProperties": hyper,
+			},
+		},
+	}
+	set := map[string]interface{}{
+		"type":     "object",
+		"required": []string{"Name", "Sheets"},
+		"properties": map[string]interface{}{
+			"Name":    str,
+			"Desc":    str,
+			"Version": map[string]interface{}{"type": "integer"},
+			"Extends": str,
+			"Order":   map[string]interface{}{"type": "array", "items": str},
+			"Sheets": map[string]interface{}{
+				"type":                 "object",
+				"additionalProperties": map[string]interface{}{"type": "array", "items": sel},
+			},
+		},
+	}
+	return map[string]interface{}{
+		"$schema":     "http://json-schema.org/draft-07/schema#",
+		"title":       "params.Sets",
+		"description": "emergent params: list of named Sets of Sheets of Sels of param path = value",
+		"type":        "array",
+		"items":       set,
+	}
+}
+
+// sampleDistNames returns the names of the SampleDists
+func sampleDistNames() []string {
+	nms := make([]string, SampleDistsN)
+	for i := range nms {
+		nms[i] = SampleDists(i).String()
+	}
+	return nms
+}
+
+// WriteJSONSchema writes the JSON schema for these Sets (see JSONSchema)
+func (ps *Sets) WriteJSONSchema(w io.Writer, objs []interface{}) error {
+	b, err := json.MarshalIndent(ps.JSONSchema(objs), "", "  ")
+	if err != nil {
+		log.Println(err) // unlikely
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// SaveJSONSchema saves the JSON schema for these Sets (see JSONSchema)
+// to given file
+func (ps *Sets) SaveJSONSchema(filename gi.FileName, objs []interface{}) error {
+	b, err := json.MarshalIndent(ps.JSONSchema(objs), "", "  ")
+	if err != nil {
+		log.Println(err) // unlikely
+		return err
+	}
+	err = ioutil.WriteFile(string(filename), b, 0644)
+	if err != nil {
+		gi.PromptDialog(nil, gi.DlgOpts{Title: "Could not Save to File", Prompt: err.Error()}, true, false, nil, nil)
+		log.Println(err)
+	}
+	return err
+}
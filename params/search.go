@@ -0,0 +1,263 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SearchParam is one parameter to search over in a Search: a param path
+// within a given Sheet and Sel selector, and the list of values to try.
+type SearchParam struct {
+	Sheet string   `desc:"name of the Sheet that the param goes in, e.g., Network"`
+	Sel   string   `desc:"selector for the param, e.g., Prjn or #Output"`
+	Path  string   `desc:"param path, e.g., Layer.Inhib.Layer.Gi"`
+	Label string   `desc:"short label for the param used in run names -- if empty, the last element of the Path is used"`
+	Vals  []string `desc:"values to search over"`
+}
+
+// Name returns the label used for this param in run names
+func (sp *SearchParam) Name() string {
+	if sp.Label != "" {
+		return sp.Label
+	}
+	pe := strings.Split(sp.Path, ".")
+	return pe[len(pe)-1]
+}
+
+// RangeVals returns the list of values from min to max (inclusive) in
+// steps of given size, formatted as param value strings.
+func RangeVals(min, max, step float64) []string {
+	if step <= 0 {
+		return []string{strconv.FormatFloat(min, 'g', -1, 64)}
+	}
+	var vals []string
+	n := int((max-min)/step + 1e-9)
+	for i := 0; i <= n; i++ {
+		v := min + float64(i)*step
+		vals = append(vals, strconv.FormatFloat(v, 'g', 10, 64))
+	}
+	return vals
+}
+
+// SearchResult records the result of one run of a Search
+type SearchResult struct {
+	Idx   int                `desc:"index of the combination of param values in the search"`
+	Name  string             `desc:"run name (see Search.RunName)"`
+	Vals  []string           `desc:"the param values, in order of Search.Params"`
+	Stats map[string]float64 `desc:"result stats as returned by the run function"`
+	Err   error              `desc:"error returned by the run function, if any"`
+}
+
+// Search is a grid search over the full cross-product of the values of a list
+// of params, each of which generates a params.Set with the given combination
+// of values, typically applied after the Base set to explore variations.
+// The last param varies fastest across the combination indexes.
+type Search struct {
+	Name    string         `desc:"name of the search, used as the prefix of the run and Set names"`
+	Params  []*SearchParam `desc:"params to search over"`
+	Results SearchResults  `desc:"results of each run, recorded by Run"`
+}
+
+// Add adds a param to search over with given values, returning it
+func (sr *Search) Add(sheet, sel, path string, vals ...string) *SearchParam {
+	sp := &SearchParam{Sheet: sheet, Sel: sel, Path: path, Vals: vals}
+	sr.Params = append(sr.Params, sp)
+	return sp
+}
+
+// AddRange adds a param to search over with values from min to max
+// (inclusive) in steps of given size, returning it
+func (sr *Search) AddRange(sheet, sel, path string, min, max, step float64) *SearchParam {
+	return sr.Add(sheet, sel, path, RangeVals(min, max, step)...)
+}
+
+// N returns the total number of combinations of param values in the search
+func (sr *Search) N() int {
+	if len(sr.Params) == 0 {
+		return 0
+	}
+	n := 1
+	for _, sp := range sr.Params {
+		n *= len(sp.Vals)
+	}
+	return n
+}
+
+// Vals returns the param values for given combination index in [0..N-1],
+// in order of the Params
+func (sr *Search) Vals(idx int) []string {
+	vals := make([]string, len(sr.Params))
+	for pi := len(sr.Params) - 1; pi >= 0; pi-- {
+		sp := sr.Params[pi]
+		nv := len(sp.Vals)
+		if nv == 0 {
+			continue
+		}
+		vals[pi] = sp.Vals[idx%nv]
+		idx /= nv
+	}
+	return vals
+}
+
+// RunName returns the name for given combination index, consisting of the
+// search Name followed by Label=Val for each param, separated by _
+func (sr *Search) RunName(idx int) string {
+	vals := sr.Vals(idx)
+	nms := make([]string, 0, len(vals)+1)
+	if sr.Name != "" {
+		nms = append(nms, sr.Name)
+	}
+	for pi, sp := range sr.Params {
+		nms = append(nms, sp.Name()+"="+vals[pi])
+	}
+	return strings.Join(nms, "_")
+}
+
+// Set returns the params.Set for given combination index, named by RunName,
+// with each param in its Sheet and Sel (params with the same Sheet and Sel
+// are combined into one Sel).
+func (sr *Search) Set(idx int) *Set {
+	return searchSet(sr.Name, sr.RunName(idx), sr.Params, sr.Vals(idx))
+}
+
+// searchSet returns a params.Set with given name for given search params
+// and values, for search with given name
+func searchSet(srNm, name string, sps []*SearchParam, vals []string) *Set {
+	st := &Set{Name: name, Sheets: Sheets{}}
+	var desc []string
+	for pi, sp := range sps {
+		sht, ok := st.Sheets[sp.Sheet]
+		if !ok {
+			sht = &Sheet{}
+			st.Sheets[sp.Sheet] = sht
+		}
+		sel := sht.SelByName(sp.Sel)
+		if sel == nil {
+			sel = &Sel{Sel: sp.Sel, Desc: "generated by params search " + srNm, Params: Params{}}
+			*sht = append(*sht, sel)
+		}
+		sel.Params[sp.Path] = vals[pi]
+		desc = append(desc, fmt.Sprintf("%s %s = %s", sp.Sel, sp.Path, vals[pi]))
+	}
+	st.Desc = "search " + srNm + ": " + strings.Join(desc, ", ")
+	return st
+}
+
+// Sets returns the params.Sets for all of the combinations in the search
+func (sr *Search) Sets() Sets {
+	n := sr.N()
+	sts := make(Sets, n)
+	for i := 0; i < n; i++ {
+		sts[i] = sr.Set(i)
+	}
+	return sts
+}
+
+// Iter returns an iterator over the Sets for all of the combinations in the
+// search, generating each one as needed (see SetIter)
+func (sr *Search) Iter() *SetIter {
+	return &SetIter{Idx: -1, N: sr.N(), setFun: sr.Set, valsFun: sr.Vals}
+}
+
+// SetIter iterates over the Sets generated by a Search or RandSearch, which
+// are named by the param values (see RunName), so that logs and result files
+// are automatically distinct per configuration:
+//
+//	it := sr.Iter()
+//	for it.Next() {
+//		st := it.Set() // apply after the Base set and run, logging with st.Name
+//	}
+type SetIter struct {
+	Idx     int                    `desc:"index of the current Set -- -1 before the first call to Next"`
+	N       int                    `desc:"total number of Sets"`
+	setFun  func(idx int) *Set     `desc:"returns the Set for given index"`
+	valsFun func(idx int) []string `desc:"returns the values for given index"`
+	set     *Set                   `desc:"current Set"`
+}
+
+// Next advances to the next Set, returning false when there are no more
+func (it *SetIter) Next() bool {
+	if it.Idx+1 >= it.N {
+		it.set = nil
+		return false
+	}
+	it.Idx++
+	it.set = it.setFun(it.Idx)
+	return true
+}
+
+// Set returns the current Set
+func (it *SetIter) Set() *Set {
+	return it.set
+}
+
+// Name returns the name of the current Set, encoding its param values
+func (it *SetIter) Name() string {
+	return it.set.Name
+}
+
+// Vals returns the param values of the current Set, in order of the params
+func (it *SetIter) Vals() []string {
+	return it.valsFun(it.Idx)
+}
+
+// Run calls given function sequentially for each combination in the search,
+// with the combination index and generated Set, which the function should
+// apply (typically after the Base set) and run, returning any result stats
+// to record in Results (which is reset first).  Errors are logged and recorded
+// in the results, and do not stop the search -- the last error is returned.
+func (sr *Search) Run(fun func(idx int, set *Set) (map[string]float64, error)) error {
+	var err error
+	sr.Results, err = runSearch(sr.Name, sr.N(), sr.Set, sr.Vals, fun)
+	return err
+}
+
+// runSearch runs given function for n runs of search with given name,
+// using given functions to generate the Set and values for each run
+func runSearch(srNm string, n int, setFun func(idx int) *Set, valsFun func(idx int) []string, fun func(idx int, set *Set) (map[string]float64, error)) (SearchResults, error) {
+	res := make(SearchResults, 0, n)
+	var rerr error
+	for i := 0; i < n; i++ {
+		st := setFun(i)
+		stats, err := fun(i, st)
+		if err != nil {
+			err = fmt.Errorf("params search: %v run: %v error: %v", srNm, st.Name, err)
+			log.Println(err)
+			rerr = err
+		}
+		res = append(res, &SearchResult{Idx: i, Name: st.Name, Vals: valsFun(i), Stats: stats, Err: err})
+	}
+	return res, rerr
+}
+
+// SearchResults are the results of the runs of a search
+type SearchResults []*SearchResult
+
+// Sort returns the results without errors that have given stat,
+// sorted by that stat, in ascending order unless descending is true
+// (e.g., descending for a percent correct stat, so the best is first).
+func (rs SearchResults) Sort(stat string, descending bool) SearchResults {
+	var res SearchResults
+	for _, r := range rs {
+		if r.Err != nil {
+			continue
+		}
+		if _, ok := r.Stats[stat]; ok {
+			res = append(res, r)
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if descending {
+			return res[i].Stats[stat] > res[j].Stats[stat]
+		}
+		return res[i].Stats[stat] < res[j].Stats[stat]
+	})
+	return res
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	sr := &Search{Name: "Inhib"}
+	sr.Add("Network", "Layer", "Layer.Inhib.Layer.Gi", "1.6", "1.8", "2.0")
+	sr.AddRange("Network", ".Back", "Prjn.WtScale.Rel", 0.1, 0.3, 0.1)
+	if sr.N() != 9 {
+		t.Errorf("N: %d != 9", sr.N())
+	}
+	if nm := sr.RunName(4); nm != "Inhib_Gi=1.8_Rel=0.2" {
+		t.Errorf("RunName(4): %s", nm)
+	}
+	st := sr.Set(5)
+	sel := st.SheetByName("Network").SelByName(".Back")
+	if sel == nil || sel.Params["Prjn.WtScale.Rel"] != "0.3" {
+		t.Errorf("Set(5) .Back: %v", sel)
+	}
+	err := sr.Run(func(idx int, set *Set) (map[string]float64, error) {
+		return map[string]float64{"Err": float64(idx % 4)}, nil
+	})
+	if err != nil || len(sr.Results) != 9 {
+		t.Errorf("Run: %v results: %d", err, len(sr.Results))
+	}
+	srt := sr.Results.Sort("Err", false)
+	if srt[0].Stats["Err"] != 0 || srt[len(srt)-1].Stats["Err"] != 3 {
+		t.Errorf("Results.Sort: first: %v last: %v", srt[0].Stats, srt[len(srt)-1].Stats)
+	}
+}
+
+func TestRandSearch(t *testing.T) {
+	sr := &RandSearch{Name: "Rand", N: 20, Seed: 10}
+	sr.Add("Network", "Prjn", "Prjn.Learn.Lrate", LogUniformDist, 0.001, 0.1)
+	sr.AddChoice("Network", "Layer", "Layer.Inhib.Layer.Gi", "1.6", "1.8")
+	sts := sr.Sets()
+	if len(sts) != 20 {
+		t.Errorf("Sets: %d != 20", len(sts))
+	}
+	for i, st := range sts {
+		if st.Name != sr.Set(i).Name {
+			t.Errorf("sample: %d not reproducible: %s vs. %s", i, st.Name, sr.Set(i).Name)
+		}
+		lr, _ := strconv.ParseFloat(st.SheetByName("Network").SelByName("Prjn").Params["Prjn.Learn.Lrate"], 64)
+		if lr < 0.001 || lr > 0.1 {
+			t.Errorf("sample: %d Lrate out of range: %g", i, lr)
+		}
+	}
+}
+
+func TestSearchIter(t *testing.T) {
+	sr := &Search{}
+	sr.AddRange("Network", "Layer", "Layer.Inhib.Layer.Gi", 1.6, 2.0, 0.2)
+	sr.Add("Network", "Prjn", "Prjn.Learn.Lrate", "0.02", "0.04")
+	var nms []string
+	it := sr.Iter()
+	for it.Next() {
+		if it.Set().Name != it.Name() || it.Vals()[1] != (*it.Set().Sheets["Network"])[1].Params["Prjn.Learn.Lrate"] {
+			t.Errorf("iter: %d: %v %v", it.Idx, it.Name(), it.Vals())
+		}
+		nms = append(nms, it.Name())
+	}
+	if len(nms) != 6 || nms[3] != "Gi=1.8_Lrate=0.04" {
+		t.Errorf("names: %v", nms)
+	}
+	if it.Next() || it.Set() != nil {
+		t.Errorf("iter should be done")
+	}
+
+	rs := &RandSearch{N: 3}
+	rs.AddChoice("Network", "Layer", "Layer.Inhib.Layer.Gi", "1.8")
+	n := 0
+	for it := rs.Iter(); it.Next(); n++ {
+		if it.Name() != rs.RunName(it.Idx) {
+			t.Errorf("rand iter: %v", it.Name())
+		}
+	}
+	if n != 3 {
+		t.Errorf("rand iter: %d", n)
+	}
+}
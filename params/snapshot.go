@@ -0,0 +1,60 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/goki/ki/kit"
+)
+
+// Snapshot returns a Sheet with the current values of given param paths
+// (including the target type, e.g., Layer.Inhib.Layer.Gi) on given objects,
+// read by reflection -- the inverse of applying params, so that a hand-tuned
+// state can be captured exactly for re-running (see emer.SnapshotParams).
+// There is one #Name Sel per Styler object with the paths for its type (or a
+// Type Sel for other objects), in order of the objects.
+// Paths that are not found or are not param values are reported in the
+// error (logged), and skipped.
+func Snapshot(objs []interface{}, paths []string) (*Sheet, error) {
+	sh := &Sheet{}
+	var rerr error
+	for _, obj := range objs {
+		typ := kit.NonPtrType(reflect.TypeOf(obj)).Name()
+		sel := typ
+		if stylr, has := obj.(Styler); has {
+			typ = stylr.TypeName()
+			sel = "#" + stylr.Name()
+		}
+		var pars Params
+		for _, pt := range paths {
+			pe := Params{pt: ""}
+			if pe.TargetType() != typ {
+				continue
+			}
+			fld, _, err := findParam(reflect.ValueOf(obj), pe.Path(pt))
+			if err != nil {
+				rerr = err
+				continue
+			}
+			switch kit.NonPtrValue(fld).Kind() {
+			case reflect.Struct, reflect.Map, reflect.Ptr, reflect.Interface, reflect.Func, reflect.Chan:
+				rerr = fmt.Errorf("params.Snapshot: path: %v on: %v is not a param value", pt, objName(obj))
+				log.Println(rerr)
+				continue
+			}
+			if pars == nil {
+				pars = make(Params)
+			}
+			pars[pt] = paramValString(fld)
+		}
+		if pars != nil {
+			*sh = append(*sh, &Sel{Sel: sel, Desc: "snapshot of current values", Params: pars})
+		}
+	}
+	return sh, rerr
+}
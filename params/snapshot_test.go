@@ -0,0 +1,34 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	objs := []interface{}{&provLayer{Nm: "Hidden", Gi: 1.8}, &provLayer{Nm: "Output", Gi: 1.4}, &flexGain{Gain: 2}}
+	sh, err := Snapshot(objs, []string{"Layer.Gi", "flexGain.Gain", "Prjn.Learn.Lrate"})
+	if err != nil || len(*sh) != 3 {
+		t.Fatalf("snapshot: %v %v", err, len(*sh))
+	}
+	if sl := sh.SelByName("#Output"); sl == nil || sl.Params["Layer.Gi"] != "1.4" {
+		t.Errorf("#Output: %v", sl)
+	}
+	if sl := sh.SelByName("flexGain"); sl == nil || sl.Params["flexGain.Gain"] != "2" {
+		t.Errorf("flexGain: %v", sl)
+	}
+
+	nobjs := []interface{}{&provLayer{Nm: "Hidden"}, &provLayer{Nm: "Output"}}
+	for _, obj := range nobjs {
+		sh.Apply(obj, false)
+	}
+	if nobjs[0].(*provLayer).Gi != 1.8 || nobjs[1].(*provLayer).Gi != 1.4 {
+		t.Errorf("re-applied snapshot: %v %v", nobjs[0], nobjs[1])
+	}
+	if _, err := Snapshot(objs, []string{"Layer.None"}); err == nil {
+		t.Errorf("missing path should be an error")
+	}
+}
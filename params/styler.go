@@ -25,3 +25,25 @@ type Styler interface {
 	// unique.  Note, do not include the # prefix in the Styler name.
 	Name() string
 }
+
+// StylerAttrs is an optional interface for Styler objects to provide
+// additional attributes that selectors can match on, beyond the TypeName,
+// Class, and Name, using [Attr=Val] terms, e.g., Layer[Type=Hidden] or
+// [Shape=4D] (see SelMatch).  For example, layers can provide their layer
+// type and shape, and user-set tags (see emer.LayerStyleAttr and Attrs).
+type StylerAttrs interface {
+	// StyleAttr returns the value of given attribute, and false if the
+	// object does not have the attribute.  Multiple values (e.g., tags)
+	// are space-separated, and matched with [Attr~=Val] terms.
+	StyleAttr(attr string) (string, bool)
+}
+
+// Attrs is a map of attribute values that implements the StylerAttrs
+// interface, e.g., to embed in an object for user-set attributes and tags
+type Attrs map[string]string
+
+// StyleAttr returns the value of given attribute, satisfying StylerAttrs
+func (at Attrs) StyleAttr(attr string) (string, bool) {
+	v, has := at[attr]
+	return v, has
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+// SubstVars are user-supplied values for ${VAR} references in param values,
+// which take precedence over environment variables of the same name
+// (see SubstVal), e.g., set from per-job command-line args.
+var SubstVars = map[string]string{}
+
+// IsSubst returns true if the param value contains ${VAR} references
+func IsSubst(val string) bool {
+	return strings.Contains(val, "${")
+}
+
+// SubstVal returns the param value with each ${VAR} reference replaced by
+// the value of VAR in SubstVars, or else the environment variable VAR.
+// ${VAR:-def} uses def if VAR is not defined in either -- otherwise it is an
+// error (logged) for VAR to be undefined, so that params are not silently
+// set to empty values.  This is done automatically when params are applied,
+// so that the same params can be used across cluster jobs with per-job
+// settings, and Sets.Subst does it in place, e.g., after loading.
+func SubstVal(val string) (string, error) {
+	if !IsSubst(val) {
+		return val, nil
+	}
+	var b strings.Builder
+	rest := val
+	for {
+		st := strings.Index(rest, "${")
+		if st < 0 {
+			b.WriteString(rest)
+			break
+		}
+		b.WriteString(rest[:st])
+		ed := strings.Index(rest[st:], "}")
+		if ed < 0 {
+			err := fmt.Errorf("params.SubstVal: missing } in value: %v", val)
+			log.Println(err)
+			return val, err
+		}
+		ref := rest[st+2 : st+ed]
+		rest = rest[st+ed+1:]
+		nm := ref
+		def, hasDef := "", false
+		if di := strings.Index(ref, ":-"); di >= 0 {
+			nm, def, hasDef = ref[:di], ref[di+2:], true
+		}
+		if v, has := SubstVars[nm]; has {
+			b.WriteString(v)
+		} else if v, has := os.LookupEnv(nm); has {
+			b.WriteString(v)
+		} else if hasDef {
+			b.WriteString(def)
+		} else {
+			err := fmt.Errorf("params.SubstVal: variable: %v not defined in params.SubstVars or environment, in value: %v", nm, val)
+			log.Println(err)
+			return val, err
+		}
+	}
+	return b.String(), nil
+}
+
+// Subst replaces the ${VAR} references in all param values (see SubstVal),
+// returning the last error, if any
+func (pr *Params) Subst() error {
+	var rerr error
+	for pt, v := range *pr {
+		if !IsSubst(v) {
+			continue
+		}
+		sv, err := SubstVal(v)
+		if err != nil {
+			rerr = err
+			continue
+		}
+		(*pr)[pt] = sv
+	}
+	return rerr
+}
+
+// Subst replaces the ${VAR} references in all param values of the Sels
+// (see SubstVal), returning the last error, if any
+func (ps *Sheet) Subst() error {
+	var rerr error
+	for _, sl := range *ps {
+		if err := sl.Params.Subst(); err != nil {
+			rerr = err
+		}
+	}
+	return rerr
+}
+
+// Subst replaces the ${VAR} references in all param values of the Sheets
+// (see SubstVal), returning the last error, if any
+func (ps *Set) Subst() error {
+	var rerr error
+	for _, sh := range ps.Sheets {
+		if err := sh.Subst(); err != nil {
+			rerr = err
+		}
+	}
+	return rerr
+}
+
+// Subst replaces the ${VAR} references in all param values of the Sets
+// (see SubstVal), returning the last error, if any
+func (ps *Sets) Subst() error {
+	var rerr error
+	for _, st := range *ps {
+		if err := st.Subst(); err != nil {
+			rerr = err
+		}
+	}
+	return rerr
+}
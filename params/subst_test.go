@@ -0,0 +1,51 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSubstVal(t *testing.T) {
+	os.Setenv("PARAMS_TEST_GI", "1.6")
+	defer os.Unsetenv("PARAMS_TEST_GI")
+	SubstVars["Lrate"] = "0.02"
+	defer delete(SubstVars, "Lrate")
+
+	tests := []struct {
+		val, res string
+		err      bool
+	}{
+		{"1.8", "1.8", false},
+		{"${PARAMS_TEST_GI}", "1.6", false},
+		{"${Lrate}", "0.02", false},
+		{"=2*${Lrate}", "=2*0.02", false},
+		{"[${PARAMS_TEST_GI},${Lrate}]", "[1.6,0.02]", false},
+		{"${PARAMS_TEST_NONE:-0.5}", "0.5", false},
+		{"${PARAMS_TEST_GI:-0.5}", "1.6", false},
+		{"${PARAMS_TEST_NONE}", "", true},
+		{"${Lrate", "", true},
+	}
+	for _, ts := range tests {
+		res, err := SubstVal(ts.val)
+		if (err != nil) != ts.err {
+			t.Errorf("%q: error: %v", ts.val, err)
+			continue
+		}
+		if !ts.err && res != ts.res {
+			t.Errorf("%q: got %q, expected %q", ts.val, res, ts.res)
+		}
+	}
+
+	ly := &provLayer{Nm: "Hidden"}
+	if err := SetParam(ly, "Gi", "${PARAMS_TEST_GI}"); err != nil || ly.Gi != 1.6 {
+		t.Errorf("apply: %v err: %v", ly.Gi, err)
+	}
+	sts := Sets{{Name: "Base", Sheets: Sheets{"Network": &Sheet{{Sel: "Layer", Params: Params{"Layer.Gi": "${PARAMS_TEST_GI}"}}}}}}
+	if err := sts.Subst(); err != nil || (*sts[0].Sheets["Network"])[0].Params["Layer.Gi"] != "1.6" {
+		t.Errorf("Sets.Subst: %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/goki/gi/gi"
+)
+
+// TableRow is one row of a params documentation table (see Sets.Table):
+// one param in one Sel of one Sheet of one Set
+type TableRow struct {
+	Set   string `desc:"name of the Set"`
+	Sheet string `desc:"name of the Sheet"`
+	Sel   string `desc:"selector of the Sel, with its Cond condition if any"`
+	Path  string `desc:"param path"`
+	Val   string `desc:"param value"`
+	Desc  string `desc:"description of the Sel"`
+	Hyper string `desc:"hyperparameter search notes for the param, if any (see Hyper.Note)"`
+}
+
+// TableHeaders are the column headers of the params documentation table
+var TableHeaders = []string{"Set", "Sheet", "Sel", "Path", "Value", "Desc", "Hyper"}
+
+// strings returns the row as a list of strings, in order of TableHeaders
+func (tr *TableRow) strings() []string {
+	return []string{tr.Set, tr.Sheet, tr.Sel, tr.Path, tr.Val, tr.Desc, tr.Hyper}
+}
+
+// Note returns a short description of the hyperparameter search settings,
+// e.g., "tune LogUniformDist [0.001, 0.1]", for documentation
+func (h *Hyper) Note() string {
+	var nt string
+	if h.Tune {
+		nt = "tune "
+	}
+	if h.Prior == ChoiceDist {
+		nt += fmt.Sprintf("%v {%s}", h.Prior, strings.Join(h.Vals, ", "))
+	} else {
+		nt += fmt.Sprintf("%v [%g, %g]", h.Prior, h.Min, h.Max)
+	}
+	if h.Sigma != 0 {
+		nt += fmt.Sprintf(" sigma %g", h.Sigma)
+	}
+	return nt
+}
+
+// Table returns a documentation table of every param in the Sets: one row
+// per param, with Sets in order, Sheets sorted by name, Sels in order, and
+// params sorted by path, e.g., for an auto-generated parameter appendix
+// (see WriteMarkdown, WriteCSV)
+func (ps *Sets) Table() []TableRow {
+	var rows []TableRow
+	for _, st := range *ps {
+		snms := make([]string, 0, len(st.Sheets))
+		for snm := range st.Sheets {
+			snms = append(snms, snm)
+		}
+		sort.Strings(snms)
+		for _, snm := range snms {
+			for _, sl := range *st.Sheets[snm] {
+				paths := make([]string, 0, len(sl.Params))
+				for pt := range sl.Params {
+					paths = append(paths, pt)
+				}
+				sort.Strings(paths)
+				for _, pt := range paths {
+					tr := TableRow{Set: st.Name, Sheet: snm, Sel: sl.selKey(), Path: pt, Val: sl.Params[pt], Desc: sl.Desc}
+					if h, has := sl.Hypers[pt]; has {
+						tr.Hyper = h.Note()
+					}
+					rows = append(rows, tr)
+				}
+			}
+		}
+	}
+	return rows
+}
+
+// mdCell returns the string escaped for use in a markdown table cell
+func mdCell(s string) string {
+	s = strings.Replace(s, "|", "\\|", -1)
+	return strings.Replace(s, "\n", " ", -1)
+}
+
+// WriteMarkdown writes the documentation table of every param in the Sets
+// (see Table) as a markdown table
+func (ps *Sets) WriteMarkdown(w io.Writer) {
+	w.Write([]byte("| " + strings.Join(TableHeaders, " | ") + " |\n"))
+	w.Write([]byte(strings.Repeat("| --- ", len(TableHeaders)) + "|\n"))
+	for _, tr := range ps.Table() {
+		cells := tr.strings()
+		for i, c := range cells {
+			cells[i] = mdCell(c)
+		}
+		w.Write([]byte("| " + strings.Join(cells, " | ") + " |\n"))
+	}
+}
+
+// WriteCSV writes the documentation table of every param in the Sets
+// (see Table) in CSV format, with a header row
+func (ps *Sets) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write(TableHeaders)
+	for _, tr := range ps.Table() {
+		cw.Write(tr.strings())
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		log.Println(err)
+		return err
+	}
+	return nil
+}
+
+// SaveMarkdown saves the documentation table of every param in the Sets
+// as a markdown table, e.g., for a paper or wiki parameter appendix
+func (ps *Sets) SaveMarkdown(filename gi.FileName) error {
+	fp, err := os.Create(string(filename))
+	if err != nil {
+		gi.PromptDialog(nil, gi.DlgOpts{Title: "Could not Save to File", Prompt: err.Error()}, true, false, nil, nil)
+		log.Println(err)
+		return err
+	}
+	defer fp.Close()
+	ps.WriteMarkdown(fp)
+	return nil
+}
+
+// SaveCSV saves the documentation table of every param in the Sets
+// in CSV format, e.g., for a spreadsheet
+func (ps *Sets) SaveCSV(filename gi.FileName) error {
+	fp, err := os.Create(string(filename))
+	if err != nil {
+		gi.PromptDialog(nil, gi.DlgOpts{Title: "Could not Save to File", Prompt: err.Error()}, true, false, nil, nil)
+		log.Println(err)
+		return err
+	}
+	defer fp.Close()
+	return ps.WriteCSV(fp)
+}
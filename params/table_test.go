@@ -0,0 +1,41 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTable(t *testing.T) {
+	sts := Sets{
+		{Name: "Base", Sheets: Sheets{
+			"Sim": &Sheet{{Sel: "Sim", Desc: "sim", Params: Params{"Sim.MaxEpcs": "100"}}},
+			"Network": &Sheet{
+				{Sel: "Layer", Desc: "all layers | general", Params: Params{"Layer.Inhib.Layer.Gi": "1.8", "Layer.Act.Gbar.L": "0.2"},
+					Hypers: Hypers{"Layer.Inhib.Layer.Gi": {Tune: true, Prior: UniformDist, Min: 1, Max: 3}}},
+			},
+		}},
+	}
+	rows := sts.Table()
+	if len(rows) != 3 || rows[0].Sheet != "Network" || rows[0].Path != "Layer.Act.Gbar.L" || rows[2].Sheet != "Sim" {
+		t.Fatalf("rows: %v", rows)
+	}
+	if rows[1].Hyper != "tune UniformDist [1, 3]" {
+		t.Errorf("hyper note: %q", rows[1].Hyper)
+	}
+	var b bytes.Buffer
+	sts.WriteMarkdown(&b)
+	md := b.String()
+	if !strings.Contains(md, "| Base | Network | Layer | Layer.Inhib.Layer.Gi | 1.8 | all layers \\| general | tune UniformDist [1, 3] |") {
+		t.Errorf("markdown:\n%s", md)
+	}
+	b.Reset()
+	sts.WriteCSV(&b)
+	if lns := strings.Split(strings.TrimSpace(b.String()), "\n"); len(lns) != 4 || lns[0] != "Set,Sheet,Sel,Path,Value,Desc,Hyper" {
+		t.Errorf("csv:\n%s", b.String())
+	}
+}
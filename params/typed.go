@@ -0,0 +1,240 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FloatsVal returns given list of numbers as a param value string: [v1,v2,...]
+func FloatsVal(vals []float64) string {
+	ss := make([]string, len(vals))
+	for i, v := range vals {
+		ss[i] = strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	return "[" + strings.Join(ss, ",") + "]"
+}
+
+// ParseFloats parses a list of numbers from a param value string,
+// either as [v1,v2,...] or separated by spaces and / or commas.
+func ParseFloats(val string) ([]float64, error) {
+	val = strings.TrimSpace(val)
+	val = strings.TrimSuffix(strings.TrimPrefix(val, "["), "]")
+	flds := strings.FieldsFunc(val, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+	vals := make([]float64, len(flds))
+	for i, fs := range flds {
+		v, err := strconv.ParseFloat(fs, 64)
+		if err != nil {
+			return nil, fmt.Errorf("params.ParseFloats: value: %q: %v", val, err)
+		}
+		vals[i] = v
+	}
+	return vals, nil
+}
+
+// SetFloat sets the value at given path to given number
+func (pr *Params) SetFloat(path string, val float64) {
+	(*pr)[path] = strconv.FormatFloat(val, 'g', -1, 64)
+}
+
+// SetInt sets the value at given path to given integer
+func (pr *Params) SetInt(path string, val int) {
+	(*pr)[path] = strconv.Itoa(val)
+}
+
+// SetBool sets the value at given path to given bool
+func (pr *Params) SetBool(path string, val bool) {
+	(*pr)[path] = strconv.FormatBool(val)
+}
+
+// SetFloats sets the value at given path to given list of numbers
+func (pr *Params) SetFloats(path string, vals []float64) {
+	(*pr)[path] = FloatsVal(vals)
+}
+
+// paramValTry returns the value at given path, or an error if not found
+func (pr *Params) paramValTry(path string) (string, error) {
+	val, ok := (*pr)[path]
+	if !ok {
+		return "", fmt.Errorf("params.Params: path: %v not found", path)
+	}
+	return val, nil
+}
+
+// Float returns the value at given path as a number, with an error
+// if not found or not a number
+func (pr *Params) Float(path string) (float64, error) {
+	val, err := pr.paramValTry(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(val, 64)
+}
+
+// Int returns the value at given path as an integer, with an error
+// if not found or not an integer
+func (pr *Params) Int(path string) (int, error) {
+	val, err := pr.paramValTry(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(val)
+}
+
+// Bool returns the value at given path as a bool, with an error
+// if not found or not a bool
+func (pr *Params) Bool(path string) (bool, error) {
+	val, err := pr.paramValTry(path)
+	if err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(val)
+}
+
+// Floats returns the value at given path as a list of numbers, with an
+// error if not found or not a list of numbers
+func (pr *Params) Floats(path string) ([]float64, error) {
+	val, err := pr.paramValTry(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFloats(val)
+}
+
+// typedJSON returns the JSON encoding of given param value as a typed
+// value (number, bool, or list of numbers) if it is in the canonical
+// format for one, and false otherwise
+func typedJSON(val string) ([]byte, bool) {
+	if val == "" || strings.TrimSpace(val) != val {
+		return nil, false
+	}
+	if val == "true" || val == "false" {
+		return []byte(val), true
+	}
+	if val[0] == '[' {
+		var fs []float64
+		if json.Unmarshal([]byte(val), &fs) != nil {
+			return nil, false
+		}
+		return []byte(val), true
+	}
+	if _, err := strconv.ParseFloat(val, 64); err != nil || !json.Valid([]byte(val)) {
+		return nil, false
+	}
+	return []byte(val), true
+}
+
+// MarshalJSON writes values that are numbers, bools, or lists of numbers as
+// typed JSON values, and everything else as strings, in sorted path order
+func (pr Params) MarshalJSON() ([]byte, error) {
+	paths := make([]string, 0, len(pr))
+	for pt := range pr {
+		paths = append(paths, pt)
+	}
+	sort.Strings(paths)
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, pt := range paths {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		kb, _ := json.Marshal(pt)
+		b.Write(kb)
+		b.WriteByte(':')
+		val := pr[pt]
+		if tb, ok := typedJSON(val); ok {
+			b.Write(tb)
+		} else {
+			vb, _ := json.Marshal(val)
+			b.Write(vb)
+		}
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// UnmarshalJSON reads values as either strings or typed JSON values
+// (numbers, bools, lists of numbers), which are stored in canonical format
+func (pr *Params) UnmarshalJSON(b []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if *pr == nil {
+		*pr = make(Params, len(raw))
+	}
+	for pt, rv := range raw {
+		rv = bytes.TrimSpace(rv)
+		if len(rv) > 0 && rv[0] == '"' {
+			var s string
+			if err := json.Unmarshal(rv, &s); err != nil {
+				return err
+			}
+			(*pr)[pt] = s
+			continue
+		}
+		if len(rv) > 0 && rv[0] == '[' {
+			var fs []float64
+			if err := json.Unmarshal(rv, &fs); err != nil {
+				return fmt.Errorf("params.Params: path: %v value: %s must be a string, number, bool, or list of numbers", pt, rv)
+			}
+			(*pr)[pt] = FloatsVal(fs)
+			continue
+		}
+		if len(rv) > 0 && rv[0] == '{' {
+			return fmt.Errorf("params.Params: path: %v value: %s must be a string, number, bool, or list of numbers", pt, rv)
+		}
+		var cb bytes.Buffer
+		if err := json.Compact(&cb, rv); err != nil {
+			return err
+		}
+		(*pr)[pt] = cb.String()
+	}
+	return nil
+}
+
+// setParamSlice sets the slice param field to the list of numbers in
+// given value (see ParseFloats), checking each against the struct field tags
+func setParamSlice(npf reflect.Value, sf *reflect.StructField, path string, val string) error {
+	ek := npf.Type().Elem().Kind()
+	switch ek {
+	case reflect.Float64, reflect.Float32, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+	default:
+		err := fmt.Errorf("params.SetParam: slice field must be of numbers. value: %v, elem kind: %v, path: %v", val, ek, path)
+		log.Println(err)
+		return err
+	}
+	vals, err := ParseFloats(val)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	sl := reflect.MakeSlice(npf.Type(), len(vals), len(vals))
+	for i, v := range vals {
+		v, err = CheckRange(sf, path, v)
+		if err != nil {
+			return err
+		}
+		if ek == reflect.Float64 || ek == reflect.Float32 {
+			sl.Index(i).SetFloat(v)
+		} else {
+			if v != float64(int64(v)) {
+				err := fmt.Errorf("params.SetParam: value: %v in: %v is not an integer, path: %v", v, val, path)
+				log.Println(err)
+				return err
+			}
+			sl.Index(i).SetInt(int64(v))
+		}
+	}
+	npf.Set(sl)
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type typedObj struct {
+	Gain  float32
+	Dims  []int
+	Wts   []float32
+	On    bool
+	Label string
+}
+
+func TestTyped(t *testing.T) {
+	pr := Params{}
+	pr.SetFloat("typedObj.Gain", 2.5)
+	pr.SetFloats("typedObj.Wts", []float64{0.1, 0.2})
+	pr.SetBool("typedObj.On", true)
+	pr["typedObj.Dims"] = "4 4"
+	pr["typedObj.Label"] = "10"
+	if v, err := pr.Float("typedObj.Gain"); err != nil || v != 2.5 {
+		t.Errorf("Float: %v %v", v, err)
+	}
+	if v, err := pr.Floats("typedObj.Dims"); err != nil || len(v) != 2 || v[1] != 4 {
+		t.Errorf("Floats: %v %v", v, err)
+	}
+	if _, err := pr.Int("typedObj.Gain"); err == nil {
+		t.Errorf("Int of float should be an error")
+	}
+	if _, err := pr.Bool("typedObj.Foo"); err == nil {
+		t.Errorf("missing path should be an error")
+	}
+
+	b, err := json.Marshal(pr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trg := `{"typedObj.Dims":"4 4","typedObj.Gain":2.5,"typedObj.Label":10,"typedObj.On":true,"typedObj.Wts":[0.1,0.2]}`
+	if string(b) != trg {
+		t.Errorf("json:\n%s\nnot:\n%s", b, trg)
+	}
+	var rp Params
+	if err := json.Unmarshal([]byte(`{"a.X": "0.5", "a.Y": 1e-3, "a.Z": [1, 2.5], "a.B": false}`), &rp); err != nil {
+		t.Fatal(err)
+	}
+	if rp["a.X"] != "0.5" || rp["a.Y"] != "1e-3" || rp["a.Z"] != "[1,2.5]" || rp["a.B"] != "false" {
+		t.Errorf("unmarshal: %v", rp)
+	}
+	if err := json.Unmarshal([]byte(`{"a.X": {"b": 1}}`), &rp); err == nil {
+		t.Errorf("object value should be an error")
+	}
+
+	ob := &typedObj{}
+	if _, err := (&Sel{Sel: "typedObj", Params: pr}).Apply(ob, false); err != nil {
+		t.Fatal(err)
+	}
+	if ob.Gain != 2.5 || len(ob.Dims) != 2 || ob.Dims[0] != 4 || len(ob.Wts) != 2 || ob.Wts[1] != 0.2 || !ob.On || ob.Label != "10" {
+		t.Errorf("apply: %+v", ob)
+	}
+	if err := SetParam(ob, "Dims", "[1.5]"); err == nil {
+		t.Errorf("non-integer in int slice should be an error")
+	}
+	if err := SetParam(ob, "Gain", "[1,2]"); err == nil {
+		t.Errorf("list for float should be an error")
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Unit is a unit that param values can be specified in, with a suffix
+// (e.g., 10ms), which is converted to the unit of the field when applied
+type Unit struct {
+	Dim   string  `desc:"dimension of the unit, e.g., time -- units can only be converted to others of the same dimension, and empty is dimensionless (e.g., %)"`
+	Scale float64 `desc:"scale of the unit relative to the base unit of the dimension (scale 1)"`
+}
+
+// Units are the units that param values can be specified in, by suffix.
+// Values with these suffixes are converted when applied to the unit of
+// the field given by its unit:"suffix" struct field tag, or to the base
+// unit of the dimension (scale 1) if it has none -- the base units follow
+// emergent conventions (ms for time, Hz for rate, mV for voltage).
+// Use AddUnit to add more.
+var Units = map[string]Unit{
+	"%":   {"", 0.01},
+	"us":  {"time", 0.001},
+	"ms":  {"time", 1},
+	"s":   {"time", 1000},
+	"Hz":  {"rate", 1},
+	"kHz": {"rate", 1000},
+	"uV":  {"voltage", 0.001},
+	"mV":  {"voltage", 1},
+	"V":   {"voltage", 1000},
+}
+
+// AddUnit adds a unit with given suffix, dimension, and scale relative to
+// the base unit of the dimension, to those that param values can use
+func AddUnit(suffix, dim string, scale float64) {
+	Units[suffix] = Unit{Dim: dim, Scale: scale}
+}
+
+// splitUnit splits the value into a number and a unit suffix, returning
+// false if it is not a number with one of the Units suffixes
+func splitUnit(val string) (float64, string, bool) {
+	val = strings.TrimSpace(val)
+	sfx := ""
+	for us := range Units {
+		if len(us) > len(sfx) && strings.HasSuffix(val, us) {
+			if _, err := strconv.ParseFloat(strings.TrimSpace(val[:len(val)-len(us)]), 64); err == nil {
+				sfx = us
+			}
+		}
+	}
+	if sfx == "" {
+		return 0, "", false
+	}
+	v, _ := strconv.ParseFloat(strings.TrimSpace(val[:len(val)-len(sfx)]), 64)
+	return v, sfx, true
+}
+
+// IsUnitVal returns true if the param value is a number with a unit suffix
+// (see Units)
+func IsUnitVal(val string) bool {
+	_, _, ok := splitUnit(val)
+	return ok
+}
+
+// UnitVal converts a param value with a unit suffix (see Units), e.g.,
+// 10ms or 5%, to a number in the unit of given struct field (from its
+// unit:"suffix" tag, or the base unit of the dimension -- sf can be nil),
+// returning the value unchanged if it has no unit suffix.  Returns an
+// error (logged) if the units have different dimensions, e.g., 10ms for
+// a field with unit:"mV".
+func UnitVal(val string, sf *reflect.StructField, path string) (string, error) {
+	v, sfx, ok := splitUnit(val)
+	if !ok {
+		return val, nil
+	}
+	u := Units[sfx]
+	fu := Unit{Dim: u.Dim, Scale: 1}
+	if sf != nil {
+		if fus, has := sf.Tag.Lookup("unit"); has && fus != "" {
+			tu, ok := Units[fus]
+			if !ok {
+				err := fmt.Errorf("params.UnitVal: path: %v unit tag: %v is not in params.Units", path, fus)
+				log.Println(err)
+				return val, err
+			}
+			fu = tu
+		}
+	}
+	if u.Dim != fu.Dim {
+		err := fmt.Errorf("params.UnitVal: path: %v value: %v is in %v units, but the param is in %v units", path, val, dimName(u.Dim), dimName(fu.Dim))
+		log.Println(err)
+		return val, err
+	}
+	return strconv.FormatFloat(v*u.Scale/fu.Scale, 'g', -1, 64), nil
+}
+
+// dimName returns the name of the dimension, for messages
+func dimName(dim string) string {
+	if dim == "" {
+		return "dimensionless"
+	}
+	return dim
+}
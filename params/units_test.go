@@ -0,0 +1,48 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"testing"
+)
+
+type unitParams struct {
+	Tau   float32 `unit:"ms"`
+	TauS  float64 `unit:"s"`
+	Dt    float32
+	Rate  float32 `unit:"Hz"`
+	Cyc   int     `unit:"ms"`
+	Thr   float32 `unit:"mV"`
+	Label string
+}
+
+func TestUnits(t *testing.T) {
+	up := &unitParams{}
+	pars := Params{
+		"unitParams.Tau":   "0.02s",
+		"unitParams.TauS":  "250ms",
+		"unitParams.Dt":    "5%",
+		"unitParams.Rate":  "1.5kHz",
+		"unitParams.Cyc":   "1s",
+		"unitParams.Label": "10ms",
+	}
+	if err := pars.Apply(up, false); err != nil {
+		t.Error(err)
+	}
+	if up.Tau != 20 || up.TauS != 0.25 || up.Dt != 0.05 || up.Rate != 1500 || up.Cyc != 1000 || up.Label != "10ms" {
+		t.Errorf("converted: %+v", *up)
+	}
+	if err := SetParam(up, "Thr", "10ms"); err == nil {
+		t.Errorf("mismatched dimensions should be an error")
+	}
+	if err := SetParam(up, "Dt", "1e-3"); err != nil || up.Dt != 0.001 {
+		t.Errorf("plain value: %v %v", up.Dt, err)
+	}
+	AddUnit("min", "time", 60000)
+	defer delete(Units, "min")
+	if v, err := UnitVal("2min", nil, "Tau"); err != nil || v != "120000" {
+		t.Errorf("added unit: %v %v", v, err)
+	}
+}
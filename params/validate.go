@@ -0,0 +1,136 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/ki/kit"
+)
+
+// ParamChange is a change in the value of a parameter on a given object
+// that would result from applying params -- see Sheet.Validate
+type ParamChange struct {
+	Obj  string `desc:"name of the object"`
+	Sel  string `desc:"selector of the Sel that sets the param"`
+	Path string `desc:"param path, including the target type"`
+	Old  string `desc:"value before applying the param"`
+	New  string `desc:"value after applying the param"`
+}
+
+// String returns a one-line description of the change
+func (pc *ParamChange) String() string {
+	return fmt.Sprintf("%s: %s: %s = %s -> %s", pc.Obj, pc.Sel, pc.Path, pc.Old, pc.New)
+}
+
+// ValidateReport is the result of a dry-run application of params
+// to a set of objects -- see Sheet.Validate
+type ValidateReport struct {
+	Unmatched []string      `desc:"selectors (with target type) of the Sels that did not match any of the objects -- typically a typo"`
+	Failed    []string      `desc:"params that would fail to be set, with the object, path, value, and error"`
+	Changes   []ParamChange `desc:"params that would change value, in order of application"`
+	NSame     int           `desc:"number of params that would be set to the value they already have"`
+}
+
+// OK returns true if all Sels matched and no params failed
+func (vr *ValidateReport) OK() bool {
+	return len(vr.Unmatched) == 0 && len(vr.Failed) == 0
+}
+
+// String returns a multi-line report
+func (vr *ValidateReport) String() string {
+	var b strings.Builder
+	if len(vr.Unmatched) > 0 {
+		b.WriteString("Unmatched Sels (did not match any object):\n")
+		for _, us := range vr.Unmatched {
+			fmt.Fprintf(&b, "\t%s\n", us)
+		}
+	}
+	if len(vr.Failed) > 0 {
+		b.WriteString("Failed params:\n")
+		for _, fs := range vr.Failed {
+			fmt.Fprintf(&b, "\t%s\n", fs)
+		}
+	}
+	fmt.Fprintf(&b, "Changes: %d  (same value: %d)\n", len(vr.Changes), vr.NSame)
+	for _, pc := range vr.Changes {
+		fmt.Fprintf(&b, "\t%s\n", pc.String())
+	}
+	return b.String()
+}
+
+// objName returns the name of given object, using Styler or gi.Labeler interfaces
+func objName(obj interface{}) string {
+	if stylr, has := obj.(Styler); has {
+		return stylr.Name()
+	} else if lblr, has := obj.(gi.Labeler); has {
+		return lblr.Label()
+	}
+	return ""
+}
+
+// Validate does a dry-run application of this sheet to given objects (e.g., all
+// the layers and projections of a network -- see emer.ValidateParams), in order,
+// without setting anything, and reports which Sels matched no objects, which
+// params would fail to be set, and what values would change.  Subsequent Sels
+// setting the same param on the same object see the earlier values, as in Apply.
+func (ps *Sheet) Validate(objs []interface{}) *ValidateReport {
+	vr := &ValidateReport{}
+	pend := make([]map[string]reflect.Value, len(objs))
+	for _, sl := range ps.Ordered() {
+		matched := false
+		pts := make([]string, 0, len(sl.Params))
+		for pt := range sl.Params {
+			pts = append(pts, pt)
+		}
+		sort.Strings(pts)
+		for oi, obj := range objs {
+			if !sl.Matches(obj) {
+				continue
+			}
+			matched = true
+			onm := objName(obj)
+			for _, pt := range pts {
+				v := sl.Params[pt]
+				path := sl.Params.Path(pt)
+				fld, sf, err := findParam(reflect.ValueOf(obj), path)
+				if err != nil {
+					vr.Failed = append(vr.Failed, fmt.Sprintf("%s: %s: %s = %s: %v", onm, sl.Sel, pt, v, err))
+					continue
+				}
+				cur := kit.NonPtrValue(fld)
+				if !cur.CanInterface() {
+					vr.Failed = append(vr.Failed, fmt.Sprintf("%s: %s: %s = %s: field is not exported", onm, sl.Sel, pt, v))
+					continue
+				}
+				nw := reflect.New(cur.Type())
+				if err := setParamVal(obj, nw, sf, path, v); err != nil {
+					vr.Failed = append(vr.Failed, fmt.Sprintf("%s: %s: %s = %s: %v", onm, sl.Sel, pt, v, err))
+					continue
+				}
+				if pend[oi] == nil {
+					pend[oi] = make(map[string]reflect.Value)
+				}
+				if pv, ok := pend[oi][path]; ok {
+					cur = pv
+				}
+				if reflect.DeepEqual(cur.Interface(), nw.Elem().Interface()) {
+					vr.NSame++
+				} else {
+					vr.Changes = append(vr.Changes, ParamChange{Obj: onm, Sel: sl.Sel, Path: pt, Old: fmt.Sprintf("%v", cur.Interface()), New: fmt.Sprintf("%v", nw.Elem().Interface())})
+				}
+				pend[oi][path] = nw.Elem()
+			}
+		}
+		if !matched {
+			vr.Unmatched = append(vr.Unmatched, fmt.Sprintf("%s (%s)", sl.Sel, sl.Params.TargetType()))
+		}
+	}
+	return vr
+}
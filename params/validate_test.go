@@ -0,0 +1,50 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"testing"
+)
+
+type tstInhib struct {
+	Gi float32
+	On bool
+}
+
+type tstLayer struct {
+	Nm    string
+	Cls   string
+	Inhib tstInhib
+}
+
+func (ly *tstLayer) TypeName() string { return "Layer" }
+func (ly *tstLayer) Class() string    { return ly.Cls }
+func (ly *tstLayer) Name() string     { return ly.Nm }
+
+func TestValidate(t *testing.T) {
+	lays := []interface{}{&tstLayer{Nm: "Input", Inhib: tstInhib{Gi: 1.8}}, &tstLayer{Nm: "Output", Cls: "Out", Inhib: tstInhib{Gi: 1.8}}}
+	sht := &Sheet{
+		{Sel: "Layer", Params: Params{"Layer.Inhib.Gi": "1.8"}},
+		{Sel: "#Outptu", Params: Params{"Layer.Inhib.Gi": "1.4"}},
+		{Sel: ".Out", Params: Params{"Layer.Inhib.Gi": "1.4", "Layer.Inhib.On": "true", "Layer.Inhib.Gain": "2"}},
+		{Sel: "#Output", Params: Params{"Layer.Inhib.Gi": "1.5"}},
+	}
+	vr := sht.Validate(lays)
+	if len(vr.Unmatched) != 1 || vr.Unmatched[0] != "#Outptu (Layer)" {
+		t.Errorf("Unmatched: %v", vr.Unmatched)
+	}
+	if len(vr.Failed) != 1 {
+		t.Errorf("Failed: %v", vr.Failed)
+	}
+	if len(vr.Changes) != 3 || vr.NSame != 2 {
+		t.Errorf("Changes: %v NSame: %d", vr.Changes, vr.NSame)
+	}
+	if pc := vr.Changes[2]; pc.Old != "1.4" || pc.New != "1.5" {
+		t.Errorf("last change: %v", pc.String())
+	}
+	if lays[1].(*tstLayer).Inhib.Gi != 1.8 {
+		t.Errorf("Validate modified object")
+	}
+}
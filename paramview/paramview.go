@@ -0,0 +1,276 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package paramview provides the ParamView editor for params.Sets, which
+// applies edited params to a live network immediately, for interactive tuning.
+package paramview
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/emer/emergent/emer"
+	"github.com/emer/emergent/params"
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/giv"
+	"github.com/goki/ki/ki"
+	"github.com/goki/ki/kit"
+)
+
+// selInfo records where a Sel in the tree is in the Sets
+type selInfo struct {
+	set, sheet string
+	sel        *params.Sel
+}
+
+// ParamView is an editor for params.Sets, with a tree of the Sets, Sheets, and
+// Sels on the left, and the Params of the selected Sel on the right.  Apply
+// applies the current Set (resolved with the Set that it Extends) to the live
+// network, recording the changes in Hist so they can be undone, and calls
+// UpdtFunc, e.g., to update the network params and the NetView.  Selecting an
+// item in the tree makes its Set (and Sheet) current, and with AutoApply on,
+// each edit is applied immediately.
+type ParamView struct {
+	gi.Layout
+	Sets      *params.Sets      `desc:"the params being edited"`
+	Net       emer.Network      `desc:"the network that the params are applied to"`
+	SetName   string            `desc:"name of the current Set, applied by Apply -- set by selecting in the tree"`
+	SheetName string            `desc:"name of the Sheet in the current Set that is applied to the network"`
+	AutoApply bool              `desc:"apply the current Set immediately after each edit"`
+	Hist      params.History    `desc:"history of the params applied, for Undo"`
+	UpdtFunc  func()            `view:"-" json:"-" desc:"function called after params are applied or undone, e.g., to call UpdateParams on the network and update the NetView"`
+	CurSel    *params.Sel       `view:"-" json:"-" desc:"currently selected Sel, shown in the params view"`
+	selMap    map[ki.Ki]selInfo `view:"-" desc:"map from tree nodes to the Sets items they represent"`
+	tree      *ki.Node          `view:"-" desc:"root of the tree of Sets, Sheets, and Sels shown in the tree view"`
+}
+
+var KiT_ParamView = kit.Types.AddType(&ParamView{}, ParamViewProps)
+
+// AddNewParamView adds a new ParamView to given parent node, with given name.
+func AddNewParamView(parent ki.Ki, name string) *ParamView {
+	return parent.AddNewChild(KiT_ParamView, name).(*ParamView)
+}
+
+// SetSets sets the params to edit and the network to apply them to,
+// with the function to call after applying them, and configures the view.
+// The current Set is the first one, and the Sheet is Network.
+func (pv *ParamView) SetSets(sets *params.Sets, net emer.Network, updtFunc func()) {
+	pv.Sets = sets
+	pv.Net = net
+	pv.UpdtFunc = updtFunc
+	if pv.SetName == "" && len(*sets) > 0 {
+		pv.SetName = (*sets)[0].Name
+	}
+	if pv.SheetName == "" {
+		pv.SheetName = "Network"
+	}
+	pv.Config()
+}
+
+// Config configures the overall view widget
+func (pv *ParamView) Config() {
+	pv.Lay = gi.LayoutVert
+	pv.SetProp("spacing", gi.StdDialogVSpaceUnits)
+	config := kit.TypeAndNameList{}
+	config.Add(gi.KiT_ToolBar, "tbar")
+	config.Add(gi.KiT_SplitView, "split")
+	config.Add(gi.KiT_Label, "status")
+	mods, updt := pv.ConfigChildren(config, false)
+	if !mods {
+		updt = pv.UpdateStart()
+	}
+	split := pv.SplitView()
+	split.Dim = gi.X
+	if len(split.Kids) == 0 {
+		tvfr := gi.AddNewFrame(split, "tvfr", gi.LayoutHoriz)
+		tvfr.SetReRenderAnchor()
+		tv := giv.AddNewTreeView(tvfr, "tv")
+		tv.TreeViewSig.Connect(pv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			if sig != int64(giv.TreeViewSelected) {
+				return
+			}
+			pvv := recv.Embed(KiT_ParamView).(*ParamView)
+			tvn := data.(ki.Ki).Embed(giv.KiT_TreeView).(*giv.TreeView)
+			pvv.SelectNode(tvn.SrcNode)
+		})
+		mv := giv.AddNewMapView(split, "params")
+		mv.ViewSig.Connect(pv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			pvv := recv.Embed(KiT_ParamView).(*ParamView)
+			if pvv.AutoApply {
+				pvv.Apply()
+			}
+		})
+		split.SetSplits(.3, .7)
+	}
+	pv.ToolbarConfig()
+	pv.TreeConfig()
+	pv.UpdateStatus()
+	pv.UpdateEnd(updt)
+}
+
+func (pv *ParamView) Toolbar() *gi.ToolBar {
+	return pv.ChildByName("tbar", 0).(*gi.ToolBar)
+}
+
+func (pv *ParamView) SplitView() *gi.SplitView {
+	return pv.ChildByName("split", 1).(*gi.SplitView)
+}
+
+func (pv *ParamView) Status() *gi.Label {
+	return pv.ChildByName("status", 2).(*gi.Label)
+}
+
+func (pv *ParamView) TreeView() *giv.TreeView {
+	return pv.SplitView().Child(0).Child(0).(*giv.TreeView)
+}
+
+func (pv *ParamView) ParamsView() *giv.MapView {
+	return pv.SplitView().Child(1).(*giv.MapView)
+}
+
+// TreeConfig rebuilds the tree of Sets, Sheets, and Sels from Sets,
+// e.g., after Sels have been added or removed
+func (pv *ParamView) TreeConfig() {
+	if pv.tree == nil {
+		pv.tree = &ki.Node{}
+		pv.tree.InitName(pv.tree, "Sets")
+	}
+	pv.tree.DeleteChildren(true)
+	pv.selMap = make(map[ki.Ki]selInfo)
+	if pv.Sets != nil {
+		for _, st := range *pv.Sets {
+			stn := pv.tree.AddNewChild(ki.KiT_Node, st.Name)
+			pv.selMap[stn] = selInfo{set: st.Name}
+			for _, shnm := range sheetNames(st) {
+				shn := stn.AddNewChild(ki.KiT_Node, shnm)
+				pv.selMap[shn] = selInfo{set: st.Name, sheet: shnm}
+				for si, sl := range *st.Sheets[shnm] {
+					sln := shn.AddNewChild(ki.KiT_Node, fmt.Sprintf("%d: %s", si, sl.Sel))
+					pv.selMap[sln] = selInfo{set: st.Name, sheet: shnm, sel: sl}
+				}
+			}
+		}
+	}
+	pv.TreeView().SetRootNode(pv.tree)
+}
+
+// sheetNames returns the names of the sheets in given set in sorted order
+func sheetNames(st *params.Set) []string {
+	nms := make([]string, 0, len(st.Sheets))
+	for nm := range st.Sheets {
+		nms = append(nms, nm)
+	}
+	sort.Strings(nms)
+	return nms
+}
+
+// SelectNode makes the Set (and Sheet) of given tree node current,
+// and shows the params of its Sel, if it is one
+func (pv *ParamView) SelectNode(nd ki.Ki) {
+	si, ok := pv.selMap[nd]
+	if !ok {
+		return
+	}
+	pv.SetName = si.set
+	if si.sheet != "" {
+		pv.SheetName = si.sheet
+	}
+	if si.sel != nil {
+		pv.CurSel = si.sel
+		pv.ParamsView().SetMap(&si.sel.Params)
+	}
+	pv.UpdateStatus()
+}
+
+// UpdateStatus updates the status label showing what Apply applies
+func (pv *ParamView) UpdateStatus() {
+	st := fmt.Sprintf("Apply: %s:%s", pv.SetName, pv.SheetName)
+	if n := pv.Hist.Len(); n > 0 {
+		st += fmt.Sprintf("  (applied: %d, last: %s)", n, pv.Hist.Entries[n-1].Name)
+	}
+	pv.Status().SetText(st)
+}
+
+// Apply applies the current Sheet of the current Set, resolved with the Set
+// that it Extends (see params.Sets.Resolve), to the network, recording the
+// changes in Hist, and calls UpdtFunc.  See PreviewDialog to review the
+// changes first.
+func (pv *ParamView) Apply() error {
+	sh, err := pv.currentSheet()
+	if err != nil {
+		return err
+	}
+	_, err = emer.ApplyParamsHist(pv.Net, sh, &pv.Hist, pv.SetName+":"+pv.SheetName, false)
+	pv.Updated()
+	return err
+}
+
+// Undo undoes the changes from the last Apply, and calls UpdtFunc
+func (pv *ParamView) Undo() error {
+	he, err := pv.Hist.Undo()
+	if he == nil {
+		return err
+	}
+	pv.Updated()
+	return err
+}
+
+// Updated calls UpdtFunc and updates the status, after params have changed
+func (pv *ParamView) Updated() {
+	if pv.UpdtFunc != nil {
+		pv.UpdtFunc()
+	}
+	pv.UpdateStatus()
+}
+
+// ToolbarConfig configures the toolbar actions
+func (pv *ParamView) ToolbarConfig() {
+	tbar := pv.Toolbar()
+	if len(tbar.Kids) != 0 {
+		return
+	}
+	tbar.SetStretchMaxWidth()
+	tbar.AddAction(gi.ActOpts{Label: "Apply", Icon: "update", Tooltip: "apply the current Set (and the Set it Extends) to the network"}, pv.This(),
+		func(recv, send ki.Ki, sig int64, data interface{}) {
+			pvv := recv.Embed(KiT_ParamView).(*ParamView)
+			pvv.Apply()
+		})
+	tbar.AddAction(gi.ActOpts{Label: "Preview...", Icon: "search", Tooltip: "show the changes that Apply would make to the network, and apply the selected ones"}, pv.This(),
+		func(recv, send ki.Ki, sig int64, data interface{}) {
+			pvv := recv.Embed(KiT_ParamView).(*ParamView)
+			pvv.PreviewDialog()
+		})
+	tbar.AddAction(gi.ActOpts{Label: "Undo", Icon: "rotate-left", Tooltip: "undo the changes from the last Apply"}, pv.This(),
+		func(recv, send ki.Ki, sig int64, data interface{}) {
+			pvv := recv.Embed(KiT_ParamView).(*ParamView)
+			pvv.Undo()
+		})
+	acb := gi.AddNewCheckBox(tbar, "auto")
+	acb.SetText("Auto Apply")
+	acb.Tooltip = "apply the current Set immediately after each edit"
+	acb.SetChecked(pv.AutoApply)
+	acb.ButtonSig.Connect(pv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig != int64(gi.ButtonToggled) {
+			return
+		}
+		pvv := recv.Embed(KiT_ParamView).(*ParamView)
+		pvv.AutoApply = send.Embed(gi.KiT_CheckBox).(*gi.CheckBox).IsChecked()
+	})
+	tbar.AddSeparator("hist")
+	tbar.AddAction(gi.ActOpts{Label: "History", Icon: "info", Tooltip: "show the history of applied params, and the net changes"}, pv.This(),
+		func(recv, send ki.Ki, sig int64, data interface{}) {
+			pvv := recv.Embed(KiT_ParamView).(*ParamView)
+			giv.TextViewDialog(pvv.Viewport, []byte(pvv.Hist.String()), giv.DlgOpts{Title: pvv.Nm + " History"})
+		})
+	tbar.AddAction(gi.ActOpts{Label: "Refresh", Icon: "update", Tooltip: "rebuild the tree after adding or removing Sels"}, pv.This(),
+		func(recv, send ki.Ki, sig int64, data interface{}) {
+			pvv := recv.Embed(KiT_ParamView).(*ParamView)
+			pvv.TreeConfig()
+		})
+}
+
+var ParamViewProps = ki.Props{
+	"max-width":  -1,
+	"max-height": -1,
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package paramview
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/emer/emergent/emer"
+	"github.com/emer/emergent/params"
+	"github.com/goki/gi/gi"
+	"github.com/goki/gi/giv"
+	"github.com/goki/ki/ki"
+)
+
+// PreviewChange is one param change that applying the current Set would
+// make, shown in the preview dialog, where it can be deselected
+type PreviewChange struct {
+	Apply bool   `desc:"apply this change -- deselect to skip it"`
+	Obj   string `inactive:"+" desc:"name of the layer or projection"`
+	Path  string `inactive:"+" desc:"param path"`
+	Old   string `inactive:"+" desc:"current value"`
+	New   string `inactive:"+" desc:"value after applying"`
+	Sel   string `inactive:"+" desc:"selector of the Sel that sets the param"`
+}
+
+// currentSheet returns the current Sheet of the current Set, resolved
+// with the Set that it Extends
+func (pv *ParamView) currentSheet() (*params.Sheet, error) {
+	if pv.Sets == nil || pv.Net == nil {
+		err := fmt.Errorf("ParamView: Sets and Net must be set")
+		log.Println(err)
+		return nil, err
+	}
+	st, err := pv.Sets.Resolve(pv.SetName)
+	if err != nil {
+		return nil, err
+	}
+	sh, ok := st.Sheets[pv.SheetName]
+	if !ok {
+		err := fmt.Errorf("ParamView: Sheet: %v not found in Set: %v", pv.SheetName, pv.SetName)
+		log.Println(err)
+		return nil, err
+	}
+	return sh, nil
+}
+
+// Preview does a dry run of Apply (see emer.ValidateParams), returning the
+// param changes that it would make to the network, in order, all selected
+func (pv *ParamView) Preview() ([]*PreviewChange, error) {
+	sh, err := pv.currentSheet()
+	if err != nil {
+		return nil, err
+	}
+	vr := emer.ValidateParams(pv.Net, sh)
+	chgs := make([]*PreviewChange, len(vr.Changes))
+	for i, pc := range vr.Changes {
+		chgs[i] = &PreviewChange{Apply: true, Obj: pc.Obj, Path: pc.Path, Old: pc.Old, New: pc.New, Sel: pc.Sel}
+	}
+	return chgs, nil
+}
+
+// ApplyChanges applies the selected changes (from Preview) to the network,
+// recording them in Hist so they can be undone, and calls UpdtFunc
+func (pv *ParamView) ApplyChanges(chgs []*PreviewChange) error {
+	sh := &params.Sheet{}
+	for _, pc := range chgs {
+		if pc.Apply {
+			*sh = append(*sh, &params.Sel{Sel: "#" + pc.Obj, Desc: "from " + pc.Sel, Params: params.Params{pc.Path: pc.New}})
+		}
+	}
+	if len(*sh) == 0 {
+		return nil
+	}
+	_, err := emer.ApplyParamsHist(pv.Net, sh, &pv.Hist, pv.SetName+":"+pv.SheetName+" (preview)", false)
+	pv.Updated()
+	return err
+}
+
+// PreviewDialog shows the changes that Apply would make to the network,
+// from which values to what values, and applies the selected ones if Ok
+// is pressed (see Preview and ApplyChanges)
+func (pv *ParamView) PreviewDialog() {
+	chgs, err := pv.Preview()
+	if err != nil {
+		gi.PromptDialog(pv.Viewport, gi.DlgOpts{Title: "Preview Failed", Prompt: err.Error()}, true, false, nil, nil)
+		return
+	}
+	if len(chgs) == 0 {
+		gi.PromptDialog(pv.Viewport, gi.DlgOpts{Title: "No Changes", Prompt: fmt.Sprintf("Applying %s:%s would not change any params", pv.SetName, pv.SheetName)}, true, false, nil, nil)
+		return
+	}
+	giv.TableViewDialog(pv.Viewport, &chgs, giv.DlgOpts{Title: "Apply " + pv.SetName + ":" + pv.SheetName, Prompt: "These params would change -- deselect Apply to skip any, and press Ok to apply the selected ones", Ok: true, Cancel: true, NoAdd: true, NoDelete: true}, nil,
+		pv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			if sig != int64(gi.DialogAccepted) {
+				return
+			}
+			pvv := recv.Embed(KiT_ParamView).(*ParamView)
+			pvv.ApplyChanges(chgs)
+		})
+}
@@ -22,6 +22,7 @@ type Circle struct {
 	Scale     mat32.Vec2 `desc:"scaling to apply to receiving unit position to compute sending center as function of recv unit position"`
 	AutoScale bool       `desc:"auto-scale sending center positions as function of relative sizes of send and recv layers -- if Start is positive then assumes it is a border, subtracted from sending size"`
 	Wrap      bool       `desc:"if true, connectivity wraps around edges"`
+	Mirror    bool       `desc:"if true, distances are computed using the closest reflection (mirror image) of sending units at the edges, taking precedence over Wrap"`
 	Sigma     float32    `desc:"gaussian sigma (width) as a proportion of the radius of the circle"`
 	MaxWt     float32    `desc:"maximum weight value for GaussWts function -- multiplies values"`
 	SelfCon   bool       `desc:"if true, and connecting layer to itself (self projection), then make a self-connection from unit to itself"`
@@ -71,7 +72,10 @@ func (cr *Circle) Connect(send, recv *etensor.Shape, same bool) (sendn, recvn *e
 			for sy := 0; sy < sNy; sy++ {
 				for sx := 0; sx < sNx; sx++ {
 					sp := mat32.Vec2{float32(sx), float32(sy)}
-					if cr.Wrap {
+					if cr.Mirror {
+						sp.X = MirrorMinDist(sp.X, float32(sNx-1), sctr.X)
+						sp.Y = MirrorMinDist(sp.Y, float32(sNy-1), sctr.Y)
+					} else if cr.Wrap {
 						sp.X = WrapMinDist(sp.X, float32(sNx-1), sctr.X)
 						sp.Y = WrapMinDist(sp.Y, float32(sNy-1), sctr.Y)
 					}
@@ -120,7 +124,10 @@ func (cr *Circle) GaussWts(si, ri int, send, recv *etensor.Shape) float32 {
 	}
 	sctr := mat32.Vec2{float32(rx)*sc.X + float32(cr.Start.X), float32(ry)*sc.Y + float32(cr.Start.Y)}
 	sp := mat32.Vec2{float32(sx), float32(sy)}
-	if cr.Wrap {
+	if cr.Mirror {
+		sp.X = MirrorMinDist(sp.X, float32(sNx-1), sctr.X)
+		sp.Y = MirrorMinDist(sp.Y, float32(sNy-1), sctr.Y)
+	} else if cr.Wrap {
 		sp.X = WrapMinDist(sp.X, float32(sNx-1), sctr.X)
 		sp.Y = WrapMinDist(sp.Y, float32(sNy-1), sctr.Y)
 	}
@@ -24,6 +24,34 @@ func Edge(ci, max int, wrap bool) (int, bool) {
 	return ci, false
 }
 
+// EdgeMirror returns coordinate value reflected (mirrored) at the edges,
+// for coordinates outside of the range [0, max), without repeating the
+// edge value: -1 -> 1, and max -> max-2.
+func EdgeMirror(ci, max int) int {
+	if max <= 1 {
+		return 0
+	}
+	per := 2 * (max - 1)
+	ci %= per
+	if ci < 0 {
+		ci += per
+	}
+	if ci >= max {
+		ci = per - ci
+	}
+	return ci
+}
+
+// EdgeWrapMirror returns coordinate value based on mirroring, wrapping, or
+// clipping at the edge, with mirror taking precedence over wrap, and if
+// clipping, whether it should be clipped (ignored)
+func EdgeWrapMirror(ci, max int, wrap, mirror bool) (int, bool) {
+	if mirror {
+		return EdgeMirror(ci, max), false
+	}
+	return Edge(ci, max, wrap)
+}
+
 // WrapMinDist returns the wrapped coordinate value that is closest to ctr
 // i.e., if going out beyond max is closer, then returns that coordinate
 // else if going below 0 is closer than not, then returns that coord
@@ -37,3 +65,16 @@ func WrapMinDist(ci, max, ctr float32) float32 {
 	}
 	return ci
 }
+
+// MirrorMinDist returns the mirrored coordinate value that is closest to ctr
+// i.e., the reflection of ci about 0 or max, if either is closer than ci
+func MirrorMinDist(ci, max, ctr float32) float32 {
+	nmd := math32.Abs(ci - ctr) // no-mirror dist
+	if math32.Abs(-ci-ctr) < nmd {
+		return -ci
+	}
+	if math32.Abs((2*max-ci)-ctr) < nmd {
+		return 2*max - ci
+	}
+	return ci
+}
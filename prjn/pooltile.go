@@ -28,6 +28,7 @@ type PoolTile struct {
 	Skip        evec.Vec2i `desc:"how many pools to skip in tiling over sending layer -- typically 1/2 of Size"`
 	Start       evec.Vec2i `desc:"starting pool offset for lower-left corner of first receptive field in sending layer"`
 	Wrap        bool       `desc:"if true, pool coordinates wrap around sending shape -- otherwise truncated at edges, which can lead to assymmetries in connectivity etc"`
+	Mirror      bool       `desc:"if true, pool coordinates are reflected (mirrored) at the edges of the sending shape, taking precedence over Wrap -- any sending pool reached more than once is connected only once"`
 	GaussFull   GaussTopo  `desc:"gaussian topographic weights / scaling parameters for full receptive field width. multiplies any other factors present"`
 	GaussInPool GaussTopo  `desc:"gaussian topographic weights / scaling parameters within individual sending pools (i.e., unit positions within their parent pool drive distance for gaussian) -- this helps organize / differentiate units more within pools, not just across entire receptive field. multiplies any other factors present"`
 	// SigmoidTopo SigmoidTopo `desc:"sigmoidal topographic weights / scaling parameters"`
@@ -88,12 +89,12 @@ func (pt *PoolTile) Connect(send, recv *etensor.Shape, same bool) (sendn, recvn
 			ris := rpi * rNu
 			for fy := 0; fy < pt.Size.Y; fy++ {
 				spy := pt.Start.Y + rpy*pt.Skip.Y + fy
-				if spy, clip = Edge(spy, sNpY, pt.Wrap); clip {
+				if spy, clip = EdgeWrapMirror(spy, sNpY, pt.Wrap, pt.Mirror); clip {
 					continue
 				}
 				for fx := 0; fx < pt.Size.X; fx++ {
 					spx := pt.Start.X + rpx*pt.Skip.X + fx
-					if spx, clip = Edge(spx, sNpX, pt.Wrap); clip {
+					if spx, clip = EdgeWrapMirror(spx, sNpX, pt.Wrap, pt.Mirror); clip {
 						continue
 					}
 					spi := spy*sNpX + spx
@@ -103,7 +104,7 @@ func (pt *PoolTile) Connect(send, recv *etensor.Shape, same bool) (sendn, recvn
 						for sui := 0; sui < sNu; sui++ {
 							si := sis + sui
 							off := ri*sNtot + si
-							if off < cons.Len() {
+							if off < cons.Len() && !(pt.Mirror && cons.Values.Index(off)) {
 								// if !pt.SelfCon && same && ri == si {
 								// 	continue
 								// }
@@ -146,12 +147,12 @@ func (pt *PoolTile) ConnectRecip(send, recv *etensor.Shape, same bool) (sendn, r
 			ris := rpi * sNu
 			for fy := 0; fy < pt.Size.Y; fy++ {
 				spy := pt.Start.Y + rpy*pt.Skip.Y + fy
-				if spy, clip = Edge(spy, sNpY, pt.Wrap); clip {
+				if spy, clip = EdgeWrapMirror(spy, sNpY, pt.Wrap, pt.Mirror); clip {
 					continue
 				}
 				for fx := 0; fx < pt.Size.X; fx++ {
 					spx := pt.Start.X + rpx*pt.Skip.X + fx
-					if spx, clip = Edge(spx, sNpX, pt.Wrap); clip {
+					if spx, clip = EdgeWrapMirror(spx, sNpX, pt.Wrap, pt.Mirror); clip {
 						continue
 					}
 					spi := spy*sNpX + spx
@@ -162,7 +163,7 @@ func (pt *PoolTile) ConnectRecip(send, recv *etensor.Shape, same bool) (sendn, r
 							si := ris + sui
 							// note: indexes reversed here
 							off := ri*sNtot + si
-							if off < cons.Len() {
+							if off < cons.Len() && !(pt.Mirror && cons.Values.Index(off)) {
 								cons.Values.Set(off, true)
 								if ri < len(rnv) {
 									rnv[ri]++
@@ -187,6 +188,7 @@ type GaussTopo struct {
 	On      bool    `desc:"use gaussian topographic weights / scaling values"`
 	Sigma   float32 `viewif:"On" def:"0.6" desc:"gaussian sigma (width) in normalized units where entire distance across relevant dimension is 1.0 -- typical useful values range from .3 to 1.5, with .6 default"`
 	Wrap    bool    `viewif:"On" desc:"wrap the gaussian around on other sides of the receptive field, with the closest distance being used -- this removes strict topography but ensures a more uniform distribution of weight values so edge units don't have weaker overall weights"`
+	Mirror  bool    `viewif:"On" desc:"reflect (mirror) the gaussian at the edges of the receptive field, with the closest distance being used, taking precedence over Wrap -- only has an effect when the gaussian center of edge units falls outside of the receptive field (CtrMove > 1), which then reflects the part of the gaussian beyond the edge back onto the edge units, instead of wrapping it onto the opposite side"`
 	CtrMove float32 `viewif:"On" def:"0.8,1" desc:"proportion to move gaussian center relative to the position of the receiving unit within its pool: 1.0 = centers span the entire range of the receptive field.  Typically want to use 1.0 for Wrap = true, and 0.8 for false"`
 }
 
@@ -274,7 +276,10 @@ func (pt *PoolTile) TopoWts(send, recv *etensor.Shape, wts *etensor.Float32) {
 							fwt := float32(1)
 							if pt.GaussFull.On {
 								sf := mat32.Vec2{float32(fx*sNuX + sux), float32(fy*sNuY + suy)}
-								if pt.GaussFull.Wrap {
+								if pt.GaussFull.Mirror {
+									sf.X = MirrorMinDist(sf.X, fsz.X, sfctr.X)
+									sf.Y = MirrorMinDist(sf.Y, fsz.Y, sfctr.Y)
+								} else if pt.GaussFull.Wrap {
 									sf.X = WrapMinDist(sf.X, fsz.X, sfctr.X)
 									sf.Y = WrapMinDist(sf.Y, fsz.Y, sfctr.Y)
 								}
@@ -283,7 +288,10 @@ func (pt *PoolTile) TopoWts(send, recv *etensor.Shape, wts *etensor.Float32) {
 							pwt := float32(1)
 							if pt.GaussInPool.On {
 								sp := mat32.Vec2{float32(sux), float32(suy)}
-								if pt.GaussInPool.Wrap {
+								if pt.GaussInPool.Mirror {
+									sp.X = MirrorMinDist(sp.X, psz.X, spctr.X)
+									sp.Y = MirrorMinDist(sp.Y, psz.Y, spctr.Y)
+								} else if pt.GaussInPool.Wrap {
 									sp.X = WrapMinDist(sp.X, psz.X, spctr.X)
 									sp.Y = WrapMinDist(sp.Y, psz.Y, spctr.Y)
 								}
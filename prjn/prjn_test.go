@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/chewxy/math32"
 	"github.com/emer/etable/etensor"
 	"github.com/goki/ki/ints"
 )
@@ -247,3 +248,77 @@ func TestUnifRndLg(t *testing.T) {
 	}
 	fmt.Printf("unif rnd large rNtot: %d  pcon: %g  max: %d  min: %d  mean: %g\n", rNtot, pj.PCon, nrMax, nrMin, float32(nrMean)/float32(sNtot))
 }
+
+func TestEdgeMirror(t *testing.T) {
+	max := 4
+	ins := []int{-7, -6, -3, -1, 0, 3, 4, 5, 6, 9}
+	trgs := []int{1, 0, 3, 1, 0, 3, 2, 1, 0, 3}
+	for i, ci := range ins {
+		mi := EdgeMirror(ci, max)
+		if mi != trgs[i] {
+			t.Errorf("EdgeMirror(%d, %d) = %d, trg: %d\n", ci, max, mi, trgs[i])
+		}
+	}
+	if mi := EdgeMirror(5, 1); mi != 0 {
+		t.Errorf("EdgeMirror(5, 1) = %d, trg: 0\n", mi)
+	}
+}
+
+func TestMirrorMinDist(t *testing.T) {
+	max := float32(4)
+	tests := []struct {
+		ci, ctr, trg float32
+	}{
+		{0, 2, 0}, // center inside: never mirrored
+		{4, 2, 4},
+		{3, 5, 5}, // center beyond max: reflected about max
+		{4, 5, 4},
+		{0, 5, 8},
+		{1, -1, -1}, // center below 0: reflected about 0
+		{2, -1, -2},
+		{4, -1, -4},
+	}
+	for _, tt := range tests {
+		if mi := MirrorMinDist(tt.ci, max, tt.ctr); mi != tt.trg {
+			t.Errorf("MirrorMinDist(%g, %g, %g) = %g, trg: %g\n", tt.ci, max, tt.ctr, mi, tt.trg)
+		}
+	}
+}
+
+func TestTopoWtsMirror(t *testing.T) {
+	send := etensor.NewShape([]int{1, 1, 1, 5}, nil, nil)
+	recv := etensor.NewShape([]int{1, 1, 1, 2}, nil, nil)
+
+	pj := NewPoolTile()
+	pj.Size.Set(1, 1)
+	pj.GaussInPool.On = false
+	pj.GaussFull.Wrap = false
+	pj.GaussFull.CtrMove = 1.5 // center of first recv unit at -1
+
+	wts := &etensor.Float32{}
+	pj.TopoWts(send, recv, wts)
+	w0 := wts.Value([]int{0, 0, 0, 0, 0, 0})
+	w1 := wts.Value([]int{0, 0, 0, 0, 0, 1})
+	w2 := wts.Value([]int{0, 0, 0, 0, 0, 2})
+	if !(w0 > w1 && w1 > w2) {
+		t.Errorf("no mirror: wts not decreasing from edge: %g %g %g\n", w0, w1, w2)
+	}
+
+	pj.GaussFull.Mirror = true
+	pj.GaussFull.Wrap = true // mirror takes precedence
+	pj.TopoWts(send, recv, wts)
+	w0 = wts.Value([]int{0, 0, 0, 0, 0, 0})
+	w1 = wts.Value([]int{0, 0, 0, 0, 0, 1})
+	w2 = wts.Value([]int{0, 0, 0, 0, 0, 2})
+	if math32.Abs(w1-pj.TopoRange.Max) > 1.0e-6 || math32.Abs(w0-w2) > 1.0e-6 || w0 >= w1 {
+		t.Errorf("mirror: wts not peaked at reflected center: %g %g %g\n", w0, w1, w2)
+	}
+	// second recv unit is the mirror image of the first
+	for sux := 0; sux < 5; sux++ {
+		w := wts.Value([]int{0, 0, 0, 0, 0, sux})
+		wr := wts.Value([]int{0, 1, 0, 0, 0, 4 - sux})
+		if math32.Abs(w-wr) > 1.0e-6 {
+			t.Errorf("mirror: recv units not symmetric at: %d: %g != %g\n", sux, w, wr)
+		}
+	}
+}
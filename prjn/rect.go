@@ -20,6 +20,7 @@ type Rect struct {
 	Scale     mat32.Vec2 `desc:"scaling to apply to receiving unit position to compute corresponding position in sending layer"`
 	AutoScale bool       `desc:"auto-scale sending positions as function of relative sizes of send and recv layers"`
 	Wrap      bool       `desc:"if true, connectivity wraps around edges"`
+	Mirror    bool       `desc:"if true, connectivity is reflected (mirrored) at the edges, taking precedence over Wrap -- any sending unit reached more than once is connected only once"`
 	SelfCon   bool       `desc:"if true, and connecting layer to itself (self projection), then make a self-connection from unit to itself"`
 }
 
@@ -61,12 +62,12 @@ func (cr *Rect) Connect(send, recv *etensor.Shape, same bool) (sendn, recvn *ete
 			sst.X += int(mat32.Round(float32(rx) * sc.X))
 			sst.Y += int(mat32.Round(float32(ry) * sc.Y))
 			for y := 0; y < cr.Size.Y; y++ {
-				sy, clipy := Edge(sst.Y+y, sNy, cr.Wrap)
+				sy, clipy := EdgeWrapMirror(sst.Y+y, sNy, cr.Wrap, cr.Mirror)
 				if clipy {
 					continue
 				}
 				for x := 0; x < cr.Size.X; x++ {
-					sx, clipx := Edge(sst.X+x, sNx, cr.Wrap)
+					sx, clipx := EdgeWrapMirror(sst.X+x, sNx, cr.Wrap, cr.Mirror)
 					if clipx {
 						continue
 					}
@@ -76,6 +77,9 @@ func (cr *Rect) Connect(send, recv *etensor.Shape, same bool) (sendn, recvn *ete
 					if !cr.SelfCon && same && ri == si {
 						continue
 					}
+					if cr.Mirror && cons.Values.Index(off) {
+						continue
+					}
 					cons.Values.Set(off, true)
 					rnv[ri]++
 					snv[si]++
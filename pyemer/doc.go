@@ -0,0 +1,24 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package pyemer provides a Python-friendly bridge to the core emergent
+interfaces (emer.Network, Layer, Prjn), params application, env stepping,
+and weights I/O, for use in the bindings generated by GoPy (see the python
+directory).
+
+The Go interfaces use pointer-to-slice args (e.g., Layer.UnitVals) and
+types that do not translate directly into Python, so the functions here
+take and return only simple types: strings, ints, and flat []float32 slices
+with separate []int shapes, which can be efficiently converted into NumPy
+arrays -- see python/emernp.py for the NumPy helpers, e.g.:
+
+	import emernp
+	act = emernp.layer_array(net, "Hidden", "Act")  # shaped as the layer
+	wts = emernp.prjn_array(net, "Hidden", "Input", "Wt")      # [NRecv, NSend]
+
+All functions return an error instead of panicking when names are not
+found, which GoPy turns into a Python exception.
+*/
+package pyemer
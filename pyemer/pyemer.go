@@ -0,0 +1,173 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pyemer
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/emer/emergent/emer"
+	"github.com/emer/emergent/env"
+	"github.com/emer/emergent/params"
+	"github.com/emer/emergent/weights"
+	"github.com/emer/etable/etensor"
+	"github.com/goki/gi/gi"
+)
+
+// LayerNames returns the names of all the layers in the network
+func LayerNames(net emer.Network) []string {
+	nl := net.NLayers()
+	nms := make([]string, nl)
+	for li := 0; li < nl; li++ {
+		nms[li] = net.Layer(li).Name()
+	}
+	return nms
+}
+
+// LayerShape returns the shape of the named layer
+func LayerShape(net emer.Network, layNm string) ([]int, error) {
+	ly, err := net.LayerByNameTry(layNm)
+	if err != nil {
+		return nil, err
+	}
+	return ly.Shape().Shp, nil
+}
+
+// LayerVals returns the values of given unit variable for all units in
+// the named layer, as a flat list in row-major order according to LayerShape
+func LayerVals(net emer.Network, layNm, varNm string) ([]float32, error) {
+	ly, err := net.LayerByNameTry(layNm)
+	if err != nil {
+		return nil, err
+	}
+	var vals []float32
+	err = ly.UnitVals(&vals, varNm)
+	return vals, err
+}
+
+// PrjnShape returns the [NRecv, NSend] shape of the matrix returned by PrjnVals
+func PrjnShape(net emer.Network, recvNm, sendNm string) ([]int, error) {
+	pj, err := emer.PrjnByNames(net, sendNm, recvNm)
+	if err != nil {
+		return nil, err
+	}
+	return []int{pj.RecvLay().Shape().Len(), pj.SendLay().Shape().Len()}, nil
+}
+
+// PrjnVals returns the values of given synaptic variable for the projection
+// into recv layer from send layer, as a flat [NRecv, NSend] matrix in
+// row-major order, with NaN for unconnected units
+func PrjnVals(net emer.Network, recvNm, sendNm, varNm string) ([]float32, error) {
+	pj, err := emer.PrjnByNames(net, sendNm, recvNm)
+	if err != nil {
+		return nil, err
+	}
+	rl := pj.RecvLay()
+	sl := pj.SendLay()
+	nr := rl.Shape().Len()
+	ns := sl.Shape().Len()
+	vals := make([]float32, nr*ns)
+	var svals []float32
+	for si := 0; si < ns; si++ {
+		err := rl.RecvPrjnVals(&svals, varNm, sl, si)
+		if err != nil {
+			return nil, err
+		}
+		for ri := 0; ri < nr; ri++ {
+			vals[ri*ns+si] = svals[ri]
+		}
+	}
+	return vals, nil
+}
+
+// ApplyParams applies the named params Sheet within the named Set to the
+// network, returning true if any params were set.  If setMsg is true,
+// a message is printed for each param that is set.
+func ApplyParams(net emer.Network, sets params.Sets, setNm, sheetNm string, setMsg bool) (bool, error) {
+	pset, err := sets.SetByNameTry(setNm)
+	if err != nil {
+		return false, err
+	}
+	sh, err := pset.SheetByNameTry(sheetNm)
+	if err != nil {
+		return false, err
+	}
+	return net.ApplyParams(sh, setMsg)
+}
+
+// EnvStep steps the environment, returning the (possibly updated) value of
+// the counter at given time scale (e.g., "Trial") and whether it changed
+func EnvStep(en env.Env, scale string) (int, bool, error) {
+	var ts env.TimeScales
+	if err := ts.FromString(scale); err != nil {
+		return 0, false, err
+	}
+	en.Step()
+	cur, _, chg := en.Counter(ts)
+	return cur, chg, nil
+}
+
+// EnvStateShape returns the shape of the named env state element
+func EnvStateShape(en env.Env, element string) ([]int, error) {
+	tsr := en.State(element)
+	if tsr == nil {
+		return nil, fmt.Errorf("pyemer.EnvStateShape: state element: %v not found in env: %v", element, en.Name())
+	}
+	return tsr.Shapes(), nil
+}
+
+// EnvState returns the values of the named env state element as a flat
+// list in row-major order according to EnvStateShape
+func EnvState(en env.Env, element string) ([]float32, error) {
+	tsr := en.State(element)
+	if tsr == nil {
+		return nil, fmt.Errorf("pyemer.EnvState: state element: %v not found in env: %v", element, en.Name())
+	}
+	vals := make([]float32, tsr.Len())
+	for i := range vals {
+		vals[i] = float32(tsr.FloatVal1D(i))
+	}
+	return vals, nil
+}
+
+// SetTensorVals returns a new float32 tensor with given shape and values,
+// e.g., for passing NumPy data to Layer.ApplyExt or Env.Action
+func SetTensorVals(shape []int, vals []float32) (*etensor.Float32, error) {
+	tsr := etensor.NewFloat32(shape, nil, nil)
+	if tsr.Len() != len(vals) {
+		return nil, fmt.Errorf("pyemer.SetTensorVals: number of values: %d does not match shape: %v", len(vals), shape)
+	}
+	copy(tsr.Values, vals)
+	return tsr, nil
+}
+
+// SaveWts saves the network weights to given file name in JSON format
+// (.gz extension for compressed)
+func SaveWts(net emer.Network, filename string) error {
+	return net.SaveWtsJSON(gi.FileName(filename))
+}
+
+// OpenWts opens network weights from given file name in JSON format
+// (.gz extension for compressed)
+func OpenWts(net emer.Network, filename string) error {
+	return net.OpenWtsJSON(gi.FileName(filename))
+}
+
+// SaveWtsNPZ saves the current network weights to given file name in
+// NumPy .npz format -- see weights.NetWriteNPZ for the array names.
+func SaveWtsNPZ(net emer.Network, filename string) error {
+	var b bytes.Buffer
+	net.WriteWtsJSON(&b)
+	nw, err := weights.NetReadJSON(&b)
+	if err != nil {
+		return err
+	}
+	shapes := make(map[string][]int, net.NLayers())
+	for li := 0; li < net.NLayers(); li++ {
+		ly := net.Layer(li)
+		shapes[ly.Name()] = ly.Shape().Shp
+	}
+	return weights.SaveNPZ(filename, nw, shapes)
+}
@@ -0,0 +1,278 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pyemer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/emer/emergent/emer"
+	"github.com/emer/emergent/weights"
+	"github.com/emer/etable/etensor"
+)
+
+// testNet is a minimal mock network, with Input (2x2) and Hidden (1x3)
+// layers, and a projection from Input to Hidden
+type testNet struct {
+	emer.Network
+	lays []*testLay
+}
+
+func (nt *testNet) NLayers() int             { return len(nt.lays) }
+func (nt *testNet) Layer(idx int) emer.Layer { return nt.lays[idx] }
+
+func (nt *testNet) LayerByNameTry(name string) (emer.Layer, error) {
+	for _, ly := range nt.lays {
+		if ly.nm == name {
+			return ly, nil
+		}
+	}
+	return nil, fmt.Errorf("layer: %v not found", name)
+}
+
+// WriteWtsJSON writes the weights of the receiving projections, skipping
+// NaN (unconnected) synapses
+func (nt *testNet) WriteWtsJSON(w io.Writer) {
+	nw := &weights.Network{Version: 1, Network: "Test"}
+	for _, ly := range nt.lays {
+		lw := weights.Layer{Layer: ly.nm}
+		for _, p := range ly.rcv {
+			pj := p.(*testPrjn)
+			pw := weights.Prjn{From: pj.send.nm}
+			for ri, rwts := range pj.wts {
+				rw := weights.Recv{Ri: ri}
+				for si, wt := range rwts {
+					if !math.IsNaN(float64(wt)) {
+						rw.Si = append(rw.Si, si)
+						rw.Wt = append(rw.Wt, wt)
+					}
+				}
+				rw.N = len(rw.Si)
+				pw.Rs = append(pw.Rs, rw)
+			}
+			lw.Prjns = append(lw.Prjns, pw)
+		}
+		nw.Layers = append(nw.Layers, lw)
+	}
+	json.NewEncoder(w).Encode(nw)
+}
+
+// testLay is a minimal mock layer, with one unit variable: Act
+type testLay struct {
+	emer.Layer
+	nm   string
+	shp  etensor.Shape
+	acts []float32
+	rcv  emer.Prjns
+}
+
+func (ly *testLay) Name() string               { return ly.nm }
+func (ly *testLay) Shape() *etensor.Shape      { return &ly.shp }
+func (ly *testLay) RecvPrjns() *emer.Prjns     { return &ly.rcv }
+func (ly *testLay) NRecvPrjns() int            { return len(ly.rcv) }
+func (ly *testLay) RecvPrjn(idx int) emer.Prjn { return ly.rcv[idx] }
+
+func (ly *testLay) UnitVals(vals *[]float32, varNm string) error {
+	if varNm != "Act" {
+		return fmt.Errorf("variable: %v not found", varNm)
+	}
+	*vals = append((*vals)[:0], ly.acts...)
+	return nil
+}
+
+func (ly *testLay) RecvPrjnVals(vals *[]float32, varNm string, sendLay emer.Layer, sendIdx1D int) error {
+	*vals = (*vals)[:0]
+	for ri := 0; ri < ly.shp.Len(); ri++ {
+		*vals = append(*vals, float32(math.NaN()))
+	}
+	if varNm != "Wt" {
+		return fmt.Errorf("variable: %v not found", varNm)
+	}
+	pj, err := ly.rcv.SendNameTry(sendLay.Name())
+	if err != nil {
+		return err
+	}
+	for ri, rwts := range pj.(*testPrjn).wts {
+		(*vals)[ri] = rwts[sendIdx1D]
+	}
+	return nil
+}
+
+// testPrjn is a minimal mock projection, with weights [NRecv][NSend],
+// NaN where not connected
+type testPrjn struct {
+	emer.Prjn
+	send, recv *testLay
+	wts        [][]float32
+}
+
+func (pj *testPrjn) SendLay() emer.Layer { return pj.send }
+func (pj *testPrjn) RecvLay() emer.Layer { return pj.recv }
+
+// newTestNet returns the test network, with weights 10*ri + si, and
+// Hidden unit 1 not connected to Input unit 2
+func newTestNet() *testNet {
+	in := &testLay{nm: "Input", acts: []float32{1, 2, 3, 4}}
+	in.shp.SetShape([]int{2, 2}, nil, nil)
+	hid := &testLay{nm: "Hidden", acts: []float32{5, 6, 7}}
+	hid.shp.SetShape([]int{1, 3}, nil, nil)
+	pj := &testPrjn{send: in, recv: hid}
+	for ri := 0; ri < 3; ri++ {
+		rwts := make([]float32, 4)
+		for si := range rwts {
+			rwts[si] = float32(10*ri + si)
+		}
+		pj.wts = append(pj.wts, rwts)
+	}
+	pj.wts[1][2] = float32(math.NaN())
+	hid.rcv = emer.Prjns{pj}
+	return &testNet{lays: []*testLay{in, hid}}
+}
+
+// sameVals returns true if the values are equal, with NaN equal to NaN
+func sameVals(a, b []float32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if math.IsNaN(float64(v)) != math.IsNaN(float64(b[i])) || (!math.IsNaN(float64(v)) && v != b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLayers(t *testing.T) {
+	nt := newTestNet()
+	if nms := LayerNames(nt); !reflect.DeepEqual(nms, []string{"Input", "Hidden"}) {
+		t.Errorf("LayerNames: %v", nms)
+	}
+	if shp, err := LayerShape(nt, "Input"); err != nil || !reflect.DeepEqual(shp, []int{2, 2}) {
+		t.Errorf("LayerShape: %v %v", shp, err)
+	}
+	if vals, err := LayerVals(nt, "Hidden", "Act"); err != nil || !reflect.DeepEqual(vals, []float32{5, 6, 7}) {
+		t.Errorf("LayerVals: %v %v", vals, err)
+	}
+	if _, err := LayerShape(nt, "Nope"); err == nil {
+		t.Errorf("LayerShape unknown layer: no error")
+	}
+	if _, err := LayerVals(nt, "Hidden", "Nope"); err == nil {
+		t.Errorf("LayerVals unknown variable: no error")
+	}
+}
+
+func TestPrjnVals(t *testing.T) {
+	nt := newTestNet()
+	shp, err := PrjnShape(nt, "Hidden", "Input")
+	if err != nil || !reflect.DeepEqual(shp, []int{3, 4}) {
+		t.Errorf("PrjnShape: %v %v", shp, err)
+	}
+	vals, err := PrjnVals(nt, "Hidden", "Input", "Wt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	nan := float32(math.NaN())
+	want := []float32{
+		0, 1, 2, 3,
+		10, 11, nan, 13,
+		20, 21, 22, 23,
+	}
+	if !sameVals(vals, want) {
+		t.Errorf("PrjnVals: %v != %v", vals, want)
+	}
+	if _, err := PrjnVals(nt, "Input", "Hidden", "Wt"); err == nil {
+		t.Errorf("PrjnVals no projection: no error")
+	}
+	if _, err := PrjnShape(nt, "Nope", "Input"); err == nil {
+		t.Errorf("PrjnShape unknown layer: no error")
+	}
+	if _, err := PrjnVals(nt, "Hidden", "Input", "Nope"); err == nil {
+		t.Errorf("PrjnVals unknown variable: no error")
+	}
+}
+
+// readNPZ returns the values of the float32 or int32 .npy arrays in given
+// .npz file, as float32, by file name within the archive
+func readNPZ(t *testing.T, filename string) map[string][]float32 {
+	zr, err := zip.OpenReader(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zr.Close()
+	arrs := make(map[string][]float32)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil || len(b) < 10 || string(b[1:6]) != "NUMPY" {
+			t.Fatalf("%s: not an npy array: %v", f.Name, err)
+		}
+		hl := int(binary.LittleEndian.Uint16(b[8:10]))
+		hdr, data := string(b[10:10+hl]), bytes.NewReader(b[10+hl:])
+		vals := make([]float32, (len(b)-10-hl)/4)
+		if strings.Contains(hdr, "'<i4'") {
+			ivals := make([]int32, len(vals))
+			binary.Read(data, binary.LittleEndian, ivals)
+			for i, v := range ivals {
+				vals[i] = float32(v)
+			}
+		} else {
+			binary.Read(data, binary.LittleEndian, vals)
+		}
+		arrs[f.Name] = vals
+	}
+	return arrs
+}
+
+func TestSaveWtsNPZ(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pyemer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	nt := newTestNet()
+	fnm := filepath.Join(dir, "test.npz")
+	if err := SaveWtsNPZ(nt, fnm); err != nil {
+		t.Fatal(err)
+	}
+	arrs := readNPZ(t, fnm)
+	var nms []string
+	for nm := range arrs {
+		nms = append(nms, nm)
+	}
+	sort.Strings(nms)
+	if want := []string{"Hidden/Input/Wt.npy", "Hidden/Shape.npy", "Input/Shape.npy"}; !reflect.DeepEqual(nms, want) {
+		t.Fatalf("arrays: %v != %v", nms, want)
+	}
+	if shp := arrs["Input/Shape.npy"]; !reflect.DeepEqual(shp, []float32{2, 2}) {
+		t.Errorf("Input/Shape: %v", shp)
+	}
+	if shp := arrs["Hidden/Shape.npy"]; !reflect.DeepEqual(shp, []float32{1, 3}) {
+		t.Errorf("Hidden/Shape: %v", shp)
+	}
+	// the saved weights must match PrjnVals
+	vals, _ := PrjnVals(nt, "Hidden", "Input", "Wt")
+	if wts := arrs["Hidden/Input/Wt.npy"]; !sameVals(wts, vals) {
+		t.Errorf("Hidden/Input/Wt: %v != PrjnVals: %v", wts, vals)
+	}
+	if err := SaveWtsNPZ(nt, filepath.Join(dir, "nodir", "test.npz")); err == nil {
+		t.Errorf("SaveWtsNPZ bad path: no error")
+	}
+}
@@ -0,0 +1,47 @@
+# Copyright (c) 2019, The Emergent Authors. All rights reserved.
+# Use of this source code is governed by a BSD-style
+# license that can be found in the LICENSE file.
+
+# emernp provides NumPy conversion helpers on top of the GoPy-generated
+# emergent bindings, using the pyemer Go package functions, which return
+# flat float32 lists and separate shapes.
+
+import numpy as np
+
+from emergent import go, pyemer
+
+
+def to_array(vals, shape):
+    """to_array converts a go Slice_float32 and shape into a NumPy float32 array"""
+    return np.array(list(vals), dtype=np.float32).reshape(list(shape))
+
+
+def layer_array(net, lay, var):
+    """layer_array returns the values of unit variable var for layer named lay,
+    shaped according to the layer shape"""
+    return to_array(pyemer.LayerVals(net, lay, var), pyemer.LayerShape(net, lay))
+
+
+def prjn_array(net, recv, send, var):
+    """prjn_array returns the values of synaptic variable var for the projection
+    into layer recv from layer send, as an [NRecv, NSend] array, with NaN for
+    unconnected units"""
+    return to_array(pyemer.PrjnVals(net, recv, send, var), pyemer.PrjnShape(net, recv, send))
+
+
+def env_state(env, element):
+    """env_state returns the values of the env state element, shaped according
+    to the element shape"""
+    return to_array(pyemer.EnvState(env, element), pyemer.EnvStateShape(env, element))
+
+
+def to_tensor(arr):
+    """to_tensor converts a NumPy array into an etensor.Float32, e.g., for
+    Layer.ApplyExt or Env.Action"""
+    arr = np.asarray(arr, dtype=np.float32)
+    return pyemer.SetTensorVals(go.Slice_int(list(arr.shape)), go.Slice_float32(arr.ravel().tolist()))
+
+
+def net_arrays(net, var):
+    """net_arrays returns a dict of layer_array for all layers in the network"""
+    return {nm: layer_array(net, nm, var) for nm in pyemer.LayerNames(net)}
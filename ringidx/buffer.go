@@ -0,0 +1,208 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ringidx
+
+import "math"
+
+// Buffer is a fixed-capacity ring buffer of rows of float32 values, where
+// each row (cell) has the same arbitrary CellShape, e.g., time x units.
+// It uses the Idx ring logic, so adding a new row overwrites the oldest one
+// when full, without any copying.  Rows are indexed in order from the
+// oldest (0) to the most recent (Len()-1).  Supports windowed min / max /
+// mean queries over a range of rows.
+type Buffer struct {
+	Ring      Idx       `desc:"ring index logic for the rows"`
+	CellShape []int     `desc:"shape of each row"`
+	CellLen   int       `desc:"number of values in each row: product of CellShape"`
+	Values    []float32 `view:"-" desc:"the values, Ring.Max * CellLen in size"`
+}
+
+// NewBuffer returns a new Buffer with given max number of rows, and
+// cell shape for each row (no shape = 1 value per row)
+func NewBuffer(max int, cellShape ...int) *Buffer {
+	bf := &Buffer{}
+	bf.SetShape(max, cellShape...)
+	return bf
+}
+
+// SetShape sets the max number of rows and the cell shape, and resets
+// the buffer to have no rows
+func (bf *Buffer) SetShape(max int, cellShape ...int) {
+	bf.CellShape = append([]int(nil), cellShape...)
+	bf.CellLen = 1
+	for _, s := range cellShape {
+		bf.CellLen *= s
+	}
+	bf.Ring.Max = max
+	bf.Ring.Reset()
+	sz := max * bf.CellLen
+	if cap(bf.Values) >= sz {
+		bf.Values = bf.Values[:sz]
+	} else {
+		bf.Values = make([]float32, sz)
+	}
+}
+
+// Len returns the number of rows currently stored
+func (bf *Buffer) Len() int {
+	return bf.Ring.Len
+}
+
+// Max returns the maximum number of rows that can be stored
+func (bf *Buffer) Max() int {
+	return bf.Ring.Max
+}
+
+// Reset resets the buffer to have no rows
+func (bf *Buffer) Reset() {
+	bf.Ring.Reset()
+}
+
+// Add adds a new row, overwriting the oldest one if full, and returns
+// the values for the row, to be set.  The values are not cleared.
+func (bf *Buffer) Add() []float32 {
+	bf.Ring.Add(1)
+	st := bf.Ring.LastIdx() * bf.CellLen
+	return bf.Values[st : st+bf.CellLen]
+}
+
+// AddRow adds a new row with given values, which must be CellLen in length
+func (bf *Buffer) AddRow(vals []float32) {
+	copy(bf.Add(), vals)
+}
+
+// Row returns the values for the i'th row, where 0 is the oldest and
+// Len()-1 is the most recent.  Returns nil if i is not valid.
+// The returned slice is the actual storage, so it can also be set.
+func (bf *Buffer) Row(i int) []float32 {
+	if !bf.Ring.IdxIsValid(i) {
+		return nil
+	}
+	st := bf.Ring.Idx(i) * bf.CellLen
+	return bf.Values[st : st+bf.CellLen]
+}
+
+// Last returns the values for the most recent row, nil if empty
+func (bf *Buffer) Last() []float32 {
+	return bf.Row(bf.Ring.Len - 1)
+}
+
+// Val returns the value at given index within the cell (in row-major
+// order according to CellShape) for the i'th row, and false if the
+// indexes are not valid
+func (bf *Buffer) Val(i, ci int) (float32, bool) {
+	if !bf.Ring.IdxIsValid(i) || ci < 0 || ci >= bf.CellLen {
+		return 0, false
+	}
+	return bf.Values[bf.Ring.Idx(i)*bf.CellLen+ci], true
+}
+
+// Window returns the valid start and end (exclusive) rows for a window
+// of n rows starting at row st, clipped to the rows that are present.
+// A negative st counts back from the most recent row, e.g., st = -10,
+// n = 10 is the most recent 10 rows.
+func (bf *Buffer) Window(st, n int) (int, int) {
+	if st < 0 {
+		st += bf.Ring.Len
+		if st < 0 {
+			n += st
+			st = 0
+		}
+	}
+	if n < 0 {
+		n = 0
+	}
+	ed := st + n
+	if ed > bf.Ring.Len {
+		ed = bf.Ring.Len
+	}
+	if st > ed {
+		st = ed
+	}
+	return st, ed
+}
+
+// WindowMinMaxMean returns the min, max, and mean over all values in
+// the window of n rows starting at row st (see Window).
+// Returns NaN values if the window is empty.
+func (bf *Buffer) WindowMinMaxMean(st, n int) (min, max, mean float32) {
+	st, ed := bf.Window(st, n)
+	if st == ed || bf.CellLen == 0 {
+		nan := float32(math.NaN())
+		return nan, nan, nan
+	}
+	min = float32(math.MaxFloat32)
+	max = -float32(math.MaxFloat32)
+	sum := 0.0
+	for i := st; i < ed; i++ {
+		for _, v := range bf.Row(i) {
+			if v < min {
+				min = v
+			}
+			if v > max {
+				max = v
+			}
+			sum += float64(v)
+		}
+	}
+	mean = float32(sum / float64((ed-st)*bf.CellLen))
+	return
+}
+
+// WindowStats computes the element-wise min, max, and mean across the rows
+// in the window of n rows starting at row st (see Window), into the given
+// slices, which are allocated to CellLen if nil or too small, and returned.
+// Any of the slices can be skipped by passing a zero-length non-nil slice.
+// Returns the number of rows in the window -- values are NaN if 0.
+func (bf *Buffer) WindowStats(st, n int, min, max, mean []float32) (nr int, mins, maxs, means []float32) {
+	st, ed := bf.Window(st, n)
+	nr = ed - st
+	mins = bf.statSlice(min)
+	maxs = bf.statSlice(max)
+	means = bf.statSlice(mean)
+	nan := float32(math.NaN())
+	for ci := 0; ci < bf.CellLen; ci++ {
+		mn := float32(math.MaxFloat32)
+		mx := -float32(math.MaxFloat32)
+		sum := 0.0
+		for i := st; i < ed; i++ {
+			v := bf.Values[bf.Ring.Idx(i)*bf.CellLen+ci]
+			if v < mn {
+				mn = v
+			}
+			if v > mx {
+				mx = v
+			}
+			sum += float64(v)
+		}
+		av := float32(sum / float64(nr))
+		if nr == 0 {
+			mn, mx, av = nan, nan, nan
+		}
+		if len(mins) > 0 {
+			mins[ci] = mn
+		}
+		if len(maxs) > 0 {
+			maxs[ci] = mx
+		}
+		if len(means) > 0 {
+			means[ci] = av
+		}
+	}
+	return
+}
+
+// statSlice returns s if non-nil and of sufficient size (or zero-length), else a new slice
+func (bf *Buffer) statSlice(s []float32) []float32 {
+	switch {
+	case s == nil:
+		return make([]float32, bf.CellLen)
+	case len(s) == 0:
+		return s
+	case len(s) < bf.CellLen:
+		return make([]float32, bf.CellLen)
+	}
+	return s[:bf.CellLen]
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ringidx
+
+import (
+	"math"
+	"testing"
+)
+
+// testBuffer returns a buffer of max 4 rows of 2 values each, with n rows
+// added, where row r has values r, -r
+func testBuffer(n int) *Buffer {
+	bf := NewBuffer(4, 2)
+	for r := 0; r < n; r++ {
+		bf.AddRow([]float32{float32(r), -float32(r)})
+	}
+	return bf
+}
+
+func isNaN(v float32) bool {
+	return math.IsNaN(float64(v))
+}
+
+func TestBufferShape(t *testing.T) {
+	bf := NewBuffer(3, 2, 5)
+	if bf.CellLen != 10 || bf.Max() != 3 || len(bf.Values) != 30 || bf.Len() != 0 {
+		t.Errorf("NewBuffer(3, 2, 5): CellLen %d Max %d Values %d Len %d", bf.CellLen, bf.Max(), len(bf.Values), bf.Len())
+	}
+	bf = NewBuffer(3)
+	if bf.CellLen != 1 || len(bf.Values) != 3 {
+		t.Errorf("NewBuffer(3): CellLen %d Values %d", bf.CellLen, len(bf.Values))
+	}
+	if bf.Last() != nil || bf.Row(0) != nil {
+		t.Errorf("empty buffer returned rows")
+	}
+}
+
+func TestBufferRows(t *testing.T) {
+	tests := []struct {
+		added int
+		len   int
+		first float32
+		last  float32
+	}{
+		{1, 1, 0, 0},
+		{3, 3, 0, 2},
+		{4, 4, 0, 3},
+		{5, 4, 1, 4},
+		{7, 4, 3, 6},
+		{9, 4, 5, 8},
+	}
+	for _, ts := range tests {
+		bf := testBuffer(ts.added)
+		if bf.Len() != ts.len {
+			t.Errorf("added %d: Len: %d != %d", ts.added, bf.Len(), ts.len)
+			continue
+		}
+		if v := bf.Row(0)[0]; v != ts.first {
+			t.Errorf("added %d: first row: %v != %v", ts.added, v, ts.first)
+		}
+		if v := bf.Last()[0]; v != ts.last {
+			t.Errorf("added %d: last row: %v != %v", ts.added, v, ts.last)
+		}
+		for i := 0; i < bf.Len(); i++ {
+			ex := ts.first + float32(i)
+			if v, ok := bf.Val(i, 1); !ok || v != -ex {
+				t.Errorf("added %d: Val(%d, 1): %v, %v != %v", ts.added, i, v, ok, -ex)
+			}
+		}
+		if bf.Row(bf.Len()) != nil || bf.Row(-1) != nil {
+			t.Errorf("added %d: invalid Row index returned a row", ts.added)
+		}
+		if _, ok := bf.Val(0, 2); ok {
+			t.Errorf("added %d: invalid Val cell index was valid", ts.added)
+		}
+	}
+}
+
+func TestBufferWindow(t *testing.T) {
+	bf := testBuffer(3)
+	tests := []struct {
+		st, n  int
+		exSt   int
+		exEd   int
+		exName string
+	}{
+		{0, 3, 0, 3, "all"},
+		{0, 10, 0, 3, "clip end"},
+		{1, 1, 1, 2, "middle"},
+		{-2, 2, 1, 3, "last 2"},
+		{-5, 3, 0, 1, "clip start"},
+		{-5, 1, 0, 0, "before start"},
+		{5, 2, 3, 3, "after end"},
+	}
+	for _, ts := range tests {
+		st, ed := bf.Window(ts.st, ts.n)
+		if st != ts.exSt || ed != ts.exEd {
+			t.Errorf("%s: Window(%d, %d): %d, %d != %d, %d", ts.exName, ts.st, ts.n, st, ed, ts.exSt, ts.exEd)
+		}
+	}
+}
+
+func TestBufferWindowMinMaxMean(t *testing.T) {
+	bf := testBuffer(6) // rows 2..5
+	tests := []struct {
+		st, n          int
+		min, max, mean float32
+	}{
+		{0, 4, -5, 5, 0},
+		{-1, 1, -5, 5, 0},
+		{0, 1, -2, 2, 0},
+	}
+	for _, ts := range tests {
+		min, max, mean := bf.WindowMinMaxMean(ts.st, ts.n)
+		if min != ts.min || max != ts.max || mean != ts.mean {
+			t.Errorf("WindowMinMaxMean(%d, %d): %v, %v, %v != %v, %v, %v", ts.st, ts.n, min, max, mean, ts.min, ts.max, ts.mean)
+		}
+	}
+	min, max, mean := bf.WindowMinMaxMean(10, 2)
+	if !isNaN(min) || !isNaN(max) || !isNaN(mean) {
+		t.Errorf("empty window: %v, %v, %v not NaN", min, max, mean)
+	}
+}
+
+func TestBufferWindowStats(t *testing.T) {
+	bf := testBuffer(6) // rows 2..5
+	tests := []struct {
+		st, n int
+		nr    int
+		mins  []float32
+		maxs  []float32
+		means []float32
+	}{
+		{0, 4, 4, []float32{2, -5}, []float32{5, -2}, []float32{3.5, -3.5}},
+		{-2, 2, 2, []float32{4, -5}, []float32{5, -4}, []float32{4.5, -4.5}},
+		{1, 1, 1, []float32{3, -3}, []float32{3, -3}, []float32{3, -3}},
+	}
+	for _, ts := range tests {
+		nr, mins, maxs, means := bf.WindowStats(ts.st, ts.n, nil, nil, nil)
+		if nr != ts.nr {
+			t.Errorf("WindowStats(%d, %d): rows: %d != %d", ts.st, ts.n, nr, ts.nr)
+		}
+		for ci := 0; ci < 2; ci++ {
+			if mins[ci] != ts.mins[ci] || maxs[ci] != ts.maxs[ci] || means[ci] != ts.means[ci] {
+				t.Errorf("WindowStats(%d, %d)[%d]: %v, %v, %v != %v, %v, %v", ts.st, ts.n, ci, mins[ci], maxs[ci], means[ci], ts.mins[ci], ts.maxs[ci], ts.means[ci])
+			}
+		}
+	}
+	mn := make([]float32, 2)
+	nr, mins, maxs, means := bf.WindowStats(0, 4, mn, []float32{}, nil)
+	if nr != 4 || &mins[0] != &mn[0] || len(maxs) != 0 || len(means) != 2 {
+		t.Errorf("WindowStats slices: rows %d, mins reused %v, maxs %d, means %d", nr, &mins[0] == &mn[0], len(maxs), len(means))
+	}
+	nr, mins, _, _ = bf.WindowStats(10, 2, nil, nil, nil)
+	if nr != 0 || !isNaN(mins[0]) {
+		t.Errorf("empty window: rows %d, min %v", nr, mins[0])
+	}
+}
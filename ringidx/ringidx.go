@@ -6,7 +6,10 @@
 Package ringidx provides circular indexing logic for writing a given
 length of data into a fixed-sized buffer and wrapping around this
 buffer, overwriting the oldest data.  No copying is required so
-it is highly efficient
+it is highly efficient.
+
+Buffer provides a fixed-capacity ring buffer of rows of float32 values
+with an arbitrary cell shape, using Idx, with windowed min / max / mean queries.
 */
 package ringidx
 
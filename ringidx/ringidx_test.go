@@ -0,0 +1,95 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ringidx
+
+import "testing"
+
+func TestIdxAdd(t *testing.T) {
+	tests := []struct {
+		name  string
+		adds  []int
+		stIdx int
+		len   int
+		last  int
+	}{
+		{"one", []int{1}, 0, 1, 0},
+		{"fill", []int{5}, 0, 5, 4},
+		{"partial", []int{2, 1}, 0, 3, 2},
+		{"wrap1", []int{5, 1}, 1, 5, 0},
+		{"wrap3", []int{4, 4}, 3, 5, 2},
+		{"wrapAround", []int{5, 5}, 0, 5, 4},
+		{"wrapPast", []int{5, 3, 3}, 1, 5, 0},
+		{"ones", []int{1, 1, 1, 1, 1, 1, 1}, 2, 5, 1},
+	}
+	for _, ts := range tests {
+		ri := Idx{Max: 5}
+		for _, n := range ts.adds {
+			ri.Add(n)
+		}
+		if ri.StIdx != ts.stIdx || ri.Len != ts.len {
+			t.Errorf("%s: StIdx, Len: %d, %d != %d, %d", ts.name, ri.StIdx, ri.Len, ts.stIdx, ts.len)
+		}
+		if li := ri.LastIdx(); li != ts.last {
+			t.Errorf("%s: LastIdx: %d != %d", ts.name, li, ts.last)
+		}
+	}
+}
+
+func TestIdxIdx(t *testing.T) {
+	tests := []struct {
+		stIdx int
+		i     int
+		idx   int
+	}{
+		{0, 0, 0},
+		{0, 4, 4},
+		{2, 0, 2},
+		{2, 2, 4},
+		{2, 3, 0},
+		{2, 4, 1},
+		{4, 1, 0},
+	}
+	for _, ts := range tests {
+		ri := Idx{StIdx: ts.stIdx, Len: 5, Max: 5}
+		if idx := ri.Idx(ts.i); idx != ts.idx {
+			t.Errorf("StIdx %d: Idx(%d): %d != %d", ts.stIdx, ts.i, idx, ts.idx)
+		}
+	}
+}
+
+func TestIdxShift(t *testing.T) {
+	tests := []struct {
+		stIdx int
+		len   int
+		n     int
+		exSt  int
+		exLen int
+	}{
+		{0, 5, 1, 1, 4},
+		{0, 5, 5, 0, 0},
+		{3, 5, 2, 0, 3},
+		{4, 3, 3, 2, 0},
+	}
+	for _, ts := range tests {
+		ri := Idx{StIdx: ts.stIdx, Len: ts.len, Max: 5}
+		ri.Shift(ts.n)
+		if ri.StIdx != ts.exSt || ri.Len != ts.exLen {
+			t.Errorf("Shift(%d) from %d, %d: %d, %d != %d, %d", ts.n, ts.stIdx, ts.len, ri.StIdx, ri.Len, ts.exSt, ts.exLen)
+		}
+	}
+}
+
+func TestIdxIsValid(t *testing.T) {
+	ri := Idx{StIdx: 3, Len: 4, Max: 5}
+	for i, ex := range map[int]bool{-1: false, 0: true, 3: true, 4: false, 5: false} {
+		if v := ri.IdxIsValid(i); v != ex {
+			t.Errorf("IdxIsValid(%d): %v != %v", i, v, ex)
+		}
+	}
+	ri.Reset()
+	if ri.StIdx != 0 || ri.Len != 0 || ri.IdxIsValid(0) {
+		t.Errorf("Reset: %+v", ri)
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package simsrv provides an HTTP server that exposes a running simulation
+for remote monitoring and control, e.g., for headless runs on a cluster
+that can then be checked on from a laptop or a dashboard.
+
+All of the elements are optional -- only those that are set on the Server
+are available:
+
+	GET  /counters                      current counters string (Counters func)
+	GET  /status                        running status and counters for each looper Stack
+	GET  /logs                          list of elog log tables (by Scope, e.g., TrainEpoch)
+	GET  /logs/<Scope>?format=csv|json  contents of log table (default tab-separated csv)
+	POST /init?mode=Train               initialize the looper Stack for mode
+	POST /run?mode=Train                start running the looper Stack for mode
+	POST /stop                          stop all running
+	POST /step?mode=Train&time=Trial&n=1  step n iterations at given time scale
+	POST /params?set=Base&sheet=Network apply params Set sheet to the network
+	POST /savewts?file=wts.wts.gz       save the network weights to file in SaveDir (on server)
+	GET  /netdata?rec=-1                snapshot of recorded NetData values (JSON)
+	GET  /netstream                     WebSocket stream of new NetData records (see netview.Streamer)
+
+The /savewts endpoint is only enabled if SaveDir is set, and only the base
+name of the file is used, so that clients can only write within SaveDir.
+
+All responses are JSON unless noted, and errors are returned with an
+appropriate HTTP status code and a text error message.  Use the Lock to
+coordinate access to shared state with the simulation goroutines.
+
+A gRPC interface could be layered on the same Server methods, but that
+requires generated protocol code and is not provided here.
+*/
+package simsrv
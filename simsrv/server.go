@@ -0,0 +1,438 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simsrv
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/emer/emergent/elog"
+	"github.com/emer/emergent/emer"
+	"github.com/emer/emergent/env"
+	"github.com/emer/emergent/looper"
+	"github.com/emer/emergent/netview"
+	"github.com/emer/emergent/params"
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+	"github.com/goki/gi/gi"
+)
+
+// Server exposes a running simulation over HTTP -- see package docs for
+// the endpoints.  All fields are optional, and only those that are set
+// are available.
+type Server struct {
+	Net      emer.Network     `desc:"network, for applying params and saving weights"`
+	Logs     *elog.Logs       `desc:"logs, for accessing log tables"`
+	Loops    *looper.Set      `desc:"loop control, for init, run, stop, and step"`
+	Params   params.Sets      `desc:"parameter sets that can be applied to the Net"`
+	NetData  *netview.NetData `desc:"recorded network data, e.g., from the NetView Data"`
+	Counters func() string    `desc:"function returning the current counters string"`
+	SaveDir  string           `desc:"directory where /savewts saves weights files, using only the base name of the requested file, so that files can only be written within it -- /savewts is disabled if empty"`
+	Lock     sync.Locker      `desc:"optional lock to coordinate access to shared state (logs, network, data) with the simulation -- if nil, an internal lock only coordinates the handlers with each other"`
+	Mux      *http.ServeMux   `view:"-" desc:"the request multiplexer -- additional handlers can be added to it"`
+	Srv      *http.Server     `view:"-" desc:"the http server, once started"`
+
+	mu sync.Mutex // used by lock if Lock is nil
+}
+
+// Config configures the request multiplexer, and is called automatically by
+// Start if not already done.  Can call first to add further handlers to Mux.
+func (sv *Server) Config() {
+	sv.Mux = http.NewServeMux()
+	sv.Mux.HandleFunc("/counters", sv.HandleCounters)
+	sv.Mux.HandleFunc("/status", sv.HandleStatus)
+	sv.Mux.HandleFunc("/logs", sv.HandleLogs)
+	sv.Mux.HandleFunc("/logs/", sv.HandleLog)
+	sv.Mux.HandleFunc("/init", sv.post(sv.HandleInit))
+	sv.Mux.HandleFunc("/run", sv.post(sv.HandleRun))
+	sv.Mux.HandleFunc("/stop", sv.post(sv.HandleStop))
+	sv.Mux.HandleFunc("/step", sv.post(sv.HandleStep))
+	sv.Mux.HandleFunc("/params", sv.post(sv.HandleParams))
+	sv.Mux.HandleFunc("/savewts", sv.post(sv.HandleSaveWts))
+	sv.Mux.HandleFunc("/netdata", sv.HandleNetData)
+	sv.Mux.HandleFunc("/netstream", sv.HandleNetStream)
+}
+
+// Start starts serving on given address (e.g., ":8080") in a separate goroutine.
+// Errors other than closing are logged.
+func (sv *Server) Start(addr string) {
+	if sv.Mux == nil {
+		sv.Config()
+	}
+	sv.Srv = &http.Server{Addr: addr, Handler: sv.Mux}
+	go func() {
+		err := sv.Srv.ListenAndServe()
+		if err != nil && err != http.ErrServerClosed {
+			log.Println(err)
+		}
+	}()
+}
+
+// Close stops the server
+func (sv *Server) Close() error {
+	if sv.Srv == nil {
+		return nil
+	}
+	return sv.Srv.Close()
+}
+
+func (sv *Server) lock() {
+	if sv.Lock != nil {
+		sv.Lock.Lock()
+	} else {
+		sv.mu.Lock()
+	}
+}
+
+func (sv *Server) unlock() {
+	if sv.Lock != nil {
+		sv.Lock.Unlock()
+	} else {
+		sv.mu.Unlock()
+	}
+}
+
+// post wraps a handler so that it only accepts POST requests
+func (sv *Server) post(fun http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method must be POST", http.StatusMethodNotAllowed)
+			return
+		}
+		fun(w, r)
+	}
+}
+
+// writeJSON writes given value as JSON
+func writeJSON(w http.ResponseWriter, val interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(val); err != nil {
+		log.Println(err)
+	}
+}
+
+// stack returns the looper Stack for mode in the request, writing an error if not found
+func (sv *Server) stack(w http.ResponseWriter, r *http.Request) *looper.Stack {
+	if sv.Loops == nil {
+		http.Error(w, "no loops available", http.StatusNotFound)
+		return nil
+	}
+	mode := r.FormValue("mode")
+	st := sv.Loops.Stack(mode)
+	if st == nil {
+		http.Error(w, fmt.Sprintf("mode: %v not found", mode), http.StatusNotFound)
+	}
+	return st
+}
+
+// HandleCounters returns the current counters string
+func (sv *Server) HandleCounters(w http.ResponseWriter, r *http.Request) {
+	if sv.Counters == nil {
+		http.Error(w, "no counters available", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, sv.Counters())
+}
+
+// Status is the running status for one looper Stack
+type Status struct {
+	Mode     string
+	Running  bool
+	Counters string
+}
+
+// HandleStatus returns the Status of each looper Stack
+func (sv *Server) HandleStatus(w http.ResponseWriter, r *http.Request) {
+	if sv.Loops == nil {
+		http.Error(w, "no loops available", http.StatusNotFound)
+		return
+	}
+	sts := make([]Status, len(sv.Loops.Modes))
+	for i, m := range sv.Loops.Modes {
+		st := sv.Loops.Stack(m)
+		sts[i] = Status{Mode: m, Running: st.IsRunning(), Counters: st.CountersString()}
+	}
+	writeJSON(w, sts)
+}
+
+// HandleLogs returns the list of log tables, by Scope name (e.g., TrainEpoch)
+func (sv *Server) HandleLogs(w http.ResponseWriter, r *http.Request) {
+	if sv.Logs == nil {
+		http.Error(w, "no logs available", http.StatusNotFound)
+		return
+	}
+	var nms []string
+	for _, sc := range sv.Logs.Scopes() {
+		nms = append(nms, sc.String())
+	}
+	writeJSON(w, nms)
+}
+
+// HandleLog returns the contents of the log table named in the path
+// (e.g., /logs/TrainEpoch), as tab-separated csv (default) or JSON (format=json)
+func (sv *Server) HandleLog(w http.ResponseWriter, r *http.Request) {
+	if sv.Logs == nil {
+		http.Error(w, "no logs available", http.StatusNotFound)
+		return
+	}
+	nm := strings.TrimPrefix(r.URL.Path, "/logs/")
+	var dt *etable.Table
+	for _, sc := range sv.Logs.Scopes() {
+		if sc.String() == nm {
+			dt = sv.Logs.Tables[sc]
+			break
+		}
+	}
+	if dt == nil {
+		http.Error(w, fmt.Sprintf("log: %v not found", nm), http.StatusNotFound)
+		return
+	}
+	sv.lock()
+	defer sv.unlock()
+	if r.FormValue("format") == "json" {
+		writeJSON(w, TableRows(dt))
+		return
+	}
+	w.Header().Set("Content-Type", "text/tab-separated-values")
+	if err := dt.WriteCSV(w, etable.Tab, true); err != nil {
+		log.Println(err)
+	}
+}
+
+// TableRows returns the table as a list of rows, each of which is a map
+// from column name to value: string, float64, or []float64 for tensor cells.
+func TableRows(dt *etable.Table) []map[string]interface{} {
+	rows := make([]map[string]interface{}, dt.Rows)
+	for ri := range rows {
+		rw := make(map[string]interface{}, len(dt.Cols))
+		for ci, cl := range dt.Cols {
+			cnm := dt.ColNames[ci]
+			switch {
+			case cl.DataType() == etensor.STRING:
+				rw[cnm] = dt.CellString(cnm, ri)
+			case cl.NumDims() == 1:
+				rw[cnm] = dt.CellFloat(cnm, ri)
+			default:
+				tsr := dt.CellTensor(cnm, ri)
+				vals := make([]float64, tsr.Len())
+				for i := range vals {
+					vals[i] = tsr.FloatVal1D(i)
+				}
+				rw[cnm] = vals
+			}
+		}
+		rows[ri] = rw
+	}
+	return rows
+}
+
+// HandleInit initializes the looper Stack for given mode
+func (sv *Server) HandleInit(w http.ResponseWriter, r *http.Request) {
+	st := sv.stack(w, r)
+	if st == nil {
+		return
+	}
+	sv.lock()
+	running := st.IsRunning()
+	if !running {
+		st.Init()
+	}
+	sv.unlock()
+	if running {
+		http.Error(w, "cannot init while running", http.StatusConflict)
+		return
+	}
+	writeJSON(w, st.CountersString())
+}
+
+// HandleRun starts running the looper Stack for given mode
+func (sv *Server) HandleRun(w http.ResponseWriter, r *http.Request) {
+	st := sv.stack(w, r)
+	if st == nil {
+		return
+	}
+	sv.lock()
+	started := !sv.Loops.IsRunning() && st.GoRun()
+	sv.unlock()
+	if !started {
+		http.Error(w, "already running", http.StatusConflict)
+		return
+	}
+	writeJSON(w, "running: "+st.Mode)
+}
+
+// HandleStop stops all running
+func (sv *Server) HandleStop(w http.ResponseWriter, r *http.Request) {
+	if sv.Loops == nil {
+		http.Error(w, "no loops available", http.StatusNotFound)
+		return
+	}
+	sv.Loops.Stop()
+	writeJSON(w, "stopped")
+}
+
+// HandleStep steps the looper Stack for given mode by n (default 1) iterations
+// at given time scale, waiting until the step is done
+func (sv *Server) HandleStep(w http.ResponseWriter, r *http.Request) {
+	st := sv.stack(w, r)
+	if st == nil {
+		return
+	}
+	var tm env.TimeScales
+	if err := tm.FromString(r.FormValue("time")); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	n := 1
+	if ns := r.FormValue("n"); ns != "" {
+		var err error
+		n, err = strconv.Atoi(ns)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	sv.lock()
+	started := !sv.Loops.IsRunning() && st.GoStep(tm, n)
+	sv.unlock()
+	if !started {
+		http.Error(w, "already running", http.StatusConflict)
+		return
+	}
+	st.Wait()
+	writeJSON(w, st.CountersString())
+}
+
+// HandleParams applies given params set and sheet (default "Network") to the network
+func (sv *Server) HandleParams(w http.ResponseWriter, r *http.Request) {
+	if sv.Net == nil {
+		http.Error(w, "no network available", http.StatusNotFound)
+		return
+	}
+	pset, err := sv.Params.SetByNameTry(r.FormValue("set"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	shnm := r.FormValue("sheet")
+	if shnm == "" {
+		shnm = "Network"
+	}
+	sheet, err := pset.SheetByNameTry(shnm)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	sv.lock()
+	applied, err := sv.Net.ApplyParams(sheet, false)
+	sv.unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, applied)
+}
+
+// HandleSaveWts saves the network weights to given file on the server,
+// within the SaveDir directory
+func (sv *Server) HandleSaveWts(w http.ResponseWriter, r *http.Request) {
+	if sv.Net == nil {
+		http.Error(w, "no network available", http.StatusNotFound)
+		return
+	}
+	if sv.SaveDir == "" {
+		http.Error(w, "saving weights is not enabled (no SaveDir)", http.StatusForbidden)
+		return
+	}
+	fnm := filepath.Base(r.FormValue("file"))
+	if fnm == "" || fnm == "." || fnm == ".." || fnm == string(filepath.Separator) {
+		http.Error(w, "file must be specified", http.StatusBadRequest)
+		return
+	}
+	fnm = filepath.Join(sv.SaveDir, fnm)
+	sv.lock()
+	err := sv.Net.SaveWtsJSON(gi.FileName(fnm))
+	sv.unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, "saved: "+fnm)
+}
+
+// NetSnapshot is a snapshot of the recorded network data for one record
+type NetSnapshot struct {
+	Rec      int                        // record number
+	Counters string                     // counters string for the record
+	Vars     []string                   // variable names
+	Layers   map[string]map[string]Vals // values for layer name, variable name, unit index
+}
+
+// Vals are unit values, which are written as null in JSON when not available (NaN)
+type Vals = netview.JSONVals
+
+// HandleNetData returns a NetSnapshot for given record (rec, default -1 = latest)
+func (sv *Server) HandleNetData(w http.ResponseWriter, r *http.Request) {
+	if sv.NetData == nil {
+		http.Error(w, "no network data available", http.StatusNotFound)
+		return
+	}
+	rec := -1
+	if rs := r.FormValue("rec"); rs != "" {
+		var err error
+		rec, err = strconv.Atoi(rs)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	sv.lock()
+	snap := Snapshot(sv.NetData, rec)
+	sv.unlock()
+	writeJSON(w, snap)
+}
+
+// HandleNetStream streams new NetData records over a WebSocket connection,
+// using the netview.Streamer attached to the NetData (see netview.NewStreamer)
+func (sv *Server) HandleNetStream(w http.ResponseWriter, r *http.Request) {
+	if sv.NetData == nil || sv.NetData.Streamer == nil {
+		http.Error(w, "no network data streamer available", http.StatusNotFound)
+		return
+	}
+	sv.NetData.Streamer.ServeHTTP(w, r)
+}
+
+// Snapshot returns a NetSnapshot for given record number in the NetData,
+// which is -1 for current (last) record, or in [0..Len-1] for prior records.
+func Snapshot(nd *netview.NetData, rec int) *NetSnapshot {
+	snap := &NetSnapshot{Rec: rec, Counters: nd.CounterRec(rec), Vars: nd.Vars}
+	snap.Layers = make(map[string]map[string]Vals, len(nd.LayData))
+	nan := float32(math.NaN())
+	for lnm, ld := range nd.LayData {
+		lv := make(map[string]Vals, len(nd.Vars))
+		for _, vnm := range nd.Vars {
+			vals := make(Vals, ld.NUnits)
+			for ui := range vals {
+				val, ok := nd.UnitVal(lnm, vnm, ui, rec)
+				if !ok {
+					val = nan
+				}
+				vals[ui] = val
+			}
+			lv[vnm] = vals
+		}
+		snap.Layers[lnm] = lv
+	}
+	return snap
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simsrv
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/emer/emergent/emer"
+	"github.com/emer/emergent/env"
+	"github.com/emer/emergent/looper"
+	"github.com/emer/emergent/params"
+	"github.com/goki/gi/gi"
+)
+
+// testNet is a mock network that records the params applied and writes
+// a placeholder weights file -- other emer.Network methods are not used
+type testNet struct {
+	emer.Network
+	applied []*params.Sheet
+}
+
+func (nt *testNet) ApplyParams(pars *params.Sheet, setMsg bool) (bool, error) {
+	nt.applied = append(nt.applied, pars)
+	return true, nil
+}
+
+func (nt *testNet) SaveWtsJSON(filename gi.FileName) error {
+	return ioutil.WriteFile(string(filename), []byte("{}"), 0644)
+}
+
+// testServer returns a configured server with Train and Test stacks of
+// Trial loops, each counting the trials run in trls
+func testServer(trls map[string]int) *Server {
+	sv := &Server{}
+	sv.Net = &testNet{}
+	sv.Loops = &looper.Set{}
+	for _, mode := range []string{"Train", "Test"} {
+		mode := mode
+		st := sv.Loops.NewStack(mode, env.Trial)
+		st.Loop(env.Trial).Max = 3
+		st.Loop(env.Trial).Main.Add("Count", func() { trls[mode]++ })
+	}
+	sv.Loops.Init()
+	sv.Params = params.Sets{{Name: "Base", Sheets: params.Sheets{
+		"Network": &params.Sheet{{Sel: "Layer", Params: params.Params{"Layer.Gi": "1.8"}}},
+	}}}
+	sv.Config()
+	return sv
+}
+
+// request sends a request to the server, returning the status code and
+// the JSON-decoded string response (if the response is a JSON string)
+func request(sv *Server, method, url string) (int, string) {
+	w := httptest.NewRecorder()
+	sv.Mux.ServeHTTP(w, httptest.NewRequest(method, url, nil))
+	var str string
+	if err := json.Unmarshal(w.Body.Bytes(), &str); err != nil {
+		str = w.Body.String()
+	}
+	return w.Code, str
+}
+
+func TestServerPostOnly(t *testing.T) {
+	sv := testServer(map[string]int{})
+	for _, ep := range []string{"/init", "/run", "/stop", "/step", "/params", "/savewts"} {
+		if code, _ := request(sv, "GET", ep+"?mode=Train"); code != http.StatusMethodNotAllowed {
+			t.Errorf("GET %s: status: %d != %d", ep, code, http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func TestServerRunStep(t *testing.T) {
+	trls := map[string]int{}
+	sv := testServer(trls)
+	code, ctrs := request(sv, "POST", "/step?mode=Train&time=Trial&n=2")
+	if code != http.StatusOK || trls["Train"] != 2 || !strings.Contains(ctrs, "Trial:\t2") {
+		t.Errorf("step: status: %d trials: %d counters: %q", code, trls["Train"], ctrs)
+	}
+	if code, _ := request(sv, "POST", "/step?mode=Train&time=Nope"); code != http.StatusBadRequest {
+		t.Errorf("step bad time: status: %d", code)
+	}
+	if code, _ := request(sv, "POST", "/step?mode=Train&time=Trial&n=x"); code != http.StatusBadRequest {
+		t.Errorf("step bad n: status: %d", code)
+	}
+	code, msg := request(sv, "POST", "/run?mode=Train")
+	if code != http.StatusOK || msg != "running: Train" {
+		t.Errorf("run: status: %d msg: %q", code, msg)
+	}
+	sv.Loops.Stack("Train").Wait()
+	if trls["Train"] != 3 {
+		t.Errorf("run after step: trials: %d != 3", trls["Train"])
+	}
+	if code, _ := request(sv, "POST", "/run?mode=Nope"); code != http.StatusNotFound {
+		t.Errorf("run unknown mode: status: %d", code)
+	}
+	if code, _ := request(sv, "POST", "/init?mode=Train"); code != http.StatusOK {
+		t.Errorf("init: status: %d", code)
+	}
+}
+
+func TestServerStopIdle(t *testing.T) {
+	trls := map[string]int{}
+	sv := testServer(trls)
+	if code, _ := request(sv, "POST", "/stop"); code != http.StatusOK {
+		t.Errorf("stop: status: %d", code)
+	}
+	if code, _ := request(sv, "POST", "/run?mode=Test"); code != http.StatusOK {
+		t.Errorf("run after stop: status: %d", code)
+	}
+	sv.Loops.Stack("Test").Wait()
+	if trls["Test"] != 3 {
+		t.Errorf("run after stop while idle: trials: %d != 3", trls["Test"])
+	}
+}
+
+func TestServerRunConflict(t *testing.T) {
+	trls := map[string]int{}
+	sv := testServer(trls)
+	release := make(chan struct{})
+	for _, mode := range []string{"Train", "Test"} {
+		sv.Loops.Stack(mode).Loop(env.Trial).Main.Prepend("Block", func() { <-release })
+	}
+
+	// concurrent run requests: exactly one starts
+	var wg sync.WaitGroup
+	codes := make([]int, 10)
+	for i := range codes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			mode := []string{"Train", "Test"}[i%2]
+			codes[i], _ = request(sv, "POST", "/run?mode="+mode)
+		}(i)
+	}
+	wg.Wait()
+	nok := 0
+	for _, code := range codes {
+		switch code {
+		case http.StatusOK:
+			nok++
+		case http.StatusConflict:
+		default:
+			t.Errorf("concurrent run: status: %d", code)
+		}
+	}
+	if nok != 1 {
+		t.Errorf("concurrent run: %d started != 1", nok)
+	}
+	if !sv.Loops.IsRunning() {
+		t.Fatalf("not running after run")
+	}
+	if code, _ := request(sv, "POST", "/step?mode=Test&time=Trial"); code != http.StatusConflict {
+		t.Errorf("step while running: status: %d", code)
+	}
+	for _, mode := range []string{"Train", "Test"} {
+		if sv.Loops.Stack(mode).IsRunning() {
+			if code, _ := request(sv, "POST", "/init?mode="+mode); code != http.StatusConflict {
+				t.Errorf("init while running: status: %d", code)
+			}
+		}
+	}
+	var sts []Status
+	w := httptest.NewRecorder()
+	sv.Mux.ServeHTTP(w, httptest.NewRequest("GET", "/status", nil))
+	if err := json.Unmarshal(w.Body.Bytes(), &sts); err != nil || len(sts) != 2 || (!sts[0].Running && !sts[1].Running) {
+		t.Errorf("status while running: %v %v", sts, err)
+	}
+	if code, _ := request(sv, "POST", "/stop"); code != http.StatusOK {
+		t.Errorf("stop: status: %d", code)
+	}
+	close(release)
+	sv.Loops.Stack("Train").Wait()
+	sv.Loops.Stack("Test").Wait()
+	if sv.Loops.IsRunning() {
+		t.Errorf("running after stop")
+	}
+}
+
+func TestServerParams(t *testing.T) {
+	sv := testServer(map[string]int{})
+	nt := sv.Net.(*testNet)
+	code, _ := request(sv, "POST", "/params?set=Base")
+	if code != http.StatusOK || len(nt.applied) != 1 || nt.applied[0] != sv.Params[0].Sheets["Network"] {
+		t.Errorf("params: status: %d applied: %v", code, nt.applied)
+	}
+	if code, _ := request(sv, "POST", "/params?set=Nope"); code != http.StatusNotFound {
+		t.Errorf("params unknown set: status: %d", code)
+	}
+	if code, _ := request(sv, "POST", "/params?set=Base&sheet=Nope"); code != http.StatusNotFound {
+		t.Errorf("params unknown sheet: status: %d", code)
+	}
+	sv.Net = nil
+	if code, _ := request(sv, "POST", "/params?set=Base"); code != http.StatusNotFound {
+		t.Errorf("params without network: status: %d", code)
+	}
+}
+
+func TestServerSaveWts(t *testing.T) {
+	sv := testServer(map[string]int{})
+	if code, _ := request(sv, "POST", "/savewts?file=wts.wts"); code != http.StatusForbidden {
+		t.Errorf("savewts without SaveDir: status: %d", code)
+	}
+	dir, err := ioutil.TempDir("", "simsrv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	sv.SaveDir = dir
+	if code, _ := request(sv, "POST", "/savewts?file="); code != http.StatusBadRequest {
+		t.Errorf("savewts without file: status: %d", code)
+	}
+	code, _ := request(sv, "POST", "/savewts?file=../../escape.wts")
+	if code != http.StatusOK {
+		t.Errorf("savewts: status: %d", code)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "escape.wts")); err != nil {
+		t.Errorf("savewts not written within SaveDir: %v", err)
+	}
+}
+
+func TestServerNotAvailable(t *testing.T) {
+	sv := &Server{}
+	sv.Config()
+	for _, ep := range []string{"/counters", "/status", "/logs", "/logs/TrainEpoch", "/netdata", "/netstream"} {
+		if code, _ := request(sv, "GET", ep); code != http.StatusNotFound {
+			t.Errorf("GET %s without data: status: %d != %d", ep, code, http.StatusNotFound)
+		}
+	}
+	for _, ep := range []string{"/init?mode=Train", "/run?mode=Train", "/stop", "/step?mode=Train&time=Trial"} {
+		if code, _ := request(sv, "POST", ep); code != http.StatusNotFound {
+			t.Errorf("POST %s without loops: status: %d != %d", ep, code, http.StatusNotFound)
+		}
+	}
+	sv.Counters = func() string { return "Epoch: 3" }
+	if code, ctrs := request(sv, "GET", "/counters"); code != http.StatusOK || ctrs != "Epoch: 3" {
+		t.Errorf("counters: status: %d %q", code, ctrs)
+	}
+}
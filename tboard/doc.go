@@ -0,0 +1,24 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package tboard exports simulation metrics to TensorBoard event files, so
+emergent runs show up in standard experiment-tracking dashboards:
+scalars (e.g., per-epoch log items), histograms (e.g., weights), and images
+(e.g., NetView snapshots).
+
+The event files are written directly (TFRecord format with minimal protobuf
+encoding), so no TensorFlow installation is needed.  View with:
+
+	tensorboard --logdir <dir>
+
+Weights & Biases (W&B) can import the same event files, either live with
+wandb.init(sync_tensorboard=True) or afterward with:
+
+	wandb sync <dir>
+
+The Exporter mirrors selected items from elog.Logs tables as scalars,
+and adds weight histograms for each projection in a network.
+*/
+package tboard
@@ -0,0 +1,87 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tboard
+
+import (
+	"github.com/emer/emergent/elog"
+	"github.com/emer/emergent/emer"
+	"github.com/emer/emergent/env"
+)
+
+// Exporter mirrors selected items from elog.Logs tables to a TensorBoard
+// Writer as scalars, with tags of the form Mode/Time/Item
+// (e.g., Train/Epoch/PctErr), and adds weight histograms.
+type Exporter struct {
+	Writer *Writer                 `desc:"the event file writer"`
+	Logs   *elog.Logs              `desc:"the logs to export from"`
+	Items  map[elog.Scope][]string `desc:"names of the items to export for each scope"`
+	Steps  map[elog.Scope]string   `desc:"optional name of the item to use as the step for each scope (e.g., Epoch) -- otherwise the table row is used"`
+}
+
+// AddItems adds items to export for given mode and time, e.g., ("Train", env.Epoch, "PctErr", "SSE")
+func (ex *Exporter) AddItems(mode string, time env.TimeScales, items ...string) {
+	if ex.Items == nil {
+		ex.Items = make(map[elog.Scope][]string)
+	}
+	sc := elog.Scope{Mode: mode, Time: time}
+	ex.Items[sc] = append(ex.Items[sc], items...)
+}
+
+// SetStep sets the item to use as the step for given mode and time
+func (ex *Exporter) SetStep(mode string, time env.TimeScales, item string) {
+	if ex.Steps == nil {
+		ex.Steps = make(map[elog.Scope]string)
+	}
+	ex.Steps[elog.Scope{Mode: mode, Time: time}] = item
+}
+
+// Export writes the selected items for given row of the log for given mode
+// and time -- typically called right after Logs.Log, with the returned row
+func (ex *Exporter) Export(mode string, time env.TimeScales, row int) error {
+	sc := elog.Scope{Mode: mode, Time: time}
+	its, has := ex.Items[sc]
+	if !has {
+		return nil
+	}
+	dt := ex.Logs.Table(mode, time)
+	if dt == nil || row < 0 || row >= dt.Rows {
+		return nil
+	}
+	step := row
+	if snm, has := ex.Steps[sc]; has {
+		step = int(dt.CellFloat(snm, row))
+	}
+	pfx := mode + "/" + time.String() + "/"
+	for _, it := range its {
+		if err := ex.Writer.AddScalar(pfx+it, step, dt.CellFloat(it, row)); err != nil {
+			return err
+		}
+	}
+	return ex.Writer.Flush()
+}
+
+// WtHists adds a histogram of the given synaptic variable (e.g., "Wt") for
+// each projection in the network, with tags of the form var/Prjn
+func (ex *Exporter) WtHists(net emer.Network, varNm string, step int) error {
+	var vals []float32
+	var fv []float64
+	for li := 0; li < net.NLayers(); li++ {
+		ly := net.Layer(li)
+		for pi := 0; pi < ly.NRecvPrjns(); pi++ {
+			pj := ly.RecvPrjn(pi)
+			if err := pj.SynVals(&vals, varNm); err != nil {
+				return err
+			}
+			fv = fv[:0]
+			for _, v := range vals {
+				fv = append(fv, float64(v))
+			}
+			if err := ex.Writer.AddHistogram(varNm+"/"+pj.Name(), step, fv, 30); err != nil {
+				return err
+			}
+		}
+	}
+	return ex.Writer.Flush()
+}
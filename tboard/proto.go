@@ -0,0 +1,122 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tboard
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// minimal protobuf encoding of the tensorflow Event messages
+
+// pbuf is a protobuf message buffer
+type pbuf []byte
+
+func (pb *pbuf) varint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	*pb = append(*pb, b[:n]...)
+}
+
+func (pb *pbuf) fixed64(v uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	*pb = append(*pb, b[:]...)
+}
+
+func (pb *pbuf) fixed32(v uint32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	*pb = append(*pb, b[:]...)
+}
+
+func (pb *pbuf) key(field int, wire int) {
+	pb.varint(uint64(field<<3 | wire))
+}
+
+func (pb *pbuf) double(field int, v float64) {
+	pb.key(field, 1)
+	pb.fixed64(math.Float64bits(v))
+}
+
+func (pb *pbuf) float(field int, v float32) {
+	pb.key(field, 5)
+	pb.fixed32(math.Float32bits(v))
+}
+
+func (pb *pbuf) int(field int, v int64) {
+	pb.key(field, 0)
+	pb.varint(uint64(v))
+}
+
+func (pb *pbuf) bytes(field int, b []byte) {
+	pb.key(field, 2)
+	pb.varint(uint64(len(b)))
+	*pb = append(*pb, b...)
+}
+
+func (pb *pbuf) string(field int, s string) {
+	pb.bytes(field, []byte(s))
+}
+
+func (pb *pbuf) packedDoubles(field int, vs []float64) {
+	var b pbuf
+	for _, v := range vs {
+		b.fixed64(math.Float64bits(v))
+	}
+	pb.bytes(field, b)
+}
+
+// event returns an encoded Event with given wall time, step, and
+// encoded Summary, or file_version if summary is nil
+func event(wall float64, step int64, fileVersion string, summary []byte) []byte {
+	var pb pbuf
+	pb.double(1, wall)
+	pb.int(2, step)
+	if summary == nil {
+		pb.string(3, fileVersion)
+	} else {
+		pb.bytes(5, summary)
+	}
+	return pb
+}
+
+// summaryValue returns an encoded Summary with one Value with given tag,
+// and value field (simple_value = 2, image = 4, histo = 5)
+func summaryValue(tag string, field int, val []byte, simple float32) []byte {
+	var vb pbuf
+	vb.string(1, tag)
+	if field == 2 {
+		vb.float(2, simple)
+	} else {
+		vb.bytes(field, val)
+	}
+	var sb pbuf
+	sb.bytes(1, vb)
+	return sb
+}
+
+// histogram returns an encoded HistogramProto
+func histogram(min, max, num, sum, sumSq float64, limits, counts []float64) []byte {
+	var pb pbuf
+	pb.double(1, min)
+	pb.double(2, max)
+	pb.double(3, num)
+	pb.double(4, sum)
+	pb.double(5, sumSq)
+	pb.packedDoubles(6, limits)
+	pb.packedDoubles(7, counts)
+	return pb
+}
+
+// image returns an encoded Summary.Image, with PNG data
+func image(height, width, colorspace int, png []byte) []byte {
+	var pb pbuf
+	pb.int(1, int64(height))
+	pb.int(2, int64(width))
+	pb.int(3, int64(colorspace))
+	pb.bytes(4, png)
+	return pb
+}
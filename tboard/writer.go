@@ -0,0 +1,202 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tboard
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	goimage "image"
+	"image/png"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Writer writes TensorBoard event files.  It is safe for concurrent use.
+type Writer struct {
+	Dir      string `desc:"directory where the event file is written -- each run should have its own directory"`
+	FileName string `desc:"full path of the event file"`
+
+	mu   sync.Mutex
+	file *os.File
+	bw   *bufio.Writer
+}
+
+// NewWriter creates a new Writer writing an event file in given directory,
+// which is created if it does not exist.
+func NewWriter(dir string) (*Writer, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	host, _ := os.Hostname()
+	wr := &Writer{Dir: dir}
+	wr.FileName = filepath.Join(dir, fmt.Sprintf("events.out.tfevents.%d.%s", time.Now().Unix(), host))
+	fp, err := os.Create(wr.FileName)
+	if err != nil {
+		return nil, err
+	}
+	wr.file = fp
+	wr.bw = bufio.NewWriter(fp)
+	if err := wr.write(event(wallTime(), 0, "brain.Event:2", nil)); err != nil {
+		fp.Close()
+		return nil, err
+	}
+	return wr, nil
+}
+
+func wallTime() float64 {
+	return float64(time.Now().UnixNano()) / 1.0e9
+}
+
+// AddScalar adds a scalar value with given tag at given step
+func (wr *Writer) AddScalar(tag string, step int, val float64) error {
+	return wr.write(event(wallTime(), int64(step), "", summaryValue(tag, 2, nil, float32(val))))
+}
+
+// AddHistogram adds a histogram of the values with given tag at given step,
+// using nBins equal-sized bins spanning the range of the values.
+// NaN values are ignored.
+func (wr *Writer) AddHistogram(tag string, step int, vals []float64, nBins int) error {
+	var vs []float64
+	for _, v := range vals {
+		if !math.IsNaN(v) {
+			vs = append(vs, v)
+		}
+	}
+	if len(vs) == 0 {
+		return nil
+	}
+	sort.Float64s(vs)
+	min := vs[0]
+	max := vs[len(vs)-1]
+	if nBins < 1 {
+		nBins = 30
+	}
+	bsz := (max - min) / float64(nBins)
+	if bsz == 0 {
+		nBins = 1
+		bsz = 1
+	}
+	limits := make([]float64, nBins)
+	counts := make([]float64, nBins)
+	for i := range limits {
+		limits[i] = min + float64(i+1)*bsz
+	}
+	limits[nBins-1] = max
+	var sum, ssq float64
+	bi := 0
+	for _, v := range vs {
+		for bi < nBins-1 && v > limits[bi] {
+			bi++
+		}
+		counts[bi]++
+		sum += v
+		ssq += v * v
+	}
+	hb := histogram(min, max, float64(len(vs)), sum, ssq, limits, counts)
+	return wr.write(event(wallTime(), int64(step), "", summaryValue(tag, 5, hb, 0)))
+}
+
+// AddImage adds an image (e.g., a NetView snapshot) with given tag at given step
+func (wr *Writer) AddImage(tag string, step int, img goimage.Image) error {
+	var b bytes.Buffer
+	if err := png.Encode(&b, img); err != nil {
+		return err
+	}
+	sz := img.Bounds().Size()
+	ib := image(sz.Y, sz.X, 4, b.Bytes()) // 4 = RGBA
+	return wr.write(event(wallTime(), int64(step), "", summaryValue(tag, 4, ib, 0)))
+}
+
+// Flush flushes any buffered events to the file
+func (wr *Writer) Flush() error {
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+	if wr.bw == nil {
+		return nil
+	}
+	return wr.bw.Flush()
+}
+
+// Close flushes and closes the event file
+func (wr *Writer) Close() error {
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+	if wr.file == nil {
+		return nil
+	}
+	err := wr.bw.Flush()
+	if cerr := wr.file.Close(); err == nil {
+		err = cerr
+	}
+	wr.file = nil
+	wr.bw = nil
+	return err
+}
+
+// write writes one event record
+func (wr *Writer) write(ev []byte) error {
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+	if wr.bw == nil {
+		return fmt.Errorf("tboard.Writer: file is closed")
+	}
+	return WriteRecord(wr.bw, ev)
+}
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// maskedCRC returns the masked crc32c checksum used in TFRecord files
+func maskedCRC(b []byte) uint32 {
+	crc := crc32.Checksum(b, crcTable)
+	return ((crc >> 15) | (crc << 17)) + 0xa282ead8
+}
+
+// WriteRecord writes data as one TFRecord: length, length crc, data, data crc
+func WriteRecord(w io.Writer, data []byte) error {
+	var hdr [12]byte
+	binary.LittleEndian.PutUint64(hdr[:8], uint64(len(data)))
+	binary.LittleEndian.PutUint32(hdr[8:], maskedCRC(hdr[:8]))
+	var ftr [4]byte
+	binary.LittleEndian.PutUint32(ftr[:], maskedCRC(data))
+	if _, err := w.Write(hdr[:]); err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	_, err := w.Write(ftr[:])
+	return err
+}
+
+// ReadRecord reads one TFRecord written by WriteRecord, checking the checksums
+func ReadRecord(r io.Reader) ([]byte, error) {
+	var hdr [12]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return nil, err
+	}
+	if binary.LittleEndian.Uint32(hdr[8:]) != maskedCRC(hdr[:8]) {
+		return nil, fmt.Errorf("tboard.ReadRecord: length checksum mismatch")
+	}
+	data := make([]byte, binary.LittleEndian.Uint64(hdr[:8]))
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	var ftr [4]byte
+	if _, err := io.ReadFull(r, ftr[:]); err != nil {
+		return nil, err
+	}
+	if binary.LittleEndian.Uint32(ftr[:]) != maskedCRC(data) {
+		return nil, fmt.Errorf("tboard.ReadRecord: data checksum mismatch")
+	}
+	return data, nil
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tboard
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestWriter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tboard")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	wr, err := NewWriter(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for ep := 0; ep < 3; ep++ {
+		if err := wr.AddScalar("Train/Epoch/SSE", ep, 1/float64(ep+1)); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := wr.AddHistogram("Wt/InputToHidden", 2, []float64{0.1, 0.5, 0.5, 0.9}, 4); err != nil {
+		t.Error(err)
+	}
+	if err := wr.Close(); err != nil {
+		t.Error(err)
+	}
+	b, err := ioutil.ReadFile(wr.FileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := bytes.NewReader(b)
+	n := 0
+	for {
+		data, err := ReadRecord(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n == 0 && !bytes.Contains(data, []byte("brain.Event:2")) {
+			t.Errorf("first record should be file version\n")
+		}
+		if n == 1 && !bytes.Contains(data, []byte("Train/Epoch/SSE")) {
+			t.Errorf("second record should have scalar tag\n")
+		}
+		n++
+	}
+	if n != 5 {
+		t.Errorf("number of records: %d != 5\n", n)
+	}
+}
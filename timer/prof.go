@@ -0,0 +1,194 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PathSep is the separator between names in the path of nested profile timers
+var PathSep = "/"
+
+// Profiler is a hierarchical profiler, with named timers nested within
+// each other (e.g., Cycle / Layer / Prjn), accumulated separately for each
+// thread to avoid any locking overhead during timing, and combined for Report.
+//
+//	pr := &timer.Profiler{}
+//	th := pr.Thread(0) // one per thread -- each Thread is only used by one goroutine
+//	th.Start("Cycle")
+//	th.Start("SendSpikes")
+//	...
+//	th.Stop() // SendSpikes
+//	th.Stop() // Cycle
+//	fmt.Println(pr.Report())
+type Profiler struct {
+	Off     bool                `desc:"if true, profiling is turned off, and Start / Stop do nothing"`
+	Threads map[int]*ProfThread `desc:"the per-thread timers, by thread number"`
+
+	mu sync.Mutex
+}
+
+// Thread returns the ProfThread for given thread number, creating it if needed.
+// Each ProfThread must only be used by one goroutine at a time.
+func (pr *Profiler) Thread(thr int) *ProfThread {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	if pr.Threads == nil {
+		pr.Threads = make(map[int]*ProfThread)
+	}
+	th, has := pr.Threads[thr]
+	if !has {
+		th = &ProfThread{Prof: pr, Thread: thr, Timers: make(map[string]*ProfTimer)}
+		pr.Threads[thr] = th
+	}
+	return th
+}
+
+// Reset resets all the accumulated times.  It must not be called while
+// any thread is running its timers (e.g., only between runs, after all
+// the threads have finished), as the ProfThreads are not locked.
+func (pr *Profiler) Reset() {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	for _, th := range pr.Threads {
+		th.Reset()
+	}
+}
+
+// ProfTimer is one named timer within a ProfThread
+type ProfTimer struct {
+	Path  string `desc:"full path of names from the top-level timer, separated by PathSep"`
+	Name  string `desc:"name of this timer"`
+	Depth int    `desc:"nesting depth, 0 = top level"`
+	Time  Time   `desc:"accumulated time"`
+}
+
+// ProfThread accumulates the nested timers for one thread
+type ProfThread struct {
+	Prof   *Profiler             `desc:"profiler that we belong to"`
+	Thread int                   `desc:"thread number"`
+	Timers map[string]*ProfTimer `desc:"timers by path"`
+	Stack  []*ProfTimer          `desc:"stack of currently running timers"`
+}
+
+// Start starts the timer of given name, nested within the currently
+// running timer, if any
+func (th *ProfThread) Start(name string) {
+	if th.Prof.Off {
+		return
+	}
+	path := name
+	if n := len(th.Stack); n > 0 {
+		path = th.Stack[n-1].Path + PathSep + name
+	}
+	tm, has := th.Timers[path]
+	if !has {
+		tm = &ProfTimer{Path: path, Name: name, Depth: len(th.Stack)}
+		th.Timers[path] = tm
+	}
+	th.Stack = append(th.Stack, tm)
+	tm.Time.Start()
+}
+
+// Stop stops the most recently started timer, returning its latest interval
+func (th *ProfThread) Stop() time.Duration {
+	n := len(th.Stack)
+	if th.Prof.Off || n == 0 {
+		return 0
+	}
+	tm := th.Stack[n-1]
+	th.Stack = th.Stack[:n-1]
+	return tm.Time.Stop()
+}
+
+// Reset resets all the accumulated times -- only call from the goroutine
+// using this thread, or when it is not running (see Profiler.Reset)
+func (th *ProfThread) Reset() {
+	for _, tm := range th.Timers {
+		tm.Time.Reset()
+	}
+	th.Stack = th.Stack[:0]
+}
+
+// ProfReport is the report for one named timer, combined across threads
+type ProfReport struct {
+	Path    string        `desc:"full path of names from the top-level timer, separated by PathSep"`
+	Name    string        `desc:"name of this timer"`
+	Depth   int           `desc:"nesting depth, 0 = top level"`
+	Total   time.Duration `desc:"total time summed across threads"`
+	Self    time.Duration `desc:"total time not accounted for by nested timers"`
+	N       int           `desc:"number of start / stops summed across threads"`
+	Threads int           `desc:"number of threads that ran this timer"`
+	Pct     float64       `desc:"percent of the total time of all top-level timers"`
+}
+
+// Avg returns the average time per start / stop
+func (rp *ProfReport) Avg() time.Duration {
+	if rp.N == 0 {
+		return 0
+	}
+	return rp.Total / time.Duration(rp.N)
+}
+
+// ProfReports is a list of ProfReport
+type ProfReports []*ProfReport
+
+// Report returns the report for each named timer, combined across threads,
+// sorted by Total time, highest first.  As with Reset, it must not be
+// called while any thread is running its timers.
+func (pr *Profiler) Report() ProfReports {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	rpm := make(map[string]*ProfReport)
+	for _, th := range pr.Threads {
+		for path, tm := range th.Timers {
+			rp, has := rpm[path]
+			if !has {
+				rp = &ProfReport{Path: path, Name: tm.Name, Depth: tm.Depth}
+				rpm[path] = rp
+			}
+			rp.Total += tm.Time.Total
+			rp.N += tm.Time.N
+			rp.Threads++
+		}
+	}
+	var top time.Duration
+	for path, rp := range rpm {
+		rp.Self += rp.Total
+		if rp.Depth == 0 {
+			top += rp.Total
+		} else if par, has := rpm[path[:strings.LastIndex(path, PathSep)]]; has {
+			par.Self -= rp.Total
+		}
+	}
+	rps := make(ProfReports, 0, len(rpm))
+	for _, rp := range rpm {
+		if top > 0 {
+			rp.Pct = 100 * float64(rp.Total) / float64(top)
+		}
+		rps = append(rps, rp)
+	}
+	sort.Slice(rps, func(i, j int) bool {
+		if rps[i].Total == rps[j].Total {
+			return rps[i].Path < rps[j].Path
+		}
+		return rps[i].Total > rps[j].Total
+	})
+	return rps
+}
+
+// String returns a multi-line report, one line per timer
+func (rps ProfReports) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-40s\t%12s\t%12s\t%12s\t%10s\t%6s\n", "Path", "Total", "Self", "Avg", "N", "Pct")
+	for _, rp := range rps {
+		fmt.Fprintf(&b, "%-40s\t%12v\t%12v\t%12v\t%10d\t%6.2f\n", rp.Path, rp.Total, rp.Self, rp.Avg(), rp.N, rp.Pct)
+	}
+	return b.String()
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timer
+
+import (
+	"testing"
+	"time"
+)
+
+// runNested starts and stops the nested timers Cycle / Layer / Prjn,
+// and Cycle / Inhib on given thread
+func runNested(th *ProfThread) {
+	th.Start("Cycle")
+	th.Start("Layer")
+	th.Start("Prjn")
+	th.Stop()
+	th.Stop()
+	th.Start("Inhib")
+	th.Stop()
+	th.Stop()
+}
+
+// setTotal sets the Total time of timer at given path on given thread
+func setTotal(t *testing.T, th *ProfThread, path string, tot time.Duration) {
+	tm, has := th.Timers[path]
+	if !has {
+		t.Fatalf("thread: %d: no timer: %s", th.Thread, path)
+	}
+	tm.Time.Total = tot
+}
+
+func TestProfilerNested(t *testing.T) {
+	pr := &Profiler{}
+	th0 := pr.Thread(0)
+	th1 := pr.Thread(1)
+	if pr.Thread(0) != th0 || len(pr.Threads) != 2 {
+		t.Fatalf("Thread: not reused")
+	}
+	runNested(th0)
+	runNested(th0)
+	runNested(th1)
+	if len(th0.Stack) != 0 {
+		t.Errorf("Stack not empty after Stop: %d", len(th0.Stack))
+	}
+	tm := th0.Timers["Cycle/Layer/Prjn"]
+	if tm == nil || tm.Name != "Prjn" || tm.Depth != 2 || tm.Time.N != 2 {
+		t.Fatalf("nested timer: %+v", tm)
+	}
+
+	// set times to known values, to check the Self time arithmetic
+	ms := time.Millisecond
+	setTotal(t, th0, "Cycle", 100*ms)
+	setTotal(t, th0, "Cycle/Layer", 60*ms)
+	setTotal(t, th0, "Cycle/Layer/Prjn", 50*ms)
+	setTotal(t, th0, "Cycle/Inhib", 10*ms)
+	setTotal(t, th1, "Cycle", 100*ms)
+	setTotal(t, th1, "Cycle/Layer", 40*ms)
+	setTotal(t, th1, "Cycle/Layer/Prjn", 30*ms)
+	setTotal(t, th1, "Cycle/Inhib", 20*ms)
+
+	rps := pr.Report()
+	want := []struct {
+		path        string
+		total, self time.Duration
+		n           int
+		pct         float64
+	}{ // sorted by Total
+		{"Cycle", 200 * ms, 70 * ms, 3, 100},
+		{"Cycle/Layer", 100 * ms, 20 * ms, 3, 50},
+		{"Cycle/Layer/Prjn", 80 * ms, 80 * ms, 3, 40},
+		{"Cycle/Inhib", 30 * ms, 30 * ms, 3, 15},
+	}
+	if len(rps) != len(want) {
+		t.Fatalf("Report: %d reports != %d:\n%s", len(rps), len(want), rps)
+	}
+	for i, w := range want {
+		rp := rps[i]
+		if rp.Path != w.path || rp.Total != w.total || rp.Self != w.self || rp.N != w.n || rp.Threads != 2 || rp.Pct != w.pct {
+			t.Errorf("Report: %d: %+v != %+v", i, rp, w)
+		}
+	}
+	if avg := rps[0].Avg(); avg != 200*ms/3 {
+		t.Errorf("Avg: %v", avg)
+	}
+
+	pr.Reset()
+	for _, rp := range pr.Report() {
+		if rp.Total != 0 || rp.Self != 0 || rp.N != 0 {
+			t.Errorf("Reset: %+v", rp)
+		}
+	}
+}
+
+func TestProfilerOff(t *testing.T) {
+	pr := &Profiler{Off: true}
+	th := pr.Thread(0)
+	th.Start("Cycle")
+	if iv := th.Stop(); iv != 0 || len(th.Timers) != 0 || len(th.Stack) != 0 {
+		t.Errorf("Off: timers: %d", len(th.Timers))
+	}
+	pr.Off = false
+	if iv := th.Stop(); iv != 0 {
+		t.Errorf("Stop with no timer started: %v", iv)
+	}
+}
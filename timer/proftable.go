@@ -0,0 +1,43 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timer
+
+import (
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+)
+
+// Table returns the profile report as an etable.Table, sorted by Total time,
+// with times in seconds.
+func (pr *Profiler) Table() *etable.Table {
+	rps := pr.Report()
+	sch := etable.Schema{
+		{"Path", etensor.STRING, nil, nil},
+		{"Name", etensor.STRING, nil, nil},
+		{"Depth", etensor.INT64, nil, nil},
+		{"Total", etensor.FLOAT64, nil, nil},
+		{"Self", etensor.FLOAT64, nil, nil},
+		{"Avg", etensor.FLOAT64, nil, nil},
+		{"N", etensor.INT64, nil, nil},
+		{"Threads", etensor.INT64, nil, nil},
+		{"Pct", etensor.FLOAT64, nil, nil},
+	}
+	dt := &etable.Table{}
+	dt.SetFromSchema(sch, len(rps))
+	dt.SetMetaData("name", "Profile")
+	dt.SetMetaData("desc", "Hierarchical profile timing, sorted by Total time, in seconds")
+	for ri, rp := range rps {
+		dt.SetCellString("Path", ri, rp.Path)
+		dt.SetCellString("Name", ri, rp.Name)
+		dt.SetCellFloat("Depth", ri, float64(rp.Depth))
+		dt.SetCellFloat("Total", ri, rp.Total.Seconds())
+		dt.SetCellFloat("Self", ri, rp.Self.Seconds())
+		dt.SetCellFloat("Avg", ri, rp.Avg().Seconds())
+		dt.SetCellFloat("N", ri, float64(rp.N))
+		dt.SetCellFloat("Threads", ri, float64(rp.Threads))
+		dt.SetCellFloat("Pct", ri, rp.Pct)
+	}
+	return dt
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package weights
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// PrjnDiff records the structural and numerical differences between the
+// weights for a projection in two networks, A and B
+type PrjnDiff struct {
+	Layer      string  // name of receiving layer
+	From       string  // name of sending layer -- empty if this is a layer-level entry for a layer present in only one network
+	Status     string  // "A and B" if present in both, else "only A" or "only B"
+	N          int     // number of synapses present in both A and B
+	NOnlyA     int     // number of synapses present only in A
+	NOnlyB     int     // number of synapses present only in B
+	MeanAbsDif float32 // mean absolute difference B - A over synapses present in both
+	MaxAbsDif  float32 // maximum absolute difference B - A over synapses present in both
+	RMSDif     float32 // root-mean-squared difference B - A over synapses present in both
+}
+
+// String returns a one-line summary of the diff
+func (pd *PrjnDiff) String() string {
+	if pd.From == "" {
+		return fmt.Sprintf("Layer: %s\t%s", pd.Layer, pd.Status)
+	}
+	return fmt.Sprintf("%s <- %s\t%s\tN: %d\tOnlyA: %d\tOnlyB: %d\tMeanAbs: %g\tMaxAbs: %g\tRMS: %g", pd.Layer, pd.From, pd.Status, pd.N, pd.NOnlyA, pd.NOnlyB, pd.MeanAbsDif, pd.MaxAbsDif, pd.RMSDif)
+}
+
+// NetDiff is the full set of differences between weights for two networks, A and B,
+// with one entry for each layer present in only one of the networks,
+// and one for each projection present in either.
+type NetDiff struct {
+	A     string      // name of A network (e.g., file name)
+	B     string      // name of B network (e.g., file name)
+	Diffs []*PrjnDiff // one per layer in only one network, and per projection
+}
+
+// Diff returns the structural and numerical differences between the weights
+// of networks a and b, e.g., for regression-testing algorithm changes
+// against saved golden weights.  See NetDiff.Table for an etable report.
+func Diff(a, b *Network) *NetDiff {
+	nd := &NetDiff{A: a.Network, B: b.Network}
+	for li := range a.Layers {
+		al := &a.Layers[li]
+		bl := b.LayerByName(al.Layer)
+		if bl == nil {
+			nd.Diffs = append(nd.Diffs, &PrjnDiff{Layer: al.Layer, Status: "only A"})
+			continue
+		}
+		for pi := range al.Prjns {
+			ap := &al.Prjns[pi]
+			bp := bl.PrjnByFrom(ap.From)
+			if bp == nil {
+				nd.Diffs = append(nd.Diffs, &PrjnDiff{Layer: al.Layer, From: ap.From, Status: "only A", NOnlyA: ap.NSyns()})
+				continue
+			}
+			nd.Diffs = append(nd.Diffs, DiffPrjn(al.Layer, ap, bp))
+		}
+		for pi := range bl.Prjns {
+			bp := &bl.Prjns[pi]
+			if al.PrjnByFrom(bp.From) == nil {
+				nd.Diffs = append(nd.Diffs, &PrjnDiff{Layer: al.Layer, From: bp.From, Status: "only B", NOnlyB: bp.NSyns()})
+			}
+		}
+	}
+	for li := range b.Layers {
+		bl := &b.Layers[li]
+		if a.LayerByName(bl.Layer) == nil {
+			nd.Diffs = append(nd.Diffs, &PrjnDiff{Layer: bl.Layer, Status: "only B"})
+		}
+	}
+	return nd
+}
+
+// DiffFiles returns the differences between the weights in two files,
+// opened using OpenNetJSON -- see Diff for details.
+func DiffFiles(afile, bfile string) (*NetDiff, error) {
+	a, err := OpenNetJSON(afile)
+	if err != nil {
+		return nil, err
+	}
+	b, err := OpenNetJSON(bfile)
+	if err != nil {
+		return nil, err
+	}
+	if a == nil || b == nil {
+		return nil, fmt.Errorf("weights.DiffFiles: empty weights file")
+	}
+	nd := Diff(a, b)
+	nd.A = afile
+	nd.B = bfile
+	return nd, nil
+}
+
+// DiffPrjn returns the differences between weights for projections a and b
+// into given receiving layer, matching synapses by recv and send unit indexes.
+func DiffPrjn(layer string, a, b *Prjn) *PrjnDiff {
+	pd := &PrjnDiff{Layer: layer, From: a.From, Status: "A and B"}
+	var sumAbs, sumSq float64
+	for ri := range a.Rs {
+		ar := &a.Rs[ri]
+		br := b.RecvByIdx(ar.Ri)
+		if br == nil {
+			pd.NOnlyA += len(ar.Si)
+			continue
+		}
+		bwts := make(map[int]float32, len(br.Si))
+		for ci, si := range br.Si {
+			bwts[si] = br.Wt[ci]
+		}
+		for ci, si := range ar.Si {
+			bwt, has := bwts[si]
+			if !has {
+				pd.NOnlyA++
+				continue
+			}
+			delete(bwts, si)
+			dif := math.Abs(float64(bwt - ar.Wt[ci]))
+			pd.N++
+			sumAbs += dif
+			sumSq += dif * dif
+			if float32(dif) > pd.MaxAbsDif {
+				pd.MaxAbsDif = float32(dif)
+			}
+		}
+		pd.NOnlyB += len(bwts)
+	}
+	for ri := range b.Rs {
+		if a.RecvByIdx(b.Rs[ri].Ri) == nil {
+			pd.NOnlyB += len(b.Rs[ri].Si)
+		}
+	}
+	if pd.N > 0 {
+		pd.MeanAbsDif = float32(sumAbs / float64(pd.N))
+		pd.RMSDif = float32(math.Sqrt(sumSq / float64(pd.N)))
+	}
+	return pd
+}
+
+// NSyns returns the total number of synapses in the projection
+func (pj *Prjn) NSyns() int {
+	n := 0
+	for ri := range pj.Rs {
+		n += len(pj.Rs[ri].Si)
+	}
+	return n
+}
+
+// Equal returns true if the two networks have the same structure, and all
+// weight differences are <= given tolerance
+func (nd *NetDiff) Equal(tol float32) bool {
+	for _, pd := range nd.Diffs {
+		if pd.Status != "A and B" || pd.NOnlyA > 0 || pd.NOnlyB > 0 || pd.MaxAbsDif > tol {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns a multi-line report of the diffs
+func (nd *NetDiff) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "A: %s  B: %s\n", nd.A, nd.B)
+	for _, pd := range nd.Diffs {
+		b.WriteString(pd.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
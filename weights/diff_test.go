@@ -0,0 +1,31 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package weights
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	a := testMergeNet(0.2)
+	b := testMergeNet(0.2)
+	if nd := Diff(a, b); !nd.Equal(0) {
+		t.Errorf("identical networks differ:\n%v", nd)
+	}
+	b.Layers[1].Prjns[0].Rs[1].Wt[0] = 0.5
+	b.Layers = append(b.Layers, Layer{Layer: "Output"})
+	nd := Diff(a, b)
+	if nd.Equal(0.1) {
+		t.Errorf("different networks should not be equal:\n%v", nd)
+	}
+	if len(nd.Diffs) != 2 {
+		t.Fatalf("expected 2 diffs:\n%v", nd)
+	}
+	pd := nd.Diffs[0]
+	if pd.N != 4 || pd.MaxAbsDif < 0.2999 || pd.MaxAbsDif > 0.3001 {
+		t.Errorf("bad prjn diff: %v\n", pd)
+	}
+	if nd.Diffs[1].Layer != "Output" || nd.Diffs[1].Status != "only B" {
+		t.Errorf("bad layer diff: %v\n", nd.Diffs[1])
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package weights
+
+import (
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+)
+
+// Table returns an etable.Table report of the diffs, with one row per Diffs entry
+func (nd *NetDiff) Table() *etable.Table {
+	dt := &etable.Table{}
+	sch := etable.Schema{
+		{"Layer", etensor.STRING, nil, nil},
+		{"From", etensor.STRING, nil, nil},
+		{"Status", etensor.STRING, nil, nil},
+		{"N", etensor.INT64, nil, nil},
+		{"NOnlyA", etensor.INT64, nil, nil},
+		{"NOnlyB", etensor.INT64, nil, nil},
+		{"MeanAbsDif", etensor.FLOAT32, nil, nil},
+		{"MaxAbsDif", etensor.FLOAT32, nil, nil},
+		{"RMSDif", etensor.FLOAT32, nil, nil},
+	}
+	dt.SetFromSchema(sch, len(nd.Diffs))
+	dt.SetMetaData("name", "WtsDiff")
+	dt.SetMetaData("desc", "weight differences, A: "+nd.A+" B: "+nd.B)
+	for row, pd := range nd.Diffs {
+		dt.SetCellString("Layer", row, pd.Layer)
+		dt.SetCellString("From", row, pd.From)
+		dt.SetCellString("Status", row, pd.Status)
+		dt.SetCellFloat("N", row, float64(pd.N))
+		dt.SetCellFloat("NOnlyA", row, float64(pd.NOnlyA))
+		dt.SetCellFloat("NOnlyB", row, float64(pd.NOnlyB))
+		dt.SetCellFloat("MeanAbsDif", row, float64(pd.MeanAbsDif))
+		dt.SetCellFloat("MaxAbsDif", row, float64(pd.MaxAbsDif))
+		dt.SetCellFloat("RMSDif", row, float64(pd.RMSDif))
+	}
+	return dt
+}
@@ -6,5 +6,10 @@
 Package weights provides weight loading routines that parse weight files into
 a temporary structure that can then be used to set weight values in the network.
 This is much simpler and allows use of the standard Go json Unmarshal routines.
+
+The JSON format has a Version field (see CurVersion), and older files are
+automatically upgraded on reading through the registered Converters.
+NetRead also detects and imports foreign formats such as the C++ emergent
+format, using the registered Importers.
 */
 package weights
@@ -5,27 +5,37 @@
 package weights
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"io"
+	"io/ioutil"
 	"log"
+	"os"
+	"path/filepath"
 )
 
 // Prec is the precision for weight output in text formats -- default is aggressive
 // for Leabra models -- may need to increase for other models.
 var Prec = 4
 
-// NetReadJSON reads weights for entire network in a JSON format into Network structure
+// NetReadJSON reads weights for entire network in a JSON format into Network structure.
+// Files from older versions of the format are upgraded to the CurVersion
+// using the registered Converters -- see Upgrade.
 func NetReadJSON(r io.Reader) (*Network, error) {
-	nw := &Network{}
-	dec := json.NewDecoder(r)
-	err := dec.Decode(nw) // this is way to do it on reader instead of bytes
-	if err == io.EOF {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	if len(bytes.TrimSpace(b)) == 0 {
 		return nil, nil
 	}
+	nw, err := netDecodeJSON(b)
 	if err != nil {
 		log.Println(err)
 	}
-	return nw, nil
+	return nw, err
 }
 
 // LayReadJSON reads weights for layer in a JSON format into Layer structure
@@ -55,3 +65,47 @@ func PrjnReadJSON(r io.Reader) (*Prjn, error) {
 	}
 	return pw, nil
 }
+
+// OpenNetJSON opens weights for entire network from a JSON-formatted file,
+// or any other format known to NetRead.  If filename has .gz extension,
+// then file is gzip uncompressed.
+func OpenNetJSON(filename string) (*Network, error) {
+	fp, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+	if filepath.Ext(filename) == ".gz" {
+		gzr, err := gzip.NewReader(fp)
+		if err != nil {
+			return nil, err
+		}
+		defer gzr.Close()
+		return NetRead(gzr)
+	}
+	return NetRead(fp)
+}
+
+// SaveNetJSON saves weights for entire network to a JSON-formatted file,
+// setting the Version to CurVersion.  If filename has .gz extension,
+// then file is gzip compressed.
+func SaveNetJSON(filename string, nw *Network) error {
+	nw.Version = CurVersion
+	b, err := json.MarshalIndent(nw, "", "\t")
+	if err != nil {
+		return err
+	}
+	fp, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+	if filepath.Ext(filename) == ".gz" {
+		gzw := gzip.NewWriter(fp)
+		defer gzw.Close()
+		_, err = gzw.Write(b)
+		return err
+	}
+	_, err = fp.Write(b)
+	return err
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package weights
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// PrjnName returns the standard name of a projection, given the receiving
+// and sending layer names: Send + "To" + Recv, as used in emer.Prjn.Name
+func PrjnName(recv, send string) string {
+	return send + "To" + recv
+}
+
+// LayerByName returns the layer weights of given name, nil if not found
+func (nt *Network) LayerByName(name string) *Layer {
+	for li := range nt.Layers {
+		if nt.Layers[li].Layer == name {
+			return &nt.Layers[li]
+		}
+	}
+	return nil
+}
+
+// PrjnByFrom returns the projection weights from given sending layer, nil if not found
+func (ly *Layer) PrjnByFrom(from string) *Prjn {
+	for pi := range ly.Prjns {
+		if ly.Prjns[pi].From == from {
+			return &ly.Prjns[pi]
+		}
+	}
+	return nil
+}
+
+// RecvByIdx returns the recv unit weights for given recv unit index, nil if not found.
+// Uses the Ri index directly if the Rs are in order, which is the usual case.
+func (pj *Prjn) RecvByIdx(ri int) *Recv {
+	if ri >= 0 && ri < len(pj.Rs) && pj.Rs[ri].Ri == ri {
+		return &pj.Rs[ri]
+	}
+	for i := range pj.Rs {
+		if pj.Rs[i].Ri == ri {
+			return &pj.Rs[i]
+		}
+	}
+	return nil
+}
+
+// Clone returns a deep copy of the network weights
+func (nt *Network) Clone() *Network {
+	cp := &Network{Version: nt.Version, Network: nt.Network, MetaData: cloneMetaData(nt.MetaData)}
+	cp.Layers = make([]Layer, len(nt.Layers))
+	for li := range nt.Layers {
+		cp.Layers[li] = *nt.Layers[li].Clone()
+	}
+	return cp
+}
+
+// Clone returns a deep copy of the layer weights
+func (ly *Layer) Clone() *Layer {
+	cp := &Layer{Layer: ly.Layer, MetaData: cloneMetaData(ly.MetaData)}
+	cp.Prjns = make([]Prjn, len(ly.Prjns))
+	for pi := range ly.Prjns {
+		cp.Prjns[pi] = *ly.Prjns[pi].Clone()
+	}
+	return cp
+}
+
+// Clone returns a deep copy of the projection weights
+func (pj *Prjn) Clone() *Prjn {
+	cp := &Prjn{From: pj.From, MetaData: cloneMetaData(pj.MetaData)}
+	cp.Rs = make([]Recv, len(pj.Rs))
+	for ri := range pj.Rs {
+		rw := &pj.Rs[ri]
+		cr := &cp.Rs[ri]
+		cr.Ri = rw.Ri
+		cr.N = rw.N
+		cr.Si = append([]int(nil), rw.Si...)
+		cr.Wt = append([]float32(nil), rw.Wt...)
+	}
+	return cp
+}
+
+func cloneMetaData(md map[string]string) map[string]string {
+	if md == nil {
+		return nil
+	}
+	cp := make(map[string]string, len(md))
+	for k, v := range md {
+		cp[k] = v
+	}
+	return cp
+}
+
+// Average returns the element-wise average of the given network weights,
+// e.g., for creating an ensemble average of multiple training runs.
+// Weights are matched by layer name, prjn sending layer, and recv and send unit
+// indexes, and all networks must have the same structure as the first one,
+// including the number of synapses and weights for each recv unit,
+// otherwise an error is returned.  Numerical MetaData values (e.g., ActMAvg)
+// are also averaged -- other MetaData is taken from the first network.
+func Average(nws ...*Network) (*Network, error) {
+	if len(nws) == 0 {
+		return nil, fmt.Errorf("weights.Average: no networks to average")
+	}
+	av := nws[0].Clone()
+	nf := float32(len(nws))
+	mds := make([]map[string]string, len(nws))
+	for i, nw := range nws {
+		mds[i] = nw.MetaData
+	}
+	averageMetaData(av.MetaData, mds)
+	for li := range av.Layers {
+		al := &av.Layers[li]
+		lws := make([]*Layer, len(nws))
+		for i, nw := range nws {
+			lws[i] = nw.LayerByName(al.Layer)
+			if lws[i] == nil {
+				return nil, fmt.Errorf("weights.Average: layer: %v not found in network: %d", al.Layer, i)
+			}
+			mds[i] = lws[i].MetaData
+		}
+		averageMetaData(al.MetaData, mds)
+		for pi := range al.Prjns {
+			ap := &al.Prjns[pi]
+			for i, lw := range lws {
+				pw := lw.PrjnByFrom(ap.From)
+				if pw == nil {
+					return nil, fmt.Errorf("weights.Average: prjn: %v not found in network: %d", PrjnName(al.Layer, ap.From), i)
+				}
+				mds[i] = pw.MetaData
+				if len(pw.Rs) != len(ap.Rs) {
+					return nil, fmt.Errorf("weights.Average: prjn: %v number of recv units: %d does not match: %d in network: %d", PrjnName(al.Layer, ap.From), len(pw.Rs), len(ap.Rs), i)
+				}
+				for ri := range pw.Rs {
+					rw := &pw.Rs[ri]
+					if len(rw.Wt) != len(rw.Si) {
+						return nil, fmt.Errorf("weights.Average: prjn: %v recv unit: %d number of weights: %d does not match number of synapses: %d in network: %d", PrjnName(al.Layer, ap.From), rw.Ri, len(rw.Wt), len(rw.Si), i)
+					}
+				}
+				if i == 0 {
+					continue
+				}
+				for ri := range ap.Rs {
+					ar := &ap.Rs[ri]
+					rw := pw.RecvByIdx(ar.Ri)
+					if rw == nil {
+						return nil, fmt.Errorf("weights.Average: prjn: %v recv unit: %d not found in network: %d", PrjnName(al.Layer, ap.From), ar.Ri, i)
+					}
+					if len(rw.Si) != len(ar.Si) {
+						return nil, fmt.Errorf("weights.Average: prjn: %v recv unit: %d number of synapses: %d does not match: %d in network: %d", PrjnName(al.Layer, ap.From), ar.Ri, len(rw.Si), len(ar.Si), i)
+					}
+					for ci, si := range ar.Si {
+						if rw.Si[ci] != si {
+							return nil, fmt.Errorf("weights.Average: prjn: %v recv unit: %d sending indexes do not match in network: %d", PrjnName(al.Layer, ap.From), ar.Ri, i)
+						}
+						ar.Wt[ci] += rw.Wt[ci]
+					}
+				}
+			}
+			averageMetaData(ap.MetaData, mds)
+			for ri := range ap.Rs {
+				ar := &ap.Rs[ri]
+				for ci := range ar.Wt {
+					ar.Wt[ci] /= nf
+				}
+			}
+		}
+	}
+	return av, nil
+}
+
+// AverageFiles returns the element-wise average of the network weights in
+// the given files, opened using OpenNetJSON -- see Average for details.
+func AverageFiles(filenames ...string) (*Network, error) {
+	nws := make([]*Network, len(filenames))
+	for i, fn := range filenames {
+		nw, err := OpenNetJSON(fn)
+		if err != nil {
+			return nil, err
+		}
+		if nw == nil {
+			return nil, fmt.Errorf("weights.AverageFiles: file: %v is empty", fn)
+		}
+		nws[i] = nw
+	}
+	return Average(nws...)
+}
+
+// averageMetaData sets values in md to the average of the values in mds,
+// for those that are numerical in all of mds
+func averageMetaData(md map[string]string, mds []map[string]string) {
+	for k := range md {
+		sum := 0.0
+		ok := true
+		for _, m := range mds {
+			v, err := strconv.ParseFloat(m[k], 64)
+			if err != nil {
+				ok = false
+				break
+			}
+			sum += v
+		}
+		if ok {
+			md[k] = strconv.FormatFloat(sum/float64(len(mds)), 'g', Prec, 64)
+		}
+	}
+}
+
+// MergeLayers copies the weights for the given layers (including all of their
+// receiving projections and MetaData) from src network into this one,
+// replacing any existing weights for those layers, or adding them if not present.
+// Returns an error if any layers are not found in src.
+func (nt *Network) MergeLayers(src *Network, layers ...string) error {
+	for _, lnm := range layers {
+		sl := src.LayerByName(lnm)
+		if sl == nil {
+			return fmt.Errorf("weights.MergeLayers: layer: %v not found in source network: %v", lnm, src.Network)
+		}
+		if dl := nt.LayerByName(lnm); dl != nil {
+			*dl = *sl.Clone()
+		} else {
+			nt.Layers = append(nt.Layers, *sl.Clone())
+		}
+	}
+	return nil
+}
+
+// MergePrjns copies the weights for the given projections, specified by their
+// standard names as returned by PrjnName (Send + "To" + Recv), from src network
+// into this one, replacing any existing weights for those projections, or adding
+// them if not present.  This supports stitching together networks from different
+// training runs.  Returns an error if any prjns are not found in src.
+func (nt *Network) MergePrjns(src *Network, prjns ...string) error {
+	for _, pnm := range prjns {
+		found := false
+		for li := range src.Layers {
+			sl := &src.Layers[li]
+			for pi := range sl.Prjns {
+				sp := &sl.Prjns[pi]
+				if PrjnName(sl.Layer, sp.From) != pnm {
+					continue
+				}
+				found = true
+				dl := nt.LayerByName(sl.Layer)
+				if dl == nil {
+					nt.Layers = append(nt.Layers, Layer{Layer: sl.Layer, MetaData: cloneMetaData(sl.MetaData)})
+					dl = &nt.Layers[len(nt.Layers)-1]
+				}
+				if dp := dl.PrjnByFrom(sp.From); dp != nil {
+					*dp = *sp.Clone()
+				} else {
+					dl.Prjns = append(dl.Prjns, *sp.Clone())
+				}
+			}
+		}
+		if !found {
+			return fmt.Errorf("weights.MergePrjns: prjn: %v not found in source network: %v", pnm, src.Network)
+		}
+	}
+	return nil
+}
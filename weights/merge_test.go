@@ -0,0 +1,92 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package weights
+
+import "testing"
+
+func testMergeNet(wt float32) *Network {
+	nw := &Network{Network: "TestNet"}
+	nw.Layers = []Layer{{Layer: "Input"}, {Layer: "Hidden"}}
+	l1 := &nw.Layers[1]
+	l1.SetMetaData("ActMAvg", "0.2")
+	l1.Prjns = []Prjn{{From: "Input"}}
+	pj := &l1.Prjns[0]
+	pj.Rs = make([]Recv, 2)
+	for ri := range pj.Rs {
+		rw := &pj.Rs[ri]
+		rw.Ri = ri
+		rw.N = 2
+		rw.Si = []int{0, 1}
+		rw.Wt = []float32{wt, wt}
+	}
+	return nw
+}
+
+func TestAverage(t *testing.T) {
+	n1 := testMergeNet(0.2)
+	n2 := testMergeNet(0.4)
+	n2.Layers[1].SetMetaData("ActMAvg", "0.4")
+	av, err := Average(n1, n2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, wt := range av.Layers[1].Prjns[0].Rs[1].Wt {
+		if wt < 0.2999 || wt > 0.3001 {
+			t.Errorf("average wt: %v != 0.3\n", wt)
+		}
+	}
+	if md := av.Layers[1].MetaData["ActMAvg"]; md != "0.3" {
+		t.Errorf("average ActMAvg: %v != 0.3\n", md)
+	}
+	if n1.Layers[1].Prjns[0].Rs[0].Wt[0] != 0.2 {
+		t.Errorf("Average modified source network\n")
+	}
+	n2.Layers[1].Prjns[0].Rs[1].Si[1] = 5
+	_, err = Average(n1, n2)
+	if err == nil {
+		t.Errorf("Average should fail on mismatched structure\n")
+	}
+	mismatch := []struct {
+		name string
+		fun  func(nw *Network)
+	}{
+		{"fewer weights", func(nw *Network) { nw.Layers[1].Prjns[0].Rs[1].Wt = []float32{0.4} }},
+		{"fewer synapses", func(nw *Network) {
+			rw := &nw.Layers[1].Prjns[0].Rs[1]
+			rw.Si = rw.Si[:1]
+			rw.Wt = rw.Wt[:1]
+		}},
+		{"fewer recv units", func(nw *Network) { nw.Layers[1].Prjns[0].Rs = nw.Layers[1].Prjns[0].Rs[:1] }},
+	}
+	for _, mm := range mismatch {
+		for _, first := range []bool{true, false} {
+			n2 = testMergeNet(0.4)
+			mm.fun(n2)
+			if first {
+				_, err = Average(n2, n1)
+			} else {
+				_, err = Average(n1, n2)
+			}
+			if err == nil {
+				t.Errorf("Average should fail on %s (first: %v)\n", mm.name, first)
+			}
+		}
+	}
+}
+
+func TestMergePrjns(t *testing.T) {
+	n1 := testMergeNet(0.2)
+	n2 := testMergeNet(0.4)
+	err := n1.MergePrjns(n2, PrjnName("Hidden", "Input"))
+	if err != nil {
+		t.Error(err)
+	}
+	if wt := n1.Layers[1].Prjns[0].Rs[0].Wt[0]; wt != 0.4 {
+		t.Errorf("merged wt: %v != 0.4\n", wt)
+	}
+	if n1.MergePrjns(n2, "OutputToHidden") == nil {
+		t.Errorf("MergePrjns should fail on missing prjn\n")
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package weights
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+)
+
+// NetWriteNPZ writes weights for the entire network to a NumPy .npz archive
+// (a zip file of .npy arrays), which can be loaded in Python in one line:
+//
+//	wts = numpy.load("mynet.npz")
+//
+// The naming scheme for the arrays in the archive is:
+//
+//	<Layer>/Shape     int32 shape of the layer (from shapes if present, else 1D [NUnits])
+//	<Recv>/<Send>/Wt  float32 [NRecv, NSend] weights, NaN where not connected
+//
+// where <Recv> is the receiving layer name, and <Send> is the sending layer name
+// (Prjn.From), e.g., wts["Hidden/Input/Wt"].  shapes is an optional map of layer
+// names to layer shapes (e.g., from Layer.Shape().Shp) -- can be nil, in which case
+// the number of units is inferred from the largest unit indexes in the weights.
+func NetWriteNPZ(w io.Writer, nw *Network, shapes map[string][]int) error {
+	zw := zip.NewWriter(w)
+	nunits := NUnits(nw, shapes)
+	for li := range nw.Layers {
+		lw := &nw.Layers[li]
+		shp, has := shapes[lw.Layer]
+		if !has {
+			shp = []int{nunits[lw.Layer]}
+		}
+		sh32 := make([]int32, len(shp))
+		for i, s := range shp {
+			sh32[i] = int32(s)
+		}
+		if err := npzWriteArray(zw, lw.Layer+"/Shape", "<i4", []int{len(shp)}, sh32); err != nil {
+			return err
+		}
+		nr := nunits[lw.Layer]
+		for pi := range lw.Prjns {
+			pw := &lw.Prjns[pi]
+			ns := nunits[pw.From]
+			wts := make([]float32, nr*ns)
+			nan := float32(math.NaN())
+			for i := range wts {
+				wts[i] = nan
+			}
+			for ri := range pw.Rs {
+				rw := &pw.Rs[ri]
+				if rw.Ri >= nr {
+					continue
+				}
+				for ci, si := range rw.Si {
+					if si < ns && ci < len(rw.Wt) {
+						wts[rw.Ri*ns+si] = rw.Wt[ci]
+					}
+				}
+			}
+			if err := npzWriteArray(zw, lw.Layer+"/"+pw.From+"/Wt", "<f4", []int{nr, ns}, wts); err != nil {
+				return err
+			}
+		}
+	}
+	return zw.Close()
+}
+
+// SaveNPZ saves weights for the entire network to given .npz file name,
+// using NetWriteNPZ -- see that for details.
+func SaveNPZ(filename string, nw *Network, shapes map[string][]int) error {
+	fp, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	err = NetWriteNPZ(fp, nw, shapes)
+	if cerr := fp.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// NUnits returns a map of layer names to number of units in each layer,
+// using the product of the given shapes if present (can be nil), and otherwise
+// the largest receiving and sending unit indexes present in the weights.
+func NUnits(nw *Network, shapes map[string][]int) map[string]int {
+	nu := make(map[string]int)
+	for li := range nw.Layers {
+		lw := &nw.Layers[li]
+		for pi := range lw.Prjns {
+			pw := &lw.Prjns[pi]
+			for ri := range pw.Rs {
+				rw := &pw.Rs[ri]
+				if rw.Ri+1 > nu[lw.Layer] {
+					nu[lw.Layer] = rw.Ri + 1
+				}
+				for _, si := range rw.Si {
+					if si+1 > nu[pw.From] {
+						nu[pw.From] = si + 1
+					}
+				}
+			}
+		}
+	}
+	for nm, shp := range shapes {
+		n := 1
+		for _, s := range shp {
+			n *= s
+		}
+		nu[nm] = n
+	}
+	return nu
+}
+
+// npzWriteArray writes one array in .npy format as a file within the .npz zip archive.
+// dtype is the numpy descr string (e.g., "<f4") and data must be a slice of
+// fixed-size values matching that type.
+func npzWriteArray(zw *zip.Writer, name, dtype string, shape []int, data interface{}) error {
+	fw, err := zw.Create(name + ".npy")
+	if err != nil {
+		return err
+	}
+	if err := WriteNPYHeader(fw, dtype, shape); err != nil {
+		return err
+	}
+	return binary.Write(fw, binary.LittleEndian, data)
+}
+
+// WriteNPYHeader writes the NumPy .npy format (version 1.0) header for an array
+// of given numpy dtype descr string (e.g., "<f4" for little-endian float32)
+// and shape.  The raw little-endian, row-major data must follow.
+func WriteNPYHeader(w io.Writer, dtype string, shape []int) error {
+	ss := make([]string, len(shape))
+	for i, s := range shape {
+		ss[i] = fmt.Sprintf("%d", s)
+	}
+	shs := strings.Join(ss, ", ")
+	if len(shape) == 1 {
+		shs += ","
+	}
+	hdr := fmt.Sprintf("{'descr': '%s', 'fortran_order': False, 'shape': (%s), }", dtype, shs)
+	// total of magic (6) + version (2) + len (2) + header must be multiple of 64, ending in \n
+	pad := 64 - (10+len(hdr)+1)%64
+	if pad == 64 {
+		pad = 0
+	}
+	hdr += strings.Repeat(" ", pad) + "\n"
+	var b bytes.Buffer
+	b.WriteString("\x93NUMPY")
+	b.WriteByte(1)
+	b.WriteByte(0)
+	binary.Write(&b, binary.LittleEndian, uint16(len(hdr)))
+	b.WriteString(hdr)
+	_, err := w.Write(b.Bytes())
+	return err
+}
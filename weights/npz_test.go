@@ -0,0 +1,74 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package weights
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"math"
+	"testing"
+)
+
+func TestNPZ(t *testing.T) {
+	nw := &Network{Network: "TestNet"}
+	nw.Layers = make([]Layer, 2)
+	nw.Layers[0].Layer = "Input"
+	l1 := &nw.Layers[1]
+	l1.Layer = "Hidden"
+	l1.Prjns = []Prjn{{From: "Input"}}
+	pj := &l1.Prjns[0]
+	pj.Rs = make([]Recv, 3)
+	for ri := range pj.Rs {
+		rw := &pj.Rs[ri]
+		rw.Ri = ri
+		rw.N = 1
+		rw.Si = []int{ri}
+		rw.Wt = []float32{float32(ri) + 0.5}
+	}
+	var b bytes.Buffer
+	err := NetWriteNPZ(&b, nw, map[string][]int{"Input": {2, 2}})
+	if err != nil {
+		t.Error(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		files[f.Name], _ = ioutil.ReadAll(rc)
+		rc.Close()
+	}
+	for _, nm := range []string{"Input/Shape.npy", "Hidden/Shape.npy", "Hidden/Input/Wt.npy"} {
+		if _, has := files[nm]; !has {
+			t.Errorf("missing array: %v\n", nm)
+		}
+	}
+	wb := files["Hidden/Input/Wt.npy"]
+	if string(wb[1:6]) != "NUMPY" {
+		t.Errorf("bad npy magic: %v\n", string(wb[:6]))
+	}
+	hl := int(binary.LittleEndian.Uint16(wb[8:10]))
+	if (10+hl)%64 != 0 {
+		t.Errorf("npy header not aligned: %v\n", 10+hl)
+	}
+	wts := make([]float32, 3*4)
+	binary.Read(bytes.NewReader(wb[10+hl:]), binary.LittleEndian, wts)
+	for ri := 0; ri < 3; ri++ {
+		for si := 0; si < 4; si++ {
+			wt := wts[ri*4+si]
+			if si == ri {
+				if wt != float32(ri)+0.5 {
+					t.Errorf("wt[%d,%d] = %v\n", ri, si, wt)
+				}
+			} else if !math.IsNaN(float64(wt)) {
+				t.Errorf("wt[%d,%d] = %v, should be NaN\n", ri, si, wt)
+			}
+		}
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package weights
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// PrjnStats has summary statistics for the weights in one projection
+type PrjnStats struct {
+	Layer string  // name of receiving layer
+	From  string  // name of sending layer
+	N     int     // number of weights
+	Mean  float32 // mean of weights
+	SD    float32 // standard deviation of weights
+	Min   float32 // minimum weight
+	Max   float32 // maximum weight
+
+	sum, sumSq float64
+}
+
+// String returns a one-line summary of the stats
+func (ps *PrjnStats) String() string {
+	return fmt.Sprintf("%s <- %s\tN: %d\tMean: %g\tSD: %g\tMin: %g\tMax: %g", ps.Layer, ps.From, ps.N, ps.Mean, ps.SD, ps.Min, ps.Max)
+}
+
+// Add adds given weight value to the running stats
+func (ps *PrjnStats) Add(wt float32) {
+	if ps.N == 0 || wt < ps.Min {
+		ps.Min = wt
+	}
+	if ps.N == 0 || wt > ps.Max {
+		ps.Max = wt
+	}
+	ps.N++
+	ps.sum += float64(wt)
+	ps.sumSq += float64(wt) * float64(wt)
+}
+
+// Final computes the final Mean and SD from the running stats
+func (ps *PrjnStats) Final() {
+	if ps.N == 0 {
+		return
+	}
+	n := float64(ps.N)
+	mean := ps.sum / n
+	vr := ps.sumSq/n - mean*mean
+	if vr < 0 {
+		vr = 0
+	}
+	ps.Mean = float32(mean)
+	ps.SD = float32(math.Sqrt(vr))
+}
+
+// NetStats computes the PrjnStats for each projection in the network weights,
+// in the order of layers and their receiving projections.
+func (nt *Network) NetStats() []*PrjnStats {
+	var sts []*PrjnStats
+	for li := range nt.Layers {
+		lw := &nt.Layers[li]
+		for pi := range lw.Prjns {
+			pw := &lw.Prjns[pi]
+			ps := &PrjnStats{Layer: lw.Layer, From: pw.From}
+			for ri := range pw.Rs {
+				for _, wt := range pw.Rs[ri].Wt {
+					ps.Add(wt)
+				}
+			}
+			ps.Final()
+			sts = append(sts, ps)
+		}
+	}
+	return sts
+}
+
+// NetStatsJSON computes the PrjnStats for each projection in a JSON weights file,
+// in a streaming fashion that does not load the weights into memory,
+// so that large numbers of weight files can be characterized quickly.
+func NetStatsJSON(r io.Reader) ([]*PrjnStats, error) {
+	ss := &statsScan{dec: json.NewDecoder(r)}
+	ss.dec.UseNumber()
+	err := ss.object(ss.net)
+	if err != nil {
+		return ss.stats, fmt.Errorf("weights.NetStatsJSON: %v", err)
+	}
+	return ss.stats, nil
+}
+
+// OpenNetStats computes the PrjnStats for each projection in a JSON weights file
+// of given name, using NetStatsJSON.  If filename has .gz extension,
+// then file is gzip uncompressed.
+func OpenNetStats(filename string) ([]*PrjnStats, error) {
+	fp, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+	if filepath.Ext(filename) == ".gz" {
+		gzr, err := gzip.NewReader(fp)
+		if err != nil {
+			return nil, err
+		}
+		defer gzr.Close()
+		return NetStatsJSON(gzr)
+	}
+	return NetStatsJSON(fp)
+}
+
+// statsScan scans the JSON tokens of a weights file for NetStatsJSON
+type statsScan struct {
+	dec   *json.Decoder
+	stats []*PrjnStats
+	lay   string
+	cur   *PrjnStats
+}
+
+// object reads an object, calling fun for each key, which must read the value
+func (ss *statsScan) object(fun func(key string) error) error {
+	if null, err := ss.delim('{'); null || err != nil {
+		return err
+	}
+	for ss.dec.More() {
+		tok, err := ss.dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("expected object key, got: %v", tok)
+		}
+		if err := fun(key); err != nil {
+			return err
+		}
+	}
+	_, err := ss.dec.Token() // closing }
+	return err
+}
+
+// array reads an array, calling fun for each element, which must read the value
+func (ss *statsScan) array(fun func() error) error {
+	if null, err := ss.delim('['); null || err != nil {
+		return err
+	}
+	for ss.dec.More() {
+		if err := fun(); err != nil {
+			return err
+		}
+	}
+	_, err := ss.dec.Token() // closing ]
+	return err
+}
+
+// delim reads the opening delimiter of an object or array, returning true if null
+func (ss *statsScan) delim(d json.Delim) (bool, error) {
+	tok, err := ss.dec.Token()
+	if err != nil {
+		return false, err
+	}
+	if tok == nil {
+		return true, nil
+	}
+	if dt, ok := tok.(json.Delim); !ok || dt != d {
+		return false, fmt.Errorf("expected: %v, got: %v", d, tok)
+	}
+	return false, nil
+}
+
+// skip skips over the next value, of any type
+func (ss *statsScan) skip() error {
+	var v json.RawMessage
+	return ss.dec.Decode(&v)
+}
+
+func (ss *statsScan) str() (string, error) {
+	var s string
+	err := ss.dec.Decode(&s)
+	return s, err
+}
+
+func (ss *statsScan) net(key string) error {
+	if key != "Layers" {
+		return ss.skip()
+	}
+	return ss.array(func() error {
+		ss.lay = ""
+		nst := len(ss.stats)
+		err := ss.object(ss.layer)
+		for _, ps := range ss.stats[nst:] {
+			ps.Layer = ss.lay // in case Layer came after Prjns
+		}
+		return err
+	})
+}
+
+func (ss *statsScan) layer(key string) error {
+	var err error
+	switch key {
+	case "Layer":
+		ss.lay, err = ss.str()
+	case "Prjns":
+		err = ss.array(func() error {
+			ss.cur = &PrjnStats{}
+			ss.stats = append(ss.stats, ss.cur)
+			err := ss.object(ss.prjn)
+			ss.cur.Final()
+			return err
+		})
+	default:
+		err = ss.skip()
+	}
+	return err
+}
+
+func (ss *statsScan) prjn(key string) error {
+	var err error
+	switch key {
+	case "From":
+		ss.cur.From, err = ss.str()
+	case "Rs":
+		err = ss.array(func() error {
+			return ss.object(ss.recv)
+		})
+	default:
+		err = ss.skip()
+	}
+	return err
+}
+
+func (ss *statsScan) recv(key string) error {
+	if key != "Wt" {
+		return ss.skip()
+	}
+	return ss.array(func() error {
+		tok, err := ss.dec.Token()
+		if err != nil {
+			return err
+		}
+		num, ok := tok.(json.Number)
+		if !ok {
+			return fmt.Errorf("expected weight value, got: %v", tok)
+		}
+		wt, err := num.Float64()
+		if err != nil {
+			return err
+		}
+		ss.cur.Add(float32(wt))
+		return nil
+	})
+}
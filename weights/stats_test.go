@@ -0,0 +1,46 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package weights
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"os"
+	"testing"
+)
+
+func TestNetStatsJSON(t *testing.T) {
+	fp, err := os.Open("FaceNetworkCpp.wts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fp.Close()
+	nw, err := NetReadCpp(fp)
+	if err != nil {
+		t.Error(err)
+	}
+	nb, err := json.MarshalIndent(nw, "", "\t")
+	if err != nil {
+		t.Error(err)
+	}
+	sts, err := NetStatsJSON(bytes.NewReader(nb))
+	if err != nil {
+		t.Error(err)
+	}
+	ests := nw.NetStats()
+	if len(sts) != len(ests) {
+		t.Fatalf("number of prjn stats: %v != %v\n", len(sts), len(ests))
+	}
+	for i, ps := range sts {
+		es := ests[i]
+		if ps.Layer != es.Layer || ps.From != es.From || ps.N != es.N || ps.Min != es.Min || ps.Max != es.Max {
+			t.Errorf("stats differ:\n%v\n%v\n", ps, es)
+		}
+		if math.Abs(float64(ps.Mean-es.Mean)) > 1.0e-6 || math.Abs(float64(ps.SD-es.SD)) > 1.0e-6 {
+			t.Errorf("stats differ:\n%v\n%v\n", ps, es)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package weights
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// CurVersion is the current version of the weights file format, which is
+// recorded in the Network.Version field.  Files without a Version field
+// are version 0, and are upgraded through the registered Converters on reading.
+const CurVersion = 1
+
+// Converter upgrades a weights file from one version to the next, operating
+// on the generic decoded JSON representation of the Network, so that files
+// that no longer match the current structures can still be read.
+type Converter func(raw map[string]interface{}) error
+
+// Converters is the registry of Converter functions, keyed by the version
+// that they upgrade *from* -- each converts to the next version.
+// Use AddConverter to add new ones when the format changes.
+var Converters = map[int]Converter{
+	0: ConvertV0,
+}
+
+// AddConverter registers a converter that upgrades from given version to version+1
+func AddConverter(from int, cv Converter) {
+	Converters[from] = cv
+}
+
+// Importer reads weights from a foreign (non-JSON) file format, e.g., the C++ emergent
+// format, into the current Network structure.
+type Importer struct {
+	Name   string                              // name of the format, for messages
+	Detect func(head []byte) bool              // returns true if the initial bytes of a file are in this format
+	Read   func(r io.Reader) (*Network, error) // reads file into a Network
+}
+
+// Importers is the registry of foreign-format Importers, which are checked in order by NetRead
+var Importers = []*Importer{
+	{Name: "C++", Detect: IsCpp, Read: NetReadCpp},
+}
+
+// AddImporter registers a new foreign-format Importer
+func AddImporter(imp *Importer) {
+	Importers = append(Importers, imp)
+}
+
+// IsCpp returns true if the initial bytes of a file indicate the C++ emergent weights format
+func IsCpp(head []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(head), []byte("<Fmt "))
+}
+
+// NetRead reads weights for entire network from any known format, detecting
+// foreign formats with the registered Importers, and otherwise reading
+// JSON format with conversion from older versions -- see NetReadJSON.
+func NetRead(r io.Reader) (*Network, error) {
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(64)
+	for _, imp := range Importers {
+		if imp.Detect(head) {
+			nw, err := imp.Read(br)
+			if nw != nil {
+				nw.Version = CurVersion
+			}
+			if err != nil {
+				err = fmt.Errorf("weights.NetRead: error importing %s format: %v", imp.Name, err)
+			}
+			return nw, err
+		}
+	}
+	return NetReadJSON(br)
+}
+
+// Upgrade runs the registered Converters on the generic decoded JSON representation
+// of a Network until it is at the CurVersion, returning the version that it started at.
+// Returns an error if the version is newer than CurVersion or there is no converter
+// for an older version.
+func Upgrade(raw map[string]interface{}) (int, error) {
+	vers := 0
+	if vf, ok := raw["Version"].(float64); ok {
+		vers = int(vf)
+	}
+	orig := vers
+	if vers > CurVersion {
+		return orig, fmt.Errorf("weights.Upgrade: file version %d is newer than current version %d -- update your software", vers, CurVersion)
+	}
+	for vers < CurVersion {
+		cv, has := Converters[vers]
+		if !has {
+			return orig, fmt.Errorf("weights.Upgrade: no converter registered to upgrade from version %d", vers)
+		}
+		if err := cv(raw); err != nil {
+			return orig, fmt.Errorf("weights.Upgrade: converting from version %d: %v", vers, err)
+		}
+		vers++
+		raw["Version"] = vers
+	}
+	return orig, nil
+}
+
+// ConvertV0 converts from unversioned (version 0) weights files: Recv entries
+// without an N count get it from the number of sending indexes.
+func ConvertV0(raw map[string]interface{}) error {
+	lays, _ := raw["Layers"].([]interface{})
+	for _, l := range lays {
+		lm, _ := l.(map[string]interface{})
+		pjs, _ := lm["Prjns"].([]interface{})
+		for _, p := range pjs {
+			pm, _ := p.(map[string]interface{})
+			rs, _ := pm["Rs"].([]interface{})
+			for _, rv := range rs {
+				rm, ok := rv.(map[string]interface{})
+				if !ok {
+					return fmt.Errorf("Prjn from: %v has invalid Rs entry", pm["From"])
+				}
+				if _, has := rm["N"]; !has {
+					si, _ := rm["Si"].([]interface{})
+					rm["N"] = len(si)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// netDecodeJSON decodes the JSON-formatted network weights in b, upgrading from
+// older versions as needed.
+func netDecodeJSON(b []byte) (*Network, error) {
+	raw := make(map[string]interface{})
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, fmt.Errorf("weights: not a valid JSON weights file: %v", err)
+	}
+	orig, err := Upgrade(raw)
+	if err != nil {
+		return nil, err
+	}
+	if orig != CurVersion {
+		b, err = json.Marshal(raw)
+		if err != nil {
+			return nil, err
+		}
+	}
+	nw := &Network{}
+	if err := json.Unmarshal(b, nw); err != nil {
+		return nil, fmt.Errorf("weights: decoding version %d weights file (upgraded from version %d): %v", CurVersion, orig, err)
+	}
+	return nw, nil
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package weights
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestUpgradeV0(t *testing.T) {
+	v0 := `{"Network": "TestNet", "Layers": [{"Layer": "Hidden", "Prjns": [{"From": "Input", "Rs": [{"Ri": 0, "Si": [0, 1], "Wt": [0.1, 0.2]}]}]}]}`
+	nw, err := NetReadJSON(strings.NewReader(v0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nw.Version != CurVersion {
+		t.Errorf("version: %v != %v\n", nw.Version, CurVersion)
+	}
+	if n := nw.Layers[0].Prjns[0].Rs[0].N; n != 2 {
+		t.Errorf("converted N: %v != 2\n", n)
+	}
+
+	_, err = NetReadJSON(strings.NewReader(`{"Version": 1000, "Network": "TestNet"}`))
+	if err == nil || !strings.Contains(err.Error(), "newer") {
+		t.Errorf("expected newer version error, got: %v\n", err)
+	}
+}
+
+func TestNetReadCpp(t *testing.T) {
+	fp, err := os.Open("FaceNetworkCpp.wts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fp.Close()
+	nw, err := NetRead(fp)
+	if err != nil {
+		t.Error(err)
+	}
+	if nw.Version != CurVersion || len(nw.Layers) == 0 {
+		t.Errorf("C++ import failed: version: %v  layers: %v\n", nw.Version, len(nw.Layers))
+	}
+}
@@ -6,6 +6,7 @@ package weights
 
 // Network is temp structure for holding decoded weights
 type Network struct {
+	Version  int // version of the weights file format -- see CurVersion -- 0 = prior to versioning
 	Network  string
 	MetaData map[string]string // used for optional network-level params, metadata
 	Layers   []Layer